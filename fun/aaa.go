@@ -0,0 +1,409 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Aaa implements the AAA (adaptive Antoulas-Anderson) rational approximation r(z) ≈ f(z) computed
+// from samples F = f(Z) [1]. The approximation is written in barycentric form:
+//
+//	          m-1                    m-1
+//	          ————   wⱼ ⋅ fⱼ         ————    wⱼ
+//	r(z) =    \     ———————   /      \     ———————
+//	          /      z - zⱼ          /      z - zⱼ
+//	          ————                   ————
+//	          j=0                    j=0
+//
+//	where zⱼ are the support points (a subset of Z selected greedily), fⱼ = f(zⱼ) and wⱼ are the
+//	barycentric weights computed by solving a linearised least-squares problem with the SVD.
+//	r(z) is of type (m-1, m-1) and interpolates f at the support points.
+//
+//	References:
+//	[1] Nakatsukasa Y, Sète O, Trefethen LN (2018) The AAA algorithm for rational approximation.
+//	    SIAM Journal on Scientific Computing, 40(3):A1494-A1522
+type Aaa struct {
+	Zj  []complex128 // support points
+	Fj  []complex128 // function values at support points
+	Wj  []complex128 // barycentric weights
+	Pol []complex128 // poles
+	Res []complex128 // residues at poles
+	Zer []complex128 // zeros
+	Err []float64    // history of the max error on the samples: max|F - r(Z)|
+
+	// internal
+	z []complex128 // all sample points
+	f []complex128 // all sample values
+}
+
+// NewAaa computes the AAA rational approximation from the samples F = f(Z)
+//
+//	Z    -- sample points (e.g. on the real axis, on the imaginary axis or on the unit circle)
+//	F    -- sample values
+//	tol  -- relative tolerance: stop when max|F - r(Z)| ≤ tol ⋅ max|F|. Use 0 for 1e-13
+//	mmax -- maximum number of support points. Use 0 for 100. The degree is ≤ mmax-1
+//
+//	NOTE: the poles, residues and zeros are computed at the end. Spurious poles (Froissart
+//	doublets) may be removed afterwards by calling Cleanup
+func NewAaa(Z, F []complex128, tol float64, mmax int) (o *Aaa) {
+	if len(Z) != len(F) {
+		chk.Panic("the number of sample points and values must be equal. %d != %d", len(Z), len(F))
+	}
+	if len(Z) < 1 {
+		chk.Panic("at least one sample is required")
+	}
+	if tol <= 0 {
+		tol = 1e-13
+	}
+	if mmax <= 0 {
+		mmax = 100
+	}
+	o = new(Aaa)
+	o.z, o.f = Z, F
+	M := len(Z)
+	mmax = utl.Imin(mmax, M)
+	fmax := aaaMaxAbs(F)
+	R := make([]complex128, M) // r(Z) at the current step
+	var fmean complex128
+	for i := 0; i < M; i++ {
+		fmean += F[i]
+	}
+	fmean /= complex(float64(M), 0)
+	for i := 0; i < M; i++ {
+		R[i] = fmean
+	}
+	isSupport := make([]bool, M)
+	C := make([][]complex128, 0, mmax) // Cauchy matrix columns: C[j][i] = 1/(Z[i] - zⱼ)
+	for m := 1; m <= mmax; m++ {
+
+		// select next support point where the error is maximum
+		jmax, emax := 0, -1.0
+		for i := 0; i < M; i++ {
+			if !isSupport[i] && cmplx.Abs(F[i]-R[i]) > emax {
+				jmax, emax = i, cmplx.Abs(F[i]-R[i])
+			}
+		}
+		isSupport[jmax] = true
+		o.Zj = append(o.Zj, Z[jmax])
+		o.Fj = append(o.Fj, F[jmax])
+		col := make([]complex128, M)
+		for i := 0; i < M; i++ {
+			if i != jmax {
+				col[i] = 1.0 / (Z[i] - Z[jmax])
+			}
+		}
+		C = append(C, col)
+
+		// compute weights
+		o.Wj = aaaWeights(Z, F, o.Fj, C, isSupport)
+
+		// evaluate r(Z) and error
+		emax = 0.0
+		for i := 0; i < M; i++ {
+			if isSupport[i] {
+				R[i] = F[i]
+				continue
+			}
+			var num, den complex128
+			for j := 0; j < m; j++ {
+				num += o.Wj[j] * o.Fj[j] * C[j][i]
+				den += o.Wj[j] * C[j][i]
+			}
+			R[i] = num / den
+			emax = math.Max(emax, cmplx.Abs(F[i]-R[i]))
+		}
+		o.Err = append(o.Err, emax)
+		if emax <= tol*fmax {
+			break
+		}
+	}
+	o.calcPolesResZeros()
+	return
+}
+
+// NewAaaReal computes the AAA rational approximation from real samples Y = f(X)
+func NewAaaReal(X, Y []float64, tol float64, mmax int) (o *Aaa) {
+	if len(X) != len(Y) {
+		chk.Panic("the number of sample points and values must be equal. %d != %d", len(X), len(Y))
+	}
+	Z := make([]complex128, len(X))
+	F := make([]complex128, len(X))
+	for i := 0; i < len(X); i++ {
+		Z[i] = complex(X[i], 0)
+		F[i] = complex(Y[i], 0)
+	}
+	return NewAaa(Z, F, tol, mmax)
+}
+
+// EvalC evaluates r(z) for complex z
+func (o *Aaa) EvalC(z complex128) complex128 {
+	var num, den, c complex128
+	for j := 0; j < len(o.Zj); j++ {
+		if z == o.Zj[j] {
+			return o.Fj[j]
+		}
+		c = o.Wj[j] / (z - o.Zj[j])
+		num += c * o.Fj[j]
+		den += c
+	}
+	return num / den
+}
+
+// Eval evaluates the real part of r(x) for real x. This function can be used as fun.Ss
+func (o *Aaa) Eval(x float64) float64 {
+	return real(o.EvalC(complex(x, 0)))
+}
+
+// Degree returns the degree m-1 of the rational function of type (m-1, m-1)
+func (o *Aaa) Degree() int {
+	return len(o.Zj) - 1
+}
+
+// Cleanup removes spurious poles (Froissart doublets); i.e. poles with residues smaller than
+// tol ⋅ max|F| (use tol=0 for 1e-13). For each spurious pole, the nearest support point is removed
+// and the weights are recomputed by least-squares using all the samples. This process is repeated
+// until no spurious poles remain. Returns the number of removed support points
+func (o *Aaa) Cleanup(tol float64) (nremoved int) {
+	if tol <= 0 {
+		tol = 1e-13
+	}
+	fmax := aaaMaxAbs(o.f)
+	for len(o.Zj) > 1 {
+
+		// find support points near spurious poles
+		remove := make([]bool, len(o.Zj))
+		nrm := 0
+		for k := 0; k < len(o.Pol); k++ {
+			if cmplx.Abs(o.Res[k]) < tol*fmax {
+				jmin, dmin := -1, math.MaxFloat64
+				for j := 0; j < len(o.Zj); j++ {
+					if !remove[j] && cmplx.Abs(o.Zj[j]-o.Pol[k]) < dmin {
+						jmin, dmin = j, cmplx.Abs(o.Zj[j]-o.Pol[k])
+					}
+				}
+				if jmin >= 0 && nrm < len(o.Zj)-1 {
+					remove[jmin] = true
+					nrm++
+				}
+			}
+		}
+		if nrm == 0 {
+			return
+		}
+		nremoved += nrm
+
+		// remove support points
+		var zj, fj []complex128
+		for j := 0; j < len(o.Zj); j++ {
+			if !remove[j] {
+				zj = append(zj, o.Zj[j])
+				fj = append(fj, o.Fj[j])
+			}
+		}
+		o.Zj, o.Fj = zj, fj
+
+		// recompute weights
+		M := len(o.z)
+		isSupport := make([]bool, M)
+		C := make([][]complex128, len(o.Zj))
+		for j := 0; j < len(o.Zj); j++ {
+			C[j] = make([]complex128, M)
+			for i := 0; i < M; i++ {
+				if o.z[i] == o.Zj[j] {
+					isSupport[i] = true
+				} else {
+					C[j][i] = 1.0 / (o.z[i] - o.Zj[j])
+				}
+			}
+		}
+		o.Wj = aaaWeights(o.z, o.f, o.Fj, C, isSupport)
+		o.calcPolesResZeros()
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// aaaWeights computes the barycentric weights as the right singular vector corresponding to the
+// smallest singular value of the Loewner matrix A[i][j] = (F[i] - fⱼ) / (Z[i] - zⱼ), where i runs
+// over the samples that are not support points
+func aaaWeights(Z, F, fj []complex128, C [][]complex128, isSupport []bool) (w []complex128) {
+	m := len(fj)
+	nrows := 0
+	for i := 0; i < len(Z); i++ {
+		if !isSupport[i] {
+			nrows++
+		}
+	}
+	w = make([]complex128, m)
+	if nrows == 0 {
+		w[m-1] = 1
+		return
+	}
+	A := la.NewMatrixC(nrows, m)
+	for j := 0; j < m; j++ {
+		r := 0
+		for i := 0; i < len(Z); i++ {
+			if !isSupport[i] {
+				A.Set(r, j, (F[i]-fj[j])*C[j][i])
+				r++
+			}
+		}
+	}
+	if nrows < m { // add zero rows such that the nullspace is found
+		B := la.NewMatrixC(m, m)
+		for i := 0; i < nrows; i++ {
+			for j := 0; j < m; j++ {
+				B.Set(i, j, A.Get(i, j))
+			}
+		}
+		A = B
+	}
+	s := make([]float64, utl.Imin(A.M, A.N))
+	vt := la.NewMatrixC(m, m)
+	la.MatSvdC(s, nil, vt, A, false)
+	for j := 0; j < m; j++ {
+		w[j] = cmplx.Conj(vt.Get(m-1, j))
+	}
+	return
+}
+
+// calcPolesResZeros computes the poles, residues and zeros
+func (o *Aaa) calcPolesResZeros() {
+	m := len(o.Zj)
+	wf := make([]complex128, m)
+	for j := 0; j < m; j++ {
+		wf[j] = o.Wj[j] * o.Fj[j]
+	}
+	o.Pol = aaaRoots(o.Wj, o.Zj)
+	o.Zer = aaaRoots(wf, o.Zj)
+	o.Res = make([]complex128, len(o.Pol))
+	for k, p := range o.Pol {
+		var num, dden, c complex128
+		for j := 0; j < m; j++ {
+			c = 1.0 / (p - o.Zj[j])
+			num += wf[j] * c
+			dden -= o.Wj[j] * c * c
+		}
+		o.Res[k] = num / dden
+	}
+}
+
+// aaaRoots computes the finite roots λ of the barycentric sum
+//
+//	 m-1
+//	 ————    wⱼ
+//	 \     ——————— = 0
+//	 /      λ - zⱼ
+//	 ————
+//	 j=0
+//
+//	The equivalent polynomial q(λ) = Σ wⱼ Π_{k≠j} (λ - zₖ) of degree ≤ m-1 is first mapped with
+//	λ = c + 1/μ, where c is a point away from the support points. Thus, all m-1 roots μ are finite
+//	and μ ≈ 0 corresponds to a root of q at infinity. The roots μ are found by the Aberth-Ehrlich
+//	method [1] using only evaluations of the barycentric sums. Roots at infinity are discarded
+//
+//	References:
+//	[1] Bini DA (1996) Numerical computation of polynomial zeros by means of Aberth's method.
+//	    Numerical Algorithms, 13:179-200
+func aaaRoots(w, z []complex128) (roots []complex128) {
+	m := len(w)
+	n := m - 1
+	if n < 1 {
+		return
+	}
+
+	// centre and radius of support points
+	var zc complex128
+	for j := 0; j < m; j++ {
+		zc += z[j]
+	}
+	zc /= complex(float64(m), 0)
+	rad := 0.0
+	for j := 0; j < m; j++ {
+		rad = math.Max(rad, cmplx.Abs(z[j]-zc))
+	}
+	if rad == 0 {
+		rad = 1
+	}
+	c := zc + complex(2*rad, 0)*cmplx.Exp(complex(0, 0.7))
+
+	// q(μ) = Π (1 + μ (c - zₖ)) ⋅ S(μ) with S(μ) = Σ wⱼ / (1 + μ (c - zⱼ))
+	// q'/q = Σ (c - zₖ) / (1 + μ (c - zₖ)) + S'/S
+	newton := func(μ complex128) complex128 {
+		var S, dS, sum, d, e complex128
+		for j := 0; j < m; j++ {
+			d = c - z[j]
+			e = 1.0 / (1.0 + μ*d)
+			S += w[j] * e
+			dS -= w[j] * d * e * e
+			sum += d * e
+		}
+		return 1.0 / (sum + dS/S) // q/q'
+	}
+
+	// initial guesses: images of points on a circle around the support points
+	μ := make([]complex128, n)
+	for k := 0; k < n; k++ {
+		θ := 2*math.Pi*float64(k)/float64(n) + 0.4
+		μ[k] = 1.0 / (zc + complex(1.5*rad, 0)*cmplx.Exp(complex(0, θ)) - c)
+	}
+
+	// Aberth-Ehrlich iterations
+	done := make([]bool, n)
+	EPS := 4.0 * machEps
+	for it := 0; it < 500; it++ {
+		alldone := true
+		for k := 0; k < n; k++ {
+			if done[k] {
+				continue
+			}
+			ratio := newton(μ[k])
+			var sum complex128
+			for j := 0; j < n; j++ {
+				if j != k {
+					sum += 1.0 / (μ[k] - μ[j])
+				}
+			}
+			δ := ratio / (1.0 - ratio*sum)
+			if cmplx.IsNaN(δ) || cmplx.IsInf(δ) {
+				done[k] = true
+				continue
+			}
+			μ[k] -= δ
+			if cmplx.Abs(δ) <= EPS*cmplx.Abs(μ[k]) {
+				done[k] = true
+			} else {
+				alldone = false
+			}
+		}
+		if alldone {
+			break
+		}
+	}
+
+	// map back and discard roots at infinity
+	for k := 0; k < n; k++ {
+		if cmplx.Abs(μ[k])*rad < 1e-12 {
+			continue
+		}
+		roots = append(roots, c+1.0/μ[k])
+	}
+	return
+}
+
+// aaaMaxAbs returns max|F|
+func aaaMaxAbs(F []complex128) (res float64) {
+	for _, v := range F {
+		res = math.Max(res, cmplx.Abs(v))
+	}
+	return
+}
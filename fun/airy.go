@@ -0,0 +1,76 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// AiryAi returns the Airy function Ai(x)
+func AiryAi(x float64) float64 {
+	ai, _, _, _ := Airy(x)
+	return ai
+}
+
+// AiryBi returns the Airy function Bi(x)
+func AiryBi(x float64) float64 {
+	_, bi, _, _ := Airy(x)
+	return bi
+}
+
+// AiryAiD1 returns the derivative of the Airy function dAi/dx
+func AiryAiD1(x float64) float64 {
+	_, _, aid, _ := Airy(x)
+	return aid
+}
+
+// AiryBiD1 returns the derivative of the Airy function dBi/dx
+func AiryBiD1(x float64) float64 {
+	_, _, _, bid := Airy(x)
+	return bid
+}
+
+// Airy computes the Airy functions Ai(x) and Bi(x) and their derivatives Ai'(x) and Bi'(x) using
+// Bessel functions of fractional order (1/3 and 2/3). See page 289 of [1]
+//
+//	           1    ___
+//	  Ai(x) = ——— ⋅ √ x/3 ⋅ K_{1/3}(z)                                with  z = 2/3 ⋅ x^(3/2)  (x > 0)
+//	           π
+//	           1    ___
+//	  Ai(x) = ——— ⋅ √ |x| ⋅ [J_{1/3}(z) - Y_{1/3}(z) / √3]             with  z = 2/3 ⋅ |x|^(3/2)  (x < 0)
+//	           2
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func Airy(x float64) (ai, bi, aid, bid float64) {
+	ONOVRT := 1.0 / math.Sqrt(3.0)
+	THR := 1.0 / 3.0
+	TWOTHR := 2.0 / 3.0
+	absx := math.Abs(x)
+	rootx := math.Sqrt(absx)
+	z := TWOTHR * absx * rootx
+	if x > 0.0 {
+		io, ko, _, _ := besselIK(THR, z)
+		ai = rootx * ONOVRT * ko / π
+		bi = rootx * (ko/π + 2.0*ONOVRT*io)
+		io, ko, _, _ = besselIK(TWOTHR, z)
+		aid = -x * ONOVRT * ko / π
+		bid = x * (ko/π + 2.0*ONOVRT*io)
+		return
+	}
+	if x < 0.0 {
+		jo, yo, _, _ := besselJY(THR, z)
+		ai = 0.5 * rootx * (jo - ONOVRT*yo)
+		bi = -0.5 * rootx * (yo + ONOVRT*jo)
+		jo, yo, _, _ = besselJY(TWOTHR, z)
+		aid = 0.5 * absx * (ONOVRT*yo + jo)
+		bid = 0.5 * absx * (ONOVRT*jo - yo)
+		return
+	}
+	ai = 0.35502805388781723926 // 1 / (3^(2/3) Γ(2/3))
+	bi = ai / ONOVRT
+	aid = -0.25881940379280679840 // -1 / (3^(1/3) Γ(1/3))
+	bid = -aid / ONOVRT
+	return
+}
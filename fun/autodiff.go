@@ -0,0 +1,132 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"github.com/cpmech/gosl/la"
+)
+
+// DualSv defines a scalar function f(x) of a vector x of dual numbers
+type DualSv func(x []Dual) Dual
+
+// DualVv defines a vector function f(x) of a vector x of dual numbers
+//
+//	Input:
+//	  x -- input vector
+//	Output:
+//	  f -- output vector
+type DualVv func(f, x []Dual)
+
+// HyperDualSv defines a scalar function f(x) of a vector x of hyper-dual numbers
+type HyperDualSv func(x []HyperDual) HyperDual
+
+// AutoDiffSv returns a scalar function F and its gradient G = dF/dx computed by forward-mode
+// automatic differentiation; i.e. the function f is written only once using dual numbers
+//
+//	NOTE: the gradient requires n = len(x) evaluations of f
+func AutoDiffSv(f DualSv) (F Sv, G Vv) {
+	F = func(x la.Vector) float64 {
+		xd := make([]Dual, len(x))
+		for i := 0; i < len(x); i++ {
+			xd[i] = Dual{x[i], 0}
+		}
+		return f(xd).V
+	}
+	G = func(g, x la.Vector) {
+		xd := make([]Dual, len(x))
+		for i := 0; i < len(x); i++ {
+			xd[i] = Dual{x[i], 0}
+		}
+		for j := 0; j < len(x); j++ {
+			xd[j].D = 1
+			g[j] = f(xd).D
+			xd[j].D = 0
+		}
+	}
+	return
+}
+
+// AutoDiffVv returns a vector function F and its Jacobian J = dF/dx computed by forward-mode
+// automatic differentiation; i.e. the function f is written only once using dual numbers
+//
+//	m -- dimension of the output vector f
+//
+//	NOTE: (1) the Jacobian requires n = len(x) evaluations of f
+//	      (2) if the triplet has not been initialised, J.Init(m, n, m*n) is called
+//	      (3) all m*n entries of the Jacobian are put into the triplet
+func AutoDiffVv(f DualVv, m int) (F Vv, J Tv) {
+	F = func(fx, x la.Vector) {
+		xd := make([]Dual, len(x))
+		fd := make([]Dual, m)
+		for i := 0; i < len(x); i++ {
+			xd[i] = Dual{x[i], 0}
+		}
+		f(fd, xd)
+		for i := 0; i < m; i++ {
+			fx[i] = fd[i].V
+		}
+	}
+	J = func(jac *la.Triplet, x la.Vector) {
+		n := len(x)
+		if jac.Max() == 0 {
+			jac.Init(m, n, m*n)
+		}
+		jac.Start()
+		xd := make([]Dual, n)
+		fd := make([]Dual, m)
+		for i := 0; i < n; i++ {
+			xd[i] = Dual{x[i], 0}
+		}
+		for j := 0; j < n; j++ {
+			xd[j].D = 1
+			f(fd, xd)
+			for i := 0; i < m; i++ {
+				jac.Put(i, j, fd[i].D)
+			}
+			xd[j].D = 0
+		}
+	}
+	return
+}
+
+// AutoDiffHessSv returns a scalar function F, its gradient G = dF/dx and its Hessian
+// H = d²F/dx² computed with hyper-dual numbers; i.e. the function f is written only once
+//
+//	NOTE: the Hessian requires n(n+1)/2 evaluations of f, where n = len(x)
+func AutoDiffHessSv(f HyperDualSv) (F Sv, G Vv, H Mv) {
+	seed := func(x la.Vector) (xh []HyperDual) {
+		xh = make([]HyperDual, len(x))
+		for i := 0; i < len(x); i++ {
+			xh[i] = HyperDual{x[i], 0, 0, 0}
+		}
+		return
+	}
+	F = func(x la.Vector) float64 {
+		return f(seed(x)).V
+	}
+	G = func(g, x la.Vector) {
+		xh := seed(x)
+		for j := 0; j < len(x); j++ {
+			xh[j].D1 = 1
+			g[j] = f(xh).D1
+			xh[j].D1 = 0
+		}
+	}
+	H = func(h *la.Matrix, x la.Vector) {
+		xh := seed(x)
+		for i := 0; i < len(x); i++ {
+			xh[i].D1 = 1
+			for j := i; j < len(x); j++ {
+				xh[j].D2 = 1
+				hij := f(xh).D12
+				h.Set(i, j, hij)
+				h.Set(j, i, hij)
+				xh[j].D2 = 0
+			}
+			xh[i].D1 = 0
+		}
+	}
+	return
+}
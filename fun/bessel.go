@@ -0,0 +1,483 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// BesselJ returns the Bessel function of the first kind Jν(x) of real order ν for x ≥ 0
+//
+//	NOTE: negative orders are computed with the reflection formula:
+//	      J₋ν(x) = cos(νπ)⋅Jν(x) - sin(νπ)⋅Yν(x)
+func BesselJ(ν, x float64) float64 {
+	j, _, _, _ := BesselJY(ν, x)
+	return j
+}
+
+// BesselY returns the Bessel function of the second kind Yν(x) of real order ν for x ≥ 0
+//
+//	NOTE: negative orders are computed with the reflection formula:
+//	      Y₋ν(x) = sin(νπ)⋅Jν(x) + cos(νπ)⋅Yν(x)
+func BesselY(ν, x float64) float64 {
+	_, y, _, _ := BesselJY(ν, x)
+	return y
+}
+
+// BesselJd1 returns the first derivative dJν/dx of the Bessel function of the first kind
+func BesselJd1(ν, x float64) float64 {
+	_, _, jd, _ := BesselJY(ν, x)
+	return jd
+}
+
+// BesselYd1 returns the first derivative dYν/dx of the Bessel function of the second kind
+func BesselYd1(ν, x float64) float64 {
+	_, _, _, yd := BesselJY(ν, x)
+	return yd
+}
+
+// BesselJY computes the Bessel functions Jν(x) and Yν(x) of real order ν and their derivatives
+// using Steed's method (continued fractions) with Temme's series for small x. See page 283 of [1].
+//
+//	Special cases:
+//	  x < 0  ⇒  all results are NaN
+//	  x = 0  ⇒  J0(0) = 1, Jν(0) = 0 (ν > 0) and Yν(0) = -Inf
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func BesselJY(ν, x float64) (j, y, jd, yd float64) {
+	if x < 0 {
+		return math.NaN(), math.NaN(), math.NaN(), math.NaN()
+	}
+	if ν < 0 {
+		jp, yp, jdp, ydp := BesselJY(-ν, x)
+		s, c := math.Sincos(-ν * π)
+		if -ν == math.Floor(-ν) { // integer order: avoid round-off in sin(νπ)
+			s, c = 0, NegOnePowN(int(-ν))
+		} else if -2.0*ν == math.Floor(-2.0*ν) { // half-integer order: avoid round-off in cos(νπ)
+			s, c = NegOnePowN(int(-ν-0.5)), 0
+		}
+		j = c*jp - s*yp
+		y = s*jp + c*yp
+		jd = c*jdp - s*ydp
+		yd = s*jdp + c*ydp
+		return
+	}
+	if x == 0 {
+		y, yd = math.Inf(-1), math.Inf(+1)
+		switch {
+		case ν == 0:
+			return 1, y, 0, yd
+		case ν < 1:
+			return 0, y, math.Inf(+1), yd
+		case ν == 1:
+			return 0, y, 0.5, yd
+		}
+		return 0, y, 0, yd
+	}
+	return besselJY(ν, x)
+}
+
+// besselJY implements BesselJY for ν ≥ 0 and x > 0
+func besselJY(xnu, x float64) (jo, yo, jpo, ypo float64) {
+
+	// constants
+	MAXIT := 10000
+	EPS := machEps
+	FPMIN := 2.2250738585072014e-308 / EPS // smallest normalised number divided by EPS
+	XMIN := 2.0
+
+	// auxiliary variables
+	var a, b, br, bi, c, cr, ci, d, del, del1, den, di, dlr, dli, dr, e, f, fact, fact2 float64
+	var fact3, ff, gam, gam1, gam2, gammi, gampl, h, p, pimu, pimu2, q, r, rjl float64
+	var rjl1, rjmu, rjp1, rjpl, rjtemp, ry1, rymu, rymup, rytemp, sum, sum1 float64
+	var temp, w, x2, xi, xi2, xmu, xmu2, xx float64
+	var i, isign, nl int
+
+	// nl is the number of downward recurrences of the J's and upward recurrences of Y's.
+	// xmu lies between -1/2 and 1/2 for x < XMIN, while it is chosen so that x is greater than
+	// the turning point for x ≥ XMIN
+	if x < XMIN {
+		nl = int(xnu + 0.5)
+	} else {
+		nl = utl.Imax(0, int(xnu-x+1.5))
+	}
+	xmu = xnu - float64(nl)
+	xmu2 = xmu * xmu
+	xi = 1.0 / x
+	xi2 = 2.0 * xi
+	w = xi2 / π // the Wronskian
+
+	// evaluate CF1 by modified Lentz's method
+	isign = 1
+	h = xnu * xi
+	if h < FPMIN {
+		h = FPMIN
+	}
+	b = xi2 * xnu
+	d = 0.0
+	c = h
+	for i = 0; i < MAXIT; i++ {
+		b += xi2
+		d = b - d
+		if math.Abs(d) < FPMIN {
+			d = FPMIN
+		}
+		c = b - 1.0/c
+		if math.Abs(c) < FPMIN {
+			c = FPMIN
+		}
+		d = 1.0 / d
+		del = c * d
+		h = del * h
+		if d < 0.0 {
+			isign = -isign
+		}
+		if math.Abs(del-1.0) <= EPS {
+			break
+		}
+	}
+	if i >= MAXIT {
+		chk.Panic("x=%g is too large in BesselJY; try asymptotic expansion", x)
+	}
+
+	// initialize Jν and J'ν for downward recurrence
+	rjl = float64(isign) * FPMIN
+	rjpl = h * rjl
+	rjl1 = rjl // store values for later rescaling
+	rjp1 = rjpl
+	fact = xnu * xi
+	for l := nl - 1; l >= 0; l-- {
+		rjtemp = fact*rjl + rjpl
+		fact -= xi
+		rjpl = fact*rjtemp - rjl
+		rjl = rjtemp
+	}
+	if rjl == 0.0 {
+		rjl = EPS
+	}
+	f = rjpl / rjl // now have unnormalized Jμ and J'μ
+
+	// use series
+	if x < XMIN {
+		x2 = 0.5 * x
+		pimu = π * xmu
+		if math.Abs(pimu) < EPS {
+			fact = 1.0
+		} else {
+			fact = pimu / math.Sin(pimu)
+		}
+		d = -math.Log(x2)
+		e = xmu * d
+		if math.Abs(e) < EPS {
+			fact2 = 1.0
+		} else {
+			fact2 = math.Sinh(e) / e
+		}
+		xx = 8.0*xmu*xmu - 1.0 // Chebyshev evaluation of Γ1 and Γ2
+		gam1 = chebev(besselC1, xx)
+		gam2 = chebev(besselC2, xx)
+		gampl = gam2 - xmu*gam1
+		gammi = gam2 + xmu*gam1
+		ff = 2.0 / π * fact * (gam1*math.Cosh(e) + gam2*fact2*d) // f0
+		e = math.Exp(e)
+		p = e / (gampl * π) // p0
+		q = 1.0 / (e * π * gammi)
+		pimu2 = 0.5 * pimu
+		if math.Abs(pimu2) < EPS {
+			fact3 = 1.0
+		} else {
+			fact3 = math.Sin(pimu2) / pimu2
+		}
+		r = π * pimu2 * fact3 * fact3
+		c = 1.0
+		d = -x2 * x2
+		sum = ff + r*q
+		sum1 = p
+		for i = 1; i <= MAXIT; i++ {
+			I := float64(i)
+			ff = (I*ff + p + q) / (I*I - xmu2)
+			c *= (d / I)
+			p /= (I - xmu)
+			q /= (I + xmu)
+			del = c * (ff + r*q)
+			sum += del
+			del1 = c*p - I*del
+			sum1 += del1
+			if math.Abs(del) < (1.0+math.Abs(sum))*EPS {
+				break
+			}
+		}
+		if i > MAXIT {
+			chk.Panic("series failed to converge in BesselJY with x=%g", x)
+		}
+		rymu = -sum
+		ry1 = -sum1 * xi2
+		rymup = xmu*xi*rymu - ry1
+		rjmu = w / (rymup - f*rymu) // equation (6.6.13) of [1]
+
+		// evaluate CF2 by modified Lentz's method
+	} else {
+		a = 0.25 - xmu2
+		p = -0.5 * xi
+		q = 1.0
+		br = 2.0 * x
+		bi = 2.0
+		fact = a * xi / (p*p + q*q)
+		cr = br + q*fact
+		ci = bi + p*fact
+		den = br*br + bi*bi
+		dr = br / den
+		di = -bi / den
+		dlr = cr*dr - ci*di
+		dli = cr*di + ci*dr
+		temp = p*dlr - q*dli
+		q = p*dli + q*dlr
+		p = temp
+		for i = 1; i < MAXIT; i++ {
+			a += float64(2 * i)
+			bi += 2.0
+			dr = a*dr + br
+			di = a*di + bi
+			if math.Abs(dr)+math.Abs(di) < FPMIN {
+				dr = FPMIN
+			}
+			fact = a / (cr*cr + ci*ci)
+			cr = br + cr*fact
+			ci = bi - ci*fact
+			if math.Abs(cr)+math.Abs(ci) < FPMIN {
+				cr = FPMIN
+			}
+			den = dr*dr + di*di
+			dr /= den
+			di /= -den
+			dlr = cr*dr - ci*di
+			dli = cr*di + ci*dr
+			temp = p*dlr - q*dli
+			q = p*dli + q*dlr
+			p = temp
+			if math.Abs(dlr-1.0)+math.Abs(dli) <= EPS {
+				break
+			}
+		}
+		if i >= MAXIT {
+			chk.Panic("CF2 failed in BesselJY with x=%g", x)
+		}
+		gam = (p - f) / q
+		rjmu = math.Sqrt(w / ((p-f)*gam + q))
+		if rjl < 0 {
+			rjmu = -rjmu
+		}
+		rymu = rjmu * gam
+		rymup = rymu * (p + q/gam)
+		ry1 = xmu*xi*rymu - rymup
+	}
+
+	// scale original Jν and J'ν
+	fact = rjmu / rjl
+	jo = rjl1 * fact
+	jpo = rjp1 * fact
+
+	// upward recurrence of Yν
+	for i = 1; i <= nl; i++ {
+		rytemp = (xmu+float64(i))*xi2*ry1 - rymu
+		rymu = ry1
+		ry1 = rytemp
+	}
+	yo = rymu
+	ypo = xnu*xi*rymu - ry1
+	return
+}
+
+// besselIK computes the modified Bessel functions Iν(x) and Kν(x) of real order ν ≥ 0 and their
+// derivatives for x > 0. See page 290 of [1]
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func besselIK(xnu, x float64) (io, ko, ipo, kpo float64) {
+
+	// constants
+	MAXIT := 10000
+	EPS := machEps
+	FPMIN := 2.2250738585072014e-308 / EPS // smallest normalised number divided by EPS
+	XMIN := 2.0
+
+	// auxiliary variables
+	var a, a1, b, c, d, del, del1, delh, dels, e, f, fact, fact2, ff, gam1, gam2 float64
+	var gammi, gampl, h, p, pimu, q, q1, q2, qnew, ril, ril1, rimu, rip1, ripl float64
+	var ritemp, rk1, rkmu, rkmup, rktemp, s, sum, sum1, x2, xi, xi2, xmu, xmu2, xx float64
+	var i int
+
+	// nl is the number of downward recurrences of the I's and upward recurrences of K's.
+	// xmu lies between -1/2 and 1/2
+	nl := int(xnu + 0.5)
+	xmu = xnu - float64(nl)
+	xmu2 = xmu * xmu
+	xi = 1.0 / x
+	xi2 = 2.0 * xi
+
+	// evaluate CF1 by modified Lentz's method
+	h = xnu * xi
+	if h < FPMIN {
+		h = FPMIN
+	}
+	b = xi2 * xnu
+	d = 0.0
+	c = h
+	for i = 0; i < MAXIT; i++ {
+		b += xi2
+		d = 1.0 / (b + d) // denominators cannot be zero here, so no need for special precautions
+		c = b + 1.0/c
+		del = c * d
+		h = del * h
+		if math.Abs(del-1.0) <= EPS {
+			break
+		}
+	}
+	if i >= MAXIT {
+		chk.Panic("x=%g is too large in besselIK; try asymptotic expansion", x)
+	}
+
+	// initialize Iν and I'ν for downward recurrence
+	ril = FPMIN
+	ripl = h * ril
+	ril1 = ril // store values for later rescaling
+	rip1 = ripl
+	fact = xnu * xi
+	for l := nl - 1; l >= 0; l-- {
+		ritemp = fact*ril + ripl
+		fact -= xi
+		ripl = fact*ritemp + ril
+		ril = ritemp
+	}
+	f = ripl / ril // now have unnormalized Iμ and I'μ
+
+	// use series
+	if x < XMIN {
+		x2 = 0.5 * x
+		pimu = π * xmu
+		if math.Abs(pimu) < EPS {
+			fact = 1.0
+		} else {
+			fact = pimu / math.Sin(pimu)
+		}
+		d = -math.Log(x2)
+		e = xmu * d
+		if math.Abs(e) < EPS {
+			fact2 = 1.0
+		} else {
+			fact2 = math.Sinh(e) / e
+		}
+		xx = 8.0*xmu*xmu - 1.0 // Chebyshev evaluation of Γ1 and Γ2
+		gam1 = chebev(besselC1, xx)
+		gam2 = chebev(besselC2, xx)
+		gampl = gam2 - xmu*gam1
+		gammi = gam2 + xmu*gam1
+		ff = fact * (gam1*math.Cosh(e) + gam2*fact2*d) // f0
+		sum = ff
+		e = math.Exp(e)
+		p = 0.5 * e / gampl // p0
+		q = 0.5 / (e * gammi)
+		c = 1.0
+		d = x2 * x2
+		sum1 = p
+		for i = 1; i <= MAXIT; i++ {
+			I := float64(i)
+			ff = (I*ff + p + q) / (I*I - xmu2)
+			c *= (d / I)
+			p /= (I - xmu)
+			q /= (I + xmu)
+			del = c * ff
+			sum += del
+			del1 = c * (p - I*ff)
+			sum1 += del1
+			if math.Abs(del) < math.Abs(sum)*EPS {
+				break
+			}
+		}
+		if i > MAXIT {
+			chk.Panic("series failed to converge in besselIK with x=%g", x)
+		}
+		rkmu = sum
+		rk1 = sum1 * xi2
+
+		// evaluate CF2 by Steed's algorithm
+	} else {
+		b = 2.0 * (1.0 + x)
+		d = 1.0 / b
+		h = d
+		delh = d
+		q1 = 0.0
+		q2 = 1.0
+		a1 = 0.25 - xmu2
+		q = a1
+		c = a1
+		a = -a1
+		s = 1.0 + q*delh
+		for i = 1; i < MAXIT; i++ {
+			a -= float64(2 * i)
+			c = -a * c / (float64(i) + 1.0)
+			qnew = (q1 - b*q2) / a
+			q1 = q2
+			q2 = qnew
+			q += c * qnew
+			b += 2.0
+			d = 1.0 / (b + a*d)
+			delh = (b*d - 1.0) * delh
+			h += delh
+			dels = q * delh
+			s += dels
+			if math.Abs(dels/s) <= EPS {
+				break
+			}
+		}
+		if i >= MAXIT {
+			chk.Panic("CF2 failed in besselIK with x=%g", x)
+		}
+		h = a1 * h
+		rkmu = math.Sqrt(π/(2.0*x)) * math.Exp(-x) / s
+		rk1 = rkmu * (xmu + x + 0.5 - h) * xi
+	}
+
+	// scale original Iν and I'ν
+	rkmup = xmu*xi*rkmu - rk1
+	rimu = xi / (f*rkmu - rkmup) // get Iμ from the Wronskian
+	io = (rimu * ril1) / ril
+	ipo = (rimu * rip1) / ril
+
+	// upward recurrence of Kν
+	for i = 1; i <= nl; i++ {
+		rktemp = (xmu+float64(i))*xi2*rk1 + rkmu
+		rkmu = rk1
+		rk1 = rktemp
+	}
+	ko = rkmu
+	kpo = xnu*xi*rkmu - rk1
+	return
+}
+
+// Chebyshev coefficients for Γ1(μ) and Γ2(μ) used by Temme's series
+var (
+	besselC1 = []float64{-1.142022680371168e0, 6.5165112670737e-3, 3.087090173086e-4,
+		-3.4706269649e-6, 6.9437664e-9, 3.67795e-11, -1.356e-13}
+	besselC2 = []float64{1.843740587300905e0, -7.68528408447867e-2, 1.2719271366546e-3,
+		-4.9717367042e-6, -3.31261198e-8, 2.423096e-10, -1.702e-13, -1.49e-15}
+)
+
+// chebev evaluates the Chebyshev series Σ c_k T_k(x) - c_0/2 with x ∈ [-1,1]
+func chebev(c []float64, x float64) float64 {
+	var sv, d, dd float64
+	for j := len(c) - 1; j > 0; j-- {
+		sv = d
+		d = 2.0*x*d - dd + c[j]
+		dd = sv
+	}
+	return x*d - dd + 0.5*c[0]
+}
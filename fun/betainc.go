@@ -0,0 +1,203 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// BetaInc computes the regularized incomplete beta function Iₓ(a,b)
+//
+//	                     x
+//	              1     ⌠
+//	Iₓ(a,b) = ———————— ⋅ │  t^(a-1) ⋅ (1-t)^(b-1) dt
+//	           B(a,b)   ⌡
+//	                   0
+//
+//	where: a > 0, b > 0 and 0 ≤ x ≤ 1
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func BetaInc(a, b, x float64) float64 {
+	if a <= 0.0 || b <= 0.0 {
+		chk.Panic("BetaInc requires a > 0 and b > 0. a=%g, b=%g is invalid", a, b)
+	}
+	if x < 0.0 || x > 1.0 {
+		chk.Panic("BetaInc requires 0 ≤ x ≤ 1. x=%g is invalid", x)
+	}
+	if x == 0.0 || x == 1.0 {
+		return x
+	}
+	if a > 3000 && b > 3000 {
+		return betaIapprox(a, b, x)
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	bt := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1.0-x))
+	if x < (a+1.0)/(a+b+2.0) {
+		return bt * betaCf(a, b, x) / a // use continued fraction directly
+	}
+	return 1.0 - bt*betaCf(b, a, 1.0-x)/b // use continued fraction after making the symmetry transformation
+}
+
+// InvBetaInc returns x such that Iₓ(a,b) = p; i.e. it inverts the regularized incomplete beta
+// function using Halley's method. See page 273 of [1]
+//
+//	where: a > 0, b > 0 and 0 ≤ p ≤ 1
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func InvBetaInc(p, a, b float64) float64 {
+	if a <= 0.0 || b <= 0.0 {
+		chk.Panic("InvBetaInc requires a > 0 and b > 0. a=%g, b=%g is invalid", a, b)
+	}
+	if p <= 0.0 {
+		return 0.0
+	}
+	if p >= 1.0 {
+		return 1.0
+	}
+	EPS := 1e-8
+	var pp, t, u, err, x, al, h, w, lna, lnb float64
+	a1 := a - 1.0
+	b1 := b - 1.0
+	if a >= 1.0 && b >= 1.0 { // set initial guess. See (26.5.22) of Abramowitz and Stegun
+		pp = p
+		if p >= 0.5 {
+			pp = 1.0 - p
+		}
+		t = math.Sqrt(-2.0 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1.0+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		al = (x*x - 3.0) / 6.0
+		h = 2.0 / (1.0/(2.0*a-1.0) + 1.0/(2.0*b-1.0))
+		w = (x*math.Sqrt(al+h)/h - (1.0/(2.0*b-1)-1.0/(2.0*a-1.0))*(al+5.0/6.0-2.0/(3.0*h)))
+		x = a / (a + b*math.Exp(2.0*w))
+	} else {
+		lna = math.Log(a / (a + b))
+		lnb = math.Log(b / (a + b))
+		t = math.Exp(a*lna) / a
+		u = math.Exp(b*lnb) / b
+		w = t + u
+		if p < t/w {
+			x = math.Pow(a*w*p, 1.0/a)
+		} else {
+			x = 1.0 - math.Pow(b*w*(1.0-p), 1.0/b)
+		}
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	afac := -la - lb + lab
+	for j := 0; j < 10; j++ {
+		if x == 0.0 || x == 1.0 {
+			return x // a or b too small for accurate calculation
+		}
+		err = BetaInc(a, b, x) - p
+		t = math.Exp(a1*math.Log(x) + b1*math.Log(1.0-x) + afac)
+		u = err / t
+		t = u / (1.0 - 0.5*math.Min(1.0, u*(a1/x-b1/(1.0-x)))) // Halley's method
+		x -= t
+		if x <= 0.0 {
+			x = 0.5 * (x + t) // bisect if x tries to go negative or greater than 1
+		}
+		if x >= 1.0 {
+			x = 0.5 * (x + t + 1.0)
+		}
+		if math.Abs(t) < EPS*x && j > 0 {
+			break
+		}
+	}
+	return x
+}
+
+// betaCf evaluates the continued fraction for the incomplete beta function by the modified
+// Lentz's method
+func betaCf(a, b, x float64) float64 {
+	var m2, aa, del float64
+	qab := a + b
+	qap := a + 1.0
+	qam := a - 1.0
+	c := 1.0
+	d := 1.0 - qab*x/qap
+	if math.Abs(d) < gamIncFpmin {
+		d = gamIncFpmin
+	}
+	d = 1.0 / d
+	h := d
+	for m := 1; m < 10000; m++ {
+		M := float64(m)
+		m2 = 2.0 * M
+		aa = M * (b - M) * x / ((qam + m2) * (a + m2))
+		d = 1.0 + aa*d // one step (the even one) of the recurrence
+		if math.Abs(d) < gamIncFpmin {
+			d = gamIncFpmin
+		}
+		c = 1.0 + aa/c
+		if math.Abs(c) < gamIncFpmin {
+			c = gamIncFpmin
+		}
+		d = 1.0 / d
+		h *= d * c
+		aa = -(a + M) * (qab + M) * x / ((a + m2) * (qap + m2))
+		d = 1.0 + aa*d // next step of the recurrence (the odd one)
+		if math.Abs(d) < gamIncFpmin {
+			d = gamIncFpmin
+		}
+		c = 1.0 + aa/c
+		if math.Abs(c) < gamIncFpmin {
+			c = gamIncFpmin
+		}
+		d = 1.0 / d
+		del = d * c
+		h *= del
+		if math.Abs(del-1.0) <= machEps {
+			break
+		}
+	}
+	return h
+}
+
+// betaIapprox computes the incomplete beta function by Gauss-Legendre quadrature for large a and b
+func betaIapprox(a, b, x float64) float64 {
+	a1 := a - 1.0
+	b1 := b - 1.0
+	mu := a / (a + b)
+	lnmu := math.Log(mu)
+	lnmuc := math.Log(1.0 - mu)
+	t := math.Sqrt(a * b / ((a + b) * (a + b) * (a + b + 1.0)))
+	var xu float64
+	if x > mu { // set how far to integrate into the tail
+		if x >= 1.0 {
+			return 1.0
+		}
+		xu = math.Min(1.0, math.Max(mu+10.0*t, x+5.0*t))
+	} else {
+		if x <= 0.0 {
+			return 0.0
+		}
+		xu = math.Max(0.0, math.Min(mu-10.0*t, x-5.0*t))
+	}
+	sum := 0.0
+	for j := 0; j < len(gamIncY); j++ { // Gauss-Legendre
+		t = x + (xu-x)*gamIncY[j]
+		sum += gamIncW[j] * math.Exp(a1*(math.Log(t)-lnmu)+b1*(math.Log(1-t)-lnmuc))
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	ans := sum * (xu - x) * math.Exp(a1*lnmu-la+b1*lnmuc-lb+lab)
+	if x > mu { // ans = 1 - Iₓ(a,b)
+		return 1.0 - ans
+	}
+	return -ans // ans = -Iₓ(a,b)
+}
@@ -0,0 +1,663 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// ChebyFun implements an adaptive (piecewise) Chebyshev representation of a function f(x) on
+// [a,b] similar to Chebfun [1]. The degree of each piece is automatically selected by sampling f
+// at 2ᵐ+1 Chebyshev-Gauss-Lobatto points (m = 4, 5, ...) until the Chebyshev coefficients decay
+// to the machine precision level (plateau) according to [2]
+//
+//	Each piece i spans [Breaks[i], Breaks[i+1]] and is represented by:
+//
+//	                N
+//	        f(x) =  Σ   Coef[i][k] ⋅ T_k(t(x))         t(x) = (2⋅x - xa - xb) / (xb - xa)
+//	               k=0
+//
+//	where xa = Breaks[i], xb = Breaks[i+1] and T_k are the Chebyshev polynomials.
+//
+//	References:
+//	[1] Driscoll TA, Hale N, Trefethen LN (2014) Chebfun Guide. Pafnuty Publications, Oxford
+//	[2] Aurentz JL, Trefethen LN (2017) Chopping a Chebyshev series. ACM Transactions on
+//	    Mathematical Software, 43(4):33
+//	[3] Trefethen LN (2013) Approximation Theory and Approximation Practice. SIAM. 305p
+//	[4] Boyd JP (2002) Computing zeros on a real interval through Chebyshev expansion and
+//	    polynomial rootfinding. SIAM Journal on Numerical Analysis, 40(5):1666-1682
+type ChebyFun struct {
+	Breaks []float64   // breakpoints: a = x₀ < x₁ < ... < xₘ = b
+	Coef   [][]float64 // Chebyshev coefficients of each piece [npieces][degree+1]
+}
+
+// constants for ChebyFun
+const (
+	chebyFunMaxN      = 1 << 16 // maximum N (number of points - 1) of each piece
+	chebyFunMaxNsplit = 1 << 7  // maximum N of each piece when splitting automatically
+	chebyFunRootSplit = 50      // degree above which the root finder subdivides the interval
+)
+
+// NewChebyFun returns a new ChebyFun representing f on [Breaks[0], Breaks[len(Breaks)-1]]
+//
+//	breaks -- breakpoints [a, x₁, x₂, ..., b] dividing the interval into pieces. At least the
+//	          two endpoints a and b must be given. The breakpoints must be increasing.
+//	          Breakpoints should be placed where f or its derivatives are discontinuous.
+//
+//	NOTE: the function must be resolvable with up to 2¹⁶+1 points in each piece
+func NewChebyFun(f Ss, breaks []float64) (o *ChebyFun) {
+	chebyFunCheckBreaks(breaks)
+	o = new(ChebyFun)
+	o.Breaks = make([]float64, len(breaks))
+	copy(o.Breaks, breaks)
+	o.Coef = make([][]float64, len(breaks)-1)
+	for i := 0; i < len(breaks)-1; i++ {
+		c, _, ok := chebyFunPiece(f, breaks[i], breaks[i+1], chebyFunMaxN, 0, 0)
+		if !ok {
+			chk.Panic("cannot resolve function on [%g,%g] with %d points. Try adding breakpoints or use NewChebyFunSplit\n", breaks[i], breaks[i+1], chebyFunMaxN+1)
+		}
+		o.Coef[i] = c
+	}
+	return
+}
+
+// NewChebyFunSplit returns a new ChebyFun representing f on [xa,xb] with breakpoints placed
+// automatically by recursive bisection of the pieces that cannot be resolved with up to 2⁷+1
+// points. This is useful for functions with discontinuities at unknown locations
+//
+//	NOTE: as in Chebfun [1], the pieces are resolved relative to the global scales of f and x
+//	      (and not to their own scales); otherwise, the rounding errors near a discontinuity of
+//	      f or f' would prevent the pieces from ever being resolved. The bisection stops at
+//	      pieces narrower than 10⁻¹⁴⋅(xb-xa)
+func NewChebyFunSplit(f Ss, xa, xb float64) (o *ChebyFun) {
+	chebyFunCheckBreaks([]float64{xa, xb})
+	o = new(ChebyFun)
+	o.Breaks = []float64{xa}
+	hmin := 1e-14 * (xb - xa)
+	hscale := math.Max(math.Max(math.Abs(xa), math.Abs(xb)), xb-xa)
+	_, vscale, _ := chebyFunPiece(f, xa, xb, chebyFunMaxNsplit, 0, 0)
+	var split func(a, b float64)
+	split = func(a, b float64) {
+		c, vlocal, ok := chebyFunPiece(f, a, b, chebyFunMaxNsplit, vscale, hscale)
+		vscale = math.Max(vscale, vlocal)
+		if !ok && b-a > hmin {
+			m := 0.5 * (a + b)
+			if m > a && m < b {
+				split(a, m)
+				split(m, b)
+				return
+			}
+		}
+		o.Breaks = append(o.Breaks, b)
+		o.Coef = append(o.Coef, c)
+	}
+	split(xa, xb)
+	o.mergePieces(f, vscale, hscale)
+	return
+}
+
+// NewChebyFunCoef returns a new ChebyFun with given breakpoints and coefficients
+func NewChebyFunCoef(breaks []float64, coef [][]float64) (o *ChebyFun) {
+	chebyFunCheckBreaks(breaks)
+	if len(coef) != len(breaks)-1 {
+		chk.Panic("the number of pieces must be equal to len(breaks)-1=%d. %d is invalid\n", len(breaks)-1, len(coef))
+	}
+	o = new(ChebyFun)
+	o.Breaks = make([]float64, len(breaks))
+	copy(o.Breaks, breaks)
+	o.Coef = make([][]float64, len(coef))
+	for i, c := range coef {
+		if len(c) < 1 {
+			chk.Panic("piece %d must have at least one coefficient\n", i)
+		}
+		o.Coef[i] = make([]float64, len(c))
+		copy(o.Coef[i], c)
+	}
+	return
+}
+
+// Domain returns the interval [a,b] where f is represented
+func (o *ChebyFun) Domain() (xa, xb float64) {
+	return o.Breaks[0], o.Breaks[len(o.Breaks)-1]
+}
+
+// Degree returns the degree of the polynomial representing piece i
+func (o *ChebyFun) Degree(i int) int {
+	return len(o.Coef[i]) - 1
+}
+
+// Eval evaluates f(x). Points outside [a,b] are extrapolated using the first or last piece
+func (o *ChebyFun) Eval(x float64) float64 {
+	i := o.pieceIndex(x)
+	return chebyClenshaw(o.Coef[i], o.mapToRef(i, x))
+}
+
+// Add returns f + g where g is another ChebyFun over the same domain
+func (o *ChebyFun) Add(g *ChebyFun) *ChebyFun {
+	return o.binaryOp(g, func(a, b float64) float64 { return a + b })
+}
+
+// Sub returns f - g where g is another ChebyFun over the same domain
+func (o *ChebyFun) Sub(g *ChebyFun) *ChebyFun {
+	return o.binaryOp(g, func(a, b float64) float64 { return a - b })
+}
+
+// Mul returns f ⋅ g where g is another ChebyFun over the same domain
+func (o *ChebyFun) Mul(g *ChebyFun) *ChebyFun {
+	return o.binaryOp(g, func(a, b float64) float64 { return a * b })
+}
+
+// Scale returns α ⋅ f + β
+func (o *ChebyFun) Scale(α, β float64) *ChebyFun {
+	r := NewChebyFunCoef(o.Breaks, o.Coef)
+	for i := range r.Coef {
+		for k := range r.Coef[i] {
+			r.Coef[i][k] *= α
+		}
+		r.Coef[i][0] += β
+	}
+	return r
+}
+
+// Compose returns g(f(x)) computed adaptively on the breakpoints of f
+//
+//	NOTE: g must be smooth over the range of f; otherwise, breakpoints will be required
+func (o *ChebyFun) Compose(g Ss) *ChebyFun {
+	r := &ChebyFun{Breaks: make([]float64, len(o.Breaks)), Coef: make([][]float64, len(o.Coef))}
+	copy(r.Breaks, o.Breaks)
+	for i := range o.Coef {
+		xm := 0.5 * (o.Breaks[i] + o.Breaks[i+1])
+		r.Coef[i] = o.buildPiece(func(x float64) float64 {
+			return g(o.evalIn(x, xm))
+		}, o.Breaks[i], o.Breaks[i+1])
+	}
+	return r
+}
+
+// Diff returns the derivative df/dx. The result may be discontinuous at the breakpoints
+func (o *ChebyFun) Diff() *ChebyFun {
+	r := &ChebyFun{Breaks: make([]float64, len(o.Breaks)), Coef: make([][]float64, len(o.Coef))}
+	copy(r.Breaks, o.Breaks)
+	for i, c := range o.Coef {
+		r.Coef[i] = chebyDiffCoef(c, 2.0/(o.Breaks[i+1]-o.Breaks[i]))
+	}
+	return r
+}
+
+// Cumsum returns the indefinite integral F(x) = ∫ f(s) ds from a to x. The result is continuous
+func (o *ChebyFun) Cumsum() *ChebyFun {
+	r := &ChebyFun{Breaks: make([]float64, len(o.Breaks)), Coef: make([][]float64, len(o.Coef))}
+	copy(r.Breaks, o.Breaks)
+	offset := 0.0
+	for i, c := range o.Coef {
+		h := 0.5 * (o.Breaks[i+1] - o.Breaks[i])
+		n := len(c)
+		C := make([]float64, n+1)
+		cc := func(k int) float64 {
+			if k < n {
+				return c[k]
+			}
+			return 0
+		}
+		C[1] = h * (cc(0) - cc(2)/2.0)
+		for k := 2; k <= n; k++ {
+			C[k] = h * (cc(k-1) - cc(k+1)) / float64(2*k)
+		}
+		sgn := -1.0
+		for k := 1; k <= n; k++ { // F(t=-1) = 0
+			C[0] -= sgn * C[k]
+			sgn = -sgn
+		}
+		C[0] += offset
+		offset = chebyFunSum(C)
+		r.Coef[i] = C
+	}
+	return r
+}
+
+// Integral computes the definite integral ∫ f(x) dx from a to b (Clenshaw-Curtis quadrature)
+func (o *ChebyFun) Integral() (res float64) {
+	for i, c := range o.Coef {
+		h := 0.5 * (o.Breaks[i+1] - o.Breaks[i])
+		for k := 0; k < len(c); k += 2 {
+			res += h * c[k] * 2.0 / (1.0 - float64(k*k))
+		}
+	}
+	return
+}
+
+// Max returns the location and value of the global maximum of f in [a,b]
+func (o *ChebyFun) Max() (xmax, fmax float64) {
+	xmax, fmax = o.extremum(1)
+	return
+}
+
+// Min returns the location and value of the global minimum of f in [a,b]
+func (o *ChebyFun) Min() (xmin, fmin float64) {
+	xmin, fmin = o.extremum(-1)
+	fmin = -fmin
+	return
+}
+
+// Roots returns all (real) roots of f in [a,b] sorted in ascending order
+//
+//	The roots of each piece are computed as the eigenvalues of the colleague matrix [3,4].
+//	Pieces of high degree are recursively subdivided first [4]. Each root is polished with
+//	Newton's method afterwards.
+func (o *ChebyFun) Roots() (roots []float64) {
+	for i, c := range o.Coef {
+		xa, xb := o.Breaks[i], o.Breaks[i+1]
+		for _, t := range chebyRootsRec(c) {
+			x := xa + 0.5*(xb-xa)*(t+1.0)
+			if len(roots) > 0 && math.Abs(x-roots[len(roots)-1]) < 1e-12*(xb-xa) {
+				continue // root at breakpoint
+			}
+			roots = append(roots, x)
+		}
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// chebyFunCheckBreaks checks breakpoints
+func chebyFunCheckBreaks(breaks []float64) {
+	if len(breaks) < 2 {
+		chk.Panic("at least two breakpoints (the endpoints) are required\n")
+	}
+	for i := 1; i < len(breaks); i++ {
+		if breaks[i] <= breaks[i-1] {
+			chk.Panic("breakpoints must be increasing. breaks=%v is invalid\n", breaks)
+		}
+	}
+}
+
+// pieceIndex returns the index of the piece containing x
+func (o *ChebyFun) pieceIndex(x float64) int {
+	i := sort.SearchFloat64s(o.Breaks, x) - 1 // Breaks[i] < x ≤ Breaks[i+1]
+	if i < 0 {
+		return 0
+	}
+	if i > len(o.Coef)-1 {
+		return len(o.Coef) - 1
+	}
+	return i
+}
+
+// mapToRef maps x to the reference interval [-1,1] of piece i
+func (o *ChebyFun) mapToRef(i int, x float64) float64 {
+	return (2.0*x - o.Breaks[i] - o.Breaks[i+1]) / (o.Breaks[i+1] - o.Breaks[i])
+}
+
+// evalIn evaluates f(x) using the piece containing xm; thus avoiding the ambiguity at breakpoints
+func (o *ChebyFun) evalIn(x, xm float64) float64 {
+	i := o.pieceIndex(xm)
+	return chebyClenshaw(o.Coef[i], o.mapToRef(i, x))
+}
+
+// buildPiece computes the coefficients of a piece of a derived function
+func (o *ChebyFun) buildPiece(f Ss, xa, xb float64) []float64 {
+	c, _, ok := chebyFunPiece(f, xa, xb, chebyFunMaxN, 0, 0)
+	if !ok {
+		chk.Panic("cannot resolve function on [%g,%g] with %d points\n", xa, xb, chebyFunMaxN+1)
+	}
+	return c
+}
+
+// binaryOp computes h(x) = op(f(x), g(x)) on the union of breakpoints
+func (o *ChebyFun) binaryOp(g *ChebyFun, op func(a, b float64) float64) *ChebyFun {
+	xa, xb := o.Domain()
+	ga, gb := g.Domain()
+	if xa != ga || xb != gb {
+		chk.Panic("domains must be equal. [%g,%g] != [%g,%g]\n", xa, xb, ga, gb)
+	}
+	breaks := append(append([]float64{}, o.Breaks...), g.Breaks[1:len(g.Breaks)-1]...)
+	sort.Float64s(breaks)
+	merged := []float64{breaks[0]}
+	for _, x := range breaks[1:] {
+		if x > merged[len(merged)-1] {
+			merged = append(merged, x)
+		}
+	}
+	r := &ChebyFun{Breaks: merged, Coef: make([][]float64, len(merged)-1)}
+	for i := 0; i < len(merged)-1; i++ {
+		xm := 0.5 * (merged[i] + merged[i+1])
+		r.Coef[i] = r.buildPiece(func(x float64) float64 {
+			return op(o.evalIn(x, xm), g.evalIn(x, xm))
+		}, merged[i], merged[i+1])
+	}
+	return r
+}
+
+// mergePieces merges neighbour pieces if the merged piece can be resolved with chebyFunMaxNsplit.
+// vscale and hscale are the global scales (see chebyFunPiece)
+func (o *ChebyFun) mergePieces(f Ss, vscale, hscale float64) {
+	for i := 0; i < len(o.Coef)-1; {
+		xa, xb := o.Breaks[i], o.Breaks[i+2]
+		c, _, ok := chebyFunPiece(f, xa, xb, chebyFunMaxNsplit, vscale, hscale)
+		if ok && len(c) <= len(o.Coef[i])+len(o.Coef[i+1]) && o.agree(i, c, xa, xb, vscale, hscale) && o.agree(i+1, c, xa, xb, vscale, hscale) {
+			o.Coef[i] = c
+			o.Coef = append(o.Coef[:i+1], o.Coef[i+2:]...)
+			o.Breaks = append(o.Breaks[:i+1], o.Breaks[i+2:]...)
+			continue
+		}
+		i++
+	}
+}
+
+// agree checks whether the piece with coefficients c on [xa,xb] agrees with piece i at the
+// Chebyshev points of piece i. This prevents merging a narrow piece with a discontinuity that
+// falls between the sampling points of the merged piece
+func (o *ChebyFun) agree(i int, c []float64, xa, xb, vscale, hscale float64) bool {
+	a, b := o.Breaks[i], o.Breaks[i+1]
+	vlocal := 0.0
+	for _, ck := range o.Coef[i] {
+		vlocal += math.Abs(ck)
+	}
+	tol := 100 * machEps * math.Max(vscale, vlocal*hscale/(b-a)) // see chebyFunPiece
+	n := 32
+	for j := 0; j <= n; j++ {
+		x := 0.5*(a+b) + 0.5*(b-a)*math.Cos(float64(j)*π/float64(n))
+		t := (2.0*x - xa - xb) / (xb - xa)
+		if math.Abs(chebyClenshaw(c, t)-chebyClenshaw(o.Coef[i], o.mapToRef(i, x))) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// extremum returns the location and value of the maximum of sgn⋅f
+func (o *ChebyFun) extremum(sgn float64) (xopt, fopt float64) {
+	fopt = math.Inf(-1)
+	check := func(i int, x float64) {
+		fx := sgn * chebyClenshaw(o.Coef[i], o.mapToRef(i, x))
+		if fx > fopt {
+			xopt, fopt = x, fx
+		}
+	}
+	for i, c := range o.Coef {
+		xa, xb := o.Breaks[i], o.Breaks[i+1]
+		check(i, xa)
+		check(i, xb)
+		for _, t := range chebyRootsRec(chebyDiffCoef(c, 1)) {
+			check(i, xa+0.5*(xb-xa)*(t+1.0))
+		}
+	}
+	return
+}
+
+// chebyFunPiece computes the Chebyshev coefficients of f on [xa,xb] by sampling f at N+1
+// Chebyshev-Gauss-Lobatto points with N = 16, 32, ..., maxN. Returns ok=false if the
+// coefficients do not reach a plateau (unresolved function)
+//
+//	vscale -- global vertical scale max|f| [0 ⇒ use the scale of this piece only]
+//	hscale -- global horizontal scale max(|x|) [0 ⇒ use the scale of this piece only]
+//	vlocal -- returns max|f| at the sampling points of this piece
+//
+//	The coefficients are chopped relative to the local scale with the tolerance
+//	ϵ⋅max(1, vscale/vlocal, hscale/(xb-xa)) because only the accuracy relative to vscale is
+//	needed and because the rounding errors of x (≈ ϵ⋅hscale) are amplified by (xb-xa)⁻¹ in the
+//	local coordinate
+func chebyFunPiece(f Ss, xa, xb float64, maxN int, vscale, hscale float64) (coef []float64, vlocal float64, ok bool) {
+	for N := 16; N <= maxN; N *= 2 {
+		coef, vlocal = chebyFunCoefs(f, xa, xb, N)
+		tol := machEps
+		if vlocal > 0 {
+			tol *= utl.Max(1, utl.Max(vscale/vlocal, hscale/(xb-xa)))
+		}
+		cutoff := chebyStandardChop(coef, tol)
+		if cutoff < len(coef) {
+			return coef[:cutoff], vlocal, true
+		}
+	}
+	return
+}
+
+// chebyFunCoefs computes the N+1 Chebyshev coefficients of the interpolant of f at the
+// Chebyshev-Gauss-Lobatto points x_j = cos(j⋅π/N) mapped onto [xa,xb] using the FFT. Also
+// returns vmax = max|f(x_j)|
+func chebyFunCoefs(f Ss, xa, xb float64, N int) (coef []float64, vmax float64) {
+	v := make([]float64, N+1)
+	v[0] = f(xb)
+	v[N] = f(xa)
+	for j := 1; j < N; j++ {
+		v[j] = f(0.5*(xa+xb) + 0.5*(xb-xa)*math.Cos(float64(j)*π/float64(N)))
+	}
+	data := make([]complex128, 2*N)
+	for j := 0; j <= N; j++ {
+		data[j] = complex(v[j], 0)
+		vmax = math.Max(vmax, math.Abs(v[j]))
+	}
+	for j := 1; j < N; j++ {
+		data[2*N-j] = data[j]
+	}
+	Dft1d(data, false)
+	coef = make([]float64, N+1)
+	for k := 0; k <= N; k++ {
+		coef[k] = real(data[k]) / float64(N)
+	}
+	coef[0] /= 2.0
+	coef[N] /= 2.0
+	return
+}
+
+// chebyStandardChop returns the number of coefficients to be kept (cutoff) according to the
+// "standard chop" algorithm of [2]. If cutoff == len(coef), no plateau was found.
+func chebyStandardChop(coef []float64, tol float64) (cutoff int) {
+
+	// step 1: compute the monotonically non-increasing normalised envelope
+	n := len(coef)
+	cutoff = n
+	if n < 17 {
+		return
+	}
+	envelope := make([]float64, n)
+	envelope[n-1] = math.Abs(coef[n-1])
+	for j := n - 2; j >= 0; j-- {
+		envelope[j] = math.Max(math.Abs(coef[j]), envelope[j+1])
+	}
+	if envelope[0] == 0 {
+		return 1
+	}
+	for j := n - 1; j >= 0; j-- {
+		envelope[j] /= envelope[0]
+	}
+
+	// step 2: find the first point plateauPoint that is followed by a plateau
+	// NOTE: the indices below are 1-based as in [2]
+	plateauPoint, j2 := 0, 0
+	found := false
+	for j := 2; j <= n; j++ {
+		j2 = int(math.Floor(1.25*float64(j) + 5.0 + 0.5))
+		if j2 > n {
+			return // there is no plateau
+		}
+		e1 := envelope[j-1]
+		e2 := envelope[j2-1]
+		r := 3.0 * (1.0 - math.Log(e1)/math.Log(tol))
+		if e1 == 0 || e2/e1 > r {
+			plateauPoint = j - 1
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+
+	// step 3: fix cutoff at a point where the envelope, plus a linear function included to bias
+	// the result towards the left end, is minimal
+	if envelope[plateauPoint-1] == 0 {
+		return plateauPoint
+	}
+	tol76 := math.Pow(tol, 7.0/6.0)
+	j3 := 0
+	for _, e := range envelope {
+		if e >= tol76 {
+			j3++
+		}
+	}
+	if j3 < j2 {
+		j2 = j3 + 1
+		envelope[j2-1] = tol76
+	}
+	d := 1
+	cmin := math.Inf(1)
+	for j := 1; j <= j2; j++ {
+		cc := math.Log10(envelope[j-1])
+		if j2 > 1 {
+			cc += (-1.0 / 3.0) * math.Log10(tol) * float64(j-1) / float64(j2-1)
+		}
+		if cc < cmin {
+			cmin, d = cc, j
+		}
+	}
+	return utl.Imax(d-1, 1)
+}
+
+// chebyClenshaw evaluates Σ c_k T_k(t) using Clenshaw's algorithm
+func chebyClenshaw(c []float64, t float64) float64 {
+	var b0, b1, b2 float64
+	for k := len(c) - 1; k >= 1; k-- {
+		b0 = c[k] + 2.0*t*b1 - b2
+		b2 = b1
+		b1 = b0
+	}
+	return c[0] + t*b1 - b2
+}
+
+// chebyDiffCoef returns the coefficients of the derivative of Σ c_k T_k(t) multiplied by scale
+func chebyDiffCoef(c []float64, scale float64) (d []float64) {
+	n := len(c)
+	if n < 2 {
+		return []float64{0}
+	}
+	d = make([]float64, n-1)
+	for k := n - 2; k >= 0; k-- {
+		d[k] = 2.0 * float64(k+1) * c[k+1]
+		if k+2 < n-1 {
+			d[k] += d[k+2]
+		}
+	}
+	d[0] /= 2.0
+	for k := range d {
+		d[k] *= scale
+	}
+	return
+}
+
+// chebyRootsRec computes the roots in [-1,1] of Σ c_k T_k(t), subdividing the interval if the
+// degree is large
+func chebyRootsRec(c []float64) (roots []float64) {
+
+	// trim trailing negligible coefficients
+	cmax := 0.0
+	for _, v := range c {
+		cmax = math.Max(cmax, math.Abs(v))
+	}
+	if cmax == 0 {
+		return
+	}
+	n := len(c)
+	for n > 1 && math.Abs(c[n-1]) < machEps*cmax {
+		n--
+	}
+	c = c[:n]
+
+	// subdivide
+	if n-1 > chebyFunRootSplit {
+		s := -0.004849834917525 // splitting point (asymmetric to avoid roots at the splitting point)
+		for p, ab := range [][]float64{{-1, s}, {s, 1}} {
+			a, b := ab[0], ab[1]
+			cc, _ := chebyFunCoefs(func(t float64) float64 { return chebyClenshaw(c, t) }, a, b, chebyFunNextPow2(n-1))
+			if cut := chebyStandardChop(cc, machEps); cut < len(cc) {
+				cc = cc[:cut]
+			}
+			for _, t := range chebyRootsRec(cc) {
+				x := a + 0.5*(b-a)*(t+1.0)
+				if p == 1 && len(roots) > 0 && math.Abs(x-roots[len(roots)-1]) < 1e-12 {
+					continue
+				}
+				roots = append(roots, x)
+			}
+		}
+		return
+	}
+
+	// colleague matrix
+	var candidates []float64
+	switch n {
+	case 1:
+		return
+	case 2:
+		candidates = []float64{-c[0] / c[1]}
+	default:
+		N := n - 1
+		A := la.NewMatrix(N, N)
+		A.Set(0, 1, 1)
+		for k := 1; k < N; k++ {
+			A.Set(k, k-1, 0.5)
+			if k+1 < N {
+				A.Set(k, k+1, 0.5)
+			}
+		}
+		for j := 0; j < N; j++ {
+			A.Add(N-1, j, -0.5*c[j]/c[N])
+		}
+		w := la.NewVectorC(N)
+		la.EigenVal(w, A, false)
+		htol := 1e-8
+		for _, λ := range w {
+			if math.Abs(imag(λ)) < htol && math.Abs(real(λ)) <= 1.0+htol {
+				candidates = append(candidates, real(λ))
+			}
+		}
+	}
+
+	// polish with Newton's method
+	dc := chebyDiffCoef(c, 1)
+	for _, t := range candidates {
+		if math.Abs(t) > 1.0+1e-8 {
+			continue
+		}
+		for it := 0; it < 3; it++ {
+			ft := chebyClenshaw(c, t)
+			dt := chebyClenshaw(dc, t)
+			if dt == 0 {
+				break
+			}
+			tn := t - ft/dt
+			if math.Abs(tn) > 1 || math.Abs(chebyClenshaw(c, tn)) >= math.Abs(ft) {
+				break
+			}
+			t = tn
+		}
+		roots = append(roots, utl.Max(-1, utl.Min(t, 1)))
+	}
+	sort.Float64s(roots)
+	return
+}
+
+// chebyFunSum returns Σ c_k which is the value of Σ c_k T_k(t) at t = 1
+func chebyFunSum(c []float64) (res float64) {
+	for _, v := range c {
+		res += v
+	}
+	return
+}
+
+// chebyFunNextPow2 returns the smallest power of 2 greater than or equal to n (and ≥ 16)
+func chebyFunNextPow2(n int) int {
+	p := 16
+	for p < n {
+		p *= 2
+	}
+	return p
+}
@@ -0,0 +1,46 @@
+import mpmath as mp
+
+# generate data for comparison with: complete elliptic integrals K(k), E(k) and Π(n,k), the nome
+# q(k) and the Jacobi elliptic functions sn, cn, dn and amplitude am. NOTE: mpmath uses the
+# parameter m = k² whereas Gosl uses the modulus k
+
+mp.mp.dps = 40
+
+# generate data for comparison
+def gendata(keys, rows):
+    l = ''.join(['%23s' % k for k in keys]) + '\n'
+    for r in rows:
+        l += ''.join(['%23.15e' % float(v) for v in r]) + '\n'
+    return l
+
+# write file
+def savefile(l, fn):
+    f = open(fn,'w')
+    f.write(l)
+    f.close()
+    print 'file <%s> written' % fn
+
+# complete integrals of the first and second kinds and nome
+K = [0, 0.1, 0.3, 0.5, 0.7, 0.8, 0.9, 0.95, 0.99, 0.999, 0.999999, 0.9999999999, 0.99999999999999]
+rows = []
+for k in K:
+    m = mp.mpf(k)**2
+    rows.append([k, mp.ellipk(m), mp.ellipe(m), mp.qfrom(k=k)])
+savefile(gendata(['k','K','E','q'], rows), '/tmp/sp-ellipk.cmp')
+
+# complete integral of the third kind
+rows = []
+for n in [-10, -1, -0.3, 0, 0.1, 0.5, 0.9, 0.99]:
+    for k in [0, 0.3, 0.7, 0.9, 0.999, 0.9999999999]:
+        rows.append([n, k, mp.ellippi(n, mp.mpf(k)**2)])
+savefile(gendata(['n','k','PI'], rows), '/tmp/sp-ellippi.cmp')
+
+# Jacobi elliptic functions
+rows = []
+for k in [0.1, 0.5, 0.8, 0.99, 0.999999, 0.9999999999, 1]:
+    for u in [-1.7, 0.3, 1, 2.5, 5, 12]:
+        m = mp.mpf(k)**2
+        sn, cn, dn = mp.ellipfun('sn', u, m=m), mp.ellipfun('cn', u, m=m), mp.ellipfun('dn', u, m=m)
+        am = mp.asin(sn) if k == 1 else mp.findroot(lambda phi: mp.ellipf(phi, m) - u, u)
+        rows.append([u, k, sn, cn, dn, am])
+savefile(gendata(['u','k','sn','cn','dn','am'], rows), '/tmp/sp-jacobi.cmp')
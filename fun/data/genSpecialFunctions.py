@@ -0,0 +1,86 @@
+import scipy.special as sp
+import numpy as np
+
+# generate data for comparison with: incomplete gamma and beta functions, error functions,
+# Bessel functions of real order, Airy functions, Lambert W, zeta, digamma and polygamma functions
+
+# generate data for comparison
+def gendata(keys, rows):
+    l = ''.join(['%23s' % k for k in keys]) + '\n'
+    for r in rows:
+        l += ''.join(['%23.15e' % v for v in r]) + '\n'
+    return l
+
+# write file
+def savefile(l, fn):
+    f = open(fn,'w')
+    f.write(l)
+    f.close()
+    print 'file <%s> written' % fn
+
+# incomplete gamma
+rows = []
+for a in [0.1, 0.5, 1, 2.5, 5, 10, 20, 50, 150]:
+    for x in [0.01, 0.5, 1, 2, 5, 10, 20, 50, 100, 200]:
+        rows.append([a, x, sp.gammainc(a,x), sp.gammaincc(a,x)])
+savefile(gendata(['a','x','P','Q'], rows), '/tmp/sp-gammainc.cmp')
+
+# incomplete beta
+rows = []
+for a in [0.2, 0.5, 1, 2, 5, 10, 50]:
+    for b in [0.3, 1, 2.5, 8, 40]:
+        for x in [0.01, 0.1, 0.3, 0.5, 0.7, 0.9, 0.99]:
+            rows.append([a, b, x, sp.betainc(a,b,x)])
+savefile(gendata(['a','b','x','I'], rows), '/tmp/sp-betainc.cmp')
+
+# error functions
+rows = []
+for x in np.hstack((np.linspace(-3,10,53), [12, 25, 50, 100, 1000])):
+    rows.append([x, sp.erf(x), sp.erfc(x), sp.erfcx(x)])
+savefile(gendata(['x','erf','erfc','erfcx'], rows), '/tmp/sp-erf.cmp')
+
+# Bessel functions of real order
+rows = []
+for nu in [-2.5, -1.3, -1, -0.5, 0, 0.25, 1, 1.5, 2, 3.7, 10, 20.5]:
+    for x in [0.1, 0.5, 1, 2.5, 5, 10, 15, 30]:
+        rows.append([nu, x, sp.jv(nu,x), sp.yv(nu,x), sp.jvp(nu,x), sp.yvp(nu,x)])
+savefile(gendata(['nu','x','J','Y','Jd','Yd'], rows), '/tmp/sp-bessel-real.cmp')
+
+# Airy functions
+rows = []
+for x in np.linspace(-10,10,81):
+    ai, aid, bi, bid = sp.airy(x)
+    rows.append([x, ai, bi, aid, bid])
+savefile(gendata(['x','Ai','Bi','Aid','Bid'], rows), '/tmp/sp-airy.cmp')
+
+# Lambert W
+X = [-0.367879441171, -0.3677, -0.3, -0.2, -0.1, -0.01, 0, 0.01, 0.5, 1, 2, 3, 10, 100, 1e5, 1e10, 1e100]
+rows = [[x, sp.lambertw(x,0).real] for x in X]
+savefile(gendata(['x','W'], rows), '/tmp/sp-lambertw0.cmp')
+X = [-0.367879441171, -0.3677, -0.3, -0.2, -0.1, -0.01, -1e-5, -1e-20, -1e-100]
+rows = [[x, sp.lambertw(x,-1).real] for x in X]
+savefile(gendata(['x','W'], rows), '/tmp/sp-lambertwm1.cmp')
+
+# Riemann zeta
+S = [-9.5, -7, -5.5, -3, -2, -1, -0.5, 0, 0.5, 0.9, 1.1, 1.5, 2, 3, 4.5, 10, 20, 50]
+rows = [[s, sp.zeta(s)] for s in S]
+savefile(gendata(['s','zeta'], rows), '/tmp/sp-zeta.cmp')
+
+# Hurwitz zeta
+rows = []
+for s in [1.1, 1.5, 2, 3, 5.5, 10, 30]:
+    for q in [0.01, 0.3, 1, 2.5, 10, 100]:
+        rows.append([s, q, sp.zeta(s,q)])
+savefile(gendata(['s','q','zeta'], rows), '/tmp/sp-hurwitz.cmp')
+
+# digamma
+X = [-10.5, -3.3, -2.5, -1.7, -0.5, -0.1, 0.001, 0.1, 0.5, 1, 1.4616321449683623, 2, 3.5, 7, 10, 25, 100, 1e4]
+rows = [[x, sp.digamma(x)] for x in X]
+savefile(gendata(['x','psi'], rows), '/tmp/sp-digamma.cmp')
+
+# polygamma
+rows = []
+for n in [1, 2, 3, 5]:
+    for x in [-2.5, -0.3, 0.1, 0.5, 1, 2.5, 10, 50]:
+        rows.append([n, x, sp.polygamma(n,x)])
+savefile(gendata(['n','x','psi'], rows), '/tmp/sp-polygamma.cmp')
@@ -15,6 +15,9 @@ import (
 // π = 3.141592653589...
 const π = math.Pi
 
+// machEps is the machine epsilon; i.e. the smallest number satisfying 1 + machEps > 1
+var machEps = math.Nextafter(1, 2) - 1.0
+
 // Ss defines a scalar function f(s) of a scalar argument s (scalar scalar)
 //   Input:
 //     s -- input scalar
@@ -0,0 +1,65 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Digamma computes the digamma (psi) function ψ(x) = d(ln Γ(x))/dx
+//
+//	The recurrence ψ(x) = ψ(x+1) - 1/x is used to shift x to a region where the asymptotic
+//	expansion is accurate. For x < 0, the reflection formula ψ(1-x) - ψ(x) = π⋅cot(π⋅x) is used.
+//
+//	Special cases:
+//	  x = 0, -1, -2, ...  ⇒  NaN
+func Digamma(x float64) float64 {
+	if x <= 0 && x == math.Floor(x) {
+		return math.NaN()
+	}
+	if x < 0 {
+		return Digamma(1.0-x) - π/math.Tan(π*x)
+	}
+	res := 0.0
+	for x < 10.0 {
+		res -= 1.0 / x
+		x += 1.0
+	}
+	ixx := 1.0 / (x * x)
+	res += math.Log(x) - 0.5/x - ixx*(1.0/12.0-ixx*(1.0/120.0-ixx*(1.0/252.0-ixx*(1.0/240.0-ixx*(1.0/132.0-ixx*(691.0/32760.0-ixx/12.0))))))
+	return res
+}
+
+// Polygamma computes the polygamma function of order n; i.e. the (n+1)-th derivative of ln Γ(x)
+//
+//	             dⁿ ψ(x)
+//	ψ⁽ⁿ⁾(x) =  ————————  =  (-1)ⁿ⁺¹ ⋅ n! ⋅ ζ(n+1, x)      (x > 0)
+//	               dxⁿ
+//
+//	where ζ(s,q) is the Hurwitz zeta function. For x < 0, the recurrence relation
+//	ψ⁽ⁿ⁾(x) = ψ⁽ⁿ⁾(x+1) - (-1)ⁿ⋅n!/xⁿ⁺¹ is used to shift x to positive values.
+//
+//	NOTE: n = 0 corresponds to the Digamma function
+func Polygamma(n int, x float64) float64 {
+	if n < 0 {
+		chk.Panic("Polygamma requires n ≥ 0. n=%d is invalid", n)
+	}
+	if n == 0 {
+		return Digamma(x)
+	}
+	if x <= 0 && x == math.Floor(x) {
+		return math.NaN()
+	}
+	nfact := math.Gamma(float64(n) + 1.0)
+	sgn := NegOnePowN(n + 1)
+	res := 0.0
+	for x <= 0 {
+		res += sgn * nfact / math.Pow(x, float64(n+1))
+		x += 1.0
+	}
+	return res + sgn*nfact*HurwitzZeta(float64(n+1), x)
+}
@@ -0,0 +1,190 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// Dual implements dual numbers a = V + D⋅ε with ε² = 0 for forward-mode automatic differentiation
+//
+//	The derivative of a function f(x) is obtained by evaluating f with the dual number x + 1⋅ε:
+//
+//	  f(x + ε) = f(x) + f'(x)⋅ε
+//
+//	Example:
+//	  x := Dual{V: 2, D: 1}              // seed
+//	  y := x.Mul(x).Add(x.Sin())         // y = x² + sin(x)
+//	  // y.V = f(2) and y.D = f'(2) = 2⋅2 + cos(2)
+//
+//	References:
+//	[1] Griewank A, Walther A (2008) Evaluating Derivatives: Principles and Techniques of
+//	    Algorithmic Differentiation. Second Edition. SIAM. 438p.
+type Dual struct {
+	V float64 // value (real part)
+	D float64 // derivative (dual part)
+}
+
+// DualConst returns the dual number representing the constant c; i.e. c + 0⋅ε
+func DualConst(c float64) Dual {
+	return Dual{c, 0}
+}
+
+// DualVar returns the dual number representing the independent variable x; i.e. x + 1⋅ε
+func DualVar(x float64) Dual {
+	return Dual{x, 1}
+}
+
+// chain applies the chain rule with f = f(a.V) and df = f'(a.V)
+func (a Dual) chain(f, df float64) Dual {
+	return Dual{f, df * a.D}
+}
+
+// arithmetic //////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a Dual) Add(b Dual) Dual {
+	return Dual{a.V + b.V, a.D + b.D}
+}
+
+// Sub returns a - b
+func (a Dual) Sub(b Dual) Dual {
+	return Dual{a.V - b.V, a.D - b.D}
+}
+
+// Mul returns a ⋅ b
+func (a Dual) Mul(b Dual) Dual {
+	return Dual{a.V * b.V, a.D*b.V + a.V*b.D}
+}
+
+// Div returns a / b
+func (a Dual) Div(b Dual) Dual {
+	return Dual{a.V / b.V, (a.D*b.V - a.V*b.D) / (b.V * b.V)}
+}
+
+// Neg returns -a
+func (a Dual) Neg() Dual {
+	return Dual{-a.V, -a.D}
+}
+
+// AddS returns a + s where s is a scalar
+func (a Dual) AddS(s float64) Dual {
+	return Dual{a.V + s, a.D}
+}
+
+// MulS returns a ⋅ s where s is a scalar
+func (a Dual) MulS(s float64) Dual {
+	return Dual{a.V * s, a.D * s}
+}
+
+// PowS returns aᵖ where p is a scalar
+func (a Dual) PowS(p float64) Dual {
+	if p == 0 {
+		return Dual{1, 0}
+	}
+	return a.chain(math.Pow(a.V, p), p*math.Pow(a.V, p-1))
+}
+
+// Pow returns aᵇ = exp(b ⋅ log(a))
+func (a Dual) Pow(b Dual) Dual {
+	return b.Mul(a.Log()).Exp()
+}
+
+// Inv returns 1/a
+func (a Dual) Inv() Dual {
+	return a.chain(1.0/a.V, -1.0/(a.V*a.V))
+}
+
+// elementary functions ////////////////////////////////////////////////////////////////////////////
+
+// Sqrt returns √a
+func (a Dual) Sqrt() Dual {
+	s := math.Sqrt(a.V)
+	return a.chain(s, 0.5/s)
+}
+
+// Exp returns exp(a)
+func (a Dual) Exp() Dual {
+	e := math.Exp(a.V)
+	return a.chain(e, e)
+}
+
+// Log returns the natural logarithm log(a)
+func (a Dual) Log() Dual {
+	return a.chain(math.Log(a.V), 1.0/a.V)
+}
+
+// Sin returns sin(a)
+func (a Dual) Sin() Dual {
+	s, c := math.Sincos(a.V)
+	return a.chain(s, c)
+}
+
+// Cos returns cos(a)
+func (a Dual) Cos() Dual {
+	s, c := math.Sincos(a.V)
+	return a.chain(c, -s)
+}
+
+// Tan returns tan(a)
+func (a Dual) Tan() Dual {
+	t := math.Tan(a.V)
+	return a.chain(t, 1.0+t*t)
+}
+
+// Asin returns asin(a)
+func (a Dual) Asin() Dual {
+	return a.chain(math.Asin(a.V), 1.0/math.Sqrt(1.0-a.V*a.V))
+}
+
+// Acos returns acos(a)
+func (a Dual) Acos() Dual {
+	return a.chain(math.Acos(a.V), -1.0/math.Sqrt(1.0-a.V*a.V))
+}
+
+// Atan returns atan(a)
+func (a Dual) Atan() Dual {
+	return a.chain(math.Atan(a.V), 1.0/(1.0+a.V*a.V))
+}
+
+// Atan2 returns atan2(a, b); i.e. the angle of the point (b, a)
+func (a Dual) Atan2(b Dual) Dual {
+	d := a.V*a.V + b.V*b.V
+	return Dual{math.Atan2(a.V, b.V), (b.V*a.D - a.V*b.D) / d}
+}
+
+// Sinh returns sinh(a)
+func (a Dual) Sinh() Dual {
+	return a.chain(math.Sinh(a.V), math.Cosh(a.V))
+}
+
+// Cosh returns cosh(a)
+func (a Dual) Cosh() Dual {
+	return a.chain(math.Cosh(a.V), math.Sinh(a.V))
+}
+
+// Tanh returns tanh(a)
+func (a Dual) Tanh() Dual {
+	t := math.Tanh(a.V)
+	return a.chain(t, 1.0-t*t)
+}
+
+// Abs returns |a|. The derivative at a=0 is taken as 0
+func (a Dual) Abs() Dual {
+	return a.chain(math.Abs(a.V), Sign(a.V))
+}
+
+// Ramp returns Ramp(a) = max(a, 0). The derivative is Heav(a)
+func (a Dual) Ramp() Dual {
+	return a.chain(Ramp(a.V), Heav(a.V))
+}
+
+// Heav returns the Heaviside function Heav(a). The derivative is taken as 0
+func (a Dual) Heav() Dual {
+	return Dual{Heav(a.V), 0}
+}
+
+// Sign returns the sign function Sign(a). The derivative is taken as 0
+func (a Dual) Sign() Dual {
+	return Dual{Sign(a.V), 0}
+}
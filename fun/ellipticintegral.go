@@ -351,3 +351,166 @@ func CarlsonRc(x, y float64) float64 {
 	}
 	return w * (1.0 + s*s*(C1+s*(C2+s*(C3+s*C4)))) / math.Sqrt(ave)
 }
+
+// EllipticK computes the complete elliptic integral of the first kind K(k) = F(π/2,k)
+//
+//	Computes:
+//	               π/2
+//	              ⌠          dt
+//	      K(k) =  │  ___________________
+//	              │     _______________
+//	              ⌡   \╱ 1 - k² sin²(t)
+//	             0
+//	where:
+//	         0 ≤ k ≤ 1
+//
+//	NOTE: K(k) is computed with Bulirsch's general complete elliptic integral [1,2] which is
+//	      accurate for the whole range of k; including near k → 1 where K(k) ~ ln(4/k')
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (1992) Numerical Recipes in C: The Art
+//	    of Scientific Computing. Second Edition. Cambridge University Press. 994p.
+//	[2] Bulirsch R (1969) Numerical calculation of elliptic integrals and elliptic functions III.
+//	    Numerische Mathematik, 13:305-315
+func EllipticK(k float64) float64 {
+	kc := ellipticKc(k)
+	if kc == 0 {
+		return math.Inf(1)
+	}
+	return ellipticCel(kc, 1, 1, 1)
+}
+
+// EllipticE computes the complete elliptic integral of the second kind E(k) = E(π/2,k)
+//
+//	Computes:
+//	               π/2
+//	              ⌠     _______________
+//	      E(k) =  │   \╱ 1 - k² sin²(t)  dt
+//	              ⌡
+//	             0
+//	where:
+//	         0 ≤ k ≤ 1
+//
+//	NOTE: E(k) is computed with Bulirsch's general complete elliptic integral [1,2]
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (1992) Numerical Recipes in C: The Art
+//	    of Scientific Computing. Second Edition. Cambridge University Press. 994p.
+//	[2] Bulirsch R (1969) Numerical calculation of elliptic integrals and elliptic functions III.
+//	    Numerische Mathematik, 13:305-315
+func EllipticE(k float64) float64 {
+	kc := ellipticKc(k)
+	if kc == 0 {
+		return 1
+	}
+	return ellipticCel(kc, 1, 1, kc*kc)
+}
+
+// EllipticPi computes the complete elliptic integral of the third kind Π(n,k) = Π(n,π/2,k)
+//
+//	Computes:
+//	                  π/2
+//	                 ⌠                  dt
+//	      Π(n, k) =  │  ___________________________________
+//	                 │                     _______________
+//	                 ⌡   (1 - n sin²(t)) \╱ 1 - k² sin²(t)
+//	                0
+//	where:
+//	         0 ≤ k ≤ 1
+//
+//	NOTE: (1) for n > 1, the Cauchy principal value is returned
+//	      (2) Π(n,k) is computed with Bulirsch's general complete elliptic integral [1,2]
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (1992) Numerical Recipes in C: The Art
+//	    of Scientific Computing. Second Edition. Cambridge University Press. 994p.
+//	[2] Bulirsch R (1969) Numerical calculation of elliptic integrals and elliptic functions III.
+//	    Numerische Mathematik, 13:305-315
+func EllipticPi(n, k float64) float64 {
+	kc := ellipticKc(k)
+	if kc == 0 || n == 1 {
+		return math.Inf(1)
+	}
+	return ellipticCel(kc, 1.0-n, 1, 1)
+}
+
+// EllipticNome computes the nome q(k) = exp(-π⋅K(k')/K(k)) where k' = √(1-k²) is the
+// complementary modulus
+//
+//	where:
+//	         0 ≤ k ≤ 1  ⇒  0 ≤ q ≤ 1
+func EllipticNome(k float64) float64 {
+	kc := ellipticKc(k)
+	if k == 0 {
+		return 0
+	}
+	if kc == 0 {
+		return 1
+	}
+	return math.Exp(-math.Pi * ellipticCel(k, 1, 1, 1) / ellipticCel(kc, 1, 1, 1))
+}
+
+// ellipticKc returns the complementary modulus k' = √(1-k²) after checking that 0 ≤ k ≤ 1
+func ellipticKc(k float64) float64 {
+	if k < 0 || k > 1 {
+		chk.Panic("k must be in 0 ≤ k ≤ 1. k=%g is invalid", k)
+	}
+	return math.Sqrt((1.0 - k) * (1.0 + k))
+}
+
+// ellipticCel computes Bulirsch's general complete elliptic integral according to [1]
+//
+//	                    π/2
+//	                   ⌠        a⋅cos²(t) + b⋅sin²(t)                 dt
+//	 cel(kc,p,a,b)  =  │  ——————————————————————————— ⋅ ——————————————————————————
+//	                   ⌡    cos²(t) + p⋅sin²(t)       √(cos²(t) + kc²⋅sin²(t))
+//	                  0
+//
+//	where kc ≠ 0. If p < 0, the Cauchy principal value is returned
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (1992) Numerical Recipes in C: The Art
+//	    of Scientific Computing. Second Edition. Cambridge University Press. 994p.
+func ellipticCel(qqc, pp, aa, bb float64) float64 {
+	CA := 1e-8 // the desired accuracy is the square of CA
+	if qqc == 0.0 {
+		chk.Panic("cannot compute cel with kc = 0")
+	}
+	qc := math.Abs(qqc)
+	a := aa
+	b := bb
+	p := pp
+	e := qc
+	em := 1.0
+	var f, g, q float64
+	if p > 0.0 {
+		p = math.Sqrt(p)
+		b /= p
+	} else {
+		f = qc * qc
+		q = 1.0 - f
+		g = 1.0 - p
+		f -= p
+		q *= b - a*p
+		p = math.Sqrt(f / g)
+		a = (a - b) / g
+		b = -q/(g*g*p) + a*p
+	}
+	for it := 0; it < 100; it++ {
+		f = a
+		a += b / p
+		g = e / p
+		b += f * g
+		b += b
+		p += g
+		g = em
+		em += qc
+		if math.Abs(g-qc) <= g*CA {
+			break
+		}
+		qc = math.Sqrt(e)
+		qc += qc
+		e = qc * em
+	}
+	return (math.Pi / 2.0) * (b + a*em) / (em * (em + p))
+}
@@ -0,0 +1,199 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// JacobiSn computes the Jacobi elliptic function sn(u,k). See JacobiSnCnDn
+func JacobiSn(u, k float64) float64 {
+	sn, _, _ := JacobiSnCnDn(u, k)
+	return sn
+}
+
+// JacobiCn computes the Jacobi elliptic function cn(u,k). See JacobiSnCnDn
+func JacobiCn(u, k float64) float64 {
+	_, cn, _ := JacobiSnCnDn(u, k)
+	return cn
+}
+
+// JacobiDn computes the Jacobi elliptic function dn(u,k). See JacobiSnCnDn
+func JacobiDn(u, k float64) float64 {
+	_, _, dn := JacobiSnCnDn(u, k)
+	return dn
+}
+
+// JacobiSnCnDn computes the Jacobi elliptic functions sn(u,k), cn(u,k) and dn(u,k)
+//
+//	Definitions:
+//
+//	  sn(u,k) = sin(φ)    cn(u,k) = cos(φ)    dn(u,k) = √(1 - k² sin²(φ))
+//
+//	where φ = am(u,k) is the Jacobi amplitude; i.e. u = F(φ,k) (see Elliptic1)
+//
+//	The functions are computed with the descending Landen transformation [1,2] for 0 ≤ k ≤ 1
+//
+//	Special cases:
+//	  k = 0  ⇒  sn = sin(u),  cn = cos(u),   dn = 1
+//	  k = 1  ⇒  sn = tanh(u), cn = sech(u),  dn = sech(u)
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//	[2] Bulirsch R (1965) Numerical calculation of elliptic integrals and elliptic functions.
+//	    Numerische Mathematik, 7:78-90
+func JacobiSnCnDn(u, k float64) (sn, cn, dn float64) {
+	kc := ellipticKc(k)
+	if kc == 0 {
+		cn = 1.0 / math.Cosh(u)
+		return math.Tanh(u), cn, cn
+	}
+	CA := 1e-8 // the accuracy is the square of CA
+	var em, en [13]float64
+	var a, b, c float64
+	emc := kc * kc
+	a = 1.0
+	dn = 1.0
+	l := 0
+	for i := 0; i < 13; i++ {
+		l = i
+		em[i] = a
+		emc = math.Sqrt(emc)
+		en[i] = emc
+		c = 0.5 * (a + emc)
+		if math.Abs(a-emc) <= CA*a {
+			break
+		}
+		emc *= a
+		a = c
+	}
+	u *= c
+	sn, cn = math.Sincos(u)
+	if sn != 0.0 {
+		a = cn / sn
+		c *= a
+		for i := l; i >= 0; i-- {
+			b = em[i]
+			a *= c
+			c *= dn
+			dn = (en[i] + a) / (b + a)
+			a = c / b
+		}
+		a = 1.0 / math.Sqrt(c*c+1.0)
+		if sn >= 0.0 {
+			sn = a
+		} else {
+			sn = -a
+		}
+		cn = c * sn
+	}
+	return
+}
+
+// JacobiAm computes the Jacobi amplitude φ = am(u,k); i.e. the inverse of the incomplete
+// elliptic integral of the first kind u = F(φ,k)
+//
+//	The amplitude is a continuous and increasing function of u with am(u + 2K, k) = am(u,k) + π,
+//	where K = K(k) is the complete elliptic integral of the first kind
+//
+//	Special cases:
+//	  k = 0  ⇒  am(u,0) = u
+//	  k = 1  ⇒  am(u,1) = gd(u) = atan(sinh(u))  (Gudermannian function)
+func JacobiAm(u, k float64) float64 {
+	kc := ellipticKc(k)
+	if k == 0 {
+		return u
+	}
+	if kc == 0 {
+		return math.Atan(math.Sinh(u))
+	}
+	K := EllipticK(k)
+	n := math.Floor(u/(2.0*K) + 0.5)
+	sn, cn, _ := JacobiSnCnDn(u-2.0*n*K, k)
+	return math.Atan2(sn, cn) + n*math.Pi
+}
+
+// JacobiArcsn computes the inverse of the Jacobi elliptic function sn(u,k); i.e. the u value
+// in [-K, K] such that sn(u,k) = x
+//
+//	Computes:
+//
+//	  u = x⋅Rf(1-x², 1-k²x², 1) = F(asin(x), k)
+//
+//	where:
+//	         -1 ≤ x ≤ 1  and  0 ≤ k ≤ 1
+//
+//	References:
+//	[1] Carlson BC (1977) Elliptic Integrals of the First Kind, SIAM Journal on Mathematical
+//	    Analysis, vol. 8, pp. 231-242.
+func JacobiArcsn(x, k float64) float64 {
+	ellipticKc(k)
+	if x < -1 || x > 1 {
+		chk.Panic("x must be in -1 ≤ x ≤ 1. x=%g is invalid", x)
+	}
+	if x == 0 {
+		return 0
+	}
+	if math.Abs(x) == 1 {
+		return x * EllipticK(k)
+	}
+	return x * CarlsonRf((1.0-x)*(1.0+x), (1.0-k*x)*(1.0+k*x), 1.0)
+}
+
+// JacobiArccn computes the inverse of the Jacobi elliptic function cn(u,k); i.e. the u value
+// in [0, 2K] such that cn(u,k) = x
+//
+//	Computes:
+//	            ______
+//	  u =  \╱ 1 - x²  ⋅ Rf(x², k'²+k²x², 1) = F(acos(x), k)      x ≥ 0
+//
+//	  u = 2K - arccn(-x)                                          x < 0
+//
+//	where:
+//	         -1 ≤ x ≤ 1,  0 ≤ k ≤ 1  and  k'² = 1 - k²
+func JacobiArccn(x, k float64) float64 {
+	kc := ellipticKc(k)
+	if x < -1 || x > 1 {
+		chk.Panic("x must be in -1 ≤ x ≤ 1. x=%g is invalid", x)
+	}
+	if x < 0 {
+		return 2.0*EllipticK(k) - JacobiArccn(-x, k)
+	}
+	if x == 1 {
+		return 0
+	}
+	if x == 0 {
+		return EllipticK(k)
+	}
+	return math.Sqrt((1.0-x)*(1.0+x)) * CarlsonRf(x*x, kc*kc+k*k*x*x, 1.0)
+}
+
+// JacobiArcdn computes the inverse of the Jacobi elliptic function dn(u,k); i.e. the u value
+// in [0, K] such that dn(u,k) = x
+//
+//	Computes:
+//	          ______
+//	        √ 1 - x²       / x² - k'²       \
+//	  u =  ————————— ⋅ Rf | ————————, x², 1 |
+//	            k          \    k²          /
+//
+//	where:
+//	         k' ≤ x ≤ 1,  0 < k ≤ 1  and  k'² = 1 - k²
+func JacobiArcdn(x, k float64) float64 {
+	kc := ellipticKc(k)
+	if x < kc || x > 1 {
+		chk.Panic("x must be in k' ≤ x ≤ 1 with k'=%g. x=%g is invalid", kc, x)
+	}
+	if x == 1 {
+		return 0
+	}
+	if x == kc {
+		return EllipticK(k)
+	}
+	return math.Sqrt((1.0-x)*(1.0+x)) * CarlsonRf((x-kc)*(x+kc)/(k*k), x*x, 1.0) / k
+}
@@ -0,0 +1,60 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// Erfcx computes the scaled complementary error function erfcx(x) = exp(x²)⋅erfc(x)
+//
+//	This function avoids the underflow of erfc(x) for large x; e.g. erfc(30) = 0 in double
+//	precision whereas erfcx(30) ≈ 1/(30⋅√π). For x ≥ 2, the continued fraction [1]
+//
+//	                1           1     1/2    1     3/2
+//	  erfcx(x) =  ——— ⋅ ( ——— ——— ——— ——— ——— ... )
+//	               √π       x +   x +   x +   x +
+//
+//	is evaluated with the modified Lentz's method. Otherwise, exp(x²)⋅erfc(x) is computed
+//	directly. For x < 0, erfcx(x) = 2⋅exp(x²) - erfcx(-x).
+//
+//	NOTE: erf, erfc, erfinv and erfcinv are available in Go's math package: math.Erf, math.Erfc,
+//	      math.Erfinv and math.Erfcinv.
+//
+//	References:
+//	[1] Abramowitz M, Stegun IA (1972) Handbook of Mathematical Functions with Formulas, Graphs,
+//	    and Mathematical Tables. U.S. Department of Commerce, NIST
+func Erfcx(x float64) float64 {
+	if x < 0 {
+		return 2.0*math.Exp(x*x) - Erfcx(-x)
+	}
+	if x < 2 {
+		return math.Exp(x*x) * math.Erfc(x)
+	}
+	if math.IsInf(x, +1) {
+		return 0
+	}
+	tiny := 1e-300
+	f := x
+	c := f
+	d := 0.0
+	var an, del float64
+	for n := 1; n < 1000; n++ {
+		an = float64(n) / 2.0
+		d = x + an*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = x + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1.0 / d
+		del = c * d
+		f *= del
+		if math.Abs(del-1.0) <= machEps {
+			break
+		}
+	}
+	return 1.0 / (math.Sqrt(π) * f)
+}
@@ -0,0 +1,742 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Expr holds a mathematical expression parsed from a string such as "A*sin(2*pi*t) + x^2"
+//
+//	Identifiers are resolved in the following order:
+//	  1) variables x, y, z and t
+//	  2) named parameters given in utl.Params (the value is read when evaluating, thus
+//	     changes in Params are reflected in the results)
+//	  3) constants pi and e
+//
+//	Operators (lowest to highest precedence):
+//	  + -      addition and subtraction
+//	  * /      multiplication and division
+//	  - +      unary minus and plus
+//	  ^        power (right-associative; e.g. -x^2 = -(x^2) and 2^3^2 = 2^9)
+//
+//	Functions:
+//	  sin cos tan asin acos atan atan2(a,b) sinh cosh tanh exp log log10 sqrt abs pow(a,b)
+//	  min(a,b) max(a,b) floor ceil ramp heav sign
+//
+//	  NOTE: ramp, heav and sign correspond to Ramp, Heav and Sign in this package and may
+//	        also be written as Ramp, Heav and Sign
+type Expr struct {
+	root *exprNode // root of the syntax tree
+	prms utl.Params
+	fcn  exprFcn // compiled function
+}
+
+// kinds of nodes
+const (
+	exprNum  = iota // number (constant)
+	exprVar         // variable: x, y, z or t
+	exprPrm         // parameter
+	exprNeg         // unary minus
+	exprAdd         // a + b
+	exprSub         // a - b
+	exprMul         // a * b
+	exprDiv         // a / b
+	exprPow         // a ^ b
+	exprCall        // function call
+)
+
+// exprVarNames holds the names of the variables; the index corresponds to the position in exprArgs
+var exprVarNames = []string{"x", "y", "z", "t"}
+
+// exprArgs holds the values of the variables x, y, z and t
+type exprArgs [4]float64
+
+// exprFcn defines the compiled version of a node
+type exprFcn func(v *exprArgs) float64
+
+// exprNode defines a node of the syntax tree
+type exprNode struct {
+	kind int         // kind of node
+	num  float64     // number if kind == exprNum
+	idx  int         // index of variable if kind == exprVar
+	prm  *utl.P      // parameter if kind == exprPrm
+	fdef *exprFdef   // function definition if kind == exprCall
+	args []*exprNode // arguments (operands) of operators and functions
+}
+
+// exprFdef defines a function that can be called in an expression
+type exprFdef struct {
+	name  string                            // name of function
+	narg  int                               // number of arguments
+	f1    func(a float64) float64           // function of one argument
+	f2    func(a, b float64) float64        // function of two arguments
+	deriv func(a, da []*exprNode) *exprNode // derivative; nil if not available
+}
+
+// NewExpr parses and compiles an expression
+//
+//	src  -- the expression; e.g. "A*sin(2*pi*t) + x^2"
+//	prms -- named parameters [may be nil]
+//
+//	NOTE: NewExpr panics if the expression cannot be parsed
+func NewExpr(src string, prms utl.Params) (o *Expr) {
+	o = new(Expr)
+	o.prms = prms
+	p := &exprParser{src: src, prms: prms}
+	p.next()
+	o.root = p.parseSum()
+	if p.tok != exprTokEnd {
+		p.fail("unexpected %q", p.text)
+	}
+	o.fcn = o.root.compile()
+	return
+}
+
+// Eval evaluates the expression at (x, y, z, t)
+func (o *Expr) Eval(x, y, z, t float64) float64 {
+	v := exprArgs{x, y, z, t}
+	return o.fcn(&v)
+}
+
+// GetSs returns a scalar function f(s) where s corresponds to one of the variables x, y, z or t;
+// the other variables are set to zero
+func (o *Expr) GetSs(variable string) Ss {
+	idx := exprVarIndex(variable)
+	if idx < 0 {
+		chk.Panic("variable must be x, y, z or t. %q is invalid\n", variable)
+	}
+	fcn := o.fcn
+	return func(s float64) float64 {
+		var v exprArgs
+		v[idx] = s
+		return fcn(&v)
+	}
+}
+
+// GetSvs returns a scalar function f({x}, t) where {x} = (x, y, z) has at most three components;
+// e.g. to be used as boundary conditions or source terms in the pde package
+func (o *Expr) GetSvs() Svs {
+	fcn := o.fcn
+	return func(x la.Vector, t float64) float64 {
+		var v exprArgs
+		copy(v[:3], x)
+		v[3] = t
+		return fcn(&v)
+	}
+}
+
+// Depends tells whether the expression depends on a variable (x, y, z or t) or parameter
+func (o *Expr) Depends(name string) bool {
+	return o.root.depends(name)
+}
+
+// Deriv returns the symbolic derivative of the expression with respect to a variable (x, y, z
+// or t) or a parameter
+//
+//	NOTE: (1) the derivatives of ramp, heav, sign, abs, floor and ceil are taken with the
+//	          same conventions as in Dual; e.g. d(ramp(x))/dx = heav(x)
+//	      (2) Deriv panics if the expression contains min or max
+func (o *Expr) Deriv(name string) (d *Expr) {
+	d = new(Expr)
+	d.prms = o.prms
+	d.root = o.root.deriv(name)
+	d.fcn = d.root.compile()
+	return
+}
+
+// String returns a string representation of the expression
+func (o *Expr) String() string {
+	return o.root.String()
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// exprVarIndex returns the index of a variable or -1 if name is not a variable
+func exprVarIndex(name string) int {
+	for i, v := range exprVarNames {
+		if v == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// exprConstants holds the named constants
+var exprConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// exprFunctions holds the functions that can be called in an expression
+var exprFunctions map[string]*exprFdef
+
+// exprOperators holds the symbols of binary operators
+var exprOperators = map[int]string{exprAdd: "+", exprSub: "-", exprMul: "*", exprDiv: "/", exprPow: "^"}
+
+// initialise exprFunctions (the derivatives refer to exprFunctions via mkCall)
+func init() {
+	exprFunctions = map[string]*exprFdef{
+		"sin": {name: "sin", narg: 1, f1: math.Sin, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkCall("cos", a[0]), da[0])
+		}},
+		"cos": {name: "cos", narg: 1, f1: math.Cos, deriv: func(a, da []*exprNode) *exprNode {
+			return mkNeg(mkMul(mkCall("sin", a[0]), da[0]))
+		}},
+		"tan": {name: "tan", narg: 1, f1: math.Tan, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkAdd(mkNum(1), mkPow(mkCall("tan", a[0]), mkNum(2))), da[0])
+		}},
+		"asin": {name: "asin", narg: 1, f1: math.Asin, deriv: func(a, da []*exprNode) *exprNode {
+			return mkDiv(da[0], mkCall("sqrt", mkSub(mkNum(1), mkPow(a[0], mkNum(2)))))
+		}},
+		"acos": {name: "acos", narg: 1, f1: math.Acos, deriv: func(a, da []*exprNode) *exprNode {
+			return mkNeg(mkDiv(da[0], mkCall("sqrt", mkSub(mkNum(1), mkPow(a[0], mkNum(2))))))
+		}},
+		"atan": {name: "atan", narg: 1, f1: math.Atan, deriv: func(a, da []*exprNode) *exprNode {
+			return mkDiv(da[0], mkAdd(mkNum(1), mkPow(a[0], mkNum(2))))
+		}},
+		"atan2": {name: "atan2", narg: 2, f2: math.Atan2, deriv: func(a, da []*exprNode) *exprNode {
+			num := mkSub(mkMul(a[1], da[0]), mkMul(a[0], da[1]))
+			den := mkAdd(mkPow(a[0], mkNum(2)), mkPow(a[1], mkNum(2)))
+			return mkDiv(num, den)
+		}},
+		"sinh": {name: "sinh", narg: 1, f1: math.Sinh, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkCall("cosh", a[0]), da[0])
+		}},
+		"cosh": {name: "cosh", narg: 1, f1: math.Cosh, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkCall("sinh", a[0]), da[0])
+		}},
+		"tanh": {name: "tanh", narg: 1, f1: math.Tanh, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkSub(mkNum(1), mkPow(mkCall("tanh", a[0]), mkNum(2))), da[0])
+		}},
+		"exp": {name: "exp", narg: 1, f1: math.Exp, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkCall("exp", a[0]), da[0])
+		}},
+		"log": {name: "log", narg: 1, f1: math.Log, deriv: func(a, da []*exprNode) *exprNode {
+			return mkDiv(da[0], a[0])
+		}},
+		"log10": {name: "log10", narg: 1, f1: math.Log10, deriv: func(a, da []*exprNode) *exprNode {
+			return mkDiv(da[0], mkMul(a[0], mkCall("log", mkNum(10))))
+		}},
+		"sqrt": {name: "sqrt", narg: 1, f1: math.Sqrt, deriv: func(a, da []*exprNode) *exprNode {
+			return mkDiv(da[0], mkMul(mkNum(2), mkCall("sqrt", a[0])))
+		}},
+		"abs": {name: "abs", narg: 1, f1: math.Abs, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkCall("sign", a[0]), da[0])
+		}},
+		"pow": {name: "pow", narg: 2, f2: math.Pow, deriv: func(a, da []*exprNode) *exprNode {
+			return derivPow(a[0], a[1], da[0], da[1])
+		}},
+		"min":   {name: "min", narg: 2, f2: math.Min},
+		"max":   {name: "max", narg: 2, f2: math.Max},
+		"floor": {name: "floor", narg: 1, f1: math.Floor, deriv: derivZero},
+		"ceil":  {name: "ceil", narg: 1, f1: math.Ceil, deriv: derivZero},
+		"ramp": {name: "ramp", narg: 1, f1: Ramp, deriv: func(a, da []*exprNode) *exprNode {
+			return mkMul(mkCall("heav", a[0]), da[0])
+		}},
+		"heav": {name: "heav", narg: 1, f1: Heav, deriv: derivZero},
+		"sign": {name: "sign", narg: 1, f1: Sign, deriv: derivZero},
+	}
+}
+
+// derivZero returns the zero derivative
+func derivZero(a, da []*exprNode) *exprNode {
+	return mkNum(0)
+}
+
+// derivPow returns the derivative of aᵇ
+func derivPow(a, b, da, db *exprNode) *exprNode {
+	if b.kind == exprNum { // d(aᶜ) = c⋅aᶜ⁻¹⋅da
+		return mkMul(mkMul(b, mkPow(a, mkNum(b.num-1))), da)
+	}
+	// d(aᵇ) = aᵇ⋅(db⋅log(a) + b⋅da/a)
+	return mkMul(mkPow(a, b), mkAdd(mkMul(db, mkCall("log", a)), mkDiv(mkMul(b, da), a)))
+}
+
+// constructors with simplification ////////////////////////////////////////////////////////////////
+
+func isNum(a *exprNode, val float64) bool {
+	return a.kind == exprNum && a.num == val
+}
+
+func mkNum(val float64) *exprNode {
+	return &exprNode{kind: exprNum, num: val}
+}
+
+func mkNeg(a *exprNode) *exprNode {
+	if a.kind == exprNum {
+		return mkNum(-a.num)
+	}
+	if a.kind == exprNeg {
+		return a.args[0]
+	}
+	return &exprNode{kind: exprNeg, args: []*exprNode{a}}
+}
+
+func mkAdd(a, b *exprNode) *exprNode {
+	switch {
+	case a.kind == exprNum && b.kind == exprNum:
+		return mkNum(a.num + b.num)
+	case isNum(a, 0):
+		return b
+	case isNum(b, 0):
+		return a
+	case b.kind == exprNeg:
+		return mkSub(a, b.args[0])
+	case b.kind == exprNum && b.num < 0:
+		return mkSub(a, mkNum(-b.num))
+	}
+	return &exprNode{kind: exprAdd, args: []*exprNode{a, b}}
+}
+
+func mkSub(a, b *exprNode) *exprNode {
+	switch {
+	case a.kind == exprNum && b.kind == exprNum:
+		return mkNum(a.num - b.num)
+	case isNum(a, 0):
+		return mkNeg(b)
+	case isNum(b, 0):
+		return a
+	case b.kind == exprNeg:
+		return mkAdd(a, b.args[0])
+	case b.kind == exprNum && b.num < 0:
+		return mkAdd(a, mkNum(-b.num))
+	}
+	return &exprNode{kind: exprSub, args: []*exprNode{a, b}}
+}
+
+func mkMul(a, b *exprNode) *exprNode {
+	switch {
+	case a.kind == exprNum && b.kind == exprNum:
+		return mkNum(a.num * b.num)
+	case isNum(a, 0) || isNum(b, 0):
+		return mkNum(0)
+	case isNum(a, 1):
+		return b
+	case isNum(b, 1):
+		return a
+	case isNum(a, -1):
+		return mkNeg(b)
+	case isNum(b, -1):
+		return mkNeg(a)
+	}
+	return &exprNode{kind: exprMul, args: []*exprNode{a, b}}
+}
+
+func mkDiv(a, b *exprNode) *exprNode {
+	switch {
+	case a.kind == exprNum && b.kind == exprNum && b.num != 0:
+		return mkNum(a.num / b.num)
+	case isNum(a, 0):
+		return mkNum(0)
+	case isNum(b, 1):
+		return a
+	}
+	return &exprNode{kind: exprDiv, args: []*exprNode{a, b}}
+}
+
+func mkPow(a, b *exprNode) *exprNode {
+	switch {
+	case a.kind == exprNum && b.kind == exprNum:
+		return mkNum(math.Pow(a.num, b.num))
+	case isNum(b, 0):
+		return mkNum(1)
+	case isNum(b, 1):
+		return a
+	}
+	return &exprNode{kind: exprPow, args: []*exprNode{a, b}}
+}
+
+func mkCall(name string, args ...*exprNode) *exprNode {
+	fdef := exprFunctions[name]
+	allnum := true
+	for _, a := range args {
+		if a.kind != exprNum {
+			allnum = false
+		}
+	}
+	if allnum {
+		if fdef.narg == 1 {
+			return mkNum(fdef.f1(args[0].num))
+		}
+		return mkNum(fdef.f2(args[0].num, args[1].num))
+	}
+	return &exprNode{kind: exprCall, fdef: fdef, args: args}
+}
+
+// node methods ////////////////////////////////////////////////////////////////////////////////////
+
+// compile converts the tree into a closure
+func (o *exprNode) compile() exprFcn {
+	switch o.kind {
+	case exprNum:
+		c := o.num
+		return func(v *exprArgs) float64 { return c }
+	case exprVar:
+		i := o.idx
+		return func(v *exprArgs) float64 { return v[i] }
+	case exprPrm:
+		p := o.prm
+		return func(v *exprArgs) float64 { return p.V }
+	case exprCall:
+		if o.fdef.narg == 1 {
+			f, a := o.fdef.f1, o.args[0].compile()
+			return func(v *exprArgs) float64 { return f(a(v)) }
+		}
+		f, a, b := o.fdef.f2, o.args[0].compile(), o.args[1].compile()
+		return func(v *exprArgs) float64 { return f(a(v), b(v)) }
+	}
+	a := o.args[0].compile()
+	if o.kind == exprNeg {
+		return func(v *exprArgs) float64 { return -a(v) }
+	}
+	b := o.args[1].compile()
+	switch o.kind {
+	case exprAdd:
+		return func(v *exprArgs) float64 { return a(v) + b(v) }
+	case exprSub:
+		return func(v *exprArgs) float64 { return a(v) - b(v) }
+	case exprMul:
+		return func(v *exprArgs) float64 { return a(v) * b(v) }
+	case exprDiv:
+		return func(v *exprArgs) float64 { return a(v) / b(v) }
+	}
+	if isNum(o.args[1], 2) {
+		return func(v *exprArgs) float64 { x := a(v); return x * x }
+	}
+	return func(v *exprArgs) float64 { return math.Pow(a(v), b(v)) }
+}
+
+// depends tells whether the node depends on a variable or parameter
+func (o *exprNode) depends(name string) bool {
+	switch o.kind {
+	case exprVar:
+		return exprVarNames[o.idx] == name
+	case exprPrm:
+		return o.prm.N == name
+	}
+	for _, a := range o.args {
+		if a.depends(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deriv computes the derivative of the node with respect to a variable or parameter
+func (o *exprNode) deriv(name string) *exprNode {
+	switch o.kind {
+	case exprNum:
+		return mkNum(0)
+	case exprVar:
+		if exprVarNames[o.idx] == name {
+			return mkNum(1)
+		}
+		return mkNum(0)
+	case exprPrm:
+		if o.prm.N == name {
+			return mkNum(1)
+		}
+		return mkNum(0)
+	case exprNeg:
+		return mkNeg(o.args[0].deriv(name))
+	case exprAdd:
+		return mkAdd(o.args[0].deriv(name), o.args[1].deriv(name))
+	case exprSub:
+		return mkSub(o.args[0].deriv(name), o.args[1].deriv(name))
+	}
+	da := make([]*exprNode, len(o.args))
+	for i, a := range o.args {
+		da[i] = a.deriv(name)
+	}
+	a := o.args[0]
+	switch o.kind {
+	case exprMul:
+		b := o.args[1]
+		return mkAdd(mkMul(da[0], b), mkMul(a, da[1]))
+	case exprDiv:
+		b := o.args[1]
+		return mkDiv(mkSub(mkMul(da[0], b), mkMul(a, da[1])), mkPow(b, mkNum(2)))
+	case exprPow:
+		return derivPow(a, o.args[1], da[0], da[1])
+	}
+	if o.fdef.deriv == nil {
+		chk.Panic("cannot differentiate function %q\n", o.fdef.name)
+	}
+	return o.fdef.deriv(o.args, da)
+}
+
+// precedence returns the precedence of the node for printing
+func (o *exprNode) precedence() int {
+	switch o.kind {
+	case exprAdd, exprSub:
+		return 1
+	case exprMul, exprDiv:
+		return 2
+	case exprNeg:
+		return 3
+	case exprPow:
+		return 4
+	case exprNum:
+		if o.num < 0 {
+			return 3
+		}
+	}
+	return 5
+}
+
+// String returns the string representation of the node
+func (o *exprNode) String() string {
+	switch o.kind {
+	case exprNum:
+		return strconv.FormatFloat(o.num, 'g', -1, 64)
+	case exprVar:
+		return exprVarNames[o.idx]
+	case exprPrm:
+		return o.prm.N
+	case exprCall:
+		s := make([]string, len(o.args))
+		for i, a := range o.args {
+			s[i] = a.String()
+		}
+		return o.fdef.name + "(" + strings.Join(s, ",") + ")"
+	}
+	p := o.precedence()
+	wrap := func(a *exprNode, minPrec int) string {
+		if a.precedence() < minPrec {
+			return "(" + a.String() + ")"
+		}
+		return a.String()
+	}
+	if o.kind == exprNeg {
+		return "-" + wrap(o.args[0], p+1)
+	}
+	switch o.kind {
+	case exprPow: // right-associative
+		return wrap(o.args[0], p+1) + "^" + wrap(o.args[1], p)
+	case exprAdd, exprMul:
+		return wrap(o.args[0], p) + exprOperators[o.kind] + wrap(o.args[1], p)
+	}
+	return wrap(o.args[0], p) + exprOperators[o.kind] + wrap(o.args[1], p+1)
+}
+
+// parser //////////////////////////////////////////////////////////////////////////////////////////
+
+// tokens
+const (
+	exprTokEnd   = iota // end of input
+	exprTokNum          // number
+	exprTokIdent        // identifier
+	exprTokOp           // operator or punctuation: + - * / ^ ( ) ,
+)
+
+// exprParser implements a recursive descent parser
+//
+//	sum     := product { ("+" | "-") product }
+//	product := unary { ("*" | "/") unary }
+//	unary   := ("-" | "+") unary | power
+//	power   := primary [ "^" unary ]
+//	primary := number | identifier | identifier "(" sum { "," sum } ")" | "(" sum ")"
+type exprParser struct {
+	src  string     // source
+	prms utl.Params // parameters
+	pos  int        // current position
+	tok  int        // current token
+	text string     // text of current token
+	num  float64    // value of current token if it is a number
+	beg  int        // position of the beginning of current token
+}
+
+// fail panics with a message indicating the position of the current token
+func (o *exprParser) fail(msg string, args ...interface{}) {
+	chk.Panic("cannot parse expression %q: %s at position %d\n", o.src, io.Sf(msg, args...), o.beg)
+}
+
+// next reads the next token
+func (o *exprParser) next() {
+	for o.pos < len(o.src) && (o.src[o.pos] == ' ' || o.src[o.pos] == '\t' || o.src[o.pos] == '\n') {
+		o.pos++
+	}
+	o.beg = o.pos
+	if o.pos == len(o.src) {
+		o.tok, o.text = exprTokEnd, ""
+		return
+	}
+	c := o.src[o.pos]
+	switch {
+	case (c >= '0' && c <= '9') || c == '.':
+		for o.pos < len(o.src) && ((o.src[o.pos] >= '0' && o.src[o.pos] <= '9') || o.src[o.pos] == '.') {
+			o.pos++
+		}
+		if o.pos < len(o.src) && (o.src[o.pos] == 'e' || o.src[o.pos] == 'E') {
+			k := o.pos + 1
+			if k < len(o.src) && (o.src[k] == '+' || o.src[k] == '-') {
+				k++
+			}
+			if k < len(o.src) && o.src[k] >= '0' && o.src[k] <= '9' {
+				for k < len(o.src) && o.src[k] >= '0' && o.src[k] <= '9' {
+					k++
+				}
+				o.pos = k
+			}
+		}
+		o.tok, o.text = exprTokNum, o.src[o.beg:o.pos]
+		var err error
+		o.num, err = strconv.ParseFloat(o.text, 64)
+		if err != nil {
+			o.fail("invalid number %q", o.text)
+		}
+	case isIdentChar(c, true):
+		for o.pos < len(o.src) && isIdentChar(o.src[o.pos], false) {
+			o.pos++
+		}
+		o.tok, o.text = exprTokIdent, o.src[o.beg:o.pos]
+	case strings.IndexByte("+-*/^(),", c) >= 0:
+		o.pos++
+		o.tok, o.text = exprTokOp, string(c)
+	default:
+		o.fail("invalid character %q", string(c))
+	}
+}
+
+// isIdentChar checks whether c can be part of an identifier
+func isIdentChar(c byte, first bool) bool {
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
+
+// isOp checks whether the current token is the operator op
+func (o *exprParser) isOp(op string) bool {
+	return o.tok == exprTokOp && o.text == op
+}
+
+// expect checks whether the current token is the operator op and reads the next token
+func (o *exprParser) expect(op string) {
+	if !o.isOp(op) {
+		if o.tok == exprTokEnd {
+			o.fail("expected %q but found the end of expression", op)
+		}
+		o.fail("expected %q but found %q", op, o.text)
+	}
+	o.next()
+}
+
+func (o *exprParser) parseSum() (res *exprNode) {
+	res = o.parseProduct()
+	for o.isOp("+") || o.isOp("-") {
+		op := o.text
+		o.next()
+		b := o.parseProduct()
+		if op == "+" {
+			res = mkAdd(res, b)
+		} else {
+			res = mkSub(res, b)
+		}
+	}
+	return
+}
+
+func (o *exprParser) parseProduct() (res *exprNode) {
+	res = o.parseUnary()
+	for o.isOp("*") || o.isOp("/") {
+		op := o.text
+		o.next()
+		b := o.parseUnary()
+		if op == "*" {
+			res = mkMul(res, b)
+		} else {
+			res = mkDiv(res, b)
+		}
+	}
+	return
+}
+
+func (o *exprParser) parseUnary() *exprNode {
+	if o.isOp("-") {
+		o.next()
+		return mkNeg(o.parseUnary())
+	}
+	if o.isOp("+") {
+		o.next()
+		return o.parseUnary()
+	}
+	return o.parsePower()
+}
+
+func (o *exprParser) parsePower() (res *exprNode) {
+	res = o.parsePrimary()
+	if o.isOp("^") {
+		o.next()
+		res = mkPow(res, o.parseUnary())
+	}
+	return
+}
+
+func (o *exprParser) parsePrimary() (res *exprNode) {
+	switch o.tok {
+	case exprTokNum:
+		res = mkNum(o.num)
+		o.next()
+		return
+	case exprTokIdent:
+		name, beg := o.text, o.beg
+		o.next()
+		if o.isOp("(") {
+			return o.parseCall(name)
+		}
+		if idx := exprVarIndex(name); idx >= 0 {
+			return &exprNode{kind: exprVar, idx: idx}
+		}
+		if p := o.prms.Find(name); p != nil {
+			return &exprNode{kind: exprPrm, prm: p}
+		}
+		if val, ok := exprConstants[name]; ok {
+			return mkNum(val)
+		}
+		o.beg = beg
+		o.fail("unknown identifier %q", name)
+	case exprTokOp:
+		if o.isOp("(") {
+			o.next()
+			res = o.parseSum()
+			o.expect(")")
+			return
+		}
+		o.fail("unexpected %q", o.text)
+	}
+	o.fail("unexpected end of expression")
+	return
+}
+
+func (o *exprParser) parseCall(name string) *exprNode {
+	key := name
+	if key == "Ramp" || key == "Heav" || key == "Sign" {
+		key = strings.ToLower(key)
+	}
+	fdef, ok := exprFunctions[key]
+	if !ok {
+		o.fail("unknown function %q", name)
+	}
+	o.expect("(")
+	args := []*exprNode{o.parseSum()}
+	for o.isOp(",") {
+		o.next()
+		args = append(args, o.parseSum())
+	}
+	o.expect(")")
+	if len(args) != fdef.narg {
+		o.fail("function %q requires %d argument(s) but %d were given", name, fdef.narg, len(args))
+	}
+	return mkCall(key, args...)
+}
@@ -0,0 +1,414 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fftw"
+	"github.com/cpmech/gosl/la"
+)
+
+// FourierInterpNd performs interpolation using tensor-product truncated Fourier series in 2D or 3D
+//
+//	                 N₀/2-1  N₁/2-1  N₂/2-1
+//	                  ————    ————    ————           +i (κ₀ x₀ + κ₁ x₁ + κ₂ x₂)
+//	  I{f}(x) =       \       \       \     A[k] ⋅ e                          with κ_d = 2π k_d / L_d
+//	                  /       /       /
+//	                  ————    ————    ————
+//	                k₀=-N₀/2 k₁=-N₁/2 k₂=-N₂/2                         x_d ϵ [0, L_d]
+//
+//	where the coefficients A are computed from the values of f at the grid points
+//	x_d[j] = L_d⋅j/N_d using the FFT along each direction. See FourierInterp for the 1D version.
+//
+//	The values at grid points (e.g. U, Lap) are stored in flat arrays in row-major order; i.e.
+//	the index of the point (i, j) is i⋅N₁ + j in 2D and the index of (i, j, k) is i⋅N₁⋅N₂ + j⋅N₂ + k
+//	in 3D. See the function Index.
+//
+//	Create a new object with NewFourierInterpNd(...) AND deallocate memory with Free()
+//
+//	Reference:
+//	  [1] Canuto C, Hussaini MY, Quarteroni A, Zang TA (2006) Spectral Methods: Fundamentals in
+//	      Single Domains. Springer. 563p
+type FourierInterpNd struct {
+
+	// main
+	Ndim int         // space dimension: 2 or 3
+	N    []int       // [ndim] number of points along each direction. must be even
+	L    []float64   // [ndim] lengths of the periodic box
+	X    [][]float64 // [ndim][N[d]] point coordinates along each direction: L[d]⋅j/N[d]
+	K    [][]float64 // [ndim][N[d]] k values computed from j; i.e. j = 0...N-1 ⇒ k = -N/2...N/2-1
+	A    la.VectorC  // [size] coefficients for interpolation. from FFT
+	S    la.VectorC  // [size] smoothing coefficients (tensor product of 1D coefficients)
+
+	// computed (U may be set externally)
+	U    la.Vector   // [size] values of f(x) at grid points
+	Grad []la.Vector // [ndim][size] gradient of f(x) at grid points
+	Lap  la.Vector   // [size] Laplacian of f(x) at grid points
+
+	// auxiliary
+	size    int            // total number of points
+	strides []int          // [ndim] strides of the flat arrays
+	scale   []float64      // [ndim] 2π/L[d]
+	line    [][]complex128 // [ndim][N[d]] workspace for the transforms along each direction
+	planFwd []*fftw.Plan1d // [ndim] forward transforms along each direction
+	planInv []*fftw.Plan1d // [ndim] inverse transforms along each direction
+	work    la.VectorC     // [size] workspace
+	work2   la.VectorC     // [size] workspace
+}
+
+// NewFourierInterpNd allocates a new FourierInterpNd object
+//
+//	N -- [ndim] number of points along each direction (ndim = 2 or 3). must be even; ideally
+//	     powers of 2, e.g. N[d] = 2ⁿ
+//	L -- [ndim] lengths of the periodic box [may be nil ⇒ L[d] = 2π]
+//
+//	smoothing -- type of smoothing (see NewFourierInterp):
+//	  "" or "none" : no smoothing
+//	  "lanc"       : Lanczos (sinc)
+//	  "rcos"       : Raised Cosine
+//	  "ces"        : Cesaro
+//
+//	NOTE: remember to call Free in the end to release memory allocated by FFTW; e.g.
+//	      defer o.Free()
+func NewFourierInterpNd(N []int, L []float64, smoothing string) (o *FourierInterpNd) {
+
+	// check
+	ndim := len(N)
+	if ndim != 2 && ndim != 3 {
+		chk.Panic("the number of dimensions must be 2 or 3. len(N)=%d is invalid\n", ndim)
+	}
+	if L != nil && len(L) != ndim {
+		chk.Panic("len(L)=%d must be equal to len(N)=%d\n", len(L), ndim)
+	}
+	for d := 0; d < ndim; d++ {
+		if N[d] < 2 || N[d]%2 != 0 {
+			chk.Panic("N[%d] must be even. N[%d]=%d is invalid\n", d, d, N[d])
+		}
+	}
+
+	// allocate
+	o = new(FourierInterpNd)
+	o.Ndim = ndim
+	o.N = make([]int, ndim)
+	o.L = make([]float64, ndim)
+	o.X = make([][]float64, ndim)
+	o.K = make([][]float64, ndim)
+	o.strides = make([]int, ndim)
+	o.scale = make([]float64, ndim)
+	o.line = make([][]complex128, ndim)
+	o.planFwd = make([]*fftw.Plan1d, ndim)
+	o.planInv = make([]*fftw.Plan1d, ndim)
+	copy(o.N, N)
+	o.size = 1
+	for d := ndim - 1; d >= 0; d-- {
+		o.strides[d] = o.size
+		o.size *= N[d]
+	}
+
+	// coordinates, k values and plans
+	for d := 0; d < ndim; d++ {
+		o.L[d] = 2.0 * math.Pi
+		if L != nil {
+			o.L[d] = L[d]
+		}
+		o.scale[d] = 2.0 * math.Pi / o.L[d]
+		o.X[d] = make([]float64, N[d])
+		o.K[d] = make([]float64, N[d])
+		h := N[d] / 2
+		for j := 0; j < N[d]; j++ {
+			o.X[d][j] = o.L[d] * float64(j) / float64(N[d])
+			o.K[d][j] = float64(j - (j/h)*N[d])
+		}
+		o.line[d] = make([]complex128, N[d])
+		o.planFwd[d] = fftw.NewPlan1d(o.line[d], false, false)
+		o.planInv[d] = fftw.NewPlan1d(o.line[d], true, false)
+	}
+
+	// smoothing coefficients
+	o.S = la.NewVectorC(o.size)
+	idx := make([]int, ndim)
+	for p := 0; p < o.size; p++ {
+		o.split(idx, p)
+		s := 1.0
+		for d := 0; d < ndim; d++ {
+			n := float64(N[d])
+			k := o.K[d][idx[d]]
+			switch smoothing {
+			case "lanc":
+				s *= Sinc(2 * k * π / n)
+			case "rcos":
+				s *= (1.0 + math.Cos(2*k*π/n)) / 2.0
+			case "ces":
+				s *= 1.0 - math.Abs(k)/(1.0+n/2.0)
+			}
+		}
+		o.S[p] = complex(s, 0)
+	}
+
+	// allocate arrays
+	o.A = la.NewVectorC(o.size)
+	o.U = la.NewVector(o.size)
+	o.Grad = make([]la.Vector, ndim)
+	for d := 0; d < ndim; d++ {
+		o.Grad[d] = la.NewVector(o.size)
+	}
+	o.Lap = la.NewVector(o.size)
+	o.work = la.NewVectorC(o.size)
+	o.work2 = la.NewVectorC(o.size)
+	return
+}
+
+// Free releases resources allocated for FFTW
+func (o *FourierInterpNd) Free() {
+	for d := 0; d < o.Ndim; d++ {
+		if o.planFwd[d] != nil {
+			o.planFwd[d].Free()
+		}
+		if o.planInv[d] != nil {
+			o.planInv[d].Free()
+		}
+	}
+}
+
+// Size returns the total number of grid points
+func (o *FourierInterpNd) Size() int {
+	return o.size
+}
+
+// Index returns the index of the grid point (i, j) in 2D or (i, j, k) in 3D in the flat arrays
+func (o *FourierInterpNd) Index(ijk ...int) (p int) {
+	if len(ijk) != o.Ndim {
+		chk.Panic("the number of indices must be equal to %d\n", o.Ndim)
+	}
+	for d := 0; d < o.Ndim; d++ {
+		p += ijk[d] * o.strides[d]
+	}
+	return
+}
+
+// CalcU calculates f(x) at grid points (to be used later with CalcA)
+func (o *FourierInterpNd) CalcU(f Sv) {
+	x := la.NewVector(o.Ndim)
+	idx := make([]int, o.Ndim)
+	for p := 0; p < o.size; p++ {
+		o.split(idx, p)
+		for d := 0; d < o.Ndim; d++ {
+			x[d] = o.X[d][idx[d]]
+		}
+		o.U[p] = f(x)
+	}
+}
+
+// CalcA calculates the coefficients A of the interpolation using (fwd) FFT
+//
+//	NOTE: remember to set U (or call CalcU) first
+func (o *FourierInterpNd) CalcA() {
+	o.forward(o.A, o.U)
+}
+
+// I computes the interpolation (with smoothing or not) at any point x
+//
+//	NOTE: remember to call CalcA first
+func (o *FourierInterpNd) I(x la.Vector) float64 {
+	return o.evaluate(x, -1)
+}
+
+// IGrad computes the gradient of the interpolation (with smoothing or not) at any point x
+//
+//	NOTE: remember to call CalcA first
+func (o *FourierInterpNd) IGrad(grad, x la.Vector) {
+	for d := 0; d < o.Ndim; d++ {
+		grad[d] = o.evaluate(x, d)
+	}
+}
+
+// CalcGrad calculates the gradient of the interpolated function @ grid points using the FFT
+// (with smoothing or not)
+//
+//	OUTPUT: the results will be stored in Grad
+//
+//	NOTE: remember to call CalcA first
+func (o *FourierInterpNd) CalcGrad() {
+	idx := make([]int, o.Ndim)
+	for d := 0; d < o.Ndim; d++ {
+		for p := 0; p < o.size; p++ {
+			o.split(idx, p)
+			ik := complex(0, o.scale[d]*o.K[d][idx[d]])
+			o.work[p] = ik * o.S[p] * o.A[p]
+		}
+		o.inverse(o.Grad[d], o.work)
+	}
+}
+
+// CalcLap calculates the Laplacian of the interpolated function @ grid points using the FFT
+// (with smoothing or not)
+//
+//	OUTPUT: the results will be stored in Lap
+//
+//	NOTE: remember to call CalcA first
+func (o *FourierInterpNd) CalcLap() {
+	for p := 0; p < o.size; p++ {
+		o.work[p] = complex(-o.kk(p), 0) * o.S[p] * o.A[p]
+	}
+	o.inverse(o.Lap, o.work)
+}
+
+// CalcInvLap solves the Poisson equation ∇²u = f with periodic boundary conditions using the FFT
+//
+//	Input:
+//	  f -- [size] values of the right-hand side at grid points
+//	Output:
+//	  u    -- [size] solution at grid points with zero mean
+//	  mean -- mean value of f which is removed from f because the periodic Poisson problem
+//	          only has a solution if the mean of the right-hand side is zero
+//
+//	NOTE: smoothing is not applied; thus, without smoothing, CalcLap recovers f - mean
+func (o *FourierInterpNd) CalcInvLap(u, f la.Vector) (mean float64) {
+	o.forward(o.work, f)
+	mean = real(o.work[0])
+	o.work[0] = 0
+	for p := 1; p < o.size; p++ {
+		o.work[p] /= complex(-o.kk(p), 0)
+	}
+	o.inverse(u, o.work)
+	return
+}
+
+// Dealias23 removes the high wavenumbers of A according to the 2/3-rule of Orszag [2]; i.e.
+// sets A[k] = 0 if |k_d| > N_d/3 for any direction d
+//
+//	Reference:
+//	  [2] Orszag SA (1971) On the elimination of aliasing in finite-difference schemes by
+//	      filtering high-wavenumber components. Journal of the Atmospheric Sciences, 28:1074
+func (o *FourierInterpNd) Dealias23() {
+	o.truncate23(o.A)
+}
+
+// Product23 computes the product w = u⋅v at grid points free of aliasing errors using the 2/3-rule
+//
+//	The high wavenumbers (see Dealias23) of u and v are removed before the multiplication and the
+//	same truncation is applied to the product. All arrays have length Size(); w may be u or v
+func (o *FourierInterpNd) Product23(w, u, v la.Vector) {
+	tu, tv := la.NewVector(o.size), la.NewVector(o.size)
+	o.forward(o.work, u)
+	o.truncate23(o.work)
+	o.inverse(tu, o.work)
+	o.forward(o.work, v)
+	o.truncate23(o.work)
+	o.inverse(tv, o.work)
+	for p := 0; p < o.size; p++ {
+		tu[p] *= tv[p]
+	}
+	o.forward(o.work, tu)
+	o.truncate23(o.work)
+	o.inverse(w, o.work)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// split converts the flat index p into the indices along each direction
+func (o *FourierInterpNd) split(idx []int, p int) {
+	for d := 0; d < o.Ndim; d++ {
+		idx[d] = p / o.strides[d]
+		p -= idx[d] * o.strides[d]
+	}
+}
+
+// kk returns |κ|² corresponding to the flat index p
+func (o *FourierInterpNd) kk(p int) (res float64) {
+	for d := 0; d < o.Ndim; d++ {
+		j := p / o.strides[d]
+		p -= j * o.strides[d]
+		κ := o.scale[d] * o.K[d][j]
+		res += κ * κ
+	}
+	return
+}
+
+// truncate23 sets c[p] = 0 for the wavenumbers eliminated by the 2/3-rule
+func (o *FourierInterpNd) truncate23(c la.VectorC) {
+	idx := make([]int, o.Ndim)
+	for p := 0; p < o.size; p++ {
+		o.split(idx, p)
+		for d := 0; d < o.Ndim; d++ {
+			if 3*math.Abs(o.K[d][idx[d]]) > float64(o.N[d]) {
+				c[p] = 0
+				break
+			}
+		}
+	}
+}
+
+// forward computes the normalised forward transform: c = FFT(u) / size
+func (o *FourierInterpNd) forward(c la.VectorC, u la.Vector) {
+	n := float64(o.size)
+	for p := 0; p < o.size; p++ {
+		c[p] = complex(u[p]/n, 0)
+	}
+	o.transform(c, false)
+}
+
+// inverse computes the inverse transform and extracts the real part: u = real(IFFT(c)).
+// c is not modified
+func (o *FourierInterpNd) inverse(u la.Vector, c la.VectorC) {
+	copy(o.work2, c)
+	o.transform(o.work2, true)
+	for p := 0; p < o.size; p++ {
+		u[p] = real(o.work2[p])
+	}
+}
+
+// transform performs the multidimensional (non-normalised) FFT by applying 1D transforms along
+// each direction
+func (o *FourierInterpNd) transform(c la.VectorC, inverse bool) {
+	for d := 0; d < o.Ndim; d++ {
+		n, s := o.N[d], o.strides[d]
+		plan := o.planFwd[d]
+		if inverse {
+			plan = o.planInv[d]
+		}
+		for p := 0; p < o.size; p++ {
+			if (p/s)%n != 0 { // p must be the first point of a line along d
+				continue
+			}
+			for j := 0; j < n; j++ {
+				o.line[d][j] = c[p+j*s]
+			}
+			plan.Execute()
+			for j := 0; j < n; j++ {
+				c[p+j*s] = o.line[d][j]
+			}
+		}
+	}
+}
+
+// evaluate computes the interpolation at x (deriv < 0) or its derivative along direction deriv
+func (o *FourierInterpNd) evaluate(x la.Vector, deriv int) float64 {
+	ee := make([][]complex128, o.Ndim)
+	for d := 0; d < o.Ndim; d++ {
+		ee[d] = make([]complex128, o.N[d])
+		for j := 0; j < o.N[d]; j++ {
+			κ := o.scale[d] * o.K[d][j]
+			ee[d][j] = cmplx.Exp(complex(0, κ*x[d]))
+			if d == deriv {
+				ee[d][j] *= complex(0, κ)
+			}
+		}
+	}
+	var res complex128
+	idx := make([]int, o.Ndim)
+	for p := 0; p < o.size; p++ {
+		o.split(idx, p)
+		e := o.S[p] * o.A[p]
+		for d := 0; d < o.Ndim; d++ {
+			e *= ee[d][idx[d]]
+		}
+		res += e
+	}
+	return real(res)
+}
@@ -0,0 +1,250 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// constants for the incomplete gamma and beta functions
+const (
+	gamIncAsw   = 100   // a ≥ gamIncAsw ⇒ use quadrature
+	gamIncMaxIt = 10000 // max number of terms of the series and continued fractions
+)
+
+// gamIncFpmin is a number near the smallest representable number
+var gamIncFpmin = 1e-300 / machEps
+
+// Gauss-Legendre abscissas and weights used by gammaPapprox and betaIapprox
+var (
+	gamIncY = []float64{0.0021695375159141994, 0.011413521097787704, 0.027972308950302116,
+		0.051727015600492421, 0.082502225484340941, 0.12007019910960293, 0.16415283300752470,
+		0.21442376986779355, 0.27051082840644336, 0.33199876341447887, 0.39843234186401943,
+		0.46931971407375483, 0.54413605556657973, 0.62232745288031077, 0.70331500465597174,
+		0.78649910768313447, 0.87126389619061517, 0.95698180152629142}
+	gamIncW = []float64{0.0055657196642445571, 0.012915947284065419, 0.020181515297735382,
+		0.027298621498568734, 0.034213810770299537, 0.040875750923643261, 0.047235083490265582,
+		0.053244713977759692, 0.058860144245324798, 0.064039797355015485, 0.068745323835736408,
+		0.072941885005653087, 0.076598410645870640, 0.079687828912071670, 0.082187266704339706,
+		0.084078218979661945, 0.085346685739338721, 0.085983275670394821}
+)
+
+// GammaP computes the regularized lower incomplete gamma function P(a,x)
+//
+//	                  x
+//	           1     ⌠
+//	P(a,x) = —————— ⋅ │  exp(-t) ⋅ t^(a-1) dt
+//	          Γ(a)   ⌡
+//	                0
+//
+//	where: a > 0 and x ≥ 0. P(a,+∞) = 1 and NaN is returned if a or x is NaN
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func GammaP(a, x float64) float64 {
+	if math.IsNaN(a) || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0.0 || a <= 0.0 {
+		chk.Panic("GammaP requires a > 0 and x ≥ 0. a=%g, x=%g is invalid", a, x)
+	}
+	if x == 0.0 {
+		return 0.0
+	}
+	if math.IsInf(x, 1) {
+		return 1.0
+	}
+	if int(a) >= gamIncAsw {
+		return gammaPapprox(a, x, true)
+	}
+	if x < a+1.0 {
+		return gammaSer(a, x)
+	}
+	return 1.0 - gammaCf(a, x)
+}
+
+// GammaQ computes the regularized upper incomplete gamma function Q(a,x) = 1 - P(a,x)
+//
+//	                  ∞
+//	           1     ⌠
+//	Q(a,x) = —————— ⋅ │  exp(-t) ⋅ t^(a-1) dt
+//	          Γ(a)   ⌡
+//	                x
+//
+//	where: a > 0 and x ≥ 0. Q(a,+∞) = 0 and NaN is returned if a or x is NaN
+func GammaQ(a, x float64) float64 {
+	if math.IsNaN(a) || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < 0.0 || a <= 0.0 {
+		chk.Panic("GammaQ requires a > 0 and x ≥ 0. a=%g, x=%g is invalid", a, x)
+	}
+	if x == 0.0 {
+		return 1.0
+	}
+	if math.IsInf(x, 1) {
+		return 0.0
+	}
+	if int(a) >= gamIncAsw {
+		return gammaPapprox(a, x, false)
+	}
+	if x < a+1.0 {
+		return 1.0 - gammaSer(a, x)
+	}
+	return gammaCf(a, x)
+}
+
+// InvGammaP returns x such that P(a,x) = p; i.e. it inverts the regularized lower incomplete gamma
+// function using Halley's method. See page 263 of [1]
+//
+//	where: a > 0 and 0 ≤ p < 1
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func InvGammaP(p, a float64) float64 {
+	if a <= 0.0 {
+		chk.Panic("InvGammaP requires a > 0. a=%g is invalid", a)
+	}
+	if p >= 1.0 {
+		return math.Max(100.0, a+100.0*math.Sqrt(a))
+	}
+	if p <= 0.0 {
+		return 0.0
+	}
+	var x, err, t, u, pp, lna1, afac float64
+	a1 := a - 1.0
+	EPS := 1e-8 // accuracy is the square of EPS
+	gln, _ := math.Lgamma(a)
+	if a > 1.0 { // initial guess based on reference [1] (26.2.22) and (26.4.17)
+		lna1 = math.Log(a1)
+		afac = math.Exp(a1*(lna1-1.0) - gln)
+		pp = p
+		if p >= 0.5 {
+			pp = 1.0 - p
+		}
+		t = math.Sqrt(-2.0 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1.0+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		x = math.Max(1.0e-3, a*math.Pow(1.0-1.0/(9.0*a)-x/(3.0*math.Sqrt(a)), 3))
+	} else { // initial guess based on equations (6.2.8) and (6.2.9) of [1]
+		t = 1.0 - a*(0.253+a*0.12)
+		if p < t {
+			x = math.Pow(p/t, 1.0/a)
+		} else {
+			x = 1.0 - math.Log(1.0-(p-t)/(1.0-t))
+		}
+	}
+	for j := 0; j < 12; j++ {
+		if x <= 0.0 {
+			return 0.0 // x too small to compute accurately
+		}
+		err = GammaP(a, x) - p
+		if a > 1.0 {
+			t = afac * math.Exp(-(x-a1)+a1*(math.Log(x)-lna1))
+		} else {
+			t = math.Exp(-x + a1*math.Log(x) - gln)
+		}
+		u = err / t
+		t = u / (1.0 - 0.5*math.Min(1.0, u*((a-1.0)/x-1))) // Halley's method
+		x -= t
+		if x <= 0.0 {
+			x = 0.5 * (x + t) // halve old value if x tries to go negative
+		}
+		if math.Abs(t) < EPS*x {
+			break
+		}
+	}
+	return x
+}
+
+// gammaSer returns the incomplete gamma function P(a,x) evaluated by its series representation
+func gammaSer(a, x float64) float64 {
+	gln, _ := math.Lgamma(a)
+	ap := a
+	sum := 1.0 / a
+	del := sum
+	for i := 0; i < gamIncMaxIt; i++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*machEps {
+			return sum * math.Exp(-x+a*math.Log(x)-gln)
+		}
+	}
+	chk.Panic("series of P(a,x) did not converge after %d terms. a=%g, x=%g\n", gamIncMaxIt, a, x)
+	return 0
+}
+
+// gammaCf returns the incomplete gamma function Q(a,x) evaluated by its continued fraction
+// representation using the modified Lentz's method
+func gammaCf(a, x float64) float64 {
+	gln, _ := math.Lgamma(a)
+	b := x + 1.0 - a
+	c := 1.0 / gamIncFpmin
+	d := 1.0 / b
+	h := d
+	var an, del float64
+	converged := false
+	for i := 1; i <= gamIncMaxIt; i++ {
+		an = -float64(i) * (float64(i) - a)
+		b += 2.0
+		d = an*d + b
+		if math.Abs(d) < gamIncFpmin {
+			d = gamIncFpmin
+		}
+		c = b + an/c
+		if math.Abs(c) < gamIncFpmin {
+			c = gamIncFpmin
+		}
+		d = 1.0 / d
+		del = d * c
+		h *= del
+		if math.Abs(del-1.0) <= machEps {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		chk.Panic("continued fraction of Q(a,x) did not converge after %d terms. a=%g, x=%g\n", gamIncMaxIt, a, x)
+	}
+	return math.Exp(-x+a*math.Log(x)-gln) * h
+}
+
+// gammaPapprox computes P(a,x) or Q(a,x) by Gauss-Legendre quadrature for large a
+func gammaPapprox(a, x float64, psig bool) float64 {
+	a1 := a - 1.0
+	lna1 := math.Log(a1)
+	sqrta1 := math.Sqrt(a1)
+	gln, _ := math.Lgamma(a)
+	var xu float64
+	if x > a1 { // set how far to integrate into the tail
+		xu = math.Max(a1+11.5*sqrta1, x+6.0*sqrta1)
+	} else {
+		xu = math.Max(0.0, math.Min(a1-7.5*sqrta1, x-5.0*sqrta1))
+	}
+	sum := 0.0
+	var t float64
+	for j := 0; j < len(gamIncY); j++ {
+		t = x + (xu-x)*gamIncY[j]
+		sum += gamIncW[j] * math.Exp(-(t-a1)+a1*(math.Log(t)-lna1))
+	}
+	ans := sum * (xu - x) * math.Exp(a1*(lna1-1.0)-gln)
+	if x > a1 { // ans = Q(a,x)
+		if psig {
+			return 1.0 - ans
+		}
+		return ans
+	}
+	if psig { // ans = -P(a,x)
+		return -ans
+	}
+	return 1.0 + ans
+}
@@ -43,12 +43,14 @@ type GeneralOrthoPoly struct {
 //     "H" or "her"    : Hermite
 //     "T" or "cheby1" : Chebyshev first kind
 //     "U" or "cheby2" : Chebyshev second kind
+//     "La"            : Laguerre
+//     "GLa"           : generalized Laguerre
 //
 //   N -- is the (max) degree of the polynomial.
 //        Lower order can later be quickly obtained after this
 //        polynomial with max(N) is created
 //
-//   alpha -- Jacobi only: α coefficient
+//   alpha -- Jacobi and generalized Laguerre only: α coefficient
 //
 //   beta -- Jacobi only: β coefficient
 //
@@ -111,11 +113,22 @@ func (o *GeneralOrthoPoly) P(n int, x float64) (res float64) {
 //                          /
 //                          ————
 //                          m = 0
+//
+//   The monic polynomials πₖ(x) = Pₖ(x) / (leading coefficient of Pₖ) satisfy the three-term
+//   recurrence relation used by rec(k) (see [2]):
+//
+//        πₖ₊₁(x) = (x - aₖ) ⋅ πₖ(x) - bₖ ⋅ πₖ₋₁(x)    with   π₋₁ = 0  and  π₀ = 1
+//
+//   where rec(0) returns b₀ = μ₀ = ∫ w(x) dx; i.e. the integral of the weight function
+//
+//   [2] Gautschi W (2004) Orthogonal Polynomials: Computation and Approximation.
+//       Oxford University Press. 301p.
 type oPoly interface {
 	M(n int) int
 	d(n int) float64
 	c(n, m int) float64
 	g(n, m int, x float64) float64
+	rec(k int) (a, b float64)
 }
 
 // oPolyMaker defines a function that makes new oPolys
@@ -159,6 +172,25 @@ func (o *opJacobi) g(n, m int, x float64) float64 {
 	return math.Pow(x-1, float64(n-m)) * math.Pow(x+1, float64(m))
 }
 
+func (o *opJacobi) rec(k int) (a, b float64) {
+	α, β := o.alpha, o.beta
+	if k == 0 {
+		la, _ := math.Lgamma(α + 1)
+		lb, _ := math.Lgamma(β + 1)
+		lab, _ := math.Lgamma(α + β + 2)
+		return (β - α) / (α + β + 2), math.Exp((α+β+1)*math.Ln2 + la + lb - lab)
+	}
+	K := float64(k)
+	s := 2*K + α + β
+	a = (β*β - α*α) / (s * (s + 2))
+	if k == 1 { // avoid 0/0 if α+β = -1
+		b = 4 * (1 + α) * (1 + β) / ((2 + α + β) * (2 + α + β) * (3 + α + β))
+		return
+	}
+	b = 4 * K * (K + α) * (K + β) * (K + α + β) / (s * s * (s + 1) * (s - 1))
+	return
+}
+
 func newJacobi(alpha, beta float64) oPoly {
 	o := new(opJacobi)
 	o.alpha = alpha
@@ -189,6 +221,14 @@ func (o *opLegendre) g(n, m int, x float64) float64 {
 	return math.Pow(x, float64(n-2*m))
 }
 
+func (o *opLegendre) rec(k int) (a, b float64) {
+	if k == 0 {
+		return 0, 2
+	}
+	K := float64(k)
+	return 0, K * K / (4*K*K - 1)
+}
+
 func newLegendre(alpha, beta float64) oPoly {
 	return new(opLegendre)
 }
@@ -215,6 +255,13 @@ func (o *opHermite) g(n, m int, x float64) float64 {
 	return math.Pow(2*x, float64(n-2*m))
 }
 
+func (o *opHermite) rec(k int) (a, b float64) {
+	if k == 0 {
+		return 0, math.Sqrt(math.Pi)
+	}
+	return 0, float64(k) / 2.0
+}
+
 func newHermite(alpha, beta float64) oPoly {
 	return new(opHermite)
 }
@@ -242,6 +289,16 @@ func (o *opChebyshev1) g(n, m int, x float64) float64 {
 	return math.Pow(2*x, float64(n-2*m))
 }
 
+func (o *opChebyshev1) rec(k int) (a, b float64) {
+	if k == 0 {
+		return 0, math.Pi
+	}
+	if k == 1 {
+		return 0, 0.5
+	}
+	return 0, 0.25
+}
+
 func newChebyshev1(alpha, beta float64) oPoly {
 	return new(opChebyshev1)
 }
@@ -269,10 +326,65 @@ func (o *opChebyshev2) g(n, m int, x float64) float64 {
 	return math.Pow(2*x, float64(n-2*m))
 }
 
+func (o *opChebyshev2) rec(k int) (a, b float64) {
+	if k == 0 {
+		return 0, math.Pi / 2.0
+	}
+	return 0, 0.25
+}
+
 func newChebyshev2(alpha, beta float64) oPoly {
 	return new(opChebyshev2)
 }
 
+// Laguerre //////////////////////////////////////////////////////////////////////////////////////////
+
+// opLaguerre implements the generalized Laguerre polynomials L⁽ᵅ⁾ₙ(x); see Table 22.3 of [1]
+type opLaguerre struct {
+	alpha float64
+}
+
+func (o *opLaguerre) M(n int) int {
+	return n
+}
+
+func (o *opLaguerre) d(n int) float64 {
+	return 1.0
+}
+
+func (o *opLaguerre) c(n, m int) float64 {
+	r := Rbinomial(float64(n)+o.alpha, float64(n-m))
+	s := math.Gamma(float64(m) + 1) // m!
+	return math.Pow(-1, float64(m)) * r / s
+}
+
+func (o *opLaguerre) g(n, m int, x float64) float64 {
+	return math.Pow(x, float64(m))
+}
+
+func (o *opLaguerre) rec(k int) (a, b float64) {
+	K := float64(k)
+	a = 2*K + o.alpha + 1
+	if k == 0 {
+		b, _ = math.Lgamma(o.alpha + 1)
+		return a, math.Exp(b)
+	}
+	return a, K * (K + o.alpha)
+}
+
+func newLaguerre(alpha, beta float64) oPoly {
+	return new(opLaguerre)
+}
+
+func newGenLaguerre(alpha, beta float64) oPoly {
+	if alpha <= -1 {
+		chk.Panic("generalized Laguerre polynomials require α > -1. α=%g is invalid", alpha)
+	}
+	o := new(opLaguerre)
+	o.alpha = alpha
+	return o
+}
+
 // add polynomials to database /////////////////////////////////////////////////////////////////////
 
 func init() {
@@ -281,4 +393,6 @@ func init() {
 	oPolyDB["H"] = newHermite
 	oPolyDB["T"] = newChebyshev1
 	oPolyDB["U"] = newChebyshev2
+	oPolyDB["La"] = newLaguerre
+	oPolyDB["GLa"] = newGenLaguerre
 }
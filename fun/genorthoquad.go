@@ -0,0 +1,208 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// GaussXW computes the N nodes (x) and weights (w) of the Gauss quadrature rule associated with
+// the weight function of this orthogonal polynomial:
+//
+//	  ⌠                   N-1
+//	  │ w(x) ⋅ f(x) dx ≈  Σ  w[i] ⋅ f(x[i])
+//	  ⌡                   i=0
+//
+//	The rule is exact if f is a polynomial of degree ≤ 2N-1; the nodes are the roots of P_N(x)
+//
+//	The weight functions w(x) and domains are:
+//	  Jacobi        (1-x)ᵅ⋅(1+x)ᵝ  in [-1, 1]
+//	  Legendre      1              in [-1, 1]
+//	  Hermite       exp(-x²)       in (-∞, ∞)
+//	  Chebyshev1    1/√(1-x²)      in [-1, 1]
+//	  Chebyshev2    √(1-x²)        in [-1, 1]
+//	  Laguerre      exp(-x)        in [0, ∞)
+//	  GenLaguerre   xᵅ⋅exp(-x)     in [0, ∞)
+//
+//	The nodes and weights are computed with the Golub-Welsch algorithm [1] from the three-term
+//	recurrence relation; i.e. the nodes are the eigenvalues of the symmetric tridiagonal Jacobi
+//	matrix and the weights are computed from the first components of the eigenvectors
+//
+//	The nodes are sorted in ascending order
+//
+//	References:
+//	[1] Golub GH, Welsch JH (1969) Calculation of Gauss quadrature rules. Mathematics of
+//	    Computation, 23(106):221-230
+func (o *GeneralOrthoPoly) GaussXW() (x, w []float64) {
+	if o.N < 1 {
+		chk.Panic("the number of points N must be at least 1. N=%d is invalid", o.N)
+	}
+	a, b := o.recurrence(o.N)
+	return gaussGolubWelsch(a, b)
+}
+
+// GaussRadauXW computes the N nodes (x) and weights (w) of the Gauss-Radau quadrature rule with
+// one node fixed at xfix (usually an end point of the domain, e.g. -1, 1 or 0 for Laguerre)
+//
+//	The rule is exact if f is a polynomial of degree ≤ 2N-2. See GaussXW for the weight functions
+//
+//	References:
+//	[1] Golub GH (1973) Some modified matrix eigenvalue problems. SIAM Review, 15(2):318-334
+//	[2] Gautschi W (2004) Orthogonal Polynomials: Computation and Approximation.
+//	    Oxford University Press. 301p.
+func (o *GeneralOrthoPoly) GaussRadauXW(xfix float64) (x, w []float64) {
+	if o.N < 2 {
+		chk.Panic("the number of points N must be at least 2. N=%d is invalid", o.N)
+	}
+	n := o.N
+	a, b := o.recurrence(n)
+	r := gaussRatio(xfix, a, b, n-1)
+	a[n-1] = xfix - b[n-1]/r // modify the last diagonal such that π_N(xfix) = 0
+	return gaussGolubWelsch(a, b)
+}
+
+// GaussLobattoXW computes the N nodes (x) and weights (w) of the Gauss-Lobatto quadrature rule
+// with two nodes fixed at xa and xb (usually the end points of the domain; e.g. -1 and 1)
+//
+//	The rule is exact if f is a polynomial of degree ≤ 2N-3. See GaussXW for the weight functions
+//
+//	References:
+//	[1] Golub GH (1973) Some modified matrix eigenvalue problems. SIAM Review, 15(2):318-334
+//	[2] Gautschi W (2004) Orthogonal Polynomials: Computation and Approximation.
+//	    Oxford University Press. 301p.
+func (o *GeneralOrthoPoly) GaussLobattoXW(xa, xb float64) (x, w []float64) {
+	if o.N < 2 {
+		chk.Panic("the number of points N must be at least 2. N=%d is invalid", o.N)
+	}
+	if xa >= xb {
+		chk.Panic("xa must be smaller than xb. xa=%g, xb=%g is invalid", xa, xb)
+	}
+	n := o.N
+	a, b := o.recurrence(n)
+	ra := gaussRatio(xa, a, b, n-1)
+	rb := gaussRatio(xb, a, b, n-1)
+	a[n-1] = (xa*ra - xb*rb) / (ra - rb) // modify the last row such that π_N(xa) = π_N(xb) = 0
+	b[n-1] = (xa - a[n-1]) * ra
+	if b[n-1] <= 0 {
+		chk.Panic("cannot compute Gauss-Lobatto rule with fixed nodes xa=%g and xb=%g", xa, xb)
+	}
+	return gaussGolubWelsch(a, b)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// recurrence returns the coefficients aₖ and bₖ (k = 0...n-1) of the monic three-term recurrence
+// relation, where b₀ = μ₀ is the integral of the weight function
+func (o *GeneralOrthoPoly) recurrence(n int) (a, b []float64) {
+	a = make([]float64, n)
+	b = make([]float64, n)
+	for k := 0; k < n; k++ {
+		a[k], b[k] = o.poly.rec(k)
+	}
+	return
+}
+
+// gaussRatio computes the ratio πₖ(x) / πₖ₋₁(x) of monic orthogonal polynomials (k ≥ 1)
+func gaussRatio(x float64, a, b []float64, k int) (r float64) {
+	r = x - a[0]
+	for j := 1; j < k; j++ {
+		r = x - a[j] - b[j]/r
+	}
+	return
+}
+
+// gaussGolubWelsch computes the nodes and weights of a Gauss rule from the Jacobi matrix with
+// diagonal a and sub-diagonal √b[1:]. NOTE: b[0] = μ₀. The input a is modified
+func gaussGolubWelsch(a, b []float64) (x, w []float64) {
+	n := len(a)
+	e := make([]float64, n)
+	for i := 1; i < n; i++ {
+		e[i-1] = math.Sqrt(b[i])
+	}
+	x = a
+	w = make([]float64, n)
+	w[0] = 1
+	gaussTridiagQL(x, e, w)
+	for i := 0; i < n; i++ {
+		w[i] = b[0] * w[i] * w[i]
+	}
+	utl.Qsort2(x, w)
+	return
+}
+
+// gaussTridiagQL computes the eigenvalues of a symmetric tridiagonal matrix using the QL algorithm
+// with implicit shifts. Only the first components of the eigenvectors are computed; see page 583
+// of [1] (tqli) and [2]
+//
+//	Input:
+//	  d -- diagonal [n]
+//	  e -- sub-diagonal with e[i] = A[i][i+1] (i = 0...n-2) and e[n-1] = 0
+//	  z -- first row of the identity matrix
+//	Output:
+//	  d -- eigenvalues
+//	  z -- first components of the normalised eigenvectors
+//	  NOTE: e is destroyed
+//
+//	References:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//	[2] Golub GH, Welsch JH (1969) Calculation of Gauss quadrature rules. Mathematics of
+//	    Computation, 23(106):221-230
+func gaussTridiagQL(d, e, z []float64) {
+	n := len(d)
+	EPS := machEps
+	var m, i int
+	var s, r, p, g, f, dd, c, b float64
+	for l := 0; l < n; l++ {
+		for iter := 0; ; iter++ {
+			for m = l; m < n-1; m++ { // look for a single small sub-diagonal element to split the matrix
+				dd = math.Abs(d[m]) + math.Abs(d[m+1])
+				if math.Abs(e[m]) <= EPS*dd {
+					break
+				}
+			}
+			if m == l {
+				break
+			}
+			if iter == 30 {
+				chk.Panic("QL algorithm did not converge after %d iterations", iter)
+			}
+			g = (d[l+1] - d[l]) / (2.0 * e[l]) // form shift
+			r = math.Hypot(g, 1.0)
+			g = d[m] - d[l] + e[l]/(g+math.Copysign(r, g))
+			s, c, p = 1.0, 1.0, 0.0
+			for i = m - 1; i >= l; i-- { // a plane rotation as in the original QL, followed by Givens rotations to restore tridiagonal form
+				f = s * e[i]
+				b = c * e[i]
+				r = math.Hypot(f, g)
+				e[i+1] = r
+				if r == 0.0 { // recover from underflow
+					d[i+1] -= p
+					e[m] = 0.0
+					break
+				}
+				s = f / r
+				c = g / r
+				g = d[i+1] - p
+				r = (d[i]-g)*s + 2.0*c*b
+				p = s * r
+				d[i+1] = g + p
+				g = c*r - b
+				f = z[i+1] // first component of the eigenvectors
+				z[i+1] = s*z[i] + c*f
+				z[i] = c*z[i] - s*f
+			}
+			if r == 0.0 && i >= l {
+				continue
+			}
+			d[l] -= p
+			e[l] = g
+			e[m] = 0.0
+		}
+	}
+}
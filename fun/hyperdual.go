@@ -0,0 +1,206 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import "math"
+
+// HyperDual implements hyper-dual numbers a = V + D1⋅ε₁ + D2⋅ε₂ + D12⋅ε₁ε₂ with ε₁² = ε₂² = 0 for
+// the exact computation of first and second derivatives [1]
+//
+//	The second derivative of a function f(x) is obtained by evaluating f with x + ε₁ + ε₂:
+//
+//	  f(x + ε₁ + ε₂) = f(x) + f'(x)⋅ε₁ + f'(x)⋅ε₂ + f''(x)⋅ε₁ε₂
+//
+//	and the mixed derivative ∂²f/∂xᵢ∂xⱼ of f(x) with x ∈ Rⁿ is obtained by seeding D1 = 1 at xᵢ
+//	and D2 = 1 at xⱼ
+//
+//	References:
+//	[1] Fike JA, Alonso JJ (2011) The development of hyper-dual numbers for exact second-derivative
+//	    calculations. 49th AIAA Aerospace Sciences Meeting. AIAA 2011-886
+type HyperDual struct {
+	V   float64 // value (real part)
+	D1  float64 // ε₁ part
+	D2  float64 // ε₂ part
+	D12 float64 // ε₁ε₂ part
+}
+
+// HyperDualConst returns the hyper-dual number representing the constant c
+func HyperDualConst(c float64) HyperDual {
+	return HyperDual{c, 0, 0, 0}
+}
+
+// HyperDualVar returns the hyper-dual number representing the independent variable x; i.e.
+// x + 1⋅ε₁ + 1⋅ε₂ + 0⋅ε₁ε₂
+func HyperDualVar(x float64) HyperDual {
+	return HyperDual{x, 1, 1, 0}
+}
+
+// chain applies the chain rule with f = f(a.V), df = df/dx(a.V) and ddf = d²f/dx²(a.V)
+func (a HyperDual) chain(f, df, ddf float64) HyperDual {
+	return HyperDual{f, df * a.D1, df * a.D2, df*a.D12 + ddf*a.D1*a.D2}
+}
+
+// arithmetic //////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a HyperDual) Add(b HyperDual) HyperDual {
+	return HyperDual{a.V + b.V, a.D1 + b.D1, a.D2 + b.D2, a.D12 + b.D12}
+}
+
+// Sub returns a - b
+func (a HyperDual) Sub(b HyperDual) HyperDual {
+	return HyperDual{a.V - b.V, a.D1 - b.D1, a.D2 - b.D2, a.D12 - b.D12}
+}
+
+// Mul returns a ⋅ b
+func (a HyperDual) Mul(b HyperDual) HyperDual {
+	return HyperDual{
+		a.V * b.V,
+		a.D1*b.V + a.V*b.D1,
+		a.D2*b.V + a.V*b.D2,
+		a.D12*b.V + a.D1*b.D2 + a.D2*b.D1 + a.V*b.D12,
+	}
+}
+
+// Div returns a / b
+func (a HyperDual) Div(b HyperDual) HyperDual {
+	return a.Mul(b.Inv())
+}
+
+// Neg returns -a
+func (a HyperDual) Neg() HyperDual {
+	return HyperDual{-a.V, -a.D1, -a.D2, -a.D12}
+}
+
+// AddS returns a + s where s is a scalar
+func (a HyperDual) AddS(s float64) HyperDual {
+	return HyperDual{a.V + s, a.D1, a.D2, a.D12}
+}
+
+// MulS returns a ⋅ s where s is a scalar
+func (a HyperDual) MulS(s float64) HyperDual {
+	return HyperDual{a.V * s, a.D1 * s, a.D2 * s, a.D12 * s}
+}
+
+// PowS returns aᵖ where p is a scalar
+func (a HyperDual) PowS(p float64) HyperDual {
+	if p == 0 {
+		return HyperDual{1, 0, 0, 0}
+	}
+	return a.chain(math.Pow(a.V, p), p*math.Pow(a.V, p-1), p*(p-1)*math.Pow(a.V, p-2))
+}
+
+// Pow returns aᵇ = exp(b ⋅ log(a))
+func (a HyperDual) Pow(b HyperDual) HyperDual {
+	return b.Mul(a.Log()).Exp()
+}
+
+// Inv returns 1/a
+func (a HyperDual) Inv() HyperDual {
+	r := 1.0 / a.V
+	return a.chain(r, -r*r, 2*r*r*r)
+}
+
+// elementary functions ////////////////////////////////////////////////////////////////////////////
+
+// Sqrt returns √a
+func (a HyperDual) Sqrt() HyperDual {
+	s := math.Sqrt(a.V)
+	return a.chain(s, 0.5/s, -0.25/(s*a.V))
+}
+
+// Exp returns exp(a)
+func (a HyperDual) Exp() HyperDual {
+	e := math.Exp(a.V)
+	return a.chain(e, e, e)
+}
+
+// Log returns the natural logarithm log(a)
+func (a HyperDual) Log() HyperDual {
+	return a.chain(math.Log(a.V), 1.0/a.V, -1.0/(a.V*a.V))
+}
+
+// Sin returns sin(a)
+func (a HyperDual) Sin() HyperDual {
+	s, c := math.Sincos(a.V)
+	return a.chain(s, c, -s)
+}
+
+// Cos returns cos(a)
+func (a HyperDual) Cos() HyperDual {
+	s, c := math.Sincos(a.V)
+	return a.chain(c, -s, -c)
+}
+
+// Tan returns tan(a)
+func (a HyperDual) Tan() HyperDual {
+	t := math.Tan(a.V)
+	d := 1.0 + t*t
+	return a.chain(t, d, 2*t*d)
+}
+
+// Asin returns asin(a)
+func (a HyperDual) Asin() HyperDual {
+	d := 1.0 - a.V*a.V
+	return a.chain(math.Asin(a.V), 1.0/math.Sqrt(d), a.V/(d*math.Sqrt(d)))
+}
+
+// Acos returns acos(a)
+func (a HyperDual) Acos() HyperDual {
+	d := 1.0 - a.V*a.V
+	return a.chain(math.Acos(a.V), -1.0/math.Sqrt(d), -a.V/(d*math.Sqrt(d)))
+}
+
+// Atan returns atan(a)
+func (a HyperDual) Atan() HyperDual {
+	d := 1.0 + a.V*a.V
+	return a.chain(math.Atan(a.V), 1.0/d, -2*a.V/(d*d))
+}
+
+// Atan2 returns atan2(a, b); i.e. the angle of the point (b, a)
+func (a HyperDual) Atan2(b HyperDual) HyperDual {
+	res := a.Div(b).Atan() // same derivatives as atan(a/b)
+	res.V = math.Atan2(a.V, b.V)
+	return res
+}
+
+// Sinh returns sinh(a)
+func (a HyperDual) Sinh() HyperDual {
+	s, c := math.Sinh(a.V), math.Cosh(a.V)
+	return a.chain(s, c, s)
+}
+
+// Cosh returns cosh(a)
+func (a HyperDual) Cosh() HyperDual {
+	s, c := math.Sinh(a.V), math.Cosh(a.V)
+	return a.chain(c, s, c)
+}
+
+// Tanh returns tanh(a)
+func (a HyperDual) Tanh() HyperDual {
+	t := math.Tanh(a.V)
+	d := 1.0 - t*t
+	return a.chain(t, d, -2*t*d)
+}
+
+// Abs returns |a|. The derivatives at a=0 are taken as 0
+func (a HyperDual) Abs() HyperDual {
+	return a.chain(math.Abs(a.V), Sign(a.V), 0)
+}
+
+// Ramp returns Ramp(a) = max(a, 0). The first derivative is Heav(a); the second one is taken as 0
+func (a HyperDual) Ramp() HyperDual {
+	return a.chain(Ramp(a.V), Heav(a.V), 0)
+}
+
+// Heav returns the Heaviside function Heav(a). The derivatives are taken as 0
+func (a HyperDual) Heav() HyperDual {
+	return HyperDual{Heav(a.V), 0, 0, 0}
+}
+
+// Sign returns the sign function Sign(a). The derivatives are taken as 0
+func (a HyperDual) Sign() HyperDual {
+	return HyperDual{Sign(a.V), 0, 0, 0}
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// LambertW0 computes the principal branch W₀(x) of the Lambert W function; i.e. the solution
+// w ≥ -1 of w⋅exp(w) = x for x ≥ -1/e
+//
+//	The initial guess is refined with Halley's method. Near the branch point the series
+//	expansion in p = √(2(e⋅x+1)) is used as the initial guess [1]
+//
+//	References:
+//	[1] Corless RM, Gonnet GH, Hare DEG, Jeffrey DJ, Knuth DE (1996) On the Lambert W function.
+//	    Advances in Computational Mathematics, 5:329-359
+func LambertW0(x float64) float64 {
+	if x < -1.0/math.E {
+		if x > -1.0/math.E-1e-15 {
+			return -1.0
+		}
+		chk.Panic("LambertW0 requires x ≥ -1/e. x=%g is invalid", x)
+	}
+	if x == 0 {
+		return 0
+	}
+	if math.IsInf(x, +1) {
+		return x
+	}
+	var w float64
+	switch {
+	case x < -0.32:
+		w = lambertWbranch(math.Sqrt(2.0 * (math.E*x + 1.0)))
+	case x < 3.0:
+		w = math.Log1p(x) * (1.0 - math.Log1p(math.Log1p(x))/(2.0+math.Log1p(x)))
+	default:
+		L1 := math.Log(x)
+		L2 := math.Log(L1)
+		w = L1 - L2 + L2/L1
+	}
+	return lambertWhalley(x, w)
+}
+
+// LambertWm1 computes the lower branch W₋₁(x) of the Lambert W function; i.e. the solution
+// w ≤ -1 of w⋅exp(w) = x for -1/e ≤ x < 0
+//
+//	References:
+//	[1] Corless RM, Gonnet GH, Hare DEG, Jeffrey DJ, Knuth DE (1996) On the Lambert W function.
+//	    Advances in Computational Mathematics, 5:329-359
+func LambertWm1(x float64) float64 {
+	if x < -1.0/math.E {
+		if x > -1.0/math.E-1e-15 {
+			return -1.0
+		}
+		chk.Panic("LambertWm1 requires -1/e ≤ x < 0. x=%g is invalid", x)
+	}
+	if x >= 0 {
+		chk.Panic("LambertWm1 requires -1/e ≤ x < 0. x=%g is invalid", x)
+	}
+	var w float64
+	if x < -0.25 {
+		w = lambertWbranch(-math.Sqrt(2.0 * (math.E*x + 1.0)))
+	} else {
+		L1 := math.Log(-x)
+		L2 := math.Log(-L1)
+		w = L1 - L2 + L2/L1
+	}
+	return lambertWhalley(x, w)
+}
+
+// lambertWbranch evaluates the series expansion of W around the branch point x = -1/e
+func lambertWbranch(p float64) float64 {
+	return -1.0 + p*(1.0+p*(-1.0/3.0+p*(11.0/72.0+p*(-43.0/540.0+p*(769.0/17280.0+p*(-221.0/8505.0))))))
+}
+
+// lambertWhalley refines the initial guess w using Halley's iterations
+func lambertWhalley(x, w float64) float64 {
+	var ew, f, wn float64
+	for it := 0; it < 50; it++ {
+		ew = math.Exp(w)
+		f = w*ew - x
+		if w == -1 || f == 0 {
+			return w
+		}
+		wn = w - f/(ew*(w+1.0)-(w+2.0)*f/(2.0*w+2.0))
+		if math.Abs(wn-w) <= 4.0*machEps*math.Abs(wn) {
+			return wn
+		}
+		w = wn
+	}
+	return w
+}
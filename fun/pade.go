@@ -0,0 +1,151 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// Pade implements the Padé approximant of type [m/n] computed from the Taylor coefficients of a
+// function f(x) = c₀ + c₁⋅x + c₂⋅x² + ... about x = 0
+//
+//	          P(x)     p₀ + p₁⋅x + ... + pₘ⋅xᵐ
+//	r(x) =  ———————  = ———————————————————————————      with   f(x) - r(x) = O(x^(m+n+1))
+//	          Q(x)     1  + q₁⋅x + ... + qₙ⋅xⁿ
+//
+//	References:
+//	[1] Baker GA, Graves-Morris P (1996) Padé Approximants. Second Edition. Cambridge University
+//	    Press. 746p.
+//	[2] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+type Pade struct {
+	P []float64 // numerator coefficients [m+1]
+	Q []float64 // denominator coefficients [n+1] with Q[0] = 1
+}
+
+// NewPade computes the Padé approximant of type [m/n]
+//
+//	c -- Taylor coefficients c₀, c₁, ..., c_{m+n} [at least m+n+1 values]
+//	m -- degree of the numerator
+//	n -- degree of the denominator
+//
+//	NOTE: the n x n Toeplitz system for the denominator coefficients must be non-singular
+func NewPade(c []float64, m, n int) (o *Pade) {
+	if m < 0 || n < 0 {
+		chk.Panic("the degrees must be non-negative. m=%d, n=%d is invalid", m, n)
+	}
+	if len(c) < m+n+1 {
+		chk.Panic("at least m+n+1 = %d Taylor coefficients are required. %d is invalid", m+n+1, len(c))
+	}
+	coef := func(i int) float64 {
+		if i < 0 {
+			return 0
+		}
+		return c[i]
+	}
+	o = new(Pade)
+	o.Q = make([]float64, n+1)
+	o.Q[0] = 1
+	if n > 0 { // Σ_{k=1}^{n} qₖ⋅c_{m+j-k} = -c_{m+j}  for j = 1...n
+		A := la.NewMatrix(n, n)
+		b := la.NewVector(n)
+		for j := 1; j <= n; j++ {
+			for k := 1; k <= n; k++ {
+				A.Set(j-1, k-1, coef(m+j-k))
+			}
+			b[j-1] = -c[m+j]
+		}
+		q := la.NewVector(n)
+		la.DenSolve(q, A, b, false)
+		copy(o.Q[1:], q)
+	}
+	o.P = make([]float64, m+1)
+	for i := 0; i <= m; i++ { // pᵢ = Σ_{k=0}^{min(i,n)} qₖ⋅c_{i-k}
+		for k := 0; k <= i && k <= n; k++ {
+			o.P[i] += o.Q[k] * c[i-k]
+		}
+	}
+	return
+}
+
+// Eval evaluates r(x) = P(x) / Q(x). This function can be used as fun.Ss
+func (o *Pade) Eval(x float64) float64 {
+	return padeHorner(o.P, x) / padeHorner(o.Q, x)
+}
+
+// EvalC evaluates r(z) = P(z) / Q(z) for complex z
+func (o *Pade) EvalC(z complex128) complex128 {
+	var p, q complex128
+	for i := len(o.P) - 1; i >= 0; i-- {
+		p = p*z + complex(o.P[i], 0)
+	}
+	for i := len(o.Q) - 1; i >= 0; i-- {
+		q = q*z + complex(o.Q[i], 0)
+	}
+	return p / q
+}
+
+// Poles returns the poles of r(x); i.e. the roots of Q(x)
+func (o *Pade) Poles() []complex128 {
+	return padeRoots(o.Q)
+}
+
+// Zeros returns the zeros of r(x); i.e. the roots of P(x)
+func (o *Pade) Zeros() []complex128 {
+	return padeRoots(o.P)
+}
+
+// Residues returns the residues P(zₖ)/Q'(zₖ) at the (simple) poles zₖ
+func (o *Pade) Residues() (poles, res []complex128) {
+	poles = o.Poles()
+	res = make([]complex128, len(poles))
+	for k, z := range poles {
+		var dq complex128
+		for i := len(o.Q) - 1; i >= 1; i-- {
+			dq = dq*z + complex(float64(i)*o.Q[i], 0)
+		}
+		var p complex128
+		for i := len(o.P) - 1; i >= 0; i-- {
+			p = p*z + complex(o.P[i], 0)
+		}
+		res[k] = p / dq
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// padeHorner evaluates the polynomial a₀ + a₁⋅x + ... + aₙ⋅xⁿ using Horner's method
+func padeHorner(a []float64, x float64) (res float64) {
+	for i := len(a) - 1; i >= 0; i-- {
+		res = res*x + a[i]
+	}
+	return
+}
+
+// padeRoots computes the roots of the polynomial a₀ + a₁⋅x + ... + aₙ⋅xⁿ as the eigenvalues of the
+// companion matrix. Leading zero coefficients are ignored
+func padeRoots(a []float64) (roots []complex128) {
+	n := len(a) - 1
+	for n > 0 && math.Abs(a[n]) == 0 {
+		n--
+	}
+	if n < 1 {
+		return
+	}
+	A := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		A.Set(0, i, -a[n-1-i]/a[n])
+		if i > 0 {
+			A.Set(i, i-1, 1)
+		}
+	}
+	roots = la.NewVectorC(n)
+	la.EigenVal(roots, A, false)
+	return
+}
@@ -0,0 +1,459 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// windows /////////////////////////////////////////////////////////////////////////////////////////
+
+// WindowRect returns the rectangular (boxcar) window; i.e. w[k] = 1
+func WindowRect(n int) (w []float64) {
+	w = make([]float64, n)
+	for k := 0; k < n; k++ {
+		w[k] = 1
+	}
+	return
+}
+
+// WindowHann returns the Hann window w[k] = 0.5 - 0.5⋅cos(2πk/(M-1))
+//
+//	periodic -- returns the periodic ("DFT-even") window of length n which is computed as the
+//	            symmetric window of length M = n+1 without the last point. Use periodic = true
+//	            for spectral analysis (e.g. Welch and Stft) and false for filter design
+func WindowHann(n int, periodic bool) (w []float64) {
+	return windowCosine(n, periodic, []float64{0.5, 0.5})
+}
+
+// WindowHamming returns the Hamming window w[k] = 0.54 - 0.46⋅cos(2πk/(M-1)); see WindowHann
+func WindowHamming(n int, periodic bool) (w []float64) {
+	return windowCosine(n, periodic, []float64{0.54, 0.46})
+}
+
+// WindowBlackman returns the Blackman window w[k] = 0.42 - 0.5⋅cos(2πk/(M-1)) + 0.08⋅cos(4πk/(M-1));
+// see WindowHann
+func WindowBlackman(n int, periodic bool) (w []float64) {
+	return windowCosine(n, periodic, []float64{0.42, 0.5, 0.08})
+}
+
+// WindowKaiser returns the Kaiser window
+//
+//	w[k] = I₀(β √(1 - (2k/(M-1) - 1)²)) / I₀(β)
+//
+//	where I₀ is the modified Bessel function of first kind and order zero. The shape parameter
+//	β controls the trade-off between the main-lobe width and the side-lobe level; e.g. β=0 gives
+//	the rectangular window, β≈5 is similar to Hamming and β≈8.6 is similar to Blackman.
+//	See WindowHann for the meaning of periodic
+func WindowKaiser(n int, β float64, periodic bool) (w []float64) {
+	m, w := windowInit(n, periodic)
+	if m == 1 {
+		return
+	}
+	den := kaiserI0(β)
+	for k := 0; k < n; k++ {
+		r := 2*float64(k)/float64(m-1) - 1
+		w[k] = kaiserI0(β*math.Sqrt(math.Max(0, 1-r*r))) / den
+	}
+	return
+}
+
+// WindowTukey returns the Tukey (tapered cosine) window
+//
+//	α -- fraction of the window inside the cosine tapered region; α=0 gives the rectangular
+//	     window and α=1 gives the Hann window
+//
+//	See WindowHann for the meaning of periodic
+func WindowTukey(n int, α float64, periodic bool) (w []float64) {
+	if α <= 0 {
+		return WindowRect(n)
+	}
+	if α >= 1 {
+		return WindowHann(n, periodic)
+	}
+	m, w := windowInit(n, periodic)
+	if m == 1 {
+		return
+	}
+	width := int(math.Floor(α * float64(m-1) / 2))
+	for k := 0; k < n; k++ {
+		K := float64(k)
+		switch {
+		case k <= width:
+			w[k] = 0.5 * (1 + math.Cos(math.Pi*(-1+2*K/(α*float64(m-1)))))
+		case k < m-width-1:
+			w[k] = 1
+		default:
+			w[k] = 0.5 * (1 + math.Cos(math.Pi*(-2/α+1+2*K/(α*float64(m-1)))))
+		}
+	}
+	return
+}
+
+// frequencies /////////////////////////////////////////////////////////////////////////////////////
+
+// FftFreq returns the frequencies corresponding to the output of Dft1d with n points and sample
+// spacing d; i.e. f = [0, 1, …, ⌈n/2⌉-1, -⌊n/2⌋, …, -1] / (d⋅n)
+func FftFreq(n int, d float64) (f []float64) {
+	f = make([]float64, n)
+	den := d * float64(n)
+	for k := 0; k < n; k++ {
+		if k < (n+1)/2 {
+			f[k] = float64(k) / den
+		} else {
+			f[k] = float64(k-n) / den
+		}
+	}
+	return
+}
+
+// RfftFreq returns the non-negative frequencies of a one-sided spectrum computed with n points and
+// sample spacing d; i.e. f = [0, 1, …, ⌊n/2⌋] / (d⋅n)
+func RfftFreq(n int, d float64) (f []float64) {
+	f = make([]float64, n/2+1)
+	den := d * float64(n)
+	for k := 0; k < len(f); k++ {
+		f[k] = float64(k) / den
+	}
+	return
+}
+
+// FftShift shifts the zero-frequency component to the centre of the spectrum; i.e. the result
+// corresponds to the frequencies [-⌊n/2⌋, …, -1, 0, 1, …, ⌈n/2⌉-1] / (d⋅n)
+func FftShift(x []float64) (y []float64) {
+	n := len(x)
+	y = make([]float64, n)
+	for k := 0; k < n; k++ {
+		y[(k+n/2)%n] = x[k]
+	}
+	return
+}
+
+// FftShiftC shifts the zero-frequency component to the centre of the spectrum; see FftShift
+func FftShiftC(x []complex128) (y []complex128) {
+	n := len(x)
+	y = make([]complex128, n)
+	for k := 0; k < n; k++ {
+		y[(k+n/2)%n] = x[k]
+	}
+	return
+}
+
+// NextPow2 returns the smallest power of two greater than or equal to n
+func NextPow2(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	return p
+}
+
+// power spectral density //////////////////////////////////////////////////////////////////////////
+
+// Periodogram estimates the one-sided power spectral density of the real signal x
+//
+//	Input:
+//	  x      -- signal sampled at uniform time intervals 1/fs
+//	  fs     -- sampling frequency
+//	  window -- window with len(window) = len(x) [may be nil => rectangular]
+//	Output:
+//	  f -- frequencies: len(f) = len(x)/2 + 1
+//	  P -- power spectral density (units of x² per unit of frequency)
+//
+//	NOTE: the mean of x is removed before computing the spectrum. With the rectangular window,
+//	      Σ P[k]⋅Δf equals the variance of x (Parseval)
+func Periodogram(x []float64, fs float64, window []float64) (f, P []float64) {
+	if window == nil {
+		window = WindowRect(len(x))
+	}
+	return Welch(x, fs, window, 0)
+}
+
+// Welch estimates the one-sided power spectral density of the real signal x by averaging the
+// modified periodograms of overlapping segments [1]
+//
+//	Input:
+//	  x        -- signal sampled at uniform time intervals 1/fs
+//	  fs       -- sampling frequency
+//	  window   -- window; the length of each segment is nperseg = len(window). e.g. WindowHann(256, true)
+//	  noverlap -- number of points to overlap between segments; e.g. nperseg/2
+//	Output:
+//	  f -- frequencies: len(f) = nperseg/2 + 1
+//	  P -- power spectral density (units of x² per unit of frequency)
+//
+//	NOTE: the mean of each segment is removed before computing its spectrum
+//
+//	References:
+//	[1] Welch PD (1967) The use of fast Fourier transform for the estimation of power spectra: A
+//	    method based on time averaging over short, modified periodograms. IEEE Transactions on
+//	    Audio and Electroacoustics, 15(2):70-73
+func Welch(x []float64, fs float64, window []float64, noverlap int) (f, P []float64) {
+	f, Pxy := Csd(x, x, fs, window, noverlap)
+	P = make([]float64, len(Pxy))
+	for k, v := range Pxy {
+		P[k] = real(v)
+	}
+	return
+}
+
+// Csd estimates the one-sided cross power spectral density Pxy = E[conj(X)⋅Y] of the real signals
+// x and y using Welch's method; see Welch
+func Csd(x, y []float64, fs float64, window []float64, noverlap int) (f []float64, Pxy []complex128) {
+	if len(x) != len(y) {
+		chk.Panic("signals must have the same length. %d != %d\n", len(x), len(y))
+	}
+	nperseg := len(window)
+	if nperseg < 1 || nperseg > len(x) {
+		chk.Panic("the length of window (%d) must be in [1, %d]\n", nperseg, len(x))
+	}
+	if noverlap < 0 || noverlap >= nperseg {
+		chk.Panic("noverlap (%d) must be in [0, %d)\n", noverlap, nperseg)
+	}
+
+	// scale for density: 1 / (fs Σw²)
+	sw2 := 0.0
+	for _, v := range window {
+		sw2 += v * v
+	}
+	scale := 1.0 / (fs * sw2)
+
+	// average over segments
+	nf := nperseg/2 + 1
+	Pxy = make([]complex128, nf)
+	step := nperseg - noverlap
+	nseg := 0
+	for start := 0; start+nperseg <= len(x); start += step {
+		X := spectralSegment(x[start:start+nperseg], window, true)
+		Y := X
+		if &x[0] != &y[0] { // not the same signal
+			Y = spectralSegment(y[start:start+nperseg], window, true)
+		}
+		for k := 0; k < nf; k++ {
+			Pxy[k] += cmplx.Conj(X[k]) * Y[k]
+		}
+		nseg++
+	}
+	for k := 0; k < nf; k++ {
+		Pxy[k] *= complex(scale/float64(nseg), 0)
+		if k > 0 && !(nperseg%2 == 0 && k == nf-1) { // one-sided: double all but DC and Nyquist
+			Pxy[k] *= 2
+		}
+	}
+	f = RfftFreq(nperseg, 1.0/fs)
+	return
+}
+
+// Coherence estimates the magnitude squared coherence Cxy = |Pxy|² / (Pxx⋅Pyy) of the real signals
+// x and y using Welch's method; see Welch. Note that 0 ≤ Cxy ≤ 1
+func Coherence(x, y []float64, fs float64, window []float64, noverlap int) (f, Cxy []float64) {
+	f, Pxx := Welch(x, fs, window, noverlap)
+	_, Pyy := Welch(y, fs, window, noverlap)
+	_, Pxy := Csd(x, y, fs, window, noverlap)
+	Cxy = make([]float64, len(f))
+	for k := range f {
+		den := Pxx[k] * Pyy[k]
+		if den > 0 {
+			a := cmplx.Abs(Pxy[k])
+			Cxy[k] = a * a / den
+		}
+	}
+	return
+}
+
+// short-time Fourier transform ////////////////////////////////////////////////////////////////////
+
+// Stft computes the short-time Fourier transform of the real signal x
+//
+//	Input:
+//	  x      -- signal sampled at uniform time intervals 1/fs
+//	  fs     -- sampling frequency
+//	  window -- window; the length of each segment is nperseg = len(window). e.g. WindowHann(256, true)
+//	  hop    -- number of points between the beginnings of consecutive segments; e.g. nperseg/2
+//	Output:
+//	  f -- frequencies: len(f) = nperseg/2 + 1
+//	  t -- times corresponding to the centres of the segments
+//	  Z -- [len(t)][len(f)] one-sided spectra scaled by 1/Σw; i.e. a sinusoid with amplitude A
+//	       gives |Z| ≈ A/2 at its frequency
+//
+//	NOTE: the signal is padded with nperseg/2 zeros at both ends (and with more zeros at the end
+//	      if needed) such that the first segment is centred at t = 0 and Istft can reconstruct
+//	      the whole signal
+func Stft(x []float64, fs float64, window []float64, hop int) (f, t []float64, Z [][]complex128) {
+	nperseg := len(window)
+	if nperseg < 1 {
+		chk.Panic("window must have at least one point\n")
+	}
+	if hop < 1 || hop > nperseg {
+		chk.Panic("hop (%d) must be in [1, %d]\n", hop, nperseg)
+	}
+	xp := stftPad(x, nperseg, hop)
+	nt := (len(xp)-nperseg)/hop + 1
+	sw := 0.0
+	for _, v := range window {
+		sw += v
+	}
+	t = make([]float64, nt)
+	Z = make([][]complex128, nt)
+	for j := 0; j < nt; j++ {
+		Z[j] = spectralSegment(xp[j*hop:j*hop+nperseg], window, false)
+		for k := range Z[j] {
+			Z[j][k] /= complex(sw, 0)
+		}
+		t[j] = float64(j*hop) / fs
+	}
+	f = RfftFreq(nperseg, 1.0/fs)
+	return
+}
+
+// Istft computes the inverse short-time Fourier transform by the weighted overlap-add method
+//
+//	Input:
+//	  Z      -- spectra computed by Stft
+//	  window -- the same window used in Stft
+//	  hop    -- the same hop used in Stft
+//	  n      -- length of the original signal
+//	Output:
+//	  x -- reconstructed signal with len(x) = n
+//
+//	NOTE: the window and hop must satisfy the nonzero overlap-add (NOLA) condition; i.e. the sum
+//	      of the squared overlapping windows must be nonzero everywhere. This is the case of most
+//	      windows with hop < nperseg
+func Istft(Z [][]complex128, window []float64, hop, n int) (x []float64) {
+	nperseg := len(window)
+	nt := len(Z)
+	sw := 0.0
+	for _, v := range window {
+		sw += v
+	}
+	length := (nt-1)*hop + nperseg
+	y := make([]float64, length)
+	norm := make([]float64, length)
+	data := make([]complex128, nperseg)
+	nf := nperseg/2 + 1
+	for j := 0; j < nt; j++ {
+		if len(Z[j]) != nf {
+			chk.Panic("spectra must have %d frequencies. len(Z[%d]) = %d is incorrect\n", nf, j, len(Z[j]))
+		}
+
+		// Hermitian extension and inverse transform
+		for k := 0; k < nperseg; k++ {
+			if k < nf {
+				data[k] = Z[j][k] * complex(sw, 0)
+			} else {
+				data[k] = cmplx.Conj(Z[j][nperseg-k]) * complex(sw, 0)
+			}
+		}
+		Dft1d(data, true)
+
+		// overlap-add
+		for i := 0; i < nperseg; i++ {
+			y[j*hop+i] += window[i] * real(data[i]) / float64(nperseg)
+			norm[j*hop+i] += window[i] * window[i]
+		}
+	}
+
+	// remove padding
+	pad := nperseg / 2
+	if pad+n > length {
+		chk.Panic("the number of segments (%d) is insufficient to reconstruct %d points\n", nt, n)
+	}
+	x = make([]float64, n)
+	for i := 0; i < n; i++ {
+		if norm[pad+i] < 1e-10 {
+			chk.Panic("window and hop do not satisfy the NOLA condition\n")
+		}
+		x[i] = y[pad+i] / norm[pad+i]
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// windowInit allocates the window and returns the length M of the equivalent symmetric window
+func windowInit(n int, periodic bool) (m int, w []float64) {
+	if n < 1 {
+		chk.Panic("the length of a window must be at least 1. n = %d is invalid\n", n)
+	}
+	w = make([]float64, n)
+	if n == 1 {
+		w[0] = 1
+		return 1, w
+	}
+	m = n
+	if periodic {
+		m = n + 1
+	}
+	return
+}
+
+// windowCosine computes generalised cosine windows: w[k] = Σ (-1)ʲ a[j] cos(2πjk/(M-1))
+func windowCosine(n int, periodic bool, a []float64) (w []float64) {
+	m, w := windowInit(n, periodic)
+	if m == 1 {
+		return
+	}
+	for k := 0; k < n; k++ {
+		θ := 2 * math.Pi * float64(k) / float64(m-1)
+		sgn := 1.0
+		for j := 0; j < len(a); j++ {
+			w[k] += sgn * a[j] * math.Cos(float64(j)*θ)
+			sgn = -sgn
+		}
+	}
+	return
+}
+
+// kaiserI0 computes the modified Bessel function I₀(x) by its power series, which converges for
+// all x and is accurate to machine precision (ModBesselI0 uses a polynomial approximation)
+func kaiserI0(x float64) (res float64) {
+	y := x * x / 4
+	term := 1.0
+	res = 1.0
+	for k := 1; k < 500; k++ {
+		term *= y / float64(k*k)
+		res += term
+		if term < 1e-17*res {
+			break
+		}
+	}
+	return
+}
+
+// spectralSegment returns the one-sided DFT of the windowed segment (with optional mean removal)
+func spectralSegment(seg, window []float64, detrend bool) (X []complex128) {
+	n := len(seg)
+	mean := 0.0
+	if detrend {
+		for _, v := range seg {
+			mean += v
+		}
+		mean /= float64(n)
+	}
+	data := make([]complex128, n)
+	for i := 0; i < n; i++ {
+		data[i] = complex(window[i]*(seg[i]-mean), 0)
+	}
+	Dft1d(data, false)
+	return data[:n/2+1]
+}
+
+// stftPad pads x with nperseg/2 zeros at both ends and with extra zeros at the end such that an
+// integer number of segments fits the padded signal
+func stftPad(x []float64, nperseg, hop int) (xp []float64) {
+	pad := nperseg / 2
+	length := len(x) + 2*pad
+	if length < nperseg {
+		length = nperseg
+	}
+	if r := (length - nperseg) % hop; r != 0 {
+		length += hop - r
+	}
+	xp = make([]float64, length)
+	copy(xp[pad:], x)
+	return
+}
@@ -0,0 +1,161 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestAaa01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Aaa01. AAA: rational functions")
+
+	// f(x) = (x - 0.3) / (1.1 - x)
+	f := func(x float64) float64 { return (x - 0.3) / (1.1 - x) }
+	X := utl.LinSpace(-1, 1, 200)
+	Y := make([]float64, len(X))
+	for i, x := range X {
+		Y[i] = f(x)
+	}
+	o := NewAaaReal(X, Y, 0, 0)
+	io.Pforan("err = %v\n", o.Err)
+	io.Pforan("pol = %v\n", o.Pol)
+	io.Pforan("res = %v\n", o.Res)
+	io.Pforan("zer = %v\n", o.Zer)
+	chk.Int(tst, "degree", o.Degree(), 1)
+	chk.Int(tst, "npoles", len(o.Pol), 1)
+	chk.Int(tst, "nzeros", len(o.Zer), 1)
+	chk.Complex128(tst, "pole", 1e-14, o.Pol[0], 1.1)
+	chk.Complex128(tst, "res", 1e-14, o.Res[0], -0.8)
+	chk.Complex128(tst, "zero", 1e-14, o.Zer[0], 0.3)
+
+	// as Ss
+	var g Ss = o.Eval
+	for _, x := range utl.LinSpace(-1, 1, 11) {
+		chk.Float64(tst, "r(x)", 1e-14, g(x), f(x))
+	}
+	chk.Complex128(tst, "r(i)", 1e-14, o.EvalC(1i), (1i-0.3)/(1.1-1i))
+}
+
+func TestAaa02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Aaa02. AAA: nearby poles")
+
+	// f(x) = exp(x) / (x² + 0.01) with poles at ±0.1i
+	f := func(z complex128) complex128 { return cmplx.Exp(z) / (z*z + 0.01) }
+	X := utl.LinSpace(-1, 1, 1000)
+	Z := make([]complex128, len(X))
+	F := make([]complex128, len(X))
+	for i, x := range X {
+		Z[i] = complex(x, 0)
+		F[i] = f(Z[i])
+	}
+	o := NewAaa(Z, F, 0, 0)
+	io.Pforan("degree = %v\n", o.Degree())
+	io.Pforan("err = %v\n", o.Err[len(o.Err)-1])
+	chk.Float64(tst, "err", 1e-13*100, o.Err[len(o.Err)-1], 0)
+
+	// check poles near the real axis
+	found := 0
+	for k, p := range o.Pol {
+		if cmplx.Abs(p-0.1i) < 1e-8 {
+			chk.Complex128(tst, "pole", 1e-11, p, 0.1i)
+			chk.Complex128(tst, "res", 1e-9, o.Res[k], cmplx.Exp(0.1i)/0.2i)
+			found++
+		}
+		if cmplx.Abs(p+0.1i) < 1e-8 {
+			chk.Complex128(tst, "pole", 1e-11, p, -0.1i)
+			chk.Complex128(tst, "res", 1e-9, o.Res[k], cmplx.Exp(-0.1i)/-0.2i)
+			found++
+		}
+	}
+	chk.Int(tst, "found poles", found, 2)
+
+	// check values between samples
+	for _, x := range utl.LinSpace(-0.999, 0.999, 37) {
+		z := complex(x, 0)
+		chk.Complex128(tst, "r(x)", 1e-10, o.EvalC(z), f(z))
+	}
+}
+
+func TestAaa03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Aaa03. AAA: frequency response")
+
+	// H(s) = (s + 2) / (s² + 0.2 s + 1) sampled at s = iω
+	H := func(s complex128) complex128 { return (s + 2) / (s*s + 0.2*s + 1) }
+	n := 300
+	Z := make([]complex128, n)
+	F := make([]complex128, n)
+	for i := 0; i < n; i++ {
+		ω := math.Pow(10, -2+4*float64(i)/float64(n-1))
+		Z[i] = complex(0, ω)
+		F[i] = H(Z[i])
+	}
+	o := NewAaa(Z, F, 0, 0)
+	io.Pforan("degree = %v\n", o.Degree())
+	io.Pforan("pol = %v\n", o.Pol)
+	io.Pforan("zer = %v\n", o.Zer)
+	chk.Int(tst, "degree", o.Degree(), 2)
+	chk.Int(tst, "npoles", len(o.Pol), 2)
+	chk.Int(tst, "nzeros", len(o.Zer), 1)
+	β := math.Sqrt(0.99)
+	for k, p := range o.Pol {
+		ref := complex(-0.1, β)
+		if imag(p) < 0 {
+			ref = complex(-0.1, -β)
+		}
+		chk.Complex128(tst, "pole", 1e-12, p, ref)
+		chk.Complex128(tst, "res", 1e-12, o.Res[k], (ref+2)/(2*ref+0.2))
+	}
+	chk.Complex128(tst, "zero", 1e-12, o.Zer[0], -2)
+	chk.Complex128(tst, "H(1+i)", 1e-13, o.EvalC(1+1i), H(1+1i))
+}
+
+func TestAaa04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Aaa04. AAA: cleanup of spurious poles")
+
+	// noisy data (noise level ≈ 1e-8)
+	f := func(x float64) float64 { return math.Tanh(5 * x) }
+	X := utl.LinSpace(-1, 1, 500)
+	Y := make([]float64, len(X))
+	for i, x := range X {
+		Y[i] = f(x) + 1e-8*math.Sin(1e4*x*x)
+	}
+	o := NewAaaReal(X, Y, 1e-15, 60)
+	io.Pforan("degree = %v\n", o.Degree())
+	nspurious := 0
+	for k := range o.Pol {
+		if cmplx.Abs(o.Res[k]) < 1e-8 {
+			nspurious++
+		}
+	}
+	io.Pforan("number of spurious poles = %v\n", nspurious)
+	nremoved := o.Cleanup(1e-8)
+	io.Pforan("number of removed poles = %v\n", nremoved)
+	io.Pforan("degree (after cleanup) = %v\n", o.Degree())
+	if nremoved < nspurious {
+		tst.Errorf("at least %d support points should have been removed\n", nspurious)
+	}
+	for k := range o.Pol {
+		if math.Abs(imag(o.Pol[k])) < 1e-3 && math.Abs(real(o.Pol[k])) <= 1 {
+			tst.Errorf("pole %v should have been removed\n", o.Pol[k])
+		}
+	}
+	for _, x := range utl.LinSpace(-1, 1, 41) {
+		chk.Float64(tst, "r(x)", 1e-6, o.Eval(x), f(x))
+	}
+}
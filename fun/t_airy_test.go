@@ -0,0 +1,47 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestAiry01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Airy01. Airy functions")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-airy.cmp")
+
+	// check Ai, Bi and derivatives. NOTE: Bi grows quickly; thus the relative error is checked
+	for i, x := range dat["x"] {
+		ai, bi, aid, bid := Airy(x)
+		sbi := math.Max(1, math.Abs(dat["Bi"][i]))
+		sbd := math.Max(1, math.Abs(dat["Bid"][i]))
+		chk.Float64(tst, io.Sf("Ai(%6.2f)=%23.15e", x, ai), 1e-14, ai, dat["Ai"][i])
+		chk.Float64(tst, io.Sf("Bi(%6.2f)=%23.15e", x, bi), 1e-14, bi/sbi, dat["Bi"][i]/sbi)
+		chk.Float64(tst, io.Sf("Ai'(%6.2f)=%23.15e", x, aid), 1e-14, aid, dat["Aid"][i])
+		chk.Float64(tst, io.Sf("Bi'(%6.2f)=%23.15e", x, bid), 1e-14, bid/sbd, dat["Bid"][i]/sbd)
+	}
+
+	// check Wronskian: Ai⋅Bi' - Ai'⋅Bi = 1/π
+	io.Pl()
+	for _, x := range []float64{-20, -7.5, -1, 0, 0.5, 3, 8} {
+		ai, bi, aid, bid := Airy(x)
+		chk.Float64(tst, io.Sf("W(%5.1f)", x), 1e-14, ai*bid-aid*bi, 1.0/math.Pi)
+	}
+
+	// check single-value functions
+	ai, bi, aid, bid := Airy(1)
+	chk.Float64(tst, "AiryAi(1)", 1e-17, AiryAi(1), ai)
+	chk.Float64(tst, "AiryBi(1)", 1e-17, AiryBi(1), bi)
+	chk.Float64(tst, "AiryAiD1(1)", 1e-17, AiryAiD1(1), aid)
+	chk.Float64(tst, "AiryBiD1(1)", 1e-17, AiryBiD1(1), bid)
+}
@@ -221,3 +221,54 @@ func TestBessel02(tst *testing.T) {
 		}
 	}
 }
+
+func TestBessel03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Bessel03. Bessel functions of real order")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-bessel-real.cmp")
+
+	// check J, Y and derivatives. NOTE: the functions are large for small x and negative ν;
+	// thus the relative error is checked
+	for i, ν := range dat["nu"] {
+		x := dat["x"][i]
+		j, y, jd, yd := BesselJY(ν, x)
+		sj := math.Max(1, math.Abs(dat["J"][i]))
+		sy := math.Max(1, math.Abs(dat["Y"][i]))
+		sjd := math.Max(1, math.Abs(dat["Jd"][i]))
+		syd := math.Max(1, math.Abs(dat["Yd"][i]))
+		chk.Float64(tst, io.Sf("J_{%5.2f}(%5.2f)=%23.15e", ν, x, j), 1e-14, j/sj, dat["J"][i]/sj)
+		chk.Float64(tst, io.Sf("Y_{%5.2f}(%5.2f)=%23.15e", ν, x, y), 1e-13, y/sy, dat["Y"][i]/sy)
+		chk.Float64(tst, io.Sf("J'_{%5.2f}(%5.2f)=%23.15e", ν, x, jd), 1e-14, jd/sjd, dat["Jd"][i]/sjd)
+		chk.Float64(tst, io.Sf("Y'_{%5.2f}(%5.2f)=%23.15e", ν, x, yd), 1e-13, yd/syd, dat["Yd"][i]/syd)
+	}
+
+	// compare with math.Jn and math.Yn
+	io.Pl()
+	for n := 0; n < 4; n++ {
+		for _, x := range []float64{0.3, 1, 4.5, 12} {
+			ν := float64(n)
+			chk.Float64(tst, io.Sf("J_%d(%4.1f)", n, x), 1e-15, BesselJ(ν, x), math.Jn(n, x))
+			chk.Float64(tst, io.Sf("Y_%d(%4.1f)", n, x), 1e-13, BesselY(ν, x), math.Yn(n, x))
+		}
+	}
+
+	// spherical Bessel: J_{1/2}(x) = √(2/(πx))⋅sin(x)
+	io.Pl()
+	for _, x := range []float64{0.5, 2, 10} {
+		chk.Float64(tst, io.Sf("J_{1/2}(%4.1f)", x), 1e-15, BesselJ(0.5, x), math.Sqrt(2.0/(math.Pi*x))*math.Sin(x))
+	}
+
+	// special cases
+	chk.Float64(tst, "J_0(0)", 1e-17, BesselJ(0, 0), 1)
+	chk.Float64(tst, "J_2.5(0)", 1e-17, BesselJ(2.5, 0), 0)
+	if !math.IsInf(BesselY(1.5, 0), -1) {
+		tst.Errorf("Y_{1.5}(0) should be -Inf\n")
+		return
+	}
+	if !math.IsNaN(BesselJ(1, -1)) {
+		tst.Errorf("J_1(-1) should be NaN\n")
+	}
+}
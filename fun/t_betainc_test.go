@@ -0,0 +1,53 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestBetaInc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BetaInc01. Regularized incomplete beta function")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-betainc.cmp")
+
+	// check I
+	for i, a := range dat["a"] {
+		b, x := dat["b"][i], dat["x"][i]
+		I := BetaInc(a, b, x)
+		chk.Float64(tst, io.Sf("I(%4.1f,%4.1f,%4.2f)=%23.15e", a, b, x, I), 1e-14, I, dat["I"][i])
+	}
+
+	// large a and b (quadrature) => check symmetry: Iₓ(a,b) = 1 - I₁₋ₓ(b,a)
+	io.Pl()
+	for _, x := range []float64{0.45, 0.49, 0.5, 0.51, 0.55} {
+		I := BetaInc(4000, 5000, x)
+		chk.Float64(tst, io.Sf("I(4000,5000,%4.2f)=%23.15e", x, I), 1e-13, I, 1-BetaInc(5000, 4000, 1-x))
+	}
+
+	// special cases
+	chk.Float64(tst, "I(2,3,0)", 1e-17, BetaInc(2, 3, 0), 0)
+	chk.Float64(tst, "I(2,3,1)", 1e-17, BetaInc(2, 3, 1), 1)
+	chk.Float64(tst, "I(1,1,x) = x", 1e-15, BetaInc(1, 1, 0.3), 0.3)
+}
+
+func TestBetaInc02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BetaInc02. Inverse of incomplete beta function")
+
+	for _, ab := range [][]float64{{0.5, 0.5}, {0.2, 3}, {1, 1}, {2, 5}, {10, 2.5}, {50, 40}} {
+		for _, p := range []float64{1e-4, 0.1, 0.5, 0.9, 0.999} {
+			x := InvBetaInc(p, ab[0], ab[1])
+			chk.Float64(tst, io.Sf("I(%4.1f,%4.1f,InvI(%g))", ab[0], ab[1], p), 1e-13, BetaInc(ab[0], ab[1], x), p)
+		}
+	}
+}
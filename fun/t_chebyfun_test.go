@@ -0,0 +1,203 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+// chebyFunMaxErr returns the maximum absolute error between ChebyFun and f on a fine grid
+func chebyFunMaxErr(o *ChebyFun, f Ss, npts int) (maxerr float64) {
+	xa, xb := o.Domain()
+	for _, x := range utl.LinSpace(xa, xb, npts) {
+		maxerr = math.Max(maxerr, math.Abs(o.Eval(x)-f(x)))
+	}
+	return
+}
+
+func TestChebyFun01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyFun01. adaptive construction")
+
+	// smooth function
+	f := func(x float64) float64 { return math.Exp(x) * math.Sin(5*x) }
+	o := NewChebyFun(f, []float64{-1, 2})
+	io.Pforan("degree = %v\n", o.Degree(0))
+	if o.Degree(0) < 20 || o.Degree(0) > 60 {
+		tst.Errorf("degree of exp(x)⋅sin(5x) is incorrect: %d\n", o.Degree(0))
+		return
+	}
+	chk.Float64(tst, "maxerr", 1e-13, chebyFunMaxErr(o, f, 1001), 0)
+
+	// polynomial: exact
+	p := NewChebyFun(func(x float64) float64 { return 1 + x*x*x }, []float64{-1, 1})
+	chk.Int(tst, "degree of 1+x³", p.Degree(0), 3)
+	chk.Array(tst, "coefficients", 1e-15, p.Coef[0], []float64{1, 0.75, 0, 0.25})
+
+	// function with a kink and a breakpoint
+	g := func(x float64) float64 { return math.Abs(x-0.3) + math.Cos(x) }
+	o = NewChebyFun(g, []float64{-1, 0.3, 1})
+	chk.Float64(tst, "maxerr |x-0.3|", 1e-14, chebyFunMaxErr(o, g, 1001), 0)
+
+	// automatic splitting
+	h := func(x float64) float64 {
+		if x < 0.1234 {
+			return math.Sin(x)
+		}
+		return 2 + math.Exp(x)
+	}
+	o = NewChebyFunSplit(h, -1, 1)
+	io.Pforan("number of pieces = %v\n", len(o.Coef))
+	for _, x := range []float64{-1, -0.5, 0, 0.12, 0.13, 0.5, 1} {
+		chk.Float64(tst, io.Sf("h(%g)", x), 1e-13, o.Eval(x), h(x))
+	}
+}
+
+func TestChebyFun02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyFun02. arithmetic and composition")
+
+	f := func(x float64) float64 { return math.Sin(3 * x) }
+	g := func(x float64) float64 { return 1.0 / (1.0 + 25.0*x*x) }
+	F := NewChebyFun(f, []float64{-1, 1})
+	G := NewChebyFun(g, []float64{-1, 0.5, 1})
+
+	sum := F.Add(G)
+	dif := F.Sub(G)
+	mul := F.Mul(G)
+	scl := F.Scale(2, -1)
+	cmp := F.Compose(math.Exp)
+	chk.Int(tst, "number of pieces of f+g", len(sum.Coef), 2)
+	chk.Float64(tst, "f+g", 1e-14, chebyFunMaxErr(sum, func(x float64) float64 { return f(x) + g(x) }, 501), 0)
+	chk.Float64(tst, "f-g", 1e-14, chebyFunMaxErr(dif, func(x float64) float64 { return f(x) - g(x) }, 501), 0)
+	chk.Float64(tst, "f⋅g", 1e-14, chebyFunMaxErr(mul, func(x float64) float64 { return f(x) * g(x) }, 501), 0)
+	chk.Float64(tst, "2f-1", 1e-14, chebyFunMaxErr(scl, func(x float64) float64 { return 2*f(x) - 1 }, 501), 0)
+	chk.Float64(tst, "exp(f)", 1e-14, chebyFunMaxErr(cmp, func(x float64) float64 { return math.Exp(f(x)) }, 501), 0)
+}
+
+func TestChebyFun03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyFun03. differentiation and integration")
+
+	// derivative
+	F := NewChebyFun(func(x float64) float64 { return math.Sin(3*x) * math.Exp(x) }, []float64{0, 1, 2})
+	dfdx := func(x float64) float64 { return (3*math.Cos(3*x) + math.Sin(3*x)) * math.Exp(x) }
+	chk.Float64(tst, "df/dx", 1e-11, chebyFunMaxErr(F.Diff(), dfdx, 501), 0)
+
+	// definite integrals
+	S := NewChebyFun(math.Sin, []float64{0, math.Pi})
+	chk.Float64(tst, "∫sin", 1e-15, S.Integral(), 2)
+	E := NewChebyFun(math.Exp, []float64{-1, 0, 3})
+	chk.Float64(tst, "∫exp", 1e-13, E.Integral(), math.Exp(3)-math.Exp(-1))
+	R := NewChebyFun(func(x float64) float64 { return 1.0 / (1.0 + 25.0*x*x) }, []float64{-1, 1})
+	chk.Float64(tst, "∫runge", 1e-15, R.Integral(), 0.4*math.Atan(5))
+
+	// indefinite integral
+	C := E.Cumsum()
+	chk.Float64(tst, "cumsum(exp)", 1e-13, chebyFunMaxErr(C, func(x float64) float64 { return math.Exp(x) - math.Exp(-1) }, 501), 0)
+	chk.Float64(tst, "cumsum(exp) @ b", 1e-13, C.Eval(3), E.Integral())
+	chk.Float64(tst, "d(cumsum(exp))/dx", 1e-12, chebyFunMaxErr(C.Diff(), math.Exp, 501), 0)
+}
+
+func TestChebyFun04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyFun04. roots")
+
+	// zeros of sin(x) in [-1, 20]
+	S := NewChebyFun(math.Sin, []float64{-1, 20})
+	roots := S.Roots()
+	io.Pforan("roots = %v\n", roots)
+	chk.Int(tst, "number of roots of sin", len(roots), 7)
+	for i, r := range roots {
+		chk.Float64(tst, io.Sf("root %d", i), 1e-14, r, float64(i)*math.Pi)
+	}
+
+	// zeros of J0(x) in [0, 100] (high degree ⇒ subdivision)
+	J := NewChebyFun(math.J0, []float64{0, 100})
+	io.Pforan("degree of J0 = %v\n", J.Degree(0))
+	roots = J.Roots()
+	chk.Int(tst, "number of roots of J0", len(roots), 32)
+	chk.Array(tst, "first roots of J0", 1e-14, roots[:3], []float64{2.404825557695773, 5.520078110286311, 8.653727912911013})
+	for _, r := range roots {
+		chk.Float64(tst, io.Sf("J0(%g)", r), 1e-14, math.J0(r), 0)
+	}
+
+	// roots at breakpoints and of piecewise functions
+	P := NewChebyFun(func(x float64) float64 { return math.Abs(x) - 0.5 }, []float64{-1, 0, 1})
+	chk.Array(tst, "roots of |x|-0.5", 1e-15, P.Roots(), []float64{-0.5, 0.5})
+	Q := NewChebyFun(func(x float64) float64 { return x * (x - 0.25) }, []float64{-1, 0, 1})
+	chk.Array(tst, "roots of x(x-0.25)", 1e-15, Q.Roots(), []float64{0, 0.25})
+
+	// no roots
+	N := NewChebyFun(func(x float64) float64 { return 2 + math.Cos(x) }, []float64{0, 10})
+	chk.Int(tst, "number of roots of 2+cos", len(N.Roots()), 0)
+}
+
+func TestChebyFun05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyFun05. max and min")
+
+	// parabola
+	P := NewChebyFun(func(x float64) float64 { return (x-0.3)*(x-0.3) - 1 }, []float64{-1, 1})
+	xmin, fmin := P.Min()
+	xmax, fmax := P.Max()
+	chk.Float64(tst, "xmin", 1e-15, xmin, 0.3)
+	chk.Float64(tst, "fmin", 1e-15, fmin, -1)
+	chk.Float64(tst, "xmax", 1e-15, xmax, -1)
+	chk.Float64(tst, "fmax", 1e-15, fmax, 0.69)
+
+	// oscillatory function: f(x) = sin(x) + sin(10x/3) on [2.7, 7.5] (global minimum at 5.145735)
+	f := func(x float64) float64 { return math.Sin(x) + math.Sin(10.0*x/3.0) }
+	F := NewChebyFun(f, []float64{2.7, 7.5})
+	xmin, fmin = F.Min()
+	chk.Float64(tst, "xmin", 1e-6, xmin, 5.145735)
+	chk.Float64(tst, "fmin", 1e-15, fmin, f(xmin))
+	for _, x := range utl.LinSpace(2.7, 7.5, 1001) {
+		if f(x) < fmin-1e-14 {
+			tst.Errorf("min is incorrect: f(%g)=%g < %g\n", x, f(x), fmin)
+			return
+		}
+	}
+}
+
+func TestChebyFun06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyFun06. automatic splitting at non-dyadic points")
+
+	// kinks and jumps at points that are not exactly representable
+	names := []string{"|x-0.3|", "H(x-0.3)", "|x-1/3|+sin(x)", "sign(x-0.3)+2"}
+	funcs := []Ss{
+		func(x float64) float64 { return math.Abs(x - 0.3) },
+		func(x float64) float64 { return Heav(x - 0.3) },
+		func(x float64) float64 { return math.Abs(x-1.0/3.0) + math.Sin(x) },
+		func(x float64) float64 { return Sign(x-0.3) + 2 },
+	}
+	for k, f := range funcs {
+		nfeval := 0
+		g := func(x float64) float64 {
+			nfeval++
+			return f(x)
+		}
+		o := NewChebyFunSplit(g, -1, 1)
+		io.Pforan("%-15s: npieces = %3d  nfeval = %6d\n", names[k], len(o.Coef), nfeval)
+		if nfeval > 50000 {
+			tst.Errorf("%s: too many function evaluations: %d\n", names[k], nfeval)
+		}
+		for _, x := range []float64{-1, -0.5, 0, 0.29, 0.31, 0.32, 0.34, 0.5, 1} {
+			chk.Float64(tst, io.Sf("%s @ %g", names[k], x), 1e-13, o.Eval(x), f(x))
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestDigamma01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Digamma01. Digamma function")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-digamma.cmp")
+
+	// check ψ(x)
+	for i, x := range dat["x"] {
+		ψ := Digamma(x)
+		chk.Float64(tst, io.Sf("ψ(%10.4f)=%23.15e", x, ψ), 1e-14*math.Max(1, math.Abs(ψ)), ψ, dat["psi"][i])
+	}
+
+	// known values
+	io.Pl()
+	γ := 0.57721566490153286061
+	chk.Float64(tst, "ψ(1) = -γ", 1e-15, Digamma(1), -γ)
+	chk.Float64(tst, "ψ(1/2) = -γ - 2ln2", 1e-15, Digamma(0.5), -γ-2.0*math.Ln2)
+	if !math.IsNaN(Digamma(-3)) {
+		tst.Errorf("ψ(-3) should be NaN\n")
+	}
+}
+
+func TestDigamma02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Digamma02. Polygamma function")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-polygamma.cmp")
+
+	// check ψ⁽ⁿ⁾(x). NOTE: the relative error is checked
+	for i, x := range dat["x"] {
+		n := int(dat["n"][i])
+		ψ := Polygamma(n, x)
+		chk.Float64(tst, io.Sf("ψ⁽%d⁾(%6.2f)=%23.15e", n, x, ψ), 1e-13, ψ/dat["psi"][i], 1)
+	}
+
+	// known values
+	io.Pl()
+	chk.Float64(tst, "ψ⁽¹⁾(1) = π²/6", 1e-15, Polygamma(1, 1), math.Pi*math.Pi/6.0)
+	chk.Float64(tst, "ψ⁽⁰⁾(3) = ψ(3)", 1e-17, Polygamma(0, 3), Digamma(3))
+}
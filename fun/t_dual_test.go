@@ -0,0 +1,164 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// dualTestFunctions holds functions written with dual and hyper-dual numbers and the corresponding
+// float64 versions used for testing
+var dualTestFunctions = []struct {
+	name string
+	x    float64
+	f    func(x float64) float64
+	fd   func(a Dual) Dual
+	fh   func(a HyperDual) HyperDual
+}{
+	{"x²+3x", 0.7, func(x float64) float64 { return x*x + 3*x },
+		func(a Dual) Dual { return a.Mul(a).Add(a.MulS(3)) },
+		func(a HyperDual) HyperDual { return a.Mul(a).Add(a.MulS(3)) }},
+	{"(x-1)/(x+2)", 0.3, func(x float64) float64 { return (x - 1) / (x + 2) },
+		func(a Dual) Dual { return a.AddS(-1).Div(a.AddS(2)) },
+		func(a HyperDual) HyperDual { return a.AddS(-1).Div(a.AddS(2)) }},
+	{"-1/x", 1.3, func(x float64) float64 { return -1 / x },
+		func(a Dual) Dual { return a.Inv().Neg() },
+		func(a HyperDual) HyperDual { return a.Inv().Neg() }},
+	{"x^2.5", 1.7, func(x float64) float64 { return math.Pow(x, 2.5) },
+		func(a Dual) Dual { return a.PowS(2.5) },
+		func(a HyperDual) HyperDual { return a.PowS(2.5) }},
+	{"x^x", 1.2, func(x float64) float64 { return math.Pow(x, x) },
+		func(a Dual) Dual { return a.Pow(a) },
+		func(a HyperDual) HyperDual { return a.Pow(a) }},
+	{"sqrt", 2.3, math.Sqrt, Dual.Sqrt, HyperDual.Sqrt},
+	{"exp", -0.4, math.Exp, Dual.Exp, HyperDual.Exp},
+	{"log", 2.1, math.Log, Dual.Log, HyperDual.Log},
+	{"sin", 0.9, math.Sin, Dual.Sin, HyperDual.Sin},
+	{"cos", 0.9, math.Cos, Dual.Cos, HyperDual.Cos},
+	{"tan", 0.6, math.Tan, Dual.Tan, HyperDual.Tan},
+	{"asin", 0.4, math.Asin, Dual.Asin, HyperDual.Asin},
+	{"acos", -0.3, math.Acos, Dual.Acos, HyperDual.Acos},
+	{"atan", 1.5, math.Atan, Dual.Atan, HyperDual.Atan},
+	{"sinh", 0.8, math.Sinh, Dual.Sinh, HyperDual.Sinh},
+	{"cosh", -0.8, math.Cosh, Dual.Cosh, HyperDual.Cosh},
+	{"tanh", 0.5, math.Tanh, Dual.Tanh, HyperDual.Tanh},
+	{"abs", -1.5, math.Abs, Dual.Abs, HyperDual.Abs},
+	{"ramp", 1.5, Ramp, Dual.Ramp, HyperDual.Ramp},
+	{"atan2(sin(x),x²)", 2.5, func(x float64) float64 { return math.Atan2(math.Sin(x), x*x) },
+		func(a Dual) Dual { return a.Sin().Atan2(a.Mul(a)) },
+		func(a HyperDual) HyperDual { return a.Sin().Atan2(a.Mul(a)) }},
+	{"atan2(-1,-x)", 0.5, func(x float64) float64 { return math.Atan2(-1, -x) },
+		func(a Dual) Dual { return DualConst(-1).Atan2(a.Neg()) },
+		func(a HyperDual) HyperDual { return HyperDualConst(-1).Atan2(a.Neg()) }},
+}
+
+func TestDual01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dual01. dual numbers: elementary functions")
+
+	for _, t := range dualTestFunctions {
+		res := t.fd(DualVar(t.x))
+		io.Pforan("%18s: f(%g) = %23.15e  df/dx = %23.15e\n", t.name, t.x, res.V, res.D)
+		chk.Float64(tst, t.name, 1e-15, res.V, t.f(t.x))
+		chk.DerivScaSca(tst, t.name, 1e-9, res.D, t.x, 1e-3, chk.Verbose, t.f)
+	}
+
+	// step functions
+	chk.Float64(tst, "heav", 1e-17, DualVar(2).Heav().V, 1)
+	chk.Float64(tst, "dheav", 1e-17, DualVar(2).Heav().D, 0)
+	chk.Float64(tst, "sign", 1e-17, DualVar(-2).Sign().V, -1)
+	chk.Float64(tst, "dsign", 1e-17, DualVar(-2).Sign().D, 0)
+	chk.Float64(tst, "ramp", 1e-17, DualVar(-2).Ramp().D, 0)
+}
+
+func TestDual02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dual02. dual numbers: gradient and Jacobian")
+
+	// Rosenbrock function: f(x) = (1 - x₀)² + 100 (x₁ - x₀²)²
+	F, G := AutoDiffSv(func(x []Dual) Dual {
+		a := DualConst(1).Sub(x[0])
+		b := x[1].Sub(x[0].Mul(x[0]))
+		return a.Mul(a).Add(b.Mul(b).MulS(100))
+	})
+	x := la.NewVectorSlice([]float64{-1.2, 1})
+	g := la.NewVector(2)
+	G(g, x)
+	io.Pforan("f = %v\n", F(x))
+	io.Pforan("g = %v\n", g)
+	chk.Float64(tst, "f", 1e-14, F(x), 24.2)
+	chk.Array(tst, "g", 1e-13, g, []float64{-2*(1-x[0]) - 400*x[0]*(x[1]-x[0]*x[0]), 200 * (x[1] - x[0]*x[0])})
+
+	// vector function: f₀ = x₀² x₁, f₁ = 5 x₀ + sin(x₁), f₂ = exp(x₀ x₁)
+	Fv, J := AutoDiffVv(func(f, x []Dual) {
+		f[0] = x[0].Mul(x[0]).Mul(x[1])
+		f[1] = x[0].MulS(5).Add(x[1].Sin())
+		f[2] = x[0].Mul(x[1]).Exp()
+	}, 3)
+	x = la.NewVectorSlice([]float64{0.5, 2})
+	fx := la.NewVector(3)
+	Fv(fx, x)
+	chk.Array(tst, "f", 1e-15, fx, []float64{0.5, 2.5 + math.Sin(2), math.Exp(1)})
+	var jac la.Triplet
+	J(&jac, x)
+	m, n := jac.Size()
+	chk.Int(tst, "m", m, 3)
+	chk.Int(tst, "n", n, 2)
+	chk.Deep2(tst, "J", 1e-15, jac.ToDense().GetDeep2(), [][]float64{
+		{2 * x[0] * x[1], x[0] * x[0]},
+		{5, math.Cos(x[1])},
+		{x[1] * math.Exp(1), x[0] * math.Exp(1)},
+	})
+	chk.DerivVecVec(tst, "J", 1e-9, jac.ToDense().GetDeep2(), x, 1e-3, chk.Verbose, func(f, x []float64) {
+		Fv(f, x)
+	})
+}
+
+func TestHyperDual01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HyperDual01. hyper-dual numbers: elementary functions")
+
+	for _, t := range dualTestFunctions {
+		res := t.fh(HyperDualVar(t.x))
+		io.Pforan("%18s: f(%g) = %23.15e  d²f/dx² = %23.15e\n", t.name, t.x, res.V, res.D12)
+		dfdx := func(x float64) float64 { return t.fd(DualVar(x)).D }
+		chk.Float64(tst, t.name, 1e-15, res.V, t.f(t.x))
+		chk.Float64(tst, t.name+": D1", 1e-15, res.D1, dfdx(t.x))
+		chk.Float64(tst, t.name+": D2", 1e-15, res.D2, dfdx(t.x))
+		chk.DerivScaSca(tst, t.name+": D12", 1e-9, res.D12, t.x, 1e-3, chk.Verbose, dfdx)
+	}
+}
+
+func TestHyperDual02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HyperDual02. hyper-dual numbers: gradient and Hessian")
+
+	// f(x) = x₀² x₁ + sin(x₁ x₂) + x₂³
+	F, G, H := AutoDiffHessSv(func(x []HyperDual) HyperDual {
+		return x[0].Mul(x[0]).Mul(x[1]).Add(x[1].Mul(x[2]).Sin()).Add(x[2].PowS(3))
+	})
+	x := la.NewVectorSlice([]float64{1.5, -0.5, 0.8})
+	g := la.NewVector(3)
+	h := la.NewMatrix(3, 3)
+	G(g, x)
+	H(h, x)
+	c, s := math.Cos(x[1]*x[2]), math.Sin(x[1]*x[2])
+	chk.Float64(tst, "f", 1e-15, F(x), x[0]*x[0]*x[1]+s+x[2]*x[2]*x[2])
+	chk.Array(tst, "g", 1e-15, g, []float64{2 * x[0] * x[1], x[0]*x[0] + x[2]*c, x[1]*c + 3*x[2]*x[2]})
+	chk.Deep2(tst, "h", 1e-15, h.GetDeep2(), [][]float64{
+		{2 * x[1], 2 * x[0], 0},
+		{2 * x[0], -x[2] * x[2] * s, c - x[1]*x[2]*s},
+		{0, c - x[1]*x[2]*s, -x[1]*x[1]*s + 6*x[2]},
+	})
+}
@@ -143,3 +143,72 @@ func Test_elliptic03(tst *testing.T) {
 		}
 	}
 }
+
+func Test_elliptic04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("elliptic04. complete integrals K(k) and E(k) and nome q(k)")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-ellipk.cmp")
+	for i, k := range dat["k"] {
+		K := EllipticK(k)
+		E := EllipticE(k)
+		q := EllipticNome(k)
+		chk.Float64(tst, io.Sf("K(%.14f)=%23.15e", k, K), 1e-15*K, K, dat["K"][i])
+		chk.Float64(tst, io.Sf("E(%.14f)=%23.15e", k, E), 1e-15, E, dat["E"][i])
+		chk.Float64(tst, io.Sf("q(%.14f)=%23.15e", k, q), 1e-15, q, dat["q"][i])
+	}
+
+	// compare with incomplete integrals
+	io.Pl()
+	for _, k := range []float64{0.2, 0.6, 0.95} {
+		chk.Float64(tst, io.Sf("K(%g) = F(π/2,%g)", k, k), 1e-15, EllipticK(k), Elliptic1(math.Pi/2, k))
+		chk.Float64(tst, io.Sf("E(%g) = E(π/2,%g)", k, k), 1e-15, EllipticE(k), Elliptic2(math.Pi/2, k))
+	}
+
+	// Legendre's relation: E⋅K' + E'⋅K - K⋅K' = π/2
+	io.Pl()
+	for _, k := range []float64{0.1, 0.5, 0.9, 0.999999} {
+		kc := math.Sqrt(1.0 - k*k)
+		K, E, Kc, Ec := EllipticK(k), EllipticE(k), EllipticK(kc), EllipticE(kc)
+		chk.Float64(tst, io.Sf("Legendre(%g)", k), 1e-14, E*Kc+Ec*K-K*Kc, math.Pi/2.0)
+	}
+
+	// special values
+	io.Pl()
+	chk.Float64(tst, "K(0)", 1e-15, EllipticK(0), math.Pi/2.0)
+	chk.Float64(tst, "E(0)", 1e-15, EllipticE(0), math.Pi/2.0)
+	chk.Float64(tst, "E(1)", 1e-15, EllipticE(1), 1)
+	chk.Float64(tst, "q(1)", 1e-15, EllipticNome(1), 1)
+	if !math.IsInf(EllipticK(1), 1) {
+		tst.Errorf("K(1) should be +Inf\n")
+	}
+}
+
+func Test_elliptic05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("elliptic05. complete integral of the third kind Π(n,k)")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-ellippi.cmp")
+	for i, n := range dat["n"] {
+		k := dat["k"][i]
+		P := EllipticPi(n, k)
+		chk.Float64(tst, io.Sf("Π(%5.2f,%.10f)=%23.15e", n, k, P), 1e-15*P, P, dat["PI"][i])
+	}
+
+	// compare with incomplete integral
+	io.Pl()
+	for _, n := range []float64{-2, 0.3, 0.8} {
+		chk.Float64(tst, io.Sf("Π(%g,0.5) = Π(%g,π/2,0.5)", n, n), 1e-14, EllipticPi(n, 0.5), Elliptic3(n, math.Pi/2, 0.5))
+	}
+
+	// Cauchy principal value: Π(n,k) = K(k) - Π(k²/n,k) for n > 1
+	io.Pl()
+	for _, n := range []float64{1.5, 4, 100} {
+		k := 0.6
+		chk.Float64(tst, io.Sf("Π(%g,%g)", n, k), 1e-14, EllipticPi(n, k), EllipticK(k)-EllipticPi(k*k/n, k))
+	}
+}
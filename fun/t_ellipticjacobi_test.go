@@ -0,0 +1,90 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestJacobiEllip01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("JacobiEllip01. Jacobi elliptic functions and amplitude")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-jacobi.cmp")
+	for i, u := range dat["u"] {
+		k := dat["k"][i]
+		sn, cn, dn := JacobiSnCnDn(u, k)
+		am := JacobiAm(u, k)
+		chk.Float64(tst, io.Sf("sn(%5.2f,%.10f)=%23.15e", u, k, sn), 1e-14, sn, dat["sn"][i])
+		chk.Float64(tst, io.Sf("cn(%5.2f,%.10f)=%23.15e", u, k, cn), 1e-14, cn, dat["cn"][i])
+		chk.Float64(tst, io.Sf("dn(%5.2f,%.10f)=%23.15e", u, k, dn), 1e-14, dn, dat["dn"][i])
+		chk.Float64(tst, io.Sf("am(%5.2f,%.10f)=%23.15e", u, k, am), 1e-14, am, dat["am"][i])
+	}
+
+	// single functions and identities: sn² + cn² = 1 and dn² + k²sn² = 1
+	io.Pl()
+	for _, k := range []float64{0, 0.3, 0.9, 1} {
+		for _, u := range []float64{-3, 0.1, 2} {
+			sn, cn, dn := JacobiSn(u, k), JacobiCn(u, k), JacobiDn(u, k)
+			chk.Float64(tst, io.Sf("sn²+cn²(%g,%g)", u, k), 1e-15, sn*sn+cn*cn, 1)
+			chk.Float64(tst, io.Sf("dn²+k²sn²(%g,%g)", u, k), 1e-15, dn*dn+k*k*sn*sn, 1)
+		}
+	}
+
+	// special cases
+	io.Pl()
+	chk.Float64(tst, "sn(u,0)", 1e-15, JacobiSn(0.7, 0), math.Sin(0.7))
+	chk.Float64(tst, "cn(u,1)", 1e-15, JacobiCn(0.7, 1), 1.0/math.Cosh(0.7))
+	chk.Float64(tst, "am(u,0)", 1e-15, JacobiAm(0.7, 0), 0.7)
+	chk.Float64(tst, "sn(K,k)", 1e-15, JacobiSn(EllipticK(0.8), 0.8), 1)
+	chk.Float64(tst, "am(2K,k)", 1e-15, JacobiAm(2.0*EllipticK(0.8), 0.8), math.Pi)
+}
+
+func TestJacobiEllip02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("JacobiEllip02. Inverse Jacobi elliptic functions")
+
+	for _, k := range []float64{0, 0.5, 0.9, 0.999999, 0.9999999999, 1} {
+
+		// arcsn
+		for _, u := range []float64{-1.1, -0.2, 0, 0.4, 1.3} {
+			if k == 1 || u < EllipticK(k) {
+				sn := JacobiSn(u, k)
+				chk.Float64(tst, io.Sf("arcsn(sn(%g,%g))", u, k), 1e-13, JacobiArcsn(sn, k), u)
+			}
+		}
+
+		// arccn
+		for _, u := range []float64{0, 0.4, 1.3, 2.5} {
+			if k == 1 || u < 2.0*EllipticK(k) {
+				cn := JacobiCn(u, k)
+				chk.Float64(tst, io.Sf("arccn(cn(%g,%g))", u, k), 1e-13, JacobiArccn(cn, k), u)
+			}
+		}
+
+		// arcdn
+		if k > 0 {
+			for _, u := range []float64{0, 0.4, 1.3} {
+				if k == 1 || u < EllipticK(k) {
+					dn := JacobiDn(u, k)
+					chk.Float64(tst, io.Sf("arcdn(dn(%g,%g))", u, k), 1e-13, JacobiArcdn(dn, k), u)
+				}
+			}
+		}
+	}
+
+	// limits
+	io.Pl()
+	chk.Float64(tst, "arcsn(1,0.5)", 1e-15, JacobiArcsn(1, 0.5), EllipticK(0.5))
+	chk.Float64(tst, "arccn(-1,0.5)", 1e-15, JacobiArccn(-1, 0.5), 2.0*EllipticK(0.5))
+	chk.Float64(tst, "arcdn(k',0.6)", 1e-15, JacobiArcdn(0.8, 0.6), EllipticK(0.6))
+}
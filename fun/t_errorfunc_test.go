@@ -0,0 +1,45 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestErrorFunc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ErrorFunc01. Error functions")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-erf.cmp")
+
+	// check erf and erfc from Go's math package. NOTE: the relative error of erfc is checked
+	for i, x := range dat["x"] {
+		erf := math.Erf(x)
+		erfc := math.Erfc(x)
+		serfc := math.Max(1e-300, math.Abs(dat["erfc"][i]))
+		chk.Float64(tst, io.Sf("erf(%7.2f)=%23.15e", x, erf), 1e-15, erf, dat["erf"][i])
+		chk.Float64(tst, io.Sf("erfc(%7.2f)=%23.15e", x, erfc), 1e-13, erfc/serfc, dat["erfc"][i]/serfc)
+	}
+
+	// check Erfcx
+	io.Pl()
+	for i, x := range dat["x"] {
+		erfcx := Erfcx(x)
+		chk.Float64(tst, io.Sf("erfcx(%7.2f)=%23.15e", x, erfcx), 1e-13, erfcx/dat["erfcx"][i], 1)
+	}
+
+	// check inverse functions from Go's math package
+	io.Pl()
+	for _, y := range []float64{-0.99, -0.5, 0, 0.1, 0.75, 0.999} {
+		chk.Float64(tst, io.Sf("erf(erfinv(%g))", y), 1e-15, math.Erf(math.Erfinv(y)), y)
+		chk.Float64(tst, io.Sf("erfc(erfcinv(%g))", 1-y), 1e-15, math.Erfc(math.Erfcinv(1-y)), 1-y)
+	}
+}
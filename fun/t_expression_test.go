@@ -0,0 +1,166 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestExpr01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Expr01. parsing and evaluation")
+
+	x, y, z, t := 0.7, -1.3, 2.1, 0.4
+	tests := []struct {
+		src string
+		ref float64
+	}{
+		{"1 + 2*3", 7},
+		{"(1 + 2)*3", 9},
+		{"2^3^2", 512},
+		{"-x^2", -x * x},
+		{"2^-x", math.Pow(2, -x)},
+		{"10 - 4 - 3", 3},
+		{"12 / 3 / 2", 2},
+		{"1.5e-1 + .5E+1", 5.15},
+		{"x*y - z/t", x*y - z/t},
+		{"sin(pi*x) + cos(y)*exp(-t)", math.Sin(math.Pi*x) + math.Cos(y)*math.Exp(-t)},
+		{"sqrt(z) + log(z) + log10(z) + abs(y)", math.Sqrt(z) + math.Log(z) + math.Log10(z) + math.Abs(y)},
+		{"tan(x) + asin(t) + acos(t) + atan(y)", math.Tan(x) + math.Asin(t) + math.Acos(t) + math.Atan(y)},
+		{"sinh(x) + cosh(y) + tanh(z)", math.Sinh(x) + math.Cosh(y) + math.Tanh(z)},
+		{"atan2(y, x) + pow(z, t)", math.Atan2(y, x) + math.Pow(z, t)},
+		{"min(x, y) + max(x, y) + floor(z) + ceil(z)", y + x + 2 + 3},
+		{"ramp(y) + heav(x) + sign(y) + Ramp(x)", 0 + 1 - 1 + x},
+		{"e^2", math.E * math.E},
+	}
+	for _, test := range tests {
+		e := NewExpr(test.src, nil)
+		res := e.Eval(x, y, z, t)
+		io.Pforan("%45s = %23.15e  ->  %v\n", test.src, res, e)
+		chk.Float64(tst, test.src, 1e-14, res, test.ref)
+
+		// the string representation must give the same results
+		chk.Float64(tst, test.src+" (String)", 1e-14, NewExpr(e.String(), nil).Eval(x, y, z, t), test.ref)
+	}
+}
+
+func TestExpr02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Expr02. parameters and callbacks")
+
+	prms := utl.NewParams(
+		&utl.P{N: "A", V: 2},
+		&utl.P{N: "omega", V: 3},
+	)
+	e := NewExpr("A*sin(omega*t) + x^2 + y*z", prms)
+	chk.Bool(tst, "depends on t", e.Depends("t"), true)
+	chk.Bool(tst, "depends on A", e.Depends("A"), true)
+	chk.Bool(tst, "depends on omega", e.Depends("omega"), true)
+	chk.Bool(tst, "depends on z", e.Depends("z"), true)
+
+	// Svs
+	var f Svs = e.GetSvs()
+	X := la.NewVectorSlice([]float64{1, 2, 3})
+	chk.Float64(tst, "f(X,t)", 1e-15, f(X, 0.5), 2*math.Sin(1.5)+1+6)
+	chk.Float64(tst, "f(X[:2],t)", 1e-15, f(X[:2], 0.5), 2*math.Sin(1.5)+1)
+
+	// Ss
+	var g Ss = e.GetSs("t")
+	chk.Float64(tst, "g(t)", 1e-15, g(0.5), 2*math.Sin(1.5))
+
+	// changes in parameters are reflected in the results
+	prms.SetValue("A", 5)
+	chk.Float64(tst, "g(t) with A=5", 1e-15, g(0.5), 5*math.Sin(1.5))
+
+	// parameters and constants
+	e = NewExpr("pi + e + x", nil)
+	chk.Bool(tst, "depends on t", e.Depends("t"), false)
+	chk.Float64(tst, "pi + e + x", 1e-15, e.GetSs("x")(1), math.Pi+math.E+1)
+}
+
+func TestExpr03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Expr03. symbolic differentiation")
+
+	prms := utl.NewParams(&utl.P{N: "k", V: 1.5})
+	srcs := []string{
+		"3*x^4 - 2*x + 7",
+		"k*x^k",
+		"x^x",
+		"sin(x)*cos(2*x)/(1 + x^2)",
+		"tan(x) + asin(x/2) + acos(x/3) + atan(x)",
+		"atan2(x, 1 + x^2) + atan2(2, x)",
+		"sinh(x) - cosh(x)*tanh(x)",
+		"exp(-x^2) + log(x) + log10(x) + sqrt(x)",
+		"abs(x - 1) + ramp(x - 1) + heav(x) + sign(x) + floor(x) + ceil(x)",
+		"pow(x, 2.5) + pow(2, x) + -x",
+	}
+	for _, src := range srcs {
+		e := NewExpr(src, prms)
+		d := e.Deriv("x")
+		io.Pforan("d(%s)/dx = %v\n", src, d)
+		f := e.GetSs("x")
+		for _, x := range []float64{0.3, 0.8, 1.2} {
+			chk.DerivScaSca(tst, src, 1e-9, d.Eval(x, 0, 0, 0), x, 1e-3, chk.Verbose, f)
+		}
+	}
+
+	// derivative with respect to a parameter
+	e := NewExpr("k*x^k", prms)
+	d := e.Deriv("k")
+	io.Pforan("d(k*x^k)/dk = %v\n", d)
+	x := 1.3
+	chk.Float64(tst, "d/dk", 1e-15, d.Eval(x, 0, 0, 0), math.Pow(x, 1.5)+1.5*math.Pow(x, 1.5)*math.Log(x))
+
+	// simplification
+	e = NewExpr("x^3 + y*t", nil)
+	chk.String(tst, e.Deriv("x").String(), "3*x^2")
+	chk.String(tst, e.Deriv("y").String(), "t")
+	chk.String(tst, e.Deriv("z").String(), "0")
+}
+
+func TestExpr04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Expr04. errors")
+
+	for _, src := range []string{
+		"",
+		"1 +",
+		"2*(x + 1",
+		"x + 1)",
+		"sin(x, y)",
+		"foo(x)",
+		"a + x",
+		"3 $ x",
+		"x y",
+	} {
+		func() {
+			defer func() {
+				if err := recover(); err != nil {
+					if chk.Verbose {
+						io.Pf("OK, caught the following message:\n\t%v\n", err)
+					}
+				} else {
+					tst.Errorf("\n\tTEST FAILED. NewExpr(%q) should have panicked\n", src)
+				}
+			}()
+			NewExpr(src, nil)
+		}()
+	}
+
+	// min and max cannot be differentiated
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewExpr("max(x, 1)", nil).Deriv("x")
+}
@@ -0,0 +1,189 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestFourierInterpNd01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FourierInterpNd01. 2D interpolation and derivatives")
+
+	// box: [0,2]×[0,3]
+	Lx, Ly := 2.0, 3.0
+	ax, ay := 2.0*math.Pi/Lx, 2.0*math.Pi/Ly
+	f := func(x la.Vector) float64 { return math.Sin(ax*x[0])*math.Cos(2*ay*x[1]) + 0.5 }
+	dfdx := func(x la.Vector) float64 { return ax * math.Cos(ax*x[0]) * math.Cos(2*ay*x[1]) }
+	dfdy := func(x la.Vector) float64 { return -2 * ay * math.Sin(ax*x[0]) * math.Sin(2*ay*x[1]) }
+	lap := func(x la.Vector) float64 { return -(ax*ax + 4*ay*ay) * math.Sin(ax*x[0]) * math.Cos(2*ay*x[1]) }
+
+	// interpolator
+	o := NewFourierInterpNd([]int{8, 16}, []float64{Lx, Ly}, "")
+	defer o.Free()
+	chk.Int(tst, "size", o.Size(), 128)
+	chk.Int(tst, "index(2,3)", o.Index(2, 3), 2*16+3)
+	chk.Array(tst, "X[0]", 1e-15, o.X[0], []float64{0, 0.25, 0.5, 0.75, 1, 1.25, 1.5, 1.75})
+	chk.Array(tst, "K[0]", 1e-15, o.K[0], []float64{0, 1, 2, 3, -4, -3, -2, -1})
+
+	// coefficients
+	o.CalcU(f)
+	o.CalcA()
+	chk.Complex128(tst, "A(0,0)", 1e-15, o.A[o.Index(0, 0)], 0.5)
+	chk.Complex128(tst, "A(1,2)", 1e-15, o.A[o.Index(1, 2)], -0.25i)
+	chk.Complex128(tst, "A(7,14)", 1e-15, o.A[o.Index(7, 14)], 0.25i)
+
+	// interpolation and gradient at arbitrary points
+	grad := la.NewVector(2)
+	for _, x := range [][]float64{{0.1, 0.2}, {1.3, 2.9}, {0.77, 1.41}} {
+		chk.Float64(tst, io.Sf("I%v", x), 1e-14, o.I(x), f(x))
+		o.IGrad(grad, x)
+		chk.Array(tst, io.Sf("∇I%v", x), 1e-13, grad, []float64{dfdx(x), dfdy(x)})
+	}
+
+	// gradient and Laplacian at grid points
+	o.CalcGrad()
+	o.CalcLap()
+	x := la.NewVector(2)
+	for i := 0; i < o.N[0]; i++ {
+		for j := 0; j < o.N[1]; j++ {
+			p := o.Index(i, j)
+			x[0], x[1] = o.X[0][i], o.X[1][j]
+			chk.Float64(tst, "U", 1e-15, o.U[p], f(x))
+			chk.Float64(tst, "dfdx", 1e-13, o.Grad[0][p], dfdx(x))
+			chk.Float64(tst, "dfdy", 1e-13, o.Grad[1][p], dfdy(x))
+			chk.Float64(tst, "lap", 1e-12, o.Lap[p], lap(x))
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewFourierInterpNd([]int{8, 7}, nil, "")
+}
+
+func TestFourierInterpNd02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FourierInterpNd02. 3D Poisson equation")
+
+	// smooth periodic function on [0,2π]³
+	g := func(x la.Vector) float64 {
+		return math.Exp(math.Sin(x[0])) * math.Cos(x[1]) * (1 + 0.5*math.Sin(2*x[2]))
+	}
+
+	o := NewFourierInterpNd([]int{32, 8, 8}, nil, "")
+	defer o.Free()
+	chk.Int(tst, "index(1,2,3)", o.Index(1, 2, 3), 1*64+2*8+3)
+
+	// f = ∇²g + 3
+	o.CalcU(g)
+	o.CalcA()
+	o.CalcLap()
+	f := la.NewVector(o.Size())
+	for p := 0; p < o.Size(); p++ {
+		f[p] = o.Lap[p] + 3
+	}
+
+	// solve ∇²u = f - mean(f); g has zero mean because of cos(y); thus u = g
+	u := la.NewVector(o.Size())
+	mean := o.CalcInvLap(u, f)
+	io.Pforan("mean = %v\n", mean)
+	chk.Float64(tst, "mean", 1e-14, mean, 3)
+	chk.Array(tst, "u", 1e-13, u, o.U)
+
+	// Laplacian of the solution recovers f - mean
+	copy(o.U, u)
+	o.CalcA()
+	o.CalcLap()
+	for p := 0; p < o.Size(); p++ {
+		f[p] -= mean
+	}
+	chk.Array(tst, "∇²u", 1e-12, o.Lap, f)
+
+	// gradient at arbitrary point
+	x := []float64{0.3, 1.1, 4.2}
+	grad := la.NewVector(3)
+	o.IGrad(grad, x)
+	ex := math.Exp(math.Sin(x[0]))
+	gx := ex * math.Cos(x[0]) * math.Cos(x[1]) * (1 + 0.5*math.Sin(2*x[2]))
+	gy := -ex * math.Sin(x[1]) * (1 + 0.5*math.Sin(2*x[2]))
+	gz := ex * math.Cos(x[1]) * math.Cos(2*x[2])
+	chk.Float64(tst, "I", 1e-14, o.I(x), g(x))
+	chk.Array(tst, "∇I", 1e-13, grad, []float64{gx, gy, gz})
+}
+
+func TestFourierInterpNd03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FourierInterpNd03. dealiasing and smoothing")
+
+	N := 12
+	o := NewFourierInterpNd([]int{N, N}, nil, "")
+	defer o.Free()
+
+	// fields at grid points
+	n := o.Size()
+	u, v, w, ref := la.NewVector(n), la.NewVector(n), la.NewVector(n), la.NewVector(n)
+	for i := 0; i < N; i++ {
+		for j := 0; j < N; j++ {
+			p := o.Index(i, j)
+			x, y := o.X[0][i], o.X[1][j]
+			u[p] = math.Cos(x) + math.Sin(2*y) + math.Cos(5*x) // k=5 > N/3 is removed
+			v[p] = math.Sin(x) + math.Cos(y)
+			ref[p] = (math.Cos(x) + math.Sin(2*y)) * (math.Sin(x) + math.Cos(y))
+		}
+	}
+
+	// low-wavenumber product is exact and high wavenumbers are removed
+	o.Product23(w, u, v)
+	chk.Array(tst, "w", 1e-14, w, ref)
+
+	// product of modes producing wavenumbers beyond N/3 is truncated
+	for i := 0; i < N; i++ {
+		for j := 0; j < N; j++ {
+			p := o.Index(i, j)
+			u[p] = math.Cos(3 * o.X[0][i])
+			v[p] = math.Cos(2 * o.X[0][i])
+		}
+	}
+	o.Product23(u, u, v)
+	for p := 0; p < n; p++ {
+		i := p / N
+		ref[p] = 0.5 * math.Cos(o.X[0][i]) // cos(3x)cos(2x) = (cos(x) + cos(5x))/2
+	}
+	chk.Array(tst, "w (truncated)", 1e-14, u, ref)
+
+	// Dealias23
+	copy(o.U, v)
+	for p := 0; p < n; p++ {
+		o.U[p] += math.Cos(5 * o.X[1][p%N])
+	}
+	o.CalcA()
+	o.Dealias23()
+	o.CalcLap()
+	for p := 0; p < n; p++ {
+		ref[p] = -4 * v[p]
+	}
+	chk.Array(tst, "lap(dealiased)", 1e-13, o.Lap, ref)
+
+	// smoothing coefficients are the tensor product of the 1D ones
+	for _, smoothing := range []string{"lanc", "rcos", "ces"} {
+		o1 := NewFourierInterp(N, smoothing)
+		o2 := NewFourierInterpNd([]int{N, N}, nil, smoothing)
+		for i := 0; i < N; i++ {
+			for j := 0; j < N; j++ {
+				chk.Complex128(tst, smoothing, 1e-15, o2.S[o2.Index(i, j)], o1.S[i]*o1.S[j])
+			}
+		}
+		o1.Free()
+		o2.Free()
+	}
+}
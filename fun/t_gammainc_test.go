@@ -0,0 +1,63 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestGammaInc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GammaInc01. Regularized incomplete gamma functions")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-gammainc.cmp")
+
+	// check P and Q
+	for i, a := range dat["a"] {
+		x := dat["x"][i]
+		P := GammaP(a, x)
+		Q := GammaQ(a, x)
+		chk.Float64(tst, io.Sf("P(%6.2f,%6.2f)=%23.15e", a, x, P), 1e-13, P, dat["P"][i])
+		chk.Float64(tst, io.Sf("Q(%6.2f,%6.2f)=%23.15e", a, x, Q), 1e-13, Q, dat["Q"][i])
+	}
+
+	// special cases
+	chk.Float64(tst, "P(1,0)", 1e-17, GammaP(1, 0), 0)
+	chk.Float64(tst, "Q(1,0)", 1e-17, GammaQ(1, 0), 1)
+	chk.Float64(tst, "P(1,x) = 1 - exp(-x)", 1e-15, GammaP(1, 3), 1-math.Exp(-3))
+
+	// infinite and NaN arguments
+	for _, a := range []float64{0.5, 2, 150} {
+		chk.Float64(tst, io.Sf("P(%g,+∞)", a), 1e-17, GammaP(a, math.Inf(1)), 1)
+		chk.Float64(tst, io.Sf("Q(%g,+∞)", a), 1e-17, GammaQ(a, math.Inf(1)), 0)
+	}
+	if !math.IsNaN(GammaP(2, math.NaN())) || !math.IsNaN(GammaQ(2, math.NaN())) || !math.IsNaN(GammaP(math.NaN(), 1)) {
+		tst.Errorf("P and Q should be NaN for NaN arguments\n")
+	}
+
+	// large x (continued fraction) and x near a+1 (series)
+	chk.Float64(tst, "Q(2,800)", 1e-17, GammaQ(2, 800), 0)
+	chk.Float64(tst, "P+Q(99.5,100.4)", 1e-13, GammaP(99.5, 100.4)+GammaQ(99.5, 100.4), 1)
+}
+
+func TestGammaInc02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GammaInc02. Inverse of incomplete gamma function")
+
+	for _, a := range []float64{0.1, 0.5, 1, 2.5, 10, 50, 150} {
+		for _, p := range []float64{1e-6, 0.01, 0.25, 0.5, 0.75, 0.99} {
+			x := InvGammaP(p, a)
+			chk.Float64(tst, io.Sf("P(%5.1f,InvP(%g))", a, p), 1e-13, GammaP(a, x), p)
+		}
+	}
+	chk.Float64(tst, "InvP(0)", 1e-17, InvGammaP(0, 2), 0)
+}
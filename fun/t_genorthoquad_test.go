@@ -0,0 +1,205 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// checkGaussOrtho checks that the quadrature rule gives ∫ w⋅Pᵢ⋅Pⱼ = 0 for i ≠ j, i,j < N and i+j ≤ deg
+func checkGaussOrtho(tst *testing.T, op *GeneralOrthoPoly, x, w []float64, deg int, tol float64) {
+	for i := 0; i < op.N; i++ {
+		for j := 0; j < i && i+j <= deg; j++ {
+			res, nrmi, nrmj := 0.0, 0.0, 0.0
+			for k := 0; k < len(x); k++ {
+				res += w[k] * op.P(i, x[k]) * op.P(j, x[k])
+				nrmi += w[k] * op.P(i, x[k]) * op.P(i, x[k])
+				nrmj += w[k] * op.P(j, x[k]) * op.P(j, x[k])
+			}
+			chk.Float64(tst, io.Sf("%s: ∫P%d⋅P%d", op.Kind, i, j), tol, res/math.Sqrt(nrmi*nrmj), 0)
+		}
+	}
+}
+
+func TestGenOrthoQuad01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GenOrthoQuad01. Gauss rules: Legendre and Chebyshev")
+
+	// Legendre
+	xRef := []float64{-0.9739065285171717, -0.8650633666889845, -0.6794095682990244, -0.4333953941292472, -0.1488743389816312, 0.1488743389816312, 0.4333953941292472, 0.6794095682990244, 0.8650633666889845, 0.9739065285171717}
+	wRef := []float64{0.0666713443086881, 0.1494513491505806, 0.2190863625159821, 0.2692667193099963, 0.2955242247147529, 0.2955242247147529, 0.2692667193099963, 0.2190863625159821, 0.1494513491505806, 0.0666713443086881}
+	op := NewGeneralOrthoPoly("L", 10, 0, 0)
+	x, w := op.GaussXW()
+	io.Pforan("x = %v\n", x)
+	io.Pforan("w = %v\n", w)
+	chk.Array(tst, "x", 1e-15, x, xRef)
+	chk.Array(tst, "w", 1e-14, w, wRef)
+	for i := 0; i < op.N; i++ {
+		chk.Float64(tst, "P(xi)", 1e-13, op.F(x[i]), 0)
+	}
+
+	// Jacobi(0,0) = Legendre
+	op = NewGeneralOrthoPoly("J", 10, 0, 0)
+	x, w = op.GaussXW()
+	chk.Array(tst, "xJ", 1e-15, x, xRef)
+	chk.Array(tst, "wJ", 1e-14, w, wRef)
+
+	// Chebyshev first and second kinds
+	n := 7
+	xT, wT := NewGeneralOrthoPoly("T", n, 0, 0).GaussXW()
+	xU, wU := NewGeneralOrthoPoly("U", n, 0, 0).GaussXW()
+	for i := 0; i < n; i++ {
+		θ := math.Pi * float64(2*(n-i)-1) / float64(2*n)
+		chk.Float64(tst, "xT", 1e-15, xT[i], math.Cos(θ))
+		chk.Float64(tst, "wT", 1e-14, wT[i], math.Pi/float64(n))
+		θ = math.Pi * float64(n-i) / float64(n+1)
+		chk.Float64(tst, "xU", 1e-15, xU[i], math.Cos(θ))
+		chk.Float64(tst, "wU", 1e-14, wU[i], math.Pi/float64(n+1)*math.Pow(math.Sin(θ), 2))
+	}
+}
+
+func TestGenOrthoQuad02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GenOrthoQuad02. Gauss rules: orthogonality and μ₀")
+
+	for _, kind := range []string{"J", "L", "H", "T", "U", "La", "GLa"} {
+		N, α, β := 8, 0.7, -0.4
+		op := NewGeneralOrthoPoly(kind, N, α, β)
+		x, w := op.GaussXW()
+		_, μ0 := op.poly.rec(0)
+		sum := 0.0
+		for i := 0; i < N; i++ {
+			sum += w[i]
+		}
+		io.Pforan("%3s: μ₀ = %v\n", kind, μ0)
+		chk.Float64(tst, kind+": Σw", 1e-14*μ0, sum, μ0)
+		checkGaussOrtho(tst, op, x, w, 2*N-1, 1e-13)
+	}
+}
+
+func TestGenOrthoQuad03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GenOrthoQuad03. Gauss-Hermite and Gauss-Laguerre")
+
+	// Gauss-Hermite: 3 points
+	x, w := NewGeneralOrthoPoly("H", 3, 0, 0).GaussXW()
+	chk.Array(tst, "x", 1e-15, x, []float64{-math.Sqrt(1.5), 0, math.Sqrt(1.5)})
+	chk.Array(tst, "w", 1e-14, w, []float64{math.Sqrt(math.Pi) / 6.0, 2.0 * math.Sqrt(math.Pi) / 3.0, math.Sqrt(math.Pi) / 6.0})
+
+	// expectation of functions of a standard normal random variable: X = √2 t
+	//   E[g(X)] = 1/√π ∫ exp(-t²) g(√2 t) dt
+	x, w = NewGeneralOrthoPoly("H", 20, 0, 0).GaussXW()
+	moments := []float64{1, 0, 1, 0, 3, 0, 15, 0, 105}
+	for k, ref := range moments {
+		res := 0.0
+		for i := 0; i < len(x); i++ {
+			res += w[i] * math.Pow(math.Sqrt2*x[i], float64(k))
+		}
+		chk.Float64(tst, io.Sf("E[X^%d]", k), 1e-13, res/math.Sqrt(math.Pi), ref)
+	}
+	res := 0.0
+	for i := 0; i < len(x); i++ {
+		res += w[i] * math.Cos(math.Sqrt2*x[i])
+	}
+	chk.Float64(tst, "E[cos(X)]", 1e-15, res/math.Sqrt(math.Pi), math.Exp(-0.5))
+
+	// Gauss-Laguerre: ∫₀^∞ xᵏ exp(-x) dx = k!
+	x, w = NewGeneralOrthoPoly("La", 12, 0, 0).GaussXW()
+	for k := 0; k < 2*12; k++ {
+		res = 0.0
+		for i := 0; i < len(x); i++ {
+			res += w[i] * math.Pow(x[i], float64(k))
+		}
+		chk.Float64(tst, io.Sf("∫xᵏ⋅exp(-x) k=%d", k), 1e-13, res/math.Gamma(float64(k+1)), 1)
+	}
+
+	// semi-infinite domain: ∫₀^∞ sin(x) exp(-x) dx = 1/2
+	x, w = NewGeneralOrthoPoly("La", 30, 0, 0).GaussXW()
+	res = 0.0
+	for i := 0; i < len(x); i++ {
+		res += w[i] * math.Sin(x[i])
+	}
+	chk.Float64(tst, "∫sin(x)⋅exp(-x)", 1e-10, res, 0.5)
+
+	// generalized Gauss-Laguerre: ∫₀^∞ x^(α+k) exp(-x) dx = Γ(α+k+1)
+	α := 1.5
+	x, w = NewGeneralOrthoPoly("GLa", 10, α, 0).GaussXW()
+	for k := 0; k < 2*10; k++ {
+		res = 0.0
+		for i := 0; i < len(x); i++ {
+			res += w[i] * math.Pow(x[i], float64(k))
+		}
+		chk.Float64(tst, io.Sf("∫x^(α+k)⋅exp(-x) k=%d", k), 1e-13, res/math.Gamma(α+float64(k)+1), 1)
+	}
+
+	// explicit generalized Laguerre polynomial: L⁽ᵅ⁾₂(x) = x²/2 - (α+2)x + (α+2)(α+1)/2
+	op := NewGeneralOrthoPoly("GLa", 2, α, 0)
+	for _, xx := range []float64{0, 0.5, 1, 3} {
+		chk.Float64(tst, "L2", 1e-15, op.F(xx), xx*xx/2-(α+2)*xx+(α+2)*(α+1)/2)
+	}
+}
+
+func TestGenOrthoQuad04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GenOrthoQuad04. Gauss-Radau and Gauss-Lobatto")
+
+	// Gauss-Radau-Legendre: 3 points
+	s6 := math.Sqrt(6)
+	op := NewGeneralOrthoPoly("L", 3, 0, 0)
+	x, w := op.GaussRadauXW(-1)
+	io.Pforan("x = %v\n", x)
+	io.Pforan("w = %v\n", w)
+	chk.Array(tst, "x", 1e-15, x, []float64{-1, (1 - s6) / 5, (1 + s6) / 5})
+	chk.Array(tst, "w", 1e-15, w, []float64{2.0 / 9.0, (16 + s6) / 18, (16 - s6) / 18})
+
+	// Gauss-Lobatto-Legendre: 4 points
+	op = NewGeneralOrthoPoly("L", 4, 0, 0)
+	x, w = op.GaussLobattoXW(-1, 1)
+	io.Pforan("x = %v\n", x)
+	io.Pforan("w = %v\n", w)
+	s5 := 1.0 / math.Sqrt(5)
+	chk.Array(tst, "x", 1e-15, x, []float64{-1, -s5, s5, 1})
+	chk.Array(tst, "w", 1e-15, w, []float64{1.0 / 6.0, 5.0 / 6.0, 5.0 / 6.0, 1.0 / 6.0})
+
+	// Gauss-Lobatto-Chebyshev: xj = -cos(π j / (n-1))
+	n := 9
+	x, w = NewGeneralOrthoPoly("T", n, 0, 0).GaussLobattoXW(-1, 1)
+	for j := 0; j < n; j++ {
+		wref := math.Pi / float64(n-1)
+		if j == 0 || j == n-1 {
+			wref /= 2
+		}
+		chk.Float64(tst, "xT", 1e-15, x[j], -math.Cos(math.Pi*float64(j)/float64(n-1)))
+		chk.Float64(tst, "wT", 1e-14, w[j], wref)
+	}
+
+	// exactness of all kinds
+	for _, kind := range []string{"J", "L", "T", "U", "H", "La", "GLa"} {
+		N, α, β := 7, 0.3, 1.2
+		op = NewGeneralOrthoPoly(kind, N, α, β)
+		xfix, xa, xb := -1.0, -1.0, 1.0
+		if kind == "H" {
+			xfix, xa, xb = -3, -4, 4
+		}
+		if kind == "La" || kind == "GLa" {
+			xfix, xa, xb = 0, 0, 30
+		}
+		x, w = op.GaussRadauXW(xfix)
+		chk.Float64(tst, kind+": Radau x0", 1e-14, x[0], xfix)
+		checkGaussOrtho(tst, op, x, w, 2*N-2, 1e-12)
+		x, w = op.GaussLobattoXW(xa, xb)
+		chk.Float64(tst, kind+": Lobatto x0", 1e-14, x[0], xa)
+		chk.Float64(tst, kind+": Lobatto xN", 1e-13, x[N-1], xb)
+		checkGaussOrtho(tst, op, x, w, 2*N-3, 1e-12)
+	}
+}
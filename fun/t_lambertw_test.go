@@ -0,0 +1,52 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestLambertW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LambertW01. Lambert W function")
+
+	// tolerance: W is ill-conditioned near the branch point x = -1/e
+	tolerance := func(x, w float64) float64 {
+		if x < -0.36 {
+			return 1e-15 * math.Abs(w/(x*(1+w)))
+		}
+		return 1e-14 * math.Max(1, math.Abs(w))
+	}
+
+	// principal branch
+	_, dat := io.ReadTable("data/sp-lambertw0.cmp")
+	for i, x := range dat["x"] {
+		w := LambertW0(x)
+		chk.Float64(tst, io.Sf("W₀(%23.15e)=%23.15e", x, w), tolerance(x, w), w, dat["W"][i])
+	}
+
+	// lower branch
+	io.Pl()
+	_, dat = io.ReadTable("data/sp-lambertwm1.cmp")
+	for i, x := range dat["x"] {
+		w := LambertWm1(x)
+		chk.Float64(tst, io.Sf("W₋₁(%23.15e)=%23.15e", x, w), tolerance(x, w), w, dat["W"][i])
+	}
+
+	// check definition: w⋅exp(w) = x
+	io.Pl()
+	for _, x := range []float64{-0.35, -0.1, 0.7, 5, 1e3} {
+		w := LambertW0(x)
+		chk.Float64(tst, io.Sf("W₀(%g)⋅exp(W₀(%g))", x, x), 1e-14*math.Max(1, x), w*math.Exp(w), x)
+	}
+	chk.Float64(tst, "W₀(e)", 1e-15, LambertW0(math.E), 1)
+	chk.Float64(tst, "W₀(-1/e)", 1e-15, LambertW0(-1.0/math.E), -1)
+	chk.Float64(tst, "W₋₁(-1/e)", 1e-15, LambertWm1(-1.0/math.E), -1)
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestPade01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Pade01. Padé approximants of exp(x)")
+
+	// Taylor coefficients of exp(x)
+	c := make([]float64, 21)
+	c[0] = 1
+	for i := 1; i < len(c); i++ {
+		c[i] = c[i-1] / float64(i)
+	}
+
+	// [2/2]: P = 1 + x/2 + x²/12 and Q = 1 - x/2 + x²/12
+	o := NewPade(c, 2, 2)
+	io.Pforan("P = %v\n", o.P)
+	io.Pforan("Q = %v\n", o.Q)
+	chk.Array(tst, "P", 1e-15, o.P, []float64{1, 0.5, 1.0 / 12.0})
+	chk.Array(tst, "Q", 1e-15, o.Q, []float64{1, -0.5, 1.0 / 12.0})
+
+	// poles: 3 ± i√3
+	poles, res := o.Residues()
+	io.Pforan("poles = %v\n", poles)
+	chk.Int(tst, "npoles", len(poles), 2)
+	for k, p := range poles {
+		ref := complex(3, math.Sqrt(3))
+		if imag(p) < 0 {
+			ref = cmplx.Conj(ref)
+		}
+		chk.Complex128(tst, "pole", 1e-14, p, ref)
+		P := 1 + ref/2 + ref*ref/12
+		dQ := -0.5 + ref/6
+		chk.Complex128(tst, "res", 1e-13, res[k], P/dQ)
+	}
+
+	// zeros: -3 ± i√3
+	zeros := o.Zeros()
+	chk.Int(tst, "nzeros", len(zeros), 2)
+	for _, z := range zeros {
+		chk.Float64(tst, "re(zero)", 1e-14, real(z), -3)
+		chk.Float64(tst, "|im(zero)|", 1e-14, math.Abs(imag(z)), math.Sqrt(3))
+	}
+
+	// [7/7] as Ss and for complex arguments
+	o = NewPade(c, 7, 7)
+	var f Ss = o.Eval
+	for _, x := range utl.LinSpace(-1, 1, 11) {
+		chk.Float64(tst, "exp(x)", 1e-14, f(x), math.Exp(x))
+	}
+	for _, z := range []complex128{1i, -1i, 0.5 + 0.5i, -1 + 0.2i} {
+		chk.Complex128(tst, "exp(z)", 1e-14, o.EvalC(z), cmplx.Exp(z))
+	}
+}
+
+func TestPade02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Pade02. Padé approximants: log(1+x) and tan(x)")
+
+	// log(1+x) converges beyond the radius of convergence of the Taylor series
+	c := make([]float64, 21)
+	for i := 1; i < len(c); i++ {
+		c[i] = math.Pow(-1, float64(i+1)) / float64(i)
+	}
+	o := NewPade(c, 10, 10)
+	for _, x := range []float64{0.5, 1, 2, 4} {
+		io.Pforan("x = %v  log(1+x) = %23.15e  r(x) = %23.15e\n", x, math.Log1p(x), o.Eval(x))
+	}
+	chk.Float64(tst, "log(2)", 1e-14, o.Eval(1), math.Log(2))
+	chk.Float64(tst, "log(3)", 1e-11, o.Eval(2), math.Log(3))
+
+	// tan(x) = x + x³/3 + 2x⁵/15 + 17x⁷/315 + 62x⁹/2835 + ...
+	c = []float64{0, 1, 0, 1.0 / 3.0, 0, 2.0 / 15.0, 0, 17.0 / 315.0, 0, 62.0 / 2835.0}
+	o = NewPade(c, 5, 4)
+	for _, x := range []float64{0.1, 0.5, 1} {
+		chk.Float64(tst, "tan(x)", 1e-6, o.Eval(x), math.Tan(x))
+	}
+
+	// the smallest poles approximate ±π/2
+	poles := o.Poles()
+	io.Pforan("poles = %v\n", poles)
+	pmin := math.MaxFloat64
+	for _, p := range poles {
+		pmin = math.Min(pmin, cmplx.Abs(p))
+	}
+	chk.Float64(tst, "π/2", 1e-4, pmin, math.Pi/2)
+}
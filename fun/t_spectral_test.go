@@ -0,0 +1,230 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestSpectral01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral01. windows and frequencies")
+
+	// symmetric windows
+	chk.Array(tst, "hann", 1e-15, WindowHann(5, false), []float64{0, 0.5, 1, 0.5, 0})
+	chk.Array(tst, "hamming", 1e-15, WindowHamming(5, false), []float64{0.08, 0.54, 1, 0.54, 0.08})
+	chk.Array(tst, "blackman", 1e-15, WindowBlackman(5, false), []float64{0, 0.34, 1, 0.34, 0})
+	chk.Array(tst, "tukey", 1e-8, WindowTukey(10, 0.5, false), []float64{0, 0.41317591, 0.96984631, 1, 1, 1, 1, 0.96984631, 0.41317591, 0})
+	chk.Array(tst, "tukey(α=0)", 1e-15, WindowTukey(4, 0, false), []float64{1, 1, 1, 1})
+	chk.Array(tst, "tukey(α=1)", 1e-15, WindowTukey(5, 1, false), WindowHann(5, false))
+	chk.Array(tst, "kaiser(β=0)", 1e-15, WindowKaiser(4, 0, false), []float64{1, 1, 1, 1})
+	I0at5 := 27.239871823604442 // I₀(5)
+	kai := WindowKaiser(5, 5, false)
+	io.Pforan("kaiser = %v\n", kai)
+	chk.Float64(tst, "kaiser[0]", 1e-15, kai[0], 1/I0at5)
+	chk.Float64(tst, "kaiser[2]", 1e-15, kai[2], 1)
+	chk.Float64(tst, "kaiser[1]", 1e-15, kai[1], kai[3])
+	chk.Float64(tst, "I₀(5)", 1e-13, kaiserI0(5), I0at5)
+	chk.Float64(tst, "I₀(5) (ModBesselI0)", 1e-5, kaiserI0(5), ModBesselI0(5))
+
+	// periodic windows
+	chk.Array(tst, "hann (periodic)", 1e-15, WindowHann(4, true), []float64{0, 0.5, 1, 0.5})
+	chk.Array(tst, "hann (n=1)", 1e-15, WindowHann(1, true), []float64{1})
+
+	// frequencies
+	chk.Array(tst, "fftfreq(8)", 1e-15, FftFreq(8, 0.1), []float64{0, 1.25, 2.5, 3.75, -5, -3.75, -2.5, -1.25})
+	chk.Array(tst, "fftfreq(5)", 1e-15, FftFreq(5, 1), []float64{0, 0.2, 0.4, -0.4, -0.2})
+	chk.Array(tst, "rfftfreq(8)", 1e-15, RfftFreq(8, 0.1), []float64{0, 1.25, 2.5, 3.75, 5})
+	chk.Array(tst, "rfftfreq(5)", 1e-15, RfftFreq(5, 1), []float64{0, 0.2, 0.4})
+	chk.Array(tst, "fftshift(8)", 1e-15, FftShift(FftFreq(8, 0.1)), []float64{-5, -3.75, -2.5, -1.25, 0, 1.25, 2.5, 3.75})
+	chk.Array(tst, "fftshift(5)", 1e-15, FftShift(FftFreq(5, 1)), []float64{-0.4, -0.2, 0, 0.2, 0.4})
+	chk.ArrayC(tst, "fftshiftC", 1e-15, FftShiftC([]complex128{0, 1i, 2, -2, -1i}), []complex128{-2, -1i, 0, 1i, 2})
+	chk.Int(tst, "nextpow2(1)", NextPow2(1), 1)
+	chk.Int(tst, "nextpow2(100)", NextPow2(100), 128)
+	chk.Int(tst, "nextpow2(128)", NextPow2(128), 128)
+}
+
+func TestSpectral02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral02. periodogram and Welch")
+
+	// Parseval: Σ P⋅Δf = variance
+	for _, n := range []int{64, 65} {
+		x := make([]float64, n)
+		mean := 0.0
+		for i := 0; i < n; i++ {
+			x[i] = 2 + math.Sin(0.7*float64(i)) + 0.3*math.Cos(2.1*float64(i*i))
+			mean += x[i]
+		}
+		mean /= float64(n)
+		variance := 0.0
+		for i := 0; i < n; i++ {
+			variance += (x[i] - mean) * (x[i] - mean)
+		}
+		variance /= float64(n)
+		fs := 10.0
+		f, P := Periodogram(x, fs, nil)
+		chk.Int(tst, "len(f)", len(f), n/2+1)
+		sum := 0.0
+		for k := range P {
+			sum += P[k] * fs / float64(n)
+		}
+		chk.Float64(tst, "Parseval", 1e-13, sum, variance)
+		chk.Float64(tst, "P[0] (mean removed)", 1e-13, P[0], 0)
+	}
+
+	// sinusoid + white noise
+	rng := rand.New(rand.NewSource(1234))
+	n, fs, A, f0, σ := 8192, 100.0, 2.0, 12.5, 0.5
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		t := float64(i) / fs
+		x[i] = A*math.Sin(2*math.Pi*f0*t) + σ*rng.NormFloat64()
+	}
+	nperseg := 256
+	f, P := Welch(x, fs, WindowHann(nperseg, true), nperseg/2)
+	chk.Int(tst, "len(f)", len(f), nperseg/2+1)
+
+	// peak
+	kmax := 0
+	for k := range P {
+		if P[k] > P[kmax] {
+			kmax = k
+		}
+	}
+	io.Pforan("peak at f = %v\n", f[kmax])
+	chk.Float64(tst, "f(peak)", 1e-15, f[kmax], f0)
+
+	// power of sinusoid (A²/2) and noise level (2σ²/fs)
+	df := f[1] - f[0]
+	power, noise, count := 0.0, 0.0, 0
+	for k := range P {
+		if math.Abs(f[k]-f0) < 4*df {
+			power += P[k] * df
+		} else if k > 0 {
+			noise += P[k]
+			count++
+		}
+	}
+	noise /= float64(count)
+	io.Pforan("power = %v (A²/2 = %v)\n", power, A*A/2)
+	io.Pforan("noise = %v (2σ²/fs = %v)\n", noise, 2*σ*σ/fs)
+	chk.Float64(tst, "power", 0.05, power, A*A/2)
+	chk.Float64(tst, "noise", 5e-4, noise, 2*σ*σ/fs)
+}
+
+func TestSpectral03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral03. cross spectral density and coherence")
+
+	rng := rand.New(rand.NewSource(4321))
+	n, fs := 4096, 1.0
+	x := make([]float64, n)
+	y := make([]float64, n)
+	z := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = rng.NormFloat64()
+		z[i] = rng.NormFloat64()
+	}
+	for i := 1; i < n; i++ {
+		y[i] = 0.5*x[i] + 0.5*x[i-1] // low-pass filtered x
+	}
+
+	nperseg := 128
+	win := WindowHann(nperseg, true)
+
+	// Csd(x,x) = Welch(x)
+	f, Pxx := Welch(x, fs, win, nperseg/2)
+	_, Pxxc := Csd(x, x, fs, win, nperseg/2)
+	for k := range f {
+		chk.Complex128(tst, "Pxx", 1e-15, Pxxc[k], complex(Pxx[k], 0))
+	}
+
+	// transfer function H = Pxy/Pxx = 0.5 + 0.5 exp(-i 2π f)
+	_, Pxy := Csd(x, y, fs, win, nperseg/2)
+	for k := 1; k < len(f)/2; k++ {
+		H := Pxy[k] / complex(Pxx[k], 0)
+		Href := 0.5 + 0.5*cmplx.Exp(complex(0, -2*math.Pi*f[k]))
+		chk.Complex128(tst, "H", 0.02, H, Href)
+	}
+
+	// coherence
+	_, Cxy := Coherence(x, y, fs, win, nperseg/2)
+	_, Cxz := Coherence(x, z, fs, win, nperseg/2)
+	meanXy, meanXz := 0.0, 0.0
+	for k := 1; k < len(f)/2; k++ {
+		meanXy += Cxy[k]
+		meanXz += Cxz[k]
+	}
+	meanXy /= float64(len(f)/2 - 1)
+	meanXz /= float64(len(f)/2 - 1)
+	io.Pforan("mean coherence: related = %v, unrelated = %v\n", meanXy, meanXz)
+	chk.Float64(tst, "Cxy", 1e-3, meanXy, 1)
+	if meanXz > 0.1 {
+		tst.Errorf("the coherence of unrelated signals is too large: %g\n", meanXz)
+	}
+}
+
+func TestSpectral04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Spectral04. short-time Fourier transform")
+
+	// chirp-like signal: first half at f1, second half at f2
+	n, fs, f1, f2 := 1000, 200.0, 25.0, 50.0
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		t := float64(i) / fs
+		if i < n/2 {
+			x[i] = 3 * math.Sin(2*math.Pi*f1*t)
+		} else {
+			x[i] = 3 * math.Cos(2*math.Pi*f2*t)
+		}
+	}
+
+	nperseg := 64
+	for _, win := range [][]float64{WindowHann(nperseg, true), WindowHamming(nperseg, true), WindowRect(nperseg), WindowTukey(nperseg, 0.3, true)} {
+		for _, hop := range []int{nperseg / 2, nperseg / 4, 24} {
+			f, t, Z := Stft(x, fs, win, hop)
+			chk.Int(tst, "len(f)", len(f), nperseg/2+1)
+			chk.Int(tst, "len(Z)", len(Z), len(t))
+
+			// reconstruction
+			y := Istft(Z, win, hop, n)
+			chk.Array(tst, io.Sf("istft: hop=%d", hop), 1e-13, y, x)
+		}
+	}
+
+	// frequency content at the beginning and at the end
+	win := WindowHann(nperseg, true)
+	f, t, Z := Stft(x, fs, win, nperseg/2)
+	peak := func(j int) (fpeak, amp float64) {
+		for k := range f {
+			if a := cmplx.Abs(Z[j][k]); a > amp {
+				fpeak, amp = f[k], a
+			}
+		}
+		return
+	}
+	j1, j2 := 3, len(t)-4
+	fp1, a1 := peak(j1)
+	fp2, a2 := peak(j2)
+	io.Pforan("t = %v: f = %v  |Z| = %v\n", t[j1], fp1, a1)
+	io.Pforan("t = %v: f = %v  |Z| = %v\n", t[j2], fp2, a2)
+	chk.Float64(tst, "f1", 1e-15, fp1, f1)
+	chk.Float64(tst, "f2", 1e-15, fp2, f2)
+	chk.Float64(tst, "A/2 at f1", 1e-12, a1, 1.5)
+	chk.Float64(tst, "A/2 at f2", 1e-12, a2, 1.5)
+	chk.Float64(tst, "Δt", 1e-15, t[1]-t[0], float64(nperseg/2)/fs)
+}
@@ -0,0 +1,237 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// waveletTestNames holds the names of all wavelets for testing
+var waveletTestNames = []string{
+	"haar", "db2", "db3", "db4", "db5", "db6", "db7", "db8", "db9", "db10",
+	"sym2", "sym3", "sym4", "sym5", "sym6", "sym7", "sym8", "sym9", "sym10", "cdf97",
+}
+
+// waveletTestSignal returns a deterministic "random-like" signal for testing
+func waveletTestSignal(n int) (x []float64) {
+	x = make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = math.Sin(1.3*float64(i)) + 0.5*math.Cos(0.37*float64(i*i)) + 0.1*float64(i)
+	}
+	return
+}
+
+func TestWavelet01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Wavelet01. filters")
+
+	for _, name := range waveletTestNames {
+		w := NewWavelet(name)
+		L := w.Len()
+		io.Pforan("%6s: L = %2d\n", name, L)
+
+		// normalisation
+		sum := 0.0
+		for _, v := range w.DecLo {
+			sum += v
+		}
+		chk.Float64(tst, name+": Σh", 1e-14, sum, math.Sqrt2)
+
+		// biorthogonality: Σ h̃[k] h[k+2m] = δ(m) (orthonormality if h̃ = h)
+		for m := 0; 2*m < L; m++ {
+			dot := 0.0
+			for k := 0; k+2*m < L; k++ {
+				dot += w.DecLo[L-1-k] * w.RecLo[k+2*m]
+			}
+			ref := 0.0
+			if m == 0 {
+				ref = 1
+			}
+			chk.Float64(tst, io.Sf("%s: δ(%d)", name, m), 1e-14, dot, ref)
+		}
+
+		// vanishing moments of the high-pass filter: Σ kᵖ g[k] = 0
+		if w.Ortho {
+			for p := 0; p < L/2; p++ {
+				mom := 0.0
+				for k := 0; k < L; k++ {
+					mom += math.Pow(float64(k)/float64(L), float64(p)) * w.RecHi[k]
+				}
+				chk.Float64(tst, io.Sf("%s: moment %d", name, p), 1e-11, mom, 0)
+			}
+		}
+	}
+
+	// Haar
+	a, d := Dwt1d([]float64{1, 2, 3, 4}, NewWavelet("haar"), DwtPeriodization)
+	s := 1.0 / math.Sqrt2
+	chk.Array(tst, "haar: a", 1e-15, a, []float64{3 * s, 7 * s})
+	chk.Array(tst, "haar: d", 1e-15, d, []float64{-s, -s})
+
+	// CDF 9/7 (JPEG2000)
+	w := NewWavelet("cdf97")
+	chk.Float64(tst, "cdf97: h₀", 1e-14, w.DecLo[5]/math.Sqrt2, 0.6029490182363579)
+	chk.Float64(tst, "cdf97: h̃₀", 1e-14, w.RecLo[4]/math.Sqrt2, 0.5575435262285023)
+}
+
+func TestWavelet02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Wavelet02. perfect reconstruction in 1D")
+
+	modes := []int{DwtZero, DwtConstant, DwtSymmetric, DwtReflect, DwtPeriodic, DwtPeriodization}
+	for _, name := range waveletTestNames {
+		w := NewWavelet(name)
+		for _, n := range []int{5, 16, 33} {
+			x := waveletTestSignal(n)
+			for _, mode := range modes {
+				a, d := Dwt1d(x, w, mode)
+				chk.Int(tst, "len(a)", len(a), DwtLen(n, w.Len(), mode))
+				y := Idwt1d(a, d, w, mode)
+				chk.Array(tst, io.Sf("%s: n=%d mode=%d", name, n, mode), 1e-13, y[:n], x)
+
+				// Parseval (energy conservation) for orthogonal wavelets and circular convolution
+				if w.Ortho && mode == DwtPeriodization && n%2 == 0 {
+					ex, ec := 0.0, 0.0
+					for i := 0; i < n; i++ {
+						ex += x[i] * x[i]
+					}
+					for i := 0; i < len(a); i++ {
+						ec += a[i]*a[i] + d[i]*d[i]
+					}
+					chk.Float64(tst, name+": energy", 1e-12, ec, ex)
+				}
+			}
+		}
+	}
+
+	// approximation only: a constant signal has no details with the symmetric mode
+	w := NewWavelet("db3")
+	x := []float64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2}
+	a, d := Dwt1d(x, w, DwtSymmetric)
+	chk.Array(tst, "d(const)", 1e-14, d, make([]float64, len(d)))
+	y := Idwt1d(a, nil, w, DwtSymmetric)
+	chk.Array(tst, "x(const)", 1e-14, y[:len(x)], x)
+}
+
+func TestWavelet03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Wavelet03. multilevel and 2D transforms")
+
+	// 1D multilevel
+	for _, name := range []string{"haar", "db4", "sym6", "cdf97"} {
+		w := NewWavelet(name)
+		for _, mode := range []int{DwtSymmetric, DwtPeriodization, DwtZero} {
+			for _, n := range []int{100, 127} {
+				x := waveletTestSignal(n)
+				coefs := Wavedec1d(x, w, mode, 0)
+				level := DwtMaxLevel(n, w.Len())
+				chk.Int(tst, "level", len(coefs)-1, level)
+				y := Waverec1d(coefs, w, mode)
+				chk.Array(tst, io.Sf("%s: mode=%d n=%d", name, mode, n), 1e-12, y[:n], x)
+			}
+		}
+	}
+
+	// 2D
+	m, n := 13, 20
+	X := la.NewMatrix(m, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			X.Set(i, j, math.Sin(0.3*float64(i*j))+float64(i)-0.2*float64(j))
+		}
+	}
+	for _, name := range []string{"haar", "db2", "sym4", "cdf97"} {
+		w := NewWavelet(name)
+		for _, mode := range []int{DwtSymmetric, DwtPeriodization} {
+
+			// single level
+			a, h, v, d := Dwt2d(X, w, mode)
+			Y := Idwt2d(a, h, v, d, w, mode)
+			chk.Deep2(tst, name+": 2D", 1e-13, waveletSubMatrix(Y, m, n), X.GetDeep2())
+
+			// multilevel
+			A, details := Wavedec2d(X, w, mode, 2)
+			chk.Int(tst, "level", len(details), 2)
+			Y = Waverec2d(A, details, w, mode)
+			chk.Deep2(tst, name+": 2D multilevel", 1e-12, waveletSubMatrix(Y, m, n), X.GetDeep2())
+		}
+	}
+
+	// separability: a matrix with constant rows has no vertical details
+	C := la.NewMatrix(8, 8)
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			C.Set(i, j, float64(i))
+		}
+	}
+	_, h, v, _ := Dwt2d(C, NewWavelet("haar"), DwtPeriodization)
+	chk.Float64(tst, "max|v|", 1e-15, v.Largest(1), 0)
+	chk.Float64(tst, "max|h|", 1e-15, h.Largest(1), 1)
+}
+
+func TestWavelet04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Wavelet04. thresholding and denoising")
+
+	// thresholds
+	c := []float64{-3, -1, 0.5, 2, 4}
+	DwtThreshold(c, 1.5, false)
+	chk.Array(tst, "hard", 1e-17, c, []float64{-3, 0, 0, 2, 4})
+	c = []float64{-3, -1, 0.5, 2, 4}
+	DwtThreshold(c, 1.5, true)
+	chk.Array(tst, "soft", 1e-17, c, []float64{-1.5, 0, 0, 0.5, 2.5})
+	chk.Float64(tst, "thr", 1e-15, DwtUniversalThreshold([]float64{-0.6745, 0.6745, 3, -0.1}, 8), math.Sqrt(2*math.Log(8))) // σ = 1
+
+	// noisy signal: smooth part + piecewise constant part + noise
+	rng := rand.New(rand.NewSource(1234))
+	n := 1024
+	x := make([]float64, n)
+	noisy := make([]float64, n)
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n)
+		x[i] = math.Sin(4*math.Pi*t) + Heav(t-0.5)
+		noisy[i] = x[i] + 0.1*rng.NormFloat64()
+	}
+	rms := func(a, b []float64) (res float64) {
+		for i := range a {
+			res += (a[i] - b[i]) * (a[i] - b[i])
+		}
+		return math.Sqrt(res / float64(len(a)))
+	}
+	e0 := rms(noisy, x)
+	for _, soft := range []bool{false, true} {
+		for _, name := range []string{"db4", "sym8"} {
+			y := DwtDenoise1d(noisy, NewWavelet(name), DwtSymmetric, 5, soft)
+			chk.Int(tst, "len(y)", len(y), n)
+			e1 := rms(y, x)
+			io.Pforan("%s: soft=%v: rms error: noisy = %.4f  denoised = %.4f\n", name, soft, e0, e1)
+			if e1 > 0.5*e0 {
+				tst.Errorf("denoising with %s failed to reduce the error: %g > 0.5 × %g\n", name, e1, e0)
+			}
+		}
+	}
+}
+
+// waveletSubMatrix returns the m×n upper-left part of A
+func waveletSubMatrix(A *la.Matrix, m, n int) (res [][]float64) {
+	res = make([][]float64, m)
+	for i := 0; i < m; i++ {
+		res[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			res[i][j] = A.Get(i, j)
+		}
+	}
+	return
+}
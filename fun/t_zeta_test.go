@@ -0,0 +1,59 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestZeta01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Zeta01. Riemann zeta function")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-zeta.cmp")
+
+	// check ζ(s)
+	for i, s := range dat["s"] {
+		z := Zeta(s)
+		chk.Float64(tst, io.Sf("ζ(%5.2f)=%23.15e", s, z), 1e-15*math.Max(1, math.Abs(z)), z, dat["zeta"][i])
+	}
+
+	// known values
+	io.Pl()
+	chk.Float64(tst, "ζ(2) = π²/6", 1e-15, Zeta(2), math.Pi*math.Pi/6.0)
+	chk.Float64(tst, "ζ(4) = π⁴/90", 1e-15, Zeta(4), math.Pow(math.Pi, 4)/90.0)
+	chk.Float64(tst, "ζ(0) = -1/2", 1e-15, Zeta(0), -0.5)
+	chk.Float64(tst, "ζ(-2) = 0", 1e-17, Zeta(-2), 0)
+	if !math.IsNaN(Zeta(1)) {
+		tst.Errorf("ζ(1) should be NaN\n")
+	}
+}
+
+func TestZeta02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Zeta02. Hurwitz zeta function")
+
+	// load data
+	_, dat := io.ReadTable("data/sp-hurwitz.cmp")
+
+	// check ζ(s,q). NOTE: the relative error is checked
+	for i, s := range dat["s"] {
+		q := dat["q"][i]
+		z := HurwitzZeta(s, q)
+		chk.Float64(tst, io.Sf("ζ(%5.2f,%6.2f)=%23.15e", s, q, z), 1e-14, z/dat["zeta"][i], 1)
+	}
+
+	// check ζ(s,1) = ζ(s) and ζ(s,q) = ζ(s,q+1) + q⁻ˢ
+	io.Pl()
+	chk.Float64(tst, "ζ(3,1)", 1e-17, HurwitzZeta(3, 1), Zeta(3))
+	chk.Float64(tst, "ζ(2.5,0.7)", 1e-15, HurwitzZeta(2.5, 0.7), HurwitzZeta(2.5, 1.7)+math.Pow(0.7, -2.5))
+}
@@ -0,0 +1,658 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// boundary modes (signal extension) for the discrete wavelet transform
+const (
+	DwtZero          = iota // zero padding:       0 0 | x₀ x₁ … xₙ₋₁ | 0 0
+	DwtConstant             // constant padding:   x₀ x₀ | x₀ x₁ … xₙ₋₁ | xₙ₋₁ xₙ₋₁
+	DwtSymmetric            // half-sample symmetric: x₁ x₀ | x₀ x₁ … xₙ₋₁ | xₙ₋₁ xₙ₋₂
+	DwtReflect              // whole-sample symmetric: x₂ x₁ | x₀ x₁ … xₙ₋₁ | xₙ₋₂ xₙ₋₃
+	DwtPeriodic             // periodic padding:   xₙ₋₂ xₙ₋₁ | x₀ x₁ … xₙ₋₁ | x₀ x₁
+	DwtPeriodization        // circular convolution (non-redundant); odd signals are padded with xₙ₋₁
+)
+
+// Wavelet holds the decomposition and reconstruction filters of a discrete wavelet transform
+//
+//	The following families are available (see NewWavelet):
+//	  "haar"             -- Haar wavelet (same as db1)
+//	  "db1", …, "db10"   -- Daubechies wavelets with N vanishing moments (2N filter coefficients)
+//	  "sym2", …, "sym10" -- symlets; i.e. least asymmetric Daubechies wavelets
+//	  "cdf97"            -- biorthogonal Cohen-Daubechies-Feauveau 9/7 wavelet (also "bior4.4")
+//
+//	The Daubechies filters are computed by the spectral factorisation of [1] (minimum phase). The
+//	symlets are obtained from the same factorisation by choosing the roots that minimise the
+//	deviation of the phase from a linear one. The CDF 9/7 filters follow [2].
+//
+//	References:
+//	[1] Daubechies I (1992) Ten Lectures on Wavelets. SIAM. 357p
+//	[2] Cohen A, Daubechies I, Feauveau JC (1992) Biorthogonal bases of compactly supported
+//	    wavelets. Communications on Pure and Applied Mathematics, 45(5):485-560
+type Wavelet struct {
+	Name  string    // name of wavelet
+	Ortho bool      // orthogonal wavelet (otherwise biorthogonal)
+	DecLo []float64 // decomposition low-pass filter
+	DecHi []float64 // decomposition high-pass filter
+	RecLo []float64 // reconstruction low-pass filter
+	RecHi []float64 // reconstruction high-pass filter
+}
+
+// NewWavelet returns a new wavelet
+//
+//	name -- "haar", "db1", …, "db10", "sym2", …, "sym10" or "cdf97" (or "bior4.4")
+func NewWavelet(name string) (o *Wavelet) {
+	o = new(Wavelet)
+	o.Name = name
+	key := strings.ToLower(name)
+	switch key {
+	case "haar":
+		key = "db1"
+	case "bior4.4":
+		key = "cdf97"
+	}
+
+	// biorthogonal
+	if key == "cdf97" {
+		L := 10 // the 9- and 7-tap filters are padded with zeros to have the same length
+		o.DecLo = make([]float64, L)
+		o.RecLo = make([]float64, L)
+		copy(o.DecLo[1:], waveletFilters["cdf97a"])
+		copy(o.RecLo[1:], waveletFilters["cdf97s"])
+		o.DecHi = make([]float64, L)
+		o.RecHi = make([]float64, L)
+		for k := 0; k < L; k++ {
+			o.RecHi[k] = math.Pow(-1, float64(k)) * o.DecLo[k]
+			o.DecHi[k] = -math.Pow(-1, float64(k)) * o.RecLo[k]
+		}
+		return
+	}
+
+	// orthogonal
+	h, ok := waveletFilters[key]
+	if !ok || strings.HasPrefix(key, "cdf") {
+		chk.Panic("cannot find wavelet named %q\n", name)
+	}
+	o.Ortho = true
+	L := len(h)
+	o.DecLo = make([]float64, L)
+	o.DecHi = make([]float64, L)
+	o.RecLo = make([]float64, L)
+	o.RecHi = make([]float64, L)
+	for k := 0; k < L; k++ {
+		o.DecLo[k] = h[k]
+		o.RecLo[k] = h[L-1-k]
+		o.RecHi[k] = math.Pow(-1, float64(k)) * h[k]
+	}
+	for k := 0; k < L; k++ {
+		o.DecHi[k] = o.RecHi[L-1-k]
+	}
+	return
+}
+
+// Len returns the length of the filters
+func (o *Wavelet) Len() int {
+	return len(o.DecLo)
+}
+
+// DwtMaxLevel returns the maximum useful level of decomposition of a signal with n samples and
+// filters of length filterLen; i.e. ⌊log₂(n/(filterLen-1))⌋
+func DwtMaxLevel(n, filterLen int) int {
+	if filterLen < 2 || n < filterLen-1 {
+		return 0
+	}
+	return int(math.Floor(math.Log2(float64(n) / float64(filterLen-1))))
+}
+
+// DwtLen returns the number of coefficients (approximation or detail) obtained with Dwt1d for a
+// signal with n samples
+func DwtLen(n, filterLen, mode int) int {
+	if mode == DwtPeriodization {
+		return (n + 1) / 2
+	}
+	return (n + filterLen - 1) / 2
+}
+
+// Dwt1d computes the (single level) discrete wavelet transform of x
+//
+//	Input:
+//	  x    -- signal
+//	  w    -- wavelet
+//	  mode -- boundary mode; e.g. DwtSymmetric or DwtPeriodization
+//	Output:
+//	  a -- approximation coefficients: len(a) = DwtLen(len(x), w.Len(), mode)
+//	  d -- detail coefficients: len(d) = len(a)
+//
+//	NOTE: with all modes but DwtPeriodization the number of coefficients is ⌊(n+L-1)/2⌋ which
+//	      allows the perfect reconstruction of any signal of length n
+func Dwt1d(x []float64, w *Wavelet, mode int) (a, d []float64) {
+	n, L := len(x), w.Len()
+	if n < 1 {
+		chk.Panic("signal must have at least one sample\n")
+	}
+	if mode == DwtPeriodization {
+		xp := x
+		if n%2 == 1 {
+			xp = append(append(make([]float64, 0, n+1), x...), x[n-1])
+		}
+		np := len(xp)
+		m, s := np/2, L/2
+		a, d = make([]float64, m), make([]float64, m)
+		for i := 0; i < m; i++ {
+			for j := 0; j < L; j++ {
+				v := xp[modulo(2*i+s-j, np)]
+				a[i] += w.DecLo[j] * v
+				d[i] += w.DecHi[j] * v
+			}
+		}
+		return
+	}
+	m := DwtLen(n, L, mode)
+	a, d = make([]float64, m), make([]float64, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < L; j++ {
+			v := dwtExtend(x, 2*i+1-j, mode)
+			a[i] += w.DecLo[j] * v
+			d[i] += w.DecHi[j] * v
+		}
+	}
+	return
+}
+
+// Idwt1d computes the (single level) inverse discrete wavelet transform
+//
+//	Input:
+//	  a    -- approximation coefficients [may be nil => zero]
+//	  d    -- detail coefficients [may be nil => zero]
+//	  w    -- wavelet
+//	  mode -- boundary mode used in Dwt1d
+//	Output:
+//	  x -- reconstructed signal with length 2⋅len(a)-L+2 or 2⋅len(a) if mode == DwtPeriodization.
+//	       Depending on the parity of the original signal, x may have one extra sample at the end
+func Idwt1d(a, d []float64, w *Wavelet, mode int) (x []float64) {
+	m := len(a)
+	if a == nil {
+		m = len(d)
+	}
+	if d != nil && len(d) != m {
+		chk.Panic("the numbers of approximation and detail coefficients must be equal. %d != %d\n", m, len(d))
+	}
+	L := w.Len()
+	coef := func(c []float64, i int) float64 {
+		if c == nil {
+			return 0
+		}
+		return c[i]
+	}
+	if mode == DwtPeriodization {
+		n, s := 2*m, L/2
+		x = make([]float64, n)
+		for i := 0; i < m; i++ {
+			ai, di := coef(a, i), coef(d, i)
+			for j := 0; j < L; j++ {
+				k := modulo(j-(L-1)+s+2*i, n)
+				x[k] += ai*w.RecLo[j] + di*w.RecHi[j]
+			}
+		}
+		return
+	}
+	n := 2*m - L + 2
+	if n < 1 {
+		chk.Panic("the number of coefficients (%d) is too small for filters of length %d\n", m, L)
+	}
+	x = make([]float64, n)
+	for k := 0; k < n; k++ {
+		p := k + L - 2
+		for i := utl.Imax(0, (p-L+2)/2); i < m; i++ {
+			j := p - 2*i
+			if j < 0 {
+				break
+			}
+			if j < L {
+				x[k] += coef(a, i)*w.RecLo[j] + coef(d, i)*w.RecHi[j]
+			}
+		}
+	}
+	return
+}
+
+// Wavedec1d computes the multilevel discrete wavelet decomposition of x
+//
+//	Input:
+//	  level -- number of levels; use level ≤ 0 for DwtMaxLevel(len(x), w.Len())
+//	Output:
+//	  coefs -- [a_level, d_level, d_level-1, …, d₁]; i.e. the coarsest approximation followed by
+//	           the details from coarsest to finest
+func Wavedec1d(x []float64, w *Wavelet, mode, level int) (coefs [][]float64) {
+	if level <= 0 {
+		level = utl.Imax(1, DwtMaxLevel(len(x), w.Len()))
+	}
+	coefs = make([][]float64, level+1)
+	a := x
+	for lev := level; lev > 0; lev-- {
+		var d []float64
+		a, d = Dwt1d(a, w, mode)
+		coefs[lev] = d
+	}
+	coefs[0] = a
+	return
+}
+
+// Waverec1d computes the multilevel inverse discrete wavelet transform
+//
+//	Input:
+//	  coefs -- coefficients computed by Wavedec1d; i.e. [a_level, d_level, …, d₁]
+//	Output:
+//	  x -- reconstructed signal. It may have one extra sample at the end if the length of the
+//	       original signal is odd
+func Waverec1d(coefs [][]float64, w *Wavelet, mode int) (x []float64) {
+	if len(coefs) < 2 {
+		chk.Panic("at least two sets of coefficients (approximation and detail) are required\n")
+	}
+	x = coefs[0]
+	for lev := 1; lev < len(coefs); lev++ {
+		d := coefs[lev]
+		if len(x) == len(d)+1 { // odd length at the previous level
+			x = x[:len(d)]
+		}
+		x = Idwt1d(x, d, w, mode)
+	}
+	return
+}
+
+// Dwt2d computes the (single level) 2D discrete wavelet transform of the matrix x
+//
+//	The rows are transformed first and then the columns; thus:
+//	  a -- approximation: low-pass along rows and columns
+//	  h -- horizontal details: low-pass along rows (j) and high-pass along columns (i)
+//	  v -- vertical details: high-pass along rows (j) and low-pass along columns (i)
+//	  d -- diagonal details: high-pass along rows and columns
+func Dwt2d(x *la.Matrix, w *Wavelet, mode int) (a, h, v, d *la.Matrix) {
+	m, n := x.M, x.N
+	nc := DwtLen(n, w.Len(), mode)
+	mr := DwtLen(m, w.Len(), mode)
+
+	// rows
+	lo, hi := la.NewMatrix(m, nc), la.NewMatrix(m, nc)
+	row := make([]float64, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			row[j] = x.Get(i, j)
+		}
+		rlo, rhi := Dwt1d(row, w, mode)
+		for j := 0; j < nc; j++ {
+			lo.Set(i, j, rlo[j])
+			hi.Set(i, j, rhi[j])
+		}
+	}
+
+	// columns
+	a, h = dwt2dCols(lo, w, mode, mr)
+	v, d = dwt2dCols(hi, w, mode, mr)
+	return
+}
+
+// Idwt2d computes the (single level) 2D inverse discrete wavelet transform
+//
+//	NOTE: any of h, v or d may be nil (=> zero); a may also be nil if h, v or d is given.
+//	      The results may have one extra row and/or column; see Idwt1d
+func Idwt2d(a, h, v, d *la.Matrix, w *Wavelet, mode int) (x *la.Matrix) {
+	var ref *la.Matrix
+	for _, c := range []*la.Matrix{a, h, v, d} {
+		if c != nil {
+			if ref != nil && (c.M != ref.M || c.N != ref.N) {
+				chk.Panic("all coefficient matrices must have the same dimensions\n")
+			}
+			ref = c
+		}
+	}
+	if ref == nil {
+		chk.Panic("at least one set of coefficients must be given\n")
+	}
+
+	// columns
+	lo := idwt2dCols(a, h, ref.M, ref.N, w, mode)
+	hi := idwt2dCols(v, d, ref.M, ref.N, w, mode)
+
+	// rows
+	m := lo.M
+	var rlo, rhi []float64
+	for i := 0; i < m; i++ {
+		rlo, rhi = lo.GetRow(i), hi.GetRow(i)
+		row := Idwt1d(rlo, rhi, w, mode)
+		if x == nil {
+			x = la.NewMatrix(m, len(row))
+		}
+		for j := 0; j < len(row); j++ {
+			x.Set(i, j, row[j])
+		}
+	}
+	return
+}
+
+// Wavedec2d computes the multilevel 2D discrete wavelet decomposition of x
+//
+//	Input:
+//	  level -- number of levels; use level ≤ 0 for the maximum level according to the smallest
+//	           dimension of x
+//	Output:
+//	  a       -- approximation at the coarsest level
+//	  details -- [level][3] (h, v, d) details from coarsest (details[0]) to finest (details[level-1])
+func Wavedec2d(x *la.Matrix, w *Wavelet, mode, level int) (a *la.Matrix, details [][3]*la.Matrix) {
+	if level <= 0 {
+		level = utl.Imax(1, DwtMaxLevel(utl.Imin(x.M, x.N), w.Len()))
+	}
+	details = make([][3]*la.Matrix, level)
+	a = x
+	for lev := level - 1; lev >= 0; lev-- {
+		var h, v, d *la.Matrix
+		a, h, v, d = Dwt2d(a, w, mode)
+		details[lev] = [3]*la.Matrix{h, v, d}
+	}
+	return
+}
+
+// Waverec2d computes the multilevel 2D inverse discrete wavelet transform; see Wavedec2d
+func Waverec2d(a *la.Matrix, details [][3]*la.Matrix, w *Wavelet, mode int) (x *la.Matrix) {
+	x = a
+	for _, det := range details {
+		var ref *la.Matrix
+		for _, c := range det {
+			if c != nil {
+				ref = c
+				break
+			}
+		}
+		if ref != nil && (x.M > ref.M || x.N > ref.N) { // odd dimensions at the previous level
+			y := la.NewMatrix(ref.M, ref.N)
+			for i := 0; i < ref.M; i++ {
+				for j := 0; j < ref.N; j++ {
+					y.Set(i, j, x.Get(i, j))
+				}
+			}
+			x = y
+		}
+		x = Idwt2d(x, det[0], det[1], det[2], w, mode)
+	}
+	return
+}
+
+// thresholding and denoising ///////////////////////////////////////////////////////////////////////
+
+// DwtThreshold applies a soft or hard threshold to the coefficients c (in-place)
+//
+//	hard: c = c        if |c| > thr   else 0
+//	soft: c = sign(c)⋅(|c| - thr) if |c| > thr else 0
+func DwtThreshold(c []float64, thr float64, soft bool) {
+	for i, v := range c {
+		if math.Abs(v) <= thr {
+			c[i] = 0
+		} else if soft {
+			c[i] = Sign(v) * (math.Abs(v) - thr)
+		}
+	}
+}
+
+// DwtUniversalThreshold returns the universal threshold of Donoho and Johnstone [1]
+//
+//	thr = σ √(2 log n)   with   σ = median(|d|) / 0.6745
+//
+//	where d are the finest detail coefficients and n is the number of samples of the signal
+//
+//	References:
+//	[1] Donoho DL, Johnstone IM (1994) Ideal spatial adaptation by wavelet shrinkage.
+//	    Biometrika, 81(3):425-455
+func DwtUniversalThreshold(d []float64, n int) float64 {
+	if len(d) == 0 {
+		return 0
+	}
+	abs := make([]float64, len(d))
+	for i, v := range d {
+		abs[i] = math.Abs(v)
+	}
+	sort.Float64s(abs)
+	k := len(abs) / 2
+	med := abs[k]
+	if len(abs)%2 == 0 {
+		med = (abs[k-1] + abs[k]) / 2
+	}
+	σ := med / 0.6745
+	return σ * math.Sqrt(2*math.Log(float64(n)))
+}
+
+// DwtDenoise1d denoises a signal by thresholding its detail coefficients with the universal
+// threshold (see DwtUniversalThreshold)
+//
+//	Input:
+//	  x     -- noisy signal
+//	  level -- number of levels; use level ≤ 0 for the maximum level
+//	  soft  -- use soft thresholding; otherwise hard thresholding
+//	Output:
+//	  y -- denoised signal with len(y) = len(x)
+func DwtDenoise1d(x []float64, w *Wavelet, mode, level int, soft bool) (y []float64) {
+	coefs := Wavedec1d(x, w, mode, level)
+	thr := DwtUniversalThreshold(coefs[len(coefs)-1], len(x))
+	for lev := 1; lev < len(coefs); lev++ {
+		DwtThreshold(coefs[lev], thr, soft)
+	}
+	return Waverec1d(coefs, w, mode)[:len(x)]
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// modulo returns the non-negative remainder of i/n
+func modulo(i, n int) int {
+	r := i % n
+	if r < 0 {
+		r += n
+	}
+	return r
+}
+
+// dwtExtend returns x[k] extended beyond the boundaries according to mode
+func dwtExtend(x []float64, k, mode int) float64 {
+	n := len(x)
+	if k >= 0 && k < n {
+		return x[k]
+	}
+	switch mode {
+	case DwtZero:
+		return 0
+	case DwtConstant:
+		if k < 0 {
+			return x[0]
+		}
+		return x[n-1]
+	case DwtSymmetric:
+		k = modulo(k, 2*n)
+		if k < n {
+			return x[k]
+		}
+		return x[2*n-1-k]
+	case DwtReflect:
+		if n == 1 {
+			return x[0]
+		}
+		k = modulo(k, 2*n-2)
+		if k < n {
+			return x[k]
+		}
+		return x[2*n-2-k]
+	case DwtPeriodic:
+		return x[modulo(k, n)]
+	}
+	chk.Panic("boundary mode %d is invalid\n", mode)
+	return 0
+}
+
+// dwt2dCols applies Dwt1d to the columns of c
+func dwt2dCols(c *la.Matrix, w *Wavelet, mode, mr int) (lo, hi *la.Matrix) {
+	lo, hi = la.NewMatrix(mr, c.N), la.NewMatrix(mr, c.N)
+	for j := 0; j < c.N; j++ {
+		clo, chi := Dwt1d(c.GetCol(j), w, mode)
+		for i := 0; i < mr; i++ {
+			lo.Set(i, j, clo[i])
+			hi.Set(i, j, chi[i])
+		}
+	}
+	return
+}
+
+// idwt2dCols applies Idwt1d to the columns of a and d (any may be nil)
+func idwt2dCols(a, d *la.Matrix, m, n int, w *Wavelet, mode int) (x *la.Matrix) {
+	var ca, cd []float64
+	for j := 0; j < n; j++ {
+		ca, cd = nil, nil
+		if a != nil {
+			ca = a.GetCol(j)
+		}
+		if d != nil {
+			cd = d.GetCol(j)
+		}
+		if ca == nil && cd == nil {
+			ca = make([]float64, m)
+		}
+		col := Idwt1d(ca, cd, w, mode)
+		if x == nil {
+			x = la.NewMatrix(len(col), n)
+		}
+		for i := 0; i < len(col); i++ {
+			x.Set(i, j, col[i])
+		}
+	}
+	return
+}
+
+// filters /////////////////////////////////////////////////////////////////////////////////////////
+
+// waveletFilters holds the (decomposition) low-pass filters normalised such that Σh = √2
+var waveletFilters = map[string][]float64{
+	"db1": {
+		0.70710678118654757, 0.70710678118654757,
+	},
+	"db2": {
+		-0.12940952255126037, 0.22414386804201339, 0.83651630373780794, 0.48296291314453416,
+	},
+	"db3": {
+		0.035226291885709533, -0.085441273882026658, -0.13501102001025458, 0.45987750211849154,
+		0.80689150931109255, 0.33267055295008263,
+	},
+	"db4": {
+		-0.010597401785069032, 0.032883011666885197, 0.030841381835560764,
+		-0.18703481171909309, -0.027983769416859854, 0.63088076792985892, 0.71484657055291567,
+		0.23037781330889651,
+	},
+	"db5": {
+		0.0033357252854737712, -0.012580751999081999, -0.0062414902127982744,
+		0.077571493840045719, -0.032244869584638375, -0.24229488706638203, 0.13842814590132074,
+		0.72430852843777294, 0.60382926979718965, 0.16010239797419293,
+	},
+	"db6": {
+		-0.0010773010853084796, 0.0047772575109455108, 0.00055384220116149613,
+		-0.03158203931748603, 0.027522865530305727, 0.097501605587323043, -0.12976686756726194,
+		-0.22626469396543983, 0.31525035170919763, 0.75113390802109536, 0.49462389039845306,
+		0.11154074335010947,
+	},
+	"db7": {
+		0.00035371379997452024, -0.0018016407040474908, 0.00042957797292136651,
+		0.01255099855609984, -0.016574541630666881, -0.038029936935014413,
+		0.080612609151083078, 0.071309219266830259, -0.22403618499387498, -0.14390600392856498,
+		0.46978228740519312, 0.72913209084623509, 0.39653931948191729, 0.077852054085009184,
+	},
+	"db8": {
+		-0.00011747678412476953, 0.00067544940645056933, -0.00039174037337694705,
+		-0.0048703529934515741, 0.0087460940474057766, 0.013981027917398282,
+		-0.044088253930794755, -0.017369301001807547, 0.12874742662047847,
+		0.00047248457391328279, -0.28401554296154691, -0.015829105256349306,
+		0.58535468365420673, 0.67563073629728976, 0.31287159091429995, 0.054415842243104008,
+	},
+	"db9": {
+		3.9347320316271603e-05, -0.00025196318894271012, 0.00023038576352319597,
+		0.0018476468830562265, -0.0042815036824634303, -0.0047232047577513972,
+		0.022361662123679096, 0.00025094711483145197, -0.067632829061329974,
+		0.03072568147933338, 0.14854074933810638, -0.096840783222976456, -0.29327378327917492,
+		0.13319738582500756, 0.65728807805130052, 0.60482312369011115, 0.24383467461259034,
+		0.038077947363878345,
+	},
+	"db10": {
+		-1.3264202894521244e-05, 9.3588670320069592e-05, -0.00011646685512928545,
+		-0.00068585669495971162, 0.0019924052951850561, 0.0013953517470529011,
+		-0.010733175483330575, 0.0036065535669561697, 0.033212674059341002,
+		-0.029457536821875813, -0.071394147166397082, 0.093057364603572348,
+		0.12736934033579325, -0.19594627437737705, -0.24984642432731538, 0.28117234366057747,
+		0.68845903945360354, 0.52720118893172563, 0.1881768000776915, 0.026670057900555554,
+	},
+	"sym2": {
+		-0.12940952255126037, 0.22414386804201339, 0.83651630373780794, 0.48296291314453416,
+	},
+	"sym3": {
+		0.035226291885709533, -0.085441273882026658, -0.13501102001025458, 0.45987750211849154,
+		0.80689150931109255, 0.33267055295008263,
+	},
+	"sym4": {
+		0.032223100604051466, -0.012603967262031304, -0.099219543576633526,
+		0.29785779560530606, 0.8037387518051321, 0.49761866763277501, -0.029635527646002493,
+		-0.075765714789502212,
+	},
+	"sym5": {
+		0.027333068344998768, 0.029519490925706261, -0.039134249302313844, 0.19939753397685558,
+		0.72340769040404074, 0.63397896345679206, 0.016602105764510849, -0.17532808990805623,
+		-0.021101834024689042, 0.019538882735249827,
+	},
+	"sym6": {
+		0.015404109327044824, 0.0034907120842221626, -0.11799011114852002,
+		-0.048311742585698057, 0.49105594192797375, 0.78764114102865102, 0.33792942172816581,
+		-0.072637522786376585, -0.021060292512370848, 0.044724901770781388,
+		0.0017677118642540077, -0.0078007083250323803,
+	},
+	"sym7": {
+		0.012015419283549189, 0.017213376300804502, -0.064908003547188481,
+		-0.064131289807385819, 0.3602184609062602, 0.78192159329172817, 0.48361091568226772,
+		-0.056804476889666972, -0.1010109208684203, 0.044742349468352378, 0.020464207577546033,
+		-0.018126605131338461, -0.0032832978474668108, 0.0022918339540537714,
+	},
+	"sym8": {
+		-0.0033824159510050028, -0.00054213233180001072, 0.031695087811525989,
+		0.0076074873249766086, -0.14329423835127267, -0.061273359067811076,
+		0.48135965125905339, 0.777185751699628, 0.36444189483617895, -0.051945838107881802,
+		-0.027219029917103486, 0.04913717967373029, 0.0038087520138944896,
+		-0.014952258337062199, -0.00030292051472413309, 0.0018899503327676891,
+	},
+	"sym9": {
+		0.0014009155259146562, 0.00061978088898550707, -0.013271967781817134,
+		-0.011528210207679187, 0.030224878858275187, 0.00058346274612498187,
+		-0.054568958430833349, 0.23876091460730517, 0.71789708276441244, 0.61733844914093416,
+		0.035272488035271041, -0.19155083129728434, -0.018233770779395506,
+		0.062077789302885746, 0.0088592674934002674, -0.010264064027633121,
+		-0.00047315449868004354, 0.001069490032908612,
+	},
+	"sym10": {
+		0.00086257822622597244, 0.00071542054205433971, -0.0070567640625873044,
+		0.00059568278374251906, 0.049686126646942878, 0.026240365058448987,
+		-0.12155210554854895, -0.015019238839137859, 0.51370987334802631, 0.76695483656060959,
+		0.34021601302346216, -0.087878711511975141, -0.067089907808381796,
+		0.033842354663575221, -0.00086875210968925809, -0.02300546135349751,
+		-0.0011404297952173285, 0.0050716491985317988, 0.00034014926631480987,
+		-0.00041011591580439831,
+	},
+	"cdf97a": {
+		0.037828455506995463, -0.023849465019380001, -0.1106244044184234, 0.37740285561265374,
+		0.85269867900940344, 0.37740285561265374, -0.1106244044184234, -0.023849465019380001,
+		0.037828455506995463,
+	},
+	"cdf97s": {
+		-0.064538882628938435, -0.040689417609558437, 0.41809227322221221, 0.78848561640566439,
+		0.41809227322221221, -0.040689417609558437, -0.064538882628938435,
+	},
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// Zeta computes the Riemann zeta function ζ(s) for real s ≠ 1
+//
+//	          ∞
+//	 ζ(s) =   Σ  1 / kˢ        (s > 1; analytically continued otherwise)
+//	         k=1
+//
+//	For s < 0, the functional equation is used:
+//
+//	 ζ(s) = 2ˢ ⋅ πˢ⁻¹ ⋅ sin(π⋅s/2) ⋅ Γ(1-s) ⋅ ζ(1-s)
+//
+//	Special cases:
+//	  s = 1  ⇒  NaN (pole)
+func Zeta(s float64) float64 {
+	if s == 1 {
+		return math.NaN()
+	}
+	if s < 0 {
+		if math.Mod(s, 2) == 0 {
+			return 0 // trivial zeros
+		}
+		return math.Pow(2.0, s) * math.Pow(π, s-1.0) * math.Sin(π*s/2.0) * math.Gamma(1.0-s) * Zeta(1.0-s)
+	}
+	return zetaEM(s, 1)
+}
+
+// HurwitzZeta computes the Hurwitz zeta function ζ(s,q) for s > 1 and q > 0
+//
+//	             ∞
+//	 ζ(s, q) =   Σ  1 / (k + q)ˢ
+//	            k=0
+//
+//	NOTE: ζ(s,1) is the Riemann zeta function
+func HurwitzZeta(s, q float64) float64 {
+	if s <= 1 {
+		chk.Panic("HurwitzZeta requires s > 1. s=%g is invalid", s)
+	}
+	if q <= 0 {
+		chk.Panic("HurwitzZeta requires q > 0. q=%g is invalid", q)
+	}
+	return zetaEM(s, q)
+}
+
+// zetaEM computes ζ(s,q) for s ≥ 0, s ≠ 1 and q > 0 using the Euler-Maclaurin summation formula.
+// The algorithm follows the Cephes Math Library zeta.c by Stephen L. Moshier
+func zetaEM(s, q float64) float64 {
+	res := math.Pow(q, -s)
+	a := q
+	b := 0.0
+	for i := 0; i < 9 || a <= 9.0; i++ {
+		a += 1.0
+		b = math.Pow(a, -s)
+		res += b
+		if math.Abs(b/res) < machEps {
+			return res
+		}
+	}
+	w := a
+	res += b * w / (s - 1.0)
+	res -= 0.5 * b
+	fac := 1.0
+	k := 0.0
+	var t float64
+	for i := 0; i < len(zetaA); i++ {
+		fac *= s + k
+		b /= w
+		t = fac * b / zetaA[i]
+		res += t
+		if math.Abs(t/res) < machEps {
+			break
+		}
+		k += 1.0
+		fac *= s + k
+		b /= w
+		k += 1.0
+	}
+	return res
+}
+
+// zetaA holds the (2k)!/B2k coefficients of the Euler-Maclaurin formula; B2k are the Bernoulli numbers
+var zetaA = []float64{
+	12.0,
+	-720.0,
+	30240.0,
+	-1209600.0,
+	47900160.0,
+	-1.8924375803183791606e9, // 1.307674368e12/691
+	7.47242496e10,
+	-2.950130727918164224e12,  // 1.067062284288e16/3617
+	1.1646782814350067249e14,  // 5.109094217170944e18/43867
+	-4.5979787224074726105e15, // 8.028398867555533e21/174611
+	1.8152105401943546773e17,  // 1.5511210043330985984e25/854513
+	-7.1661652561756670113e18, // 1.6938241367317436694528e27/236364091
+}
@@ -80,6 +80,28 @@ func MatSvd(s []float64, u, vt, a *Matrix, copyA bool) {
 	oblas.Dgesvd('A', 'A', a.M, a.N, acpy.Data, a.M, s, u.Data, a.M, vt.Data, a.N, superb)
 }
 
+// MatSvdC performs the SVD decomposition of a complex matrix
+//   Input:
+//     a     -- matrix a
+//     copyA -- creates a copy of a; otherwise 'a' is modified
+//   Output:
+//     s  -- diagonal terms [must be pre-allocated] len(s) = imin(a.M, a.N)
+//     u  -- left matrix [must be pre-allocated] u is (a.M x a.M). If u == nil, it is not computed
+//     vt -- conjugate transposed right matrix [must be pre-allocated] vt is (a.N x a.N)
+func MatSvdC(s []float64, u, vt, a *MatrixC, copyA bool) {
+	superb := make([]float64, utl.Imin(a.M, a.N))
+	acpy := a
+	if copyA {
+		acpy = a.GetCopy()
+	}
+	if u == nil {
+		dummy := []complex128{0}
+		oblas.Zgesvd('N', 'A', a.M, a.N, acpy.Data, a.M, s, dummy, 1, vt.Data, a.N, superb)
+		return
+	}
+	oblas.Zgesvd('A', 'A', a.M, a.N, acpy.Data, a.M, s, u.Data, a.M, vt.Data, a.N, superb)
+}
+
 // MatInv computes the inverse of a general matrix (square or not). It also computes the
 // pseudo-inverse if the matrix is not square.
 //   Input:
@@ -119,6 +119,11 @@ func (o *Triplet) Size() (m, n int) {
 	return o.m, o.n
 }
 
+// Indices returns the row and column indices of the items inserted so far (not copies)
+func (o *Triplet) Indices() (I, J []int) {
+	return o.i[:o.pos], o.j[:o.pos]
+}
+
 // ToDense returns the dense matrix corresponding to this Triplet
 func (o *Triplet) ToDense() (a *Matrix) {
 	a = NewMatrix(o.m, o.n)
@@ -6,6 +6,7 @@ package la
 
 import (
 	"math"
+	"math/cmplx"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -118,6 +119,81 @@ func checkSvd(tst *testing.T, k string, a *Matrix, correctS []float64, correctU,
 	chk.Array(tst, k+": u⋅s⋅vt", tolUsv, usv.Data, a.Data)
 }
 
+func checkSvdC(tst *testing.T, k string, a *MatrixC, correctS []float64, tolS, tolUsv float64) {
+
+	// compute SVD
+	s := make([]float64, utl.Imin(a.M, a.N))
+	u := NewMatrixC(a.M, a.M)
+	vt := NewMatrixC(a.N, a.N)
+	MatSvdC(s, u, vt, a, true)
+
+	// compare results
+	if correctS != nil {
+		chk.Array(tst, k+": s", tolS, s, correctS)
+	}
+
+	// check u⋅s⋅vt
+	usv := NewMatrixC(a.M, a.N)
+	for i := 0; i < a.M; i++ {
+		for j := 0; j < a.N; j++ {
+			for k := 0; k < len(s); k++ {
+				usv.Add(i, j, u.Get(i, k)*complex(s[k], 0)*vt.Get(k, j))
+			}
+		}
+	}
+	chk.ArrayC(tst, k+": u⋅s⋅vt", tolUsv, usv.Data, a.Data)
+
+	// check vt⋅vtᴴ = I
+	for i := 0; i < a.N; i++ {
+		for j := 0; j < a.N; j++ {
+			var res complex128
+			for k := 0; k < a.N; k++ {
+				res += vt.Get(i, k) * cmplx.Conj(vt.Get(j, k))
+			}
+			if i == j {
+				chk.Complex128(tst, k+": vt⋅vtᴴ", tolUsv, res, 1)
+			} else {
+				chk.Complex128(tst, k+": vt⋅vtᴴ", tolUsv, res, 0)
+			}
+		}
+	}
+
+	// without u
+	s2 := make([]float64, len(s))
+	MatSvdC(s2, nil, vt, a, true)
+	chk.Array(tst, k+": s (without u)", 1e-15, s2, s)
+}
+
+func TestMatSvd02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MatSvd02. SVD decomposition of complex matrices")
+
+	// 3 x 2
+	a := NewMatrixDeep2c([][]complex128{
+		{3, 0},
+		{0, 4i},
+		{0, 0},
+	})
+	checkSvdC(tst, "a", a, []float64{4, 3}, 1e-15, 1e-15)
+
+	// 2 x 2
+	b := NewMatrixDeep2c([][]complex128{
+		{1, 1i},
+		{1i, 1},
+	})
+	checkSvdC(tst, "b", b, []float64{math.Sqrt2, math.Sqrt2}, 1e-15, 1e-15)
+
+	// 4 x 3
+	c := NewMatrixDeep2c([][]complex128{
+		{1 + 1i, 2, 0.5},
+		{0, 3i, 1 - 2i},
+		{4, -1i, 2},
+		{1, 1, 1i},
+	})
+	checkSvdC(tst, "c", c, nil, 1e-15, 1e-14)
+}
+
 func TestMatInv01(tst *testing.T) {
 
 	//verbose()
@@ -33,6 +33,10 @@ func TestTriplet01(tst *testing.T) {
 	io.Pf("%v\n", l)
 	chk.String(tst, l, " 0 2 0 0\n 1 0 4 0\n 0 0 0 5\n 0 3 0 6")
 
+	I, J := a.Indices()
+	chk.Ints(tst, "I", I, []int{1, 0, 3, 1, 2, 3})
+	chk.Ints(tst, "J", J, []int{0, 1, 1, 2, 3, 3})
+
 	a.ToMatrix(nil).WriteSmat("/tmp/gosl/la", "triplet01", 0, "%23.15e", false, false)
 	d := io.ReadFile("/tmp/gosl/la/triplet01.smat")
 	io.Pforan("d = %v\n", string(d))
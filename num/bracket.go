@@ -26,8 +26,9 @@ import (
 type Bracket struct {
 
 	// configuration
-	MaxIt   int  // max iterations
-	Verbose bool // show messages
+	MaxIt    int  // max iterations
+	MaxLevel int  // max number of recursive subdivisions of each subinterval when scanning for roots
+	Verbose  bool // show messages
 
 	// statistics
 	NumFeval int // number of calls to Ffcn (function evaluations)
@@ -44,6 +45,7 @@ type Bracket struct {
 func NewBracket(ffcn fun.Ss) (o *Bracket) {
 	o = new(Bracket)
 	o.MaxIt = 500
+	o.MaxLevel = 10
 	o.ffcn = ffcn
 	o.gold = (1.0 + math.Sqrt(5.0)) / 2.0
 	o.tiny = math.Sqrt(MACHEPS)
@@ -178,3 +180,103 @@ func (o *Bracket) Min(a0, b0 float64) (a, b, c, fa, fb, fc float64) {
 	chk.Panic("fail to converge after %d iterations", o.NumIter)
 	return
 }
+
+// Root brackets a root by expanding outward from a single guess
+//
+//	Starting with [x0-h, x0+h], the bound with the smallest |f| is moved away from the other one
+//	by the golden ratio times the width of the interval until f(a) and f(b) have opposite signs [1].
+//	Returns the bracket with a < b and the function values at a and b. If f(a) = 0 or f(b) = 0,
+//	the root is at a or b.
+//
+//	h -- initial half-width of the interval; use h = 0 to select h = 0.1⋅max(1, |x0|)
+//
+//	NOTE: roots of even multiplicity (e.g. tangent to the x-axis) cannot be bracketed
+func (o *Bracket) Root(x0, h float64) (a, b, fa, fb float64) {
+	if h <= 0 {
+		h = 0.1 * math.Max(1.0, math.Abs(x0))
+	}
+	a, b = x0-h, x0+h
+	fa, fb = o.ffcn(a), o.ffcn(b)
+	o.NumFeval = 2
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+		if o.Verbose {
+			io.Pf("%4d%23.15e%23.15e%23.15e%23.15e\n", o.NumIter, a, b, fa, fb)
+		}
+		if fa*fb <= 0 {
+			return
+		}
+		if math.Abs(fa) < math.Abs(fb) {
+			a += o.gold * (a - b)
+			fa = o.ffcn(a)
+		} else {
+			b += o.gold * (b - a)
+			fb = o.ffcn(b)
+		}
+		o.NumFeval++
+	}
+	chk.Panic("cannot bracket a root starting from x0=%g after %d iterations. [%g, %g] is the last interval\n", x0, o.NumIter, a, b)
+	return
+}
+
+// Roots finds all sign changes of f(x) in [xa, xb] by adaptive subdivision
+//
+//	The interval is divided into n subintervals; a subinterval is bisected recursively (up to
+//	MaxLevel times) when f(x) changes sign within it or when the second difference fa - 2⋅fm + fb
+//	is not smaller than the smallest |f| at the ends and midpoint; i.e. when the curvature of f may
+//	hide two nearby roots (or a root next to an exact root). Returns the list of brackets {a, b}
+//	sorted in ascending order. Exact roots found at the sampled points are returned as {x, x}.
+//
+//	NOTE: roots of even multiplicity are not found unless f vanishes exactly at a sampled point;
+//	      roots closer than (xb-xa)/(n⋅2^MaxLevel) to each other may be missed
+func (o *Bracket) Roots(xa, xb float64, n int) (brackets [][]float64) {
+	if xb <= xa || n < 1 {
+		chk.Panic("xa=%g must be smaller than xb=%g and n=%d must be positive\n", xa, xb, n)
+	}
+	o.NumFeval = 0
+	dx := (xb - xa) / float64(n)
+	a := xa
+	fa := o.ffcn(a)
+	o.NumFeval++
+	if fa == 0 {
+		brackets = append(brackets, []float64{a, a})
+	}
+	for i := 1; i <= n; i++ {
+		b := xa + float64(i)*dx
+		if i == n {
+			b = xb
+		}
+		fb := o.ffcn(b)
+		o.NumFeval++
+		brackets = o.scan(brackets, a, b, fa, fb, 0)
+		if fb == 0 {
+			brackets = append(brackets, []float64{b, b})
+		}
+		a, fa = b, fb
+	}
+	if o.Verbose {
+		io.Pf("%d brackets found with %d function evaluations\n", len(brackets), o.NumFeval)
+	}
+	return
+}
+
+// scan appends the brackets of roots in (a, b) to the list; see Roots
+func (o *Bracket) scan(brackets [][]float64, a, b, fa, fb float64, level int) [][]float64 {
+	if fa*fb < 0 {
+		return append(brackets, []float64{a, b})
+	}
+	if level == o.MaxLevel {
+		return brackets
+	}
+	m := (a + b) / 2.0
+	fm := o.ffcn(m)
+	o.NumFeval++
+	fmin := math.Min(math.Abs(fm), math.Min(math.Abs(fa), math.Abs(fb)))
+	if fa*fm < 0 || fm*fb < 0 || fmin <= math.Abs(fa-2.0*fm+fb) {
+		brackets = o.scan(brackets, a, m, fa, fm, level+1)
+		if fm == 0 {
+			brackets = append(brackets, []float64{m, m})
+		}
+		brackets = o.scan(brackets, m, b, fm, fb, level+1)
+	}
+	return brackets
+}
@@ -0,0 +1,638 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// ContPoint holds a solution point (x, λ) computed by Continuation
+type ContPoint struct {
+	X       la.Vector // solution [n]
+	Lambda  float64   // parameter λ
+	S       float64   // approximate arclength from the starting point (sum of chords)
+	Ds      float64   // step size used to reach this point
+	Niter   int       // number of corrector iterations
+	DetJ    float64   // det(Fx)
+	DetA    float64   // det(A) where A = [[Fx, Fλ], [tᵀ]] is the augmented Jacobian
+	Tangent la.Vector // unit tangent t = (dx/ds, dλ/ds) [n+1]
+	Kind    string    // "start", "regular", "fold" or "branch"
+}
+
+// Continuation traces curves of solutions of F(x, λ) = 0, where F and x have n components and λ
+// is a parameter; e.g. load-displacement curves with the load factor λ. Methods:
+//
+//	"natural"   -- natural-parameter continuation: λ is incremented by ds and F(x, λ) = 0 is
+//	               solved for x, starting from the tangent predictor. Cannot pass folds
+//	"arclength" -- pseudo-arclength continuation [1,2]: u = (x, λ) is the unknown and the system
+//	               is augmented by tᵀ⋅(u - u₀) = ds, where t is the unit tangent at the previous
+//	               point u₀. Folds (limit points) are passed without difficulty
+//
+//	The step size is adapted according to the number of corrector (Newton) iterations. Steps with
+//	a failed corrector or with a sharp turn of the tangent are rejected and the step is halved.
+//
+//	Special points are detected by sign changes of the determinants of the Jacobian matrices:
+//
+//	"fold"   -- det(Fx) changes sign but det(A) does not, where A = [[Fx, Fλ], [tᵀ]]; note that
+//	            dλ/ds = det(Fx)/det(A) changes sign
+//	"branch" -- det(A) changes sign (simple bifurcation point). With the natural-parameter method,
+//	            any sign change of det(Fx) indicates a branch point
+//
+//	If Locate is set, special points are located by the Illinois (regula falsi) method applied to
+//	dλ/ds (folds) or to the determinant (branch points). The secondary branch passing through a
+//	branch point can be traced by SwitchBranch.
+//
+//	NOTE: the determinants are computed by the LU factorisation and may overflow for large n
+//
+//	References:
+//	  [1] Keller HB (1977) Numerical solution of bifurcation and nonlinear eigenvalue problems. In:
+//	      Rabinowitz PH (editor) Applications of Bifurcation Theory. Academic Press. pp 359-384
+//	  [2] Allgower EL, Georg K (2003) Introduction to Numerical Continuation Methods. SIAM. 388p
+type Continuation struct {
+
+	// configuration
+	Method   string                         // "arclength" (pseudo-arclength) or "natural" (natural-parameter)
+	Ds       float64                        // initial step size; its sign gives the initial direction of λ
+	DsMin    float64                        // minimum step size
+	DsMax    float64                        // maximum step size
+	LamMin   float64                        // stop when λ < LamMin
+	LamMax   float64                        // stop when λ > LamMax
+	MaxSteps int                            // maximum number of (accepted) steps
+	MaxIt    int                            // maximum number of corrector iterations
+	NitOpt   int                            // desired number of corrector iterations (step size adaptation)
+	MaxAngle float64                        // maximum angle between consecutive tangents (radians)
+	Atol     float64                        // tolerance of the corrector: max|F| ≤ Atol and max|δu| ≤ Atol⋅(1+max|u|)
+	Locate   bool                           // locate the special points accurately
+	Verbose  bool                           // show messages
+	Callback func(p *ContPoint) (stop bool) // called for each new point (including special points) [may be nil]
+
+	// results
+	Points  []*ContPoint // all points from the last call to Run or SwitchBranch, ordered by arclength
+	Special []*ContPoint // fold and branch points (also in Points)
+	Reason  string       // reason for stopping
+	Nsteps  int          // number of accepted steps
+	Nfeval  int          // number of evaluations of F
+	Njeval  int          // number of evaluations of the Jacobian matrices (numerical or not)
+
+	// internal
+	n       int                                           // number of equations
+	ffcn    func(fx, x la.Vector, λ float64)              // F(x, λ)
+	jxfcn   func(dfdx *la.Matrix, x la.Vector, λ float64) // Fx = dF/dx [may be nil ⇒ numerical]
+	jlfcn   func(dfdλ, x la.Vector, λ float64)            // Fλ = dF/dλ [may be nil ⇒ numerical]
+	natural bool                                          // natural-parameter method is being used
+	dir     float64                                       // direction of λ for the natural-parameter method
+	fu      la.Vector                                     // F at the current u [n]
+	fw      la.Vector                                     // workspace for the numerical Jacobian [n]
+	dfdx    *la.Matrix                                    // Fx [n][n]
+	dfdl    la.Vector                                     // Fλ [n]
+	aug     *la.Matrix                                    // augmented Jacobian [n+1][n+1]
+	r       la.Vector                                     // residual of the augmented system [n+1]
+	δu      la.Vector                                     // correction [n+1]
+	e       la.Vector                                     // unit vector (0, …, 0, 1) [n+1]
+}
+
+// NewContinuation returns a new continuation driver
+//
+//	neq  -- number of equations n (equal to the number of unknowns x)
+//	ffcn -- function computing F(x, λ)
+//
+//	Defaults: Method = "arclength", Ds = DsMax = 0.1, DsMin = 1e-6, λ ∈ (-∞, ∞), MaxSteps = 1000,
+//	MaxIt = 10, NitOpt = 4, MaxAngle = π/6 and Atol = 1e-10. The Jacobian matrices are computed by
+//	forward differences unless SetJacobian is called.
+func NewContinuation(neq int, ffcn func(fx, x la.Vector, λ float64)) (o *Continuation) {
+	if neq < 1 {
+		chk.Panic("number of equations must be at least 1. neq = %d is invalid\n", neq)
+	}
+	o = new(Continuation)
+	o.Method = "arclength"
+	o.Ds = 0.1
+	o.DsMin = 1e-6
+	o.DsMax = 0.1
+	o.LamMin = math.Inf(-1)
+	o.LamMax = math.Inf(+1)
+	o.MaxSteps = 1000
+	o.MaxIt = 10
+	o.NitOpt = 4
+	o.MaxAngle = math.Pi / 6
+	o.Atol = 1e-10
+	o.n = neq
+	o.ffcn = ffcn
+	o.fu = la.NewVector(neq)
+	o.fw = la.NewVector(neq)
+	o.dfdx = la.NewMatrix(neq, neq)
+	o.dfdl = la.NewVector(neq)
+	o.aug = la.NewMatrix(neq+1, neq+1)
+	o.r = la.NewVector(neq + 1)
+	o.δu = la.NewVector(neq + 1)
+	o.e = la.NewVector(neq + 1)
+	o.e[neq] = 1
+	return
+}
+
+// SetJacobian sets the functions computing the Jacobian matrices
+//
+//	dfdx -- computes Fx = dF/dx [n][n]
+//	dfdλ -- computes Fλ = dF/dλ [n]
+func (o *Continuation) SetJacobian(dfdx func(dfdx *la.Matrix, x la.Vector, λ float64), dfdλ func(dfdλ, x la.Vector, λ float64)) {
+	o.jxfcn = dfdx
+	o.jlfcn = dfdλ
+}
+
+// Run traces the curve of solutions starting at λ0. The first point is computed by solving
+// F(x, λ0) = 0 for x with the initial guess x0. The results are saved in Points
+func (o *Continuation) Run(x0 la.Vector, λ0 float64) {
+
+	// check
+	if len(x0) != o.n {
+		chk.Panic("the length of x0 must be equal to n=%d. len(x0)=%d is invalid\n", o.n, len(x0))
+	}
+	switch o.Method {
+	case "arclength", "natural":
+	default:
+		chk.Panic("Method = %q is invalid. Options are \"arclength\" and \"natural\"\n", o.Method)
+	}
+	if o.Ds == 0 {
+		chk.Panic("the initial step size Ds must not be zero\n")
+	}
+	o.reset()
+	o.natural = o.Method == "natural"
+	o.dir = 1
+	if o.Ds < 0 {
+		o.dir = -1
+	}
+
+	// starting point: solve F(x, λ0) = 0 with fixed λ
+	n := o.n
+	u0 := la.NewVector(n + 1)
+	copy(u0, x0)
+	u0[n] = λ0
+	u := u0.GetCopy()
+	nit, ok := o.correct(u, u0, o.e, 0)
+	if !ok {
+		chk.Panic("cannot compute the starting point with λ = %g\n", λ0)
+	}
+
+	// trace curve; the initial tangent is oriented towards increasing or decreasing λ
+	tref := la.NewVector(n + 1)
+	tref[n] = o.dir
+	o.trace(u, tref, nit, false)
+}
+
+// SwitchBranch traces the secondary branch passing through the branch point bp (e.g. from Special).
+// The direction of the secondary branch at bp is the null vector v of the augmented Jacobian
+// (orthogonal to the tangent of the primary branch), normalised such that its component with the
+// largest magnitude is positive; the sign of Ds selects v or -v. The pseudo-arclength method is
+// used regardless of Method. The results replace Points
+func (o *Continuation) SwitchBranch(bp *ContPoint) {
+
+	// check
+	if bp.Kind != "branch" {
+		chk.Panic("SwitchBranch requires a branch point. Kind = %q is invalid\n", bp.Kind)
+	}
+	if o.Ds == 0 {
+		chk.Panic("the initial step size Ds must not be zero\n")
+	}
+	o.reset()
+	o.natural = false
+
+	// direction of the secondary branch
+	n := o.n
+	u := la.NewVector(n + 1)
+	copy(u, bp.X)
+	u[n] = bp.Lambda
+	o.ffcn(o.fu, bp.X, bp.Lambda)
+	o.Nfeval++
+	o.jacobian(u)
+	o.assemble(bp.Tangent)
+	v := nullVector(o.aug)
+	imax := 0
+	for i := range v {
+		if math.Abs(v[i]) > math.Abs(v[imax]) {
+			imax = i
+		}
+	}
+	if v[imax]*o.Ds < 0 {
+		v.Apply(-1, v)
+	}
+
+	// trace secondary branch
+	o.trace(u, v, 0, true)
+}
+
+// Table returns a table with the points from the last call to Run or SwitchBranch
+func (o *Continuation) Table() string {
+	var b bytes.Buffer
+	io.Ff(&b, "%5s%8s%15s%23s%15s%13s%5s%13s\n", "i", "kind", "s", "λ", "‖x‖", "ds", "nit", "det(Fx)")
+	for i, p := range o.Points {
+		io.Ff(&b, "%5d%8s%15.6e%23.15e%15.6e%13.4e%5d%13.4e\n", i, p.Kind, p.S, p.Lambda, p.X.Norm(), p.Ds, p.Niter, p.DetJ)
+	}
+	io.Ff(&b, "%s\n", o.Reason)
+	return b.String()
+}
+
+// reset clears the results
+func (o *Continuation) reset() {
+	o.Points, o.Special = nil, nil
+	o.Reason = ""
+	o.Nsteps, o.Nfeval, o.Njeval = 0, 0, 0
+}
+
+// trace traces the curve from the solution u with the tangent computed with the reference tref.
+// At a branch point, the tangent is tref itself and the detection is skipped in the first step
+func (o *Continuation) trace(u, tref la.Vector, nit int, branch bool) {
+
+	// starting point
+	n := o.n
+	p0 := o.point(u, tref, !branch)
+	p0.Niter = nit
+	p0.Kind = "start"
+	if o.add(p0) {
+		return
+	}
+
+	// steps
+	u0 := u.GetCopy()
+	u1 := la.NewVector(n + 1)
+	d := la.NewVector(n + 1) // direction of the predictor
+	c := la.NewVector(n + 1) // constraint cᵀ⋅(u - u0) = ds
+	cosMin := math.Cos(o.MaxAngle)
+	ds := utl.Min(math.Abs(o.Ds), o.DsMax)
+	for {
+
+		// check number of steps
+		if o.Nsteps == o.MaxSteps {
+			o.Reason = io.Sf("maximum number of steps (%d) reached", o.MaxSteps)
+			break
+		}
+
+		// predictor direction and constraint
+		t0 := p0.Tangent
+		if o.natural {
+			tλ := o.dir * t0[n]
+			if tλ <= MACHEPS {
+				o.Reason = "a fold has been reached (natural-parameter method)"
+				break
+			}
+			d.Apply(1/tλ, t0)
+			c.Apply(o.dir, o.e)
+		} else {
+			copy(d, t0)
+			copy(c, t0)
+		}
+
+		// predictor-corrector
+		var p1 *ContPoint
+		nit, ok := o.predictCorrect(u1, u0, d, c, ds)
+		if ok {
+			p1 = o.point(u1, t0, true)
+			if la.VecDot(p1.Tangent, t0) < cosMin {
+				ok = false // sharp turn: the corrector may have jumped to another branch
+			}
+			if o.natural && o.dir*p1.Tangent[n] <= 0 {
+				ok = false // jumped across a fold
+			}
+		}
+		if !ok {
+			ds /= 2
+			if ds < o.DsMin {
+				o.Reason = io.Sf("step size is too small (ds = %g)", ds)
+				break
+			}
+			continue
+		}
+		o.Nsteps++
+		p1.S = p0.S + u1.NormDiff(u0)
+		p1.Ds = ds
+		p1.Niter = nit
+		p1.Kind = "regular"
+
+		// detect special points
+		kind := ""
+		if !branch || o.Nsteps > 1 {
+			if o.natural {
+				if p0.DetJ*p1.DetJ < 0 {
+					kind = "branch"
+				}
+			} else {
+				if p0.DetA*p1.DetA < 0 {
+					kind = "branch"
+				} else if p0.DetJ*p1.DetJ < 0 {
+					kind = "fold"
+				}
+			}
+		}
+		if kind != "" {
+			var sp *ContPoint
+			if o.Locate {
+				sp = o.locate(kind, u0, d, c, ds, p0, p1)
+			}
+			if sp == nil {
+				p1.Kind = kind // the special point is between p0 and p1
+				o.Special = append(o.Special, p1)
+			} else {
+				sp.S = p0.S + sp.S
+				o.Special = append(o.Special, sp)
+				if o.add(sp) {
+					o.Reason = "stopped by callback"
+					break
+				}
+			}
+		}
+
+		// save point
+		if o.add(p1) {
+			o.Reason = "stopped by callback"
+			break
+		}
+
+		// check λ
+		if p1.Lambda < o.LamMin || p1.Lambda > o.LamMax {
+			o.Reason = io.Sf("λ = %g is out of range", p1.Lambda)
+			break
+		}
+
+		// next step size
+		f := float64(o.NitOpt) / float64(utl.Imax(nit, 1))
+		ds = utl.Min(ds*utl.Min(utl.Max(f, 0.5), 2.0), o.DsMax)
+		copy(u0, u1)
+		p0 = p1
+	}
+}
+
+// add appends a point to the results, shows a message and calls the callback function
+func (o *Continuation) add(p *ContPoint) (stop bool) {
+	if o.Verbose {
+		if len(o.Points) == 0 {
+			io.Pf("%5s%8s%23s%23s%13s%5s\n", "step", "kind", "λ", "‖x‖", "ds", "nit")
+		}
+		io.Pf("%5d%8s%23.15e%23.15e%13.4e%5d\n", o.Nsteps, p.Kind, p.Lambda, p.X.Norm(), p.Ds, p.Niter)
+	}
+	o.Points = append(o.Points, p)
+	if o.Callback != nil {
+		return o.Callback(p)
+	}
+	return
+}
+
+// point creates a new point at u. The tangent is computed with the reference tref (if solve is
+// true) or set equal to tref. NOTE: o.fu must hold F(u)
+func (o *Continuation) point(u, tref la.Vector, solve bool) (p *ContPoint) {
+	n := o.n
+	p = &ContPoint{X: u[:n].GetCopy(), Lambda: u[n], Tangent: tref.GetCopy()}
+	o.jacobian(u)
+	if solve {
+		o.assemble(tref)
+		la.DenSolve(p.Tangent, o.aug, o.e, false)
+		p.Tangent.Apply(1/p.Tangent.Norm(), p.Tangent)
+	}
+	o.assemble(p.Tangent)
+	p.DetJ = determinant(o.dfdx)
+	p.DetA = determinant(o.aug)
+	return
+}
+
+// locate locates the special point between p0 (s = 0) and p1 (s = ds) by the Illinois method.
+// Returns nil if the corrector fails. NOTE: the returned S is relative to p0
+func (o *Continuation) locate(kind string, u0, d, c la.Vector, ds float64, p0, p1 *ContPoint) (p *ContPoint) {
+
+	// test function
+	n := o.n
+	g := func(q *ContPoint) float64 {
+		if kind == "fold" {
+			return q.Tangent[n] // dλ/ds
+		}
+		if o.natural {
+			return q.DetJ
+		}
+		return q.DetA
+	}
+
+	// Illinois method
+	u := la.NewVector(n + 1)
+	a, b := 0.0, ds
+	ga, gb := g(p0), g(p1)
+	sprev := math.NaN()
+	side := 0
+	for it := 0; it < 50; it++ {
+		s := (a*gb - b*ga) / (gb - ga)
+		nit, ok := o.predictCorrect(u, u0, d, c, s)
+		if !ok {
+			return nil
+		}
+		p = o.point(u, p0.Tangent, kind == "fold")
+		p.S, p.Ds, p.Niter, p.Kind = u.NormDiff(u0), s, nit, kind
+		gs := g(p)
+		if gs == 0 || math.Abs(s-sprev) <= o.Atol*(1+math.Abs(s)) {
+			break
+		}
+		sprev = s
+		if gs*gb > 0 {
+			b, gb = s, gs
+			if side == -1 {
+				ga /= 2
+			}
+			side = -1
+		} else {
+			a, ga = s, gs
+			if side == +1 {
+				gb /= 2
+			}
+			side = +1
+		}
+	}
+	return
+}
+
+// predictCorrect computes u = u0 + ds⋅d and corrects it such that F(u) = 0 and cᵀ⋅(u - u0) = ds
+func (o *Continuation) predictCorrect(u, u0, d, c la.Vector, ds float64) (nit int, ok bool) {
+	for i := range u {
+		u[i] = u0[i] + ds*d[i]
+	}
+	return o.correct(u, u0, c, ds)
+}
+
+// correct solves F(u) = 0 and cᵀ⋅(u - u0) = ds by Newton's method starting from u.
+// On success, o.fu holds F(u)
+func (o *Continuation) correct(u, u0, c la.Vector, ds float64) (nit int, ok bool) {
+	n := o.n
+	δ, δprev := 0.0, 0.0
+	for nit = 0; nit <= o.MaxIt; nit++ {
+
+		// residual
+		o.ffcn(o.fu, u[:n], u[n])
+		o.Nfeval++
+		copy(o.r, o.fu)
+		o.r[n] = la.VecDot(c, u) - la.VecDot(c, u0) - ds
+		rmax := o.r.Largest(1)
+		if math.IsNaN(rmax) || math.IsInf(rmax, 0) {
+			return
+		}
+
+		// check convergence
+		if rmax <= o.Atol && (nit == 0 || δ <= o.Atol*(1+u.Largest(1))) {
+			ok = true
+			return
+		}
+		if nit == o.MaxIt || (nit > 1 && δ > δprev) {
+			return
+		}
+
+		// update
+		o.jacobian(u)
+		o.assemble(c)
+		la.DenSolve(o.δu, o.aug, o.r, false)
+		for i := range u {
+			u[i] -= o.δu[i]
+		}
+		δprev, δ = δ, o.δu.Largest(1)
+	}
+	return
+}
+
+// jacobian computes Fx and Fλ at u. NOTE: o.fu must hold F(u)
+func (o *Continuation) jacobian(u la.Vector) {
+	n := o.n
+	x, λ := u[:n], u[n]
+	o.Njeval++
+	if o.jxfcn != nil {
+		o.jxfcn(o.dfdx, x, λ)
+	} else {
+		for j := 0; j < n; j++ {
+			xsafe := x[j]
+			δ := math.Sqrt(MACHEPS * utl.Max(1e-5, math.Abs(xsafe)))
+			x[j] = xsafe + δ
+			o.ffcn(o.fw, x, λ)
+			o.Nfeval++
+			for i := 0; i < n; i++ {
+				o.dfdx.Set(i, j, (o.fw[i]-o.fu[i])/δ)
+			}
+			x[j] = xsafe
+		}
+	}
+	if o.jlfcn != nil {
+		o.jlfcn(o.dfdl, x, λ)
+	} else {
+		δ := math.Sqrt(MACHEPS * utl.Max(1e-5, math.Abs(λ)))
+		o.ffcn(o.fw, x, λ+δ)
+		o.Nfeval++
+		for i := 0; i < n; i++ {
+			o.dfdl[i] = (o.fw[i] - o.fu[i]) / δ
+		}
+	}
+}
+
+// assemble assembles the augmented Jacobian A = [[Fx, Fλ], [cᵀ]]
+func (o *Continuation) assemble(c la.Vector) {
+	n := o.n
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			o.aug.Set(i, j, o.dfdx.Get(i, j))
+		}
+		o.aug.Set(i, n, o.dfdl[i])
+	}
+	for j := 0; j <= n; j++ {
+		o.aug.Set(n, j, c[j])
+	}
+}
+
+// nullVector computes a unit vector v such that A⋅v ≈ 0, where A is a (nearly) singular square
+// matrix with rank deficiency one, by Gaussian elimination with complete pivoting
+func nullVector(A *la.Matrix) (v la.Vector) {
+
+	// elimination
+	m := A.M
+	a := A.GetCopy()
+	col := utl.IntRange(m) // column permutation
+	r := m - 1             // index of the zero pivot
+	for k := 0; k < m-1; k++ {
+		p, q, amax := k, k, 0.0
+		for i := k; i < m; i++ {
+			for j := k; j < m; j++ {
+				if math.Abs(a.Get(i, j)) > amax {
+					p, q, amax = i, j, math.Abs(a.Get(i, j))
+				}
+			}
+		}
+		if amax == 0 {
+			r = k
+			break
+		}
+		for j := 0; j < m; j++ {
+			aij := a.Get(k, j)
+			a.Set(k, j, a.Get(p, j))
+			a.Set(p, j, aij)
+		}
+		for i := 0; i < m; i++ {
+			aij := a.Get(i, k)
+			a.Set(i, k, a.Get(i, q))
+			a.Set(i, q, aij)
+		}
+		col[k], col[q] = col[q], col[k]
+		for i := k + 1; i < m; i++ {
+			f := a.Get(i, k) / a.Get(k, k)
+			for j := k; j < m; j++ {
+				a.Add(i, j, -f*a.Get(k, j))
+			}
+		}
+	}
+
+	// back substitution with y[r] = 1
+	y := la.NewVector(m)
+	y[r] = 1
+	for i := r - 1; i >= 0; i-- {
+		sum := 0.0
+		for j := i + 1; j <= r; j++ {
+			sum += a.Get(i, j) * y[j]
+		}
+		y[i] = -sum / a.Get(i, i)
+	}
+	v = la.NewVector(m)
+	for j := 0; j < m; j++ {
+		v[col[j]] = y[j]
+	}
+	v.Apply(1/v.Norm(), v)
+	return
+}
+
+// determinant computes the determinant of a square matrix by the LU factorisation with partial
+// pivoting. Differently from la.Matrix.Det, exactly singular matrices are allowed (det = 0)
+func determinant(A *la.Matrix) (det float64) {
+	m := A.M
+	a := A.GetCopy()
+	det = 1.0
+	for k := 0; k < m; k++ {
+		p := k
+		for i := k + 1; i < m; i++ {
+			if math.Abs(a.Get(i, k)) > math.Abs(a.Get(p, k)) {
+				p = i
+			}
+		}
+		if a.Get(p, k) == 0 {
+			return 0
+		}
+		if p != k {
+			for j := k; j < m; j++ {
+				akj := a.Get(k, j)
+				a.Set(k, j, a.Get(p, j))
+				a.Set(p, j, akj)
+			}
+			det = -det
+		}
+		det *= a.Get(k, k)
+		for i := k + 1; i < m; i++ {
+			f := a.Get(i, k) / a.Get(k, k)
+			for j := k + 1; j < m; j++ {
+				a.Add(i, j, -f*a.Get(k, j))
+			}
+		}
+	}
+	return
+}
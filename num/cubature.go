@@ -0,0 +1,367 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+)
+
+// Cubature implements an adaptive integrator for scalar functions f(x) over hyper-rectangles
+// [a0,b0]×[a1,b1]×...×[an-1,bn-1] using the embedded degree 7/5 rule of Genz and Malik [1].
+// The region with the largest error is bisected along the direction with the largest fourth
+// divided difference until the requested accuracy or the maximum number of function evaluations
+// is reached (globally adaptive subdivision as in [2]). For n=1, the G7K15 rule is used instead.
+//
+//	The requested accuracy has been achieved if abserr ≤ max(EpsAbs, EpsRel⋅|res|). The number of
+//	function evaluations per region is 1 + 4n + 2n(n-1) + 2ⁿ; thus the method is adequate for
+//	dimensions up to about 7 to 10.
+//
+//	NOTE: Cubature holds only the configuration and is not modified by Integrate; thus the same
+//	      object can be used concurrently by several goroutines (provided that f is safe).
+//
+//	References:
+//	  [1] Genz AC, Malik AA (1980) Remarks on algorithm 006: An adaptive algorithm for numerical
+//	      integration over an N-dimensional rectangular region. Journal of Computational and
+//	      Applied Mathematics, 6(4):295-302
+//	  [2] Berntsen J, Espelid TO, Genz A (1991) An adaptive algorithm for the approximate
+//	      calculation of multiple integrals. ACM Transactions on Mathematical Software,
+//	      17(4):437-451
+type Cubature struct {
+	EpsAbs  float64 // absolute tolerance
+	EpsRel  float64 // relative tolerance
+	MaxEval int     // maximum number of function evaluations
+}
+
+// NewCubature returns a new adaptive cubature integrator with default parameters
+//
+//	epsAbs -- absolute tolerance
+//	epsRel -- relative tolerance
+//
+//	Defaults: MaxEval = 1000000
+func NewCubature(epsAbs, epsRel float64) (o *Cubature) {
+	o = new(Cubature)
+	o.EpsAbs = epsAbs
+	o.EpsRel = epsRel
+	o.MaxEval = 1000000
+	return
+}
+
+// Integrate computes the integral of f(x) over the hyper-rectangle defined by a and b
+//
+//	a -- lower limits [ndim]; must be finite
+//	b -- upper limits [ndim]; must be finite
+//
+//	Output:
+//	  res    -- the integral
+//	  abserr -- estimate of the absolute error
+//	  neval  -- number of function evaluations
+//	  converged -- abserr ≤ max(EpsAbs, EpsRel⋅|res|) has been achieved within MaxEval evaluations
+func (o *Cubature) Integrate(f fun.Sv, a, b []float64) (res, abserr float64, neval int, converged bool) {
+
+	// check
+	if o.EpsAbs <= 0 && o.EpsRel <= 0 {
+		chk.Panic("EpsAbs=%g and EpsRel=%g are invalid: at least one tolerance must be positive\n", o.EpsAbs, o.EpsRel)
+	}
+	ndim := len(a)
+	if ndim < 1 || len(b) != ndim {
+		chk.Panic("limits must have the same (non-zero) length. len(a)=%d, len(b)=%d is invalid\n", len(a), len(b))
+	}
+
+	// region and sign
+	sign := 1.0
+	c := make([]float64, ndim)
+	h := make([]float64, ndim)
+	for i := 0; i < ndim; i++ {
+		if math.IsInf(a[i], 0) || math.IsInf(b[i], 0) {
+			chk.Panic("limits of integration must be finite. a[%d]=%g, b[%d]=%g is invalid\n", i, a[i], i, b[i])
+		}
+		if a[i] == b[i] {
+			converged = true
+			return
+		}
+		if a[i] > b[i] {
+			sign = -sign
+		}
+		c[i] = (a[i] + b[i]) / 2.0
+		h[i] = math.Abs(b[i]-a[i]) / 2.0
+	}
+
+	// rule
+	var rule cubRule
+	if ndim == 1 {
+		rule = newCubRuleGK15(f)
+	} else {
+		rule = newCubRuleGenzMalik(f, ndim)
+	}
+
+	// first region
+	first := &cubRegion{c: c, h: h}
+	rule.eval(first)
+	neval = rule.npts()
+	regions := &cubHeap{first}
+	res, abserr = first.r, first.e
+
+	// adaptive subdivision
+	for abserr > math.Max(o.EpsAbs, o.EpsRel*math.Abs(res)) && neval+2*rule.npts() <= o.MaxEval {
+
+		// bisect region with largest error
+		r := heap.Pop(regions).(*cubRegion)
+		k := r.split
+		left := &cubRegion{c: make([]float64, ndim), h: make([]float64, ndim)}
+		right := &cubRegion{c: make([]float64, ndim), h: make([]float64, ndim)}
+		copy(left.c, r.c)
+		copy(left.h, r.h)
+		copy(right.c, r.c)
+		copy(right.h, r.h)
+		left.h[k] /= 2.0
+		right.h[k] /= 2.0
+		left.c[k] -= left.h[k]
+		right.c[k] += right.h[k]
+		rule.eval(left)
+		rule.eval(right)
+		neval += 2 * rule.npts()
+		heap.Push(regions, left)
+		heap.Push(regions, right)
+
+		// update estimates
+		res += left.r + right.r - r.r
+		abserr += left.e + right.e - r.e
+	}
+
+	// sum results again to avoid the accumulation of roundoff errors
+	res, abserr = 0, 0
+	for _, r := range *regions {
+		res += r.r
+		abserr += r.e
+	}
+	converged = abserr <= math.Max(o.EpsAbs, o.EpsRel*math.Abs(res))
+	res *= sign
+	return
+}
+
+// QuadGaussLegendreNd approximates the integral of f(x) over the hyper-rectangle defined by a
+// and b using the tensor product of n-point Gauss-Legendre rules; i.e. nⁿᵈⁱᵐ function evaluations.
+// The rule is exact for polynomials of degree up to 2n-1 in each variable.
+//
+//	a -- lower limits [ndim]
+//	b -- upper limits [ndim]
+//	n -- number of points along each direction
+func QuadGaussLegendreNd(a, b []float64, n int, f fun.Sv) (res float64) {
+	ndim := len(a)
+	if ndim < 1 || len(b) != ndim {
+		chk.Panic("limits must have the same (non-zero) length. len(a)=%d, len(b)=%d is invalid\n", len(a), len(b))
+	}
+	if n < 1 {
+		chk.Panic("number of points must be positive. n=%d is invalid\n", n)
+	}
+	X := make([][]float64, ndim)
+	W := make([][]float64, ndim)
+	for i := 0; i < ndim; i++ {
+		X[i], W[i] = GaussLegendreXW(a[i], b[i], n)
+	}
+	x := la.NewVector(ndim)
+	idx := make([]int, ndim) // multi-index
+	for {
+		w := 1.0
+		for i := 0; i < ndim; i++ {
+			x[i] = X[i][idx[i]]
+			w *= W[i][idx[i]]
+		}
+		res += w * f(x)
+		i := 0
+		for ; i < ndim; i++ {
+			idx[i]++
+			if idx[i] < n {
+				break
+			}
+			idx[i] = 0
+		}
+		if i == ndim {
+			break
+		}
+	}
+	return
+}
+
+// cubRegion holds the data of a subregion in the adaptive algorithm
+type cubRegion struct {
+	c, h  []float64 // center and half-widths
+	r, e  float64   // integral and error estimate
+	split int       // direction for the next bisection
+}
+
+// cubHeap implements a priority queue of regions where the region with the largest error comes first
+type cubHeap []*cubRegion
+
+func (o cubHeap) Len() int            { return len(o) }
+func (o cubHeap) Less(i, j int) bool  { return o[i].e > o[j].e }
+func (o cubHeap) Swap(i, j int)       { o[i], o[j] = o[j], o[i] }
+func (o *cubHeap) Push(x interface{}) { *o = append(*o, x.(*cubRegion)) }
+func (o *cubHeap) Pop() interface{} {
+	old := *o
+	n := len(old)
+	r := old[n-1]
+	*o = old[:n-1]
+	return r
+}
+
+// cubRule defines an embedded cubature rule over a region
+type cubRule interface {
+	eval(r *cubRegion) // computes r.r, r.e and r.split
+	npts() int         // number of function evaluations per region
+}
+
+// cubRuleGK15 implements the G7K15 rule for one-dimensional regions
+type cubRuleGK15 struct {
+	f fun.Ss
+}
+
+// newCubRuleGK15 returns a new G7K15 rule
+func newCubRuleGK15(f fun.Sv) (o *cubRuleGK15) {
+	x := la.NewVector(1)
+	return &cubRuleGK15{func(s float64) float64 {
+		x[0] = s
+		return f(x)
+	}}
+}
+
+func (o *cubRuleGK15) npts() int { return 15 }
+
+func (o *cubRuleGK15) eval(r *cubRegion) {
+	r.r, r.e, _, _ = quadGK(o.f, r.c[0]-r.h[0], r.c[0]+r.h[0], quadXgk15, quadWgk15, quadWg7)
+	r.split = 0
+}
+
+// cubRuleGenzMalik implements the degree 7 rule of Genz and Malik with the embedded degree 5 rule
+type cubRuleGenzMalik struct {
+	f      fun.Sv
+	ndim   int
+	x      la.Vector // point
+	w7, w5 []float64 // weights of the degree 7 and degree 5 rules
+	diff   []float64 // fourth divided differences
+}
+
+// constants of the Genz-Malik rule
+var (
+	cubλ2 = math.Sqrt(9.0 / 70.0)
+	cubλ4 = math.Sqrt(9.0 / 10.0)
+	cubλ5 = math.Sqrt(9.0 / 19.0)
+)
+
+// newCubRuleGenzMalik returns a new Genz-Malik rule
+func newCubRuleGenzMalik(f fun.Sv, ndim int) (o *cubRuleGenzMalik) {
+	if ndim > 30 {
+		chk.Panic("Genz-Malik rule can only handle a maximum dimension of 30. ndim=%d is invalid\n", ndim)
+	}
+	o = new(cubRuleGenzMalik)
+	o.f = f
+	o.ndim = ndim
+	o.x = la.NewVector(ndim)
+	n := float64(ndim)
+	o.w7 = []float64{
+		(12824.0 - 9120.0*n + 400.0*n*n) / 19683.0,
+		980.0 / 6561.0,
+		(1820.0 - 400.0*n) / 19683.0,
+		200.0 / 19683.0,
+		6859.0 / 19683.0 / math.Pow(2, n),
+	}
+	o.w5 = []float64{
+		(729.0 - 950.0*n + 50.0*n*n) / 729.0,
+		245.0 / 486.0,
+		(265.0 - 100.0*n) / 1458.0,
+		25.0 / 729.0,
+	}
+	o.diff = make([]float64, ndim)
+	return
+}
+
+func (o *cubRuleGenzMalik) npts() int {
+	return 1 + 4*o.ndim + 2*o.ndim*(o.ndim-1) + (1 << uint(o.ndim))
+}
+
+func (o *cubRuleGenzMalik) eval(r *cubRegion) {
+
+	// center
+	n := o.ndim
+	copy(o.x, r.c)
+	f0 := o.f(o.x)
+
+	// points along the axes
+	ratio := (cubλ2 * cubλ2) / (cubλ4 * cubλ4)
+	var sum2, sum3 float64
+	for i := 0; i < n; i++ {
+		o.x[i] = r.c[i] - cubλ2*r.h[i]
+		f2 := o.f(o.x)
+		o.x[i] = r.c[i] + cubλ2*r.h[i]
+		f2 += o.f(o.x)
+		o.x[i] = r.c[i] - cubλ4*r.h[i]
+		f3 := o.f(o.x)
+		o.x[i] = r.c[i] + cubλ4*r.h[i]
+		f3 += o.f(o.x)
+		o.x[i] = r.c[i]
+		sum2 += f2
+		sum3 += f3
+		o.diff[i] = math.Abs(f2 - 2.0*f0 - ratio*(f3-2.0*f0))
+	}
+
+	// points along the diagonals of the coordinate planes
+	var sum4 float64
+	for i := 0; i < n-1; i++ {
+		for j := i + 1; j < n; j++ {
+			for _, si := range []float64{-1, 1} {
+				for _, sj := range []float64{-1, 1} {
+					o.x[i] = r.c[i] + si*cubλ4*r.h[i]
+					o.x[j] = r.c[j] + sj*cubλ4*r.h[j]
+					sum4 += o.f(o.x)
+				}
+			}
+			o.x[j] = r.c[j]
+		}
+		o.x[i] = r.c[i]
+	}
+
+	// corners
+	var sum5 float64
+	for mask := 0; mask < 1<<uint(n); mask++ {
+		for i := 0; i < n; i++ {
+			if mask&(1<<uint(i)) == 0 {
+				o.x[i] = r.c[i] - cubλ5*r.h[i]
+			} else {
+				o.x[i] = r.c[i] + cubλ5*r.h[i]
+			}
+		}
+		sum5 += o.f(o.x)
+	}
+
+	// results
+	vol := 1.0
+	for i := 0; i < n; i++ {
+		vol *= 2.0 * r.h[i]
+	}
+	r7 := vol * (o.w7[0]*f0 + o.w7[1]*sum2 + o.w7[2]*sum3 + o.w7[3]*sum4 + o.w7[4]*sum5)
+	r5 := vol * (o.w5[0]*f0 + o.w5[1]*sum2 + o.w5[2]*sum3 + o.w5[3]*sum4)
+	r.r = r7
+	r.e = math.Abs(r7 - r5)
+
+	// split direction: largest fourth difference; the widest direction if differences are similar
+	maxdiff := 0.0
+	for i := 0; i < n; i++ {
+		maxdiff = math.Max(maxdiff, o.diff[i])
+	}
+	noise := 5.0 * MACHEPS * math.Abs(f0)
+	r.split = 0
+	width := -1.0
+	for i := 0; i < n; i++ {
+		if o.diff[i] >= maxdiff-noise && r.h[i] > width {
+			r.split = i
+			width = r.h[i]
+		}
+	}
+}
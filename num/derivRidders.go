@@ -0,0 +1,189 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// DerivRidders approximates the derivative df/dx by Ridders' method [1,2]: central differences
+// with decreasing steps h, h/1.4, h/1.4², … are extrapolated to h → 0 (Richardson extrapolation
+// by Neville's algorithm). The best estimate of the tableau is returned with its error estimate.
+//
+//	h -- initial (rather large) step; the step is reduced automatically.
+//	     Use h = 0 to select h = 0.1⋅max(1, |x|)
+//
+//	NOTE: f must be smooth over [x-h, x+h]
+//
+//	References:
+//	  [1] Ridders CJF (1982) Accurate computation of F'(x) and F'(x) F''(x). Advances in
+//	      Engineering Software, 4(2):75-76
+//	  [2] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	      Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func DerivRidders(x, h float64, f fun.Ss) (res, errEst float64) {
+	return ridders(riddersStep(x, h), func(h float64) float64 {
+		return (f(x+h) - f(x-h)) / (2.0 * h)
+	})
+}
+
+// SecondDerivRidders approximates the second derivative d²f/dx² by Ridders' method applied to the
+// central differences with 3 points. See DerivRidders
+func SecondDerivRidders(x, h float64, f fun.Ss) (res, errEst float64) {
+	fx := f(x)
+	return ridders(riddersStep(x, h), func(h float64) float64 {
+		return (f(x-h) - 2.0*fx + f(x+h)) / (h * h)
+	})
+}
+
+// GradientRidders approximates the gradient g = df/dx of a scalar function of a vector by Ridders'
+// method applied to each component. See DerivRidders. Returns the largest error estimate
+//
+//	g -- gradient [must be pre-allocated with len(x)]
+//	h -- initial step; use h = 0 to select h = 0.1⋅max(1, |xᵢ|) for each component
+func GradientRidders(g, x la.Vector, h float64, f fun.Sv) (maxErr float64) {
+	xtmp := x.GetCopy()
+	for i := range x {
+		gi, err := ridders(riddersStep(x[i], h), func(h float64) float64 {
+			xtmp[i] = x[i] + h
+			fp := f(xtmp)
+			xtmp[i] = x[i] - h
+			fm := f(xtmp)
+			xtmp[i] = x[i]
+			return (fp - fm) / (2.0 * h)
+		})
+		g[i] = gi
+		maxErr = utl.Max(maxErr, err)
+	}
+	return
+}
+
+// HessianRidders approximates the Hessian H = d²f/dx² of a scalar function of a vector by Ridders'
+// method applied to the central differences of each component (symmetric). See DerivRidders.
+// Returns the largest error estimate
+//
+//	H -- Hessian matrix [must be pre-allocated with len(x) × len(x)]
+//	h -- initial step; use h = 0 to select h = 0.1⋅max(1, |xᵢ|, |xⱼ|) for each component
+func HessianRidders(H *la.Matrix, x la.Vector, h float64, f fun.Sv) (maxErr float64) {
+	xtmp := x.GetCopy()
+	fx := f(x)
+	for i := range x {
+		for j := i; j < len(x); j++ {
+			var hij, err float64
+			if i == j {
+				hij, err = ridders(riddersStep(x[i], h), func(h float64) float64 {
+					xtmp[i] = x[i] + h
+					fp := f(xtmp)
+					xtmp[i] = x[i] - h
+					fm := f(xtmp)
+					xtmp[i] = x[i]
+					return (fp - 2.0*fx + fm) / (h * h)
+				})
+			} else {
+				h0 := riddersStep(utl.Max(math.Abs(x[i]), math.Abs(x[j])), h)
+				hij, err = ridders(h0, func(h float64) float64 {
+					fpp := evalShifted(f, xtmp, x, i, j, +h, +h)
+					fpm := evalShifted(f, xtmp, x, i, j, +h, -h)
+					fmp := evalShifted(f, xtmp, x, i, j, -h, +h)
+					fmm := evalShifted(f, xtmp, x, i, j, -h, -h)
+					return (fpp - fpm - fmp + fmm) / (4.0 * h * h)
+				})
+			}
+			H.Set(i, j, hij)
+			H.Set(j, i, hij)
+			maxErr = utl.Max(maxErr, err)
+		}
+	}
+	return
+}
+
+// DerivComplexStep computes the derivative df/dx by the complex-step method [3]:
+//
+//	df/dx ≈ Im(f(x + i⋅h)) / h   with h = 1e-100
+//
+//	There is no subtractive cancellation; thus the derivative is accurate to machine precision.
+//	NOTE: f must be analytic (holomorphic) and implemented with complex arithmetic; e.g. functions
+//	      using cmplx.Abs, comparisons on real parts or conversions to float64 are not allowed
+//
+//	Reference:
+//	  [3] Martins JRRA, Sturdza P, Alonso JJ (2003) The complex-step derivative approximation. ACM
+//	      Transactions on Mathematical Software, 29(3):245-262
+func DerivComplexStep(x float64, f func(z complex128) complex128) float64 {
+	h := 1e-100
+	return imag(f(complex(x, h))) / h
+}
+
+// GradientComplexStep computes the gradient g = df/dx of a scalar function of a vector by the
+// complex-step method. See DerivComplexStep
+//
+//	g -- gradient [must be pre-allocated with len(x)]
+func GradientComplexStep(g, x la.Vector, f func(z []complex128) complex128) {
+	h := 1e-100
+	z := make([]complex128, len(x))
+	for i := range x {
+		z[i] = complex(x[i], 0)
+	}
+	for i := range x {
+		z[i] = complex(x[i], h)
+		g[i] = imag(f(z)) / h
+		z[i] = complex(x[i], 0)
+	}
+}
+
+// lower level functions //////////////////////////////////////////////////////////////////////////
+
+// riddersStep returns the initial step of Ridders' method
+func riddersStep(x, h float64) float64 {
+	if h > 0 {
+		return h
+	}
+	return 0.1 * utl.Max(1.0, math.Abs(x))
+}
+
+// evalShifted evaluates f(x + δi⋅eᵢ + δj⋅eⱼ) using the workspace xtmp (restored on exit)
+func evalShifted(f fun.Sv, xtmp, x la.Vector, i, j int, δi, δj float64) (res float64) {
+	xtmp[i] = x[i] + δi
+	xtmp[j] = x[j] + δj
+	res = f(xtmp)
+	xtmp[i], xtmp[j] = x[i], x[j]
+	return
+}
+
+// ridders extrapolates approx(h) to h → 0 by Neville's algorithm with h, h/c, h/c², … where c = 1.4.
+// The error of approx(h) must have an expansion in even powers of h (e.g. central differences).
+// The iterations stop when the error grows by a factor of 2 (e.g. due to roundoff)
+func ridders(h float64, approx func(h float64) float64) (res, errEst float64) {
+	const (
+		con  = 1.4       // step reduction factor
+		con2 = con * con // factor for the extrapolation
+		safe = 2.0       // return when the error is safe times worse than the best so far
+		ntab = 10        // maximum size of the tableau
+	)
+	a := la.NewMatrix(ntab, ntab)
+	a.Set(0, 0, approx(h))
+	res = a.Get(0, 0)
+	errEst = math.MaxFloat64
+	for i := 1; i < ntab; i++ {
+		h /= con
+		a.Set(0, i, approx(h))
+		fac := con2
+		for j := 1; j <= i; j++ {
+			a.Set(j, i, (a.Get(j-1, i)*fac-a.Get(j-1, i-1))/(fac-1.0))
+			fac *= con2
+			errt := utl.Max(math.Abs(a.Get(j, i)-a.Get(j-1, i)), math.Abs(a.Get(j, i)-a.Get(j-1, i-1)))
+			if errt <= errEst {
+				errEst = errt
+				res = a.Get(j, i)
+			}
+		}
+		if math.Abs(a.Get(i, i)-a.Get(i-1, i-1)) >= safe*errEst {
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,336 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// FixedPoint solves fixed-point problems x = G(x); e.g. staggered (partitioned) coupling of
+// multiphysics problems. With the residual r(x) = G(x) - x, the methods are:
+//
+//	"picard"   -- relaxed Picard iterations: xₖ₊₁ = xₖ + β⋅rₖ
+//	"aitken"   -- Aitken's dynamic relaxation [1,2]: xₖ₊₁ = xₖ + ωₖ⋅rₖ with ω₀ = β and
+//	              ωₖ = -ωₖ₋₁⋅rₖ₋₁ᵀ⋅(rₖ - rₖ₋₁) / ‖rₖ - rₖ₋₁‖²
+//	"anderson" -- Anderson (type-II) acceleration [3,4] with depth m (Depth): the coefficients γ
+//	              minimise ‖rₖ - ΔRₖ⋅γ‖, where ΔRₖ holds the last mₖ = min(m, k) differences of
+//	              the residuals, and xₖ₊₁ = xₖ + β⋅rₖ - (ΔXₖ + β⋅ΔRₖ)⋅γ, where ΔXₖ holds the
+//	              differences of x. The least-squares problem is solved by the QR decomposition
+//	              (modified Gram-Schmidt) and the oldest columns are dropped if ΔRₖ is (nearly)
+//	              rank deficient. With m = 0, Picard's iterations are recovered
+//
+//	The tolerances, maximum number of iterations, Verbose flag and output callback are taken from
+//	NlSolverConfig; i.e. the iterations stop when max|r| < Ftol or when the RMS of the scaled δx is
+//	smaller than the tolerance of Newton's method (derived from Rtol).
+//
+//	References:
+//	  [1] Irons BM, Tuck RC (1969) A version of the Aitken accelerator for computer iteration.
+//	      International Journal for Numerical Methods in Engineering, 1(3):275-277
+//	  [2] Küttler U, Wall WA (2008) Fixed-point fluid-structure interaction solvers with dynamic
+//	      relaxation. Computational Mechanics, 43(1):61-72
+//	  [3] Anderson DG (1965) Iterative procedures for nonlinear integral equations. Journal of the
+//	      ACM, 12(4):547-560
+//	  [4] Walker HF, Ni P (2011) Anderson acceleration for fixed-point iterations. SIAM Journal on
+//	      Numerical Analysis, 49(4):1715-1735
+type FixedPoint struct {
+
+	// configuration
+	Method string  // "picard", "aitken" or "anderson"
+	Depth  int     // Anderson: maximum number of previous iterates m
+	Beta   float64 // relaxation (mixing) parameter β; initial relaxation ω₀ of Aitken's method
+
+	// stats
+	Niter  int     // number of iterations from the last call to Solve
+	Nfeval int     // number of calls to G (function evaluations)
+	Omega  float64 // Aitken: last relaxation parameter ω
+
+	// convergence history
+	Report NlSolverReport // history of iterations from the last call to Solve
+
+	// internal
+	config *NlSolverConfig // tolerances and other configuration parameters
+	neq    int             // number of equations
+	gfcn   fun.Vv          // G(x)
+	scal   la.Vector       // scaling vector
+	gx     la.Vector       // G(x)
+	r      la.Vector       // residual r = G(x) - x
+	rprev  la.Vector       // residual at the previous iteration
+	xprev  la.Vector       // x at the previous iteration
+	dX     []la.Vector     // Anderson: differences of x
+	dR     []la.Vector     // Anderson: differences of r
+	qmat   []la.Vector     // Anderson: orthonormal basis of ΔR
+	rmat   *la.Matrix      // Anderson: upper triangular factor of ΔR
+	γ      la.Vector       // Anderson: coefficients
+}
+
+// NewFixedPoint returns a new fixed-point solver
+//
+//	neq    -- number of equations (length of x)
+//	G      -- function computing G(x) by G(gx, x)
+//	config -- tolerances and other parameters [may be nil ⇒ NewNlSolverConfig()]
+//
+//	Defaults: Method = "anderson", Depth = 5 and Beta = 1.
+//	NOTE: fixed-point iterations usually need more than the default MaxIterations of NlSolverConfig
+func NewFixedPoint(neq int, G fun.Vv, config *NlSolverConfig) (o *FixedPoint) {
+	if neq < 1 {
+		chk.Panic("number of equations must be at least 1. neq = %d is invalid\n", neq)
+	}
+	o = new(FixedPoint)
+	o.Method = "anderson"
+	o.Depth = 5
+	o.Beta = 1
+	o.config = config
+	if o.config == nil {
+		o.config = NewNlSolverConfig()
+	}
+	o.neq = neq
+	o.gfcn = G
+	o.scal = la.NewVector(neq)
+	o.gx = la.NewVector(neq)
+	o.r = la.NewVector(neq)
+	o.rprev = la.NewVector(neq)
+	o.xprev = la.NewVector(neq)
+	return
+}
+
+// Solve solves x = G(x)
+//
+//	x -- trial x on input and solution on output
+func (o *FixedPoint) Solve(x []float64) {
+
+	// check
+	switch o.Method {
+	case "picard", "aitken":
+	case "anderson":
+		if o.Depth < 0 {
+			chk.Panic("Depth must be non-negative. Depth = %d is invalid\n", o.Depth)
+		}
+		o.dX, o.dR, o.qmat = nil, nil, make([]la.Vector, o.Depth)
+		for j := 0; j < o.Depth; j++ {
+			o.qmat[j] = la.NewVector(o.neq)
+		}
+		o.rmat = la.NewMatrix(o.Depth+1, o.Depth+1)
+		o.γ = la.NewVector(o.Depth + 1)
+	default:
+		chk.Panic("Method = %q is invalid. Options are \"picard\", \"aitken\" and \"anderson\"\n", o.Method)
+	}
+	if len(x) != o.neq {
+		chk.Panic("the length of x must be equal to neq=%d. len(x)=%d is invalid\n", o.neq, len(x))
+	}
+
+	// compute scaling vector
+	la.VecScaleAbs(o.scal, o.config.atol, o.config.rtol, x) // scal = Atol + Rtol*abs(x)
+
+	// residual @ x
+	o.Nfeval = 0
+	fxMax := o.residual(x)
+	o.Report.reset()
+	o.Report.push(fxMax, 0, 0, "", o.Nfeval)
+
+	// show message
+	if o.config.Verbose {
+		o.msg("", 0, 0, 0, true, false)
+	}
+
+	// iterations
+	o.Omega = o.Beta
+	var Ldx float64
+	for o.Niter = 0; o.Niter < o.config.MaxIterations; o.Niter++ {
+
+		// check convergence on r(x)
+		if fxMax < o.config.ftol {
+			o.Report.finish(true, "fxMax(ini)")
+			if o.config.Verbose {
+				o.msg("fxMax(ini)", o.Niter, Ldx, fxMax, false, true)
+			}
+			break
+		}
+
+		// show message
+		if o.config.Verbose {
+			o.msg("", o.Niter, Ldx, fxMax, false, false)
+		}
+
+		// output
+		if o.config.OutCallback != nil {
+			o.config.OutCallback(x)
+		}
+
+		// update x
+		Ldx = o.update(x)
+
+		// residual @ updated x
+		fxMax = o.residual(x)
+		if math.IsNaN(fxMax) || math.IsInf(fxMax, 0) {
+			o.Report.finish(false, "the iterations are diverging")
+			chk.Panic("the iterations are diverging (max|r| = %g)\n", fxMax)
+		}
+		o.Report.push(fxMax, Ldx, 0, o.Method, o.Nfeval)
+
+		// check convergence
+		if fxMax < o.config.ftol {
+			o.Report.finish(true, "fxMax")
+			if o.config.Verbose {
+				o.msg("fxMax", o.Niter, Ldx, fxMax, false, true)
+			}
+			break
+		}
+		if Ldx < o.config.fnewt {
+			o.Report.finish(true, "Ldx")
+			if o.config.Verbose {
+				o.msg("Ldx", o.Niter, Ldx, fxMax, false, true)
+			}
+			break
+		}
+	}
+
+	// output
+	if o.config.OutCallback != nil {
+		o.config.OutCallback(x)
+	}
+
+	// check convergence
+	if o.Niter == o.config.MaxIterations {
+		o.Report.finish(false, io.Sf("maximum number of iterations (%d) reached", o.Niter))
+		chk.Panic("cannot converge after %d iterations", o.Niter)
+	}
+}
+
+// residual computes r = G(x) - x and returns max|r|
+func (o *FixedPoint) residual(x []float64) (rmax float64) {
+	o.gfcn(o.gx, x)
+	o.Nfeval++
+	for i := 0; i < o.neq; i++ {
+		o.r[i] = o.gx[i] - x[i]
+	}
+	return o.r.Largest(1.0)
+}
+
+// update computes the next x and returns the RMS of the scaled δx
+func (o *FixedPoint) update(x []float64) (Ldx float64) {
+
+	// relaxation parameter and Anderson's correction
+	ω := o.Beta
+	switch o.Method {
+	case "aitken":
+		if o.Niter > 0 {
+			num, den := 0.0, 0.0
+			for i := 0; i < o.neq; i++ {
+				dr := o.r[i] - o.rprev[i]
+				num += o.rprev[i] * dr
+				den += dr * dr
+			}
+			if den > 0 {
+				o.Omega = -o.Omega * num / den
+			}
+		}
+		ω = o.Omega
+	case "anderson":
+		if o.Niter > 0 && o.Depth > 0 {
+			o.andersonHistory(x)
+		}
+	}
+
+	// save current state
+	copy(o.xprev, x)
+	copy(o.rprev, o.r)
+
+	// update x
+	for i := 0; i < o.neq; i++ {
+		x[i] += ω * o.r[i]
+	}
+	if o.Method == "anderson" && len(o.dR) > 0 {
+		o.andersonCorrection(x)
+	}
+
+	// RMS of the scaled δx
+	for i := 0; i < o.neq; i++ {
+		δx := (x[i] - o.xprev[i]) / o.scal[i]
+		Ldx += δx * δx
+	}
+	return math.Sqrt(Ldx / float64(o.neq))
+}
+
+// andersonHistory appends the latest differences of x and r and computes the QR decomposition of
+// ΔR. The oldest columns are removed if there are more than Depth columns or if ΔR is (nearly)
+// rank deficient
+func (o *FixedPoint) andersonHistory(x []float64) {
+
+	// append differences
+	var dx, dr la.Vector
+	if len(o.dR) == o.Depth { // reuse the oldest vectors
+		dx, dr = o.dX[0], o.dR[0]
+		o.dX, o.dR = o.dX[1:], o.dR[1:]
+	} else {
+		dx, dr = la.NewVector(o.neq), la.NewVector(o.neq)
+	}
+	for i := 0; i < o.neq; i++ {
+		dx[i] = x[i] - o.xprev[i]
+		dr[i] = o.r[i] - o.rprev[i]
+	}
+	o.dX, o.dR = append(o.dX, dx), append(o.dR, dr)
+
+	// QR decomposition by modified Gram-Schmidt; drop the oldest column if ΔR is ill-conditioned
+	dropTol := 1e-10
+	for len(o.dR) > 0 {
+		ok := true
+		for j := 0; j < len(o.dR); j++ {
+			q := o.qmat[j]
+			copy(q, o.dR[j])
+			nrm := q.Norm()
+			for i := 0; i < j; i++ {
+				rij := la.VecDot(o.qmat[i], q)
+				o.rmat.Set(i, j, rij)
+				la.VecAdd(q, -rij, o.qmat[i], 1, q)
+			}
+			rjj := q.Norm()
+			if rjj <= dropTol*nrm || rjj == 0 {
+				ok = false
+				break
+			}
+			o.rmat.Set(j, j, rjj)
+			q.Apply(1.0/rjj, q)
+		}
+		if ok {
+			break
+		}
+		o.dX, o.dR = o.dX[1:], o.dR[1:]
+	}
+}
+
+// andersonCorrection computes γ = R⁻¹⋅Qᵀ⋅r and applies x -= (ΔX + β⋅ΔR)⋅γ
+func (o *FixedPoint) andersonCorrection(x []float64) {
+	mk := len(o.dR)
+	for i := mk - 1; i >= 0; i-- {
+		sum := la.VecDot(o.qmat[i], o.r)
+		for j := i + 1; j < mk; j++ {
+			sum -= o.rmat.Get(i, j) * o.γ[j]
+		}
+		o.γ[i] = sum / o.rmat.Get(i, i)
+	}
+	for j := 0; j < mk; j++ {
+		for i := 0; i < o.neq; i++ {
+			x[i] -= o.γ[j] * (o.dX[j][i] + o.Beta*o.dR[j][i])
+		}
+	}
+}
+
+// msg prints information on the iterations
+func (o *FixedPoint) msg(typ string, it int, Ldx, fxMax float64, first, last bool) {
+	if first {
+		io.Pf("\n%4s%23s%23s\n", "it", "Ldx", "fxMax")
+		io.Pf("%4s%23s%23s\n", "", io.Sf("(%7.1e)", o.config.fnewt), io.Sf("(%7.1e)", o.config.ftol))
+		return
+	}
+	io.Pf("%4d%23.15e%23.15e\n", it, Ldx, fxMax)
+	if last {
+		io.Pf(". . . converged with %s. nit=%d, nFeval=%d\n", typ, it, o.Nfeval)
+	}
+}
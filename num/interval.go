@@ -0,0 +1,486 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// Interval implements closed intervals [Lo, Hi] of real numbers with outward-rounded arithmetic [1,2]
+//
+//	The result of each operation contains all results of the operation applied to the numbers in
+//	the operands; i.e. if x ∈ X and y ∈ Y then x ∘ y ∈ X ∘ Y. Thus, evaluating a function with
+//	intervals gives rigorous bounds of its range, including all rounding errors.
+//
+//	Go has no control of the rounding mode; thus the bounds are rounded outward by means of
+//	error-free transformations (TwoSum and FMA): a bound is moved to the next floating-point
+//	number only if the rounded result is not exact. The elementary functions of the math package
+//	are assumed to be accurate within 1 ulp; their bounds are moved outward by 2 ulps.
+//
+//	Example:
+//	  x := NewInterval(1, 2)
+//	  y := x.Sqr().Sub(x.MulS(2))   // y ⊇ {x² - 2x : x ∈ [1, 2]} = [-1, 0]; y = [-3, 2]
+//
+//	NOTE: the bounds may be infinite; an empty interval is not represented (see Intersect)
+//
+//	References:
+//	  [1] Moore RE, Kearfott RB, Cloud MJ (2009) Introduction to Interval Analysis. SIAM. 223p
+//	  [2] Tucker W (2011) Validated Numerics: A Short Introduction to Rigorous Computations.
+//	      Princeton University Press. 144p
+type Interval struct {
+	Lo float64 // lower bound
+	Hi float64 // upper bound
+}
+
+// NewInterval returns the interval [lo, hi]
+func NewInterval(lo, hi float64) Interval {
+	if lo > hi || math.IsNaN(lo) || math.IsNaN(hi) {
+		chk.Panic("lower bound %g must not be greater than upper bound %g\n", lo, hi)
+	}
+	return Interval{lo, hi}
+}
+
+// IntervalPoint returns the degenerate interval [x, x]
+func IntervalPoint(x float64) Interval {
+	return Interval{x, x}
+}
+
+// IntervalEntire returns the interval (-∞, +∞)
+func IntervalEntire() Interval {
+	return Interval{math.Inf(-1), math.Inf(+1)}
+}
+
+// String returns a string representation of the interval
+func (a Interval) String() string {
+	return io.Sf("[%.17g, %.17g]", a.Lo, a.Hi)
+}
+
+// properties //////////////////////////////////////////////////////////////////////////////////////
+
+// Mid returns the midpoint of the interval (rounded; thus it may not be the exact midpoint)
+func (a Interval) Mid() float64 {
+	switch {
+	case math.IsInf(a.Lo, -1) && math.IsInf(a.Hi, +1):
+		return 0
+	case math.IsInf(a.Lo, -1):
+		return -math.MaxFloat64
+	case math.IsInf(a.Hi, +1):
+		return math.MaxFloat64
+	}
+	return a.Lo/2.0 + a.Hi/2.0
+}
+
+// Width returns an upper bound of the width Hi - Lo
+func (a Interval) Width() float64 {
+	return subUp(a.Hi, a.Lo)
+}
+
+// Mag returns the magnitude max{|x| : x ∈ a}
+func (a Interval) Mag() float64 {
+	return math.Max(math.Abs(a.Lo), math.Abs(a.Hi))
+}
+
+// Mig returns the mignitude min{|x| : x ∈ a}
+func (a Interval) Mig() float64 {
+	if a.Contains(0) {
+		return 0
+	}
+	return math.Min(math.Abs(a.Lo), math.Abs(a.Hi))
+}
+
+// Contains returns whether x ∈ a
+func (a Interval) Contains(x float64) bool {
+	return a.Lo <= x && x <= a.Hi
+}
+
+// Subset returns whether a ⊆ b
+func (a Interval) Subset(b Interval) bool {
+	return b.Lo <= a.Lo && a.Hi <= b.Hi
+}
+
+// Interior returns whether a is in the interior of b; i.e. b.Lo < a.Lo and a.Hi < b.Hi
+func (a Interval) Interior(b Interval) bool {
+	return b.Lo < a.Lo && a.Hi < b.Hi
+}
+
+// Intersect returns a ∩ b. Returns ok = false if the intersection is empty
+func (a Interval) Intersect(b Interval) (res Interval, ok bool) {
+	res = Interval{math.Max(a.Lo, b.Lo), math.Min(a.Hi, b.Hi)}
+	return res, res.Lo <= res.Hi
+}
+
+// Hull returns the smallest interval containing a and b
+func (a Interval) Hull(b Interval) Interval {
+	return Interval{math.Min(a.Lo, b.Lo), math.Max(a.Hi, b.Hi)}
+}
+
+// Bisect returns the halves [Lo, m] and [m, Hi] where m = Mid()
+func (a Interval) Bisect() (left, right Interval) {
+	m := a.Mid()
+	return Interval{a.Lo, m}, Interval{m, a.Hi}
+}
+
+// arithmetic //////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a Interval) Add(b Interval) Interval {
+	return Interval{addDown(a.Lo, b.Lo), addUp(a.Hi, b.Hi)}
+}
+
+// Sub returns a - b
+func (a Interval) Sub(b Interval) Interval {
+	return Interval{subDown(a.Lo, b.Hi), subUp(a.Hi, b.Lo)}
+}
+
+// Mul returns a ⋅ b
+func (a Interval) Mul(b Interval) Interval {
+	res := Interval{math.Inf(+1), math.Inf(-1)}
+	for _, x := range []float64{a.Lo, a.Hi} {
+		for _, y := range []float64{b.Lo, b.Hi} {
+			res.Lo = math.Min(res.Lo, mulDown(x, y))
+			res.Hi = math.Max(res.Hi, mulUp(x, y))
+		}
+	}
+	return res
+}
+
+// Div returns a / b. The divisor must not contain zero; see DivExt
+func (a Interval) Div(b Interval) Interval {
+	if b.Contains(0) {
+		chk.Panic("cannot divide by interval %v containing zero\n", b)
+	}
+	res := Interval{math.Inf(+1), math.Inf(-1)}
+	for _, x := range []float64{a.Lo, a.Hi} {
+		for _, y := range []float64{b.Lo, b.Hi} {
+			res.Lo = math.Min(res.Lo, divDown(x, y))
+			res.Hi = math.Max(res.Hi, divUp(x, y))
+		}
+	}
+	return res
+}
+
+// DivExt returns the extended division a / b = {x / y : x ∈ a, y ∈ b, y ≠ 0} as the union of n
+// intervals (n = 0, 1 or 2). The divisor may contain zero; e.g. [1, 2] / [-1, 1] = (-∞, -1] ∪ [1, +∞)
+func (a Interval) DivExt(b Interval) (q1, q2 Interval, n int) {
+	inf := math.Inf(+1)
+	switch {
+	case !b.Contains(0):
+		return a.Div(b), q2, 1
+	case a.Contains(0):
+		return IntervalEntire(), q2, 1
+	case b.Lo == 0 && b.Hi == 0:
+		return q1, q2, 0
+	case a.Hi < 0 && b.Hi == 0:
+		return Interval{divDown(a.Hi, b.Lo), inf}, q2, 1
+	case a.Hi < 0 && b.Lo == 0:
+		return Interval{-inf, divUp(a.Hi, b.Hi)}, q2, 1
+	case a.Hi < 0:
+		return Interval{-inf, divUp(a.Hi, b.Hi)}, Interval{divDown(a.Hi, b.Lo), inf}, 2
+	case b.Hi == 0:
+		return Interval{-inf, divUp(a.Lo, b.Lo)}, q2, 1
+	case b.Lo == 0:
+		return Interval{divDown(a.Lo, b.Hi), inf}, q2, 1
+	}
+	return Interval{-inf, divUp(a.Lo, b.Lo)}, Interval{divDown(a.Lo, b.Hi), inf}, 2
+}
+
+// Neg returns -a
+func (a Interval) Neg() Interval {
+	return Interval{-a.Hi, -a.Lo}
+}
+
+// AddS returns a + s where s is a scalar
+func (a Interval) AddS(s float64) Interval {
+	return a.Add(IntervalPoint(s))
+}
+
+// MulS returns a ⋅ s where s is a scalar
+func (a Interval) MulS(s float64) Interval {
+	return a.Mul(IntervalPoint(s))
+}
+
+// Inv returns 1/a
+func (a Interval) Inv() Interval {
+	return IntervalPoint(1).Div(a)
+}
+
+// Sqr returns a² (tighter than a⋅a if a contains zero)
+func (a Interval) Sqr() Interval {
+	return a.PowN(2)
+}
+
+// PowN returns aⁿ for an integer n ≥ 0 (tighter than the repeated product if n is even)
+func (a Interval) PowN(n int) Interval {
+	if n < 0 {
+		chk.Panic("exponent n=%d must be non-negative\n", n)
+	}
+	if n == 0 {
+		return IntervalPoint(1)
+	}
+	lo, hi := math.Abs(a.Lo), math.Abs(a.Hi)
+	if n%2 == 1 {
+		res := Interval{powDown(lo, n), powUp(hi, n)}
+		if a.Lo < 0 {
+			res.Lo = -powUp(lo, n)
+		}
+		if a.Hi < 0 {
+			res.Hi = -powDown(hi, n)
+		}
+		return res
+	}
+	if a.Contains(0) {
+		return Interval{0, powUp(math.Max(lo, hi), n)}
+	}
+	mig, mag := math.Min(lo, hi), math.Max(lo, hi)
+	return Interval{powDown(mig, n), powUp(mag, n)}
+}
+
+// elementary functions ////////////////////////////////////////////////////////////////////////////
+
+// Abs returns |a|
+func (a Interval) Abs() Interval {
+	return Interval{a.Mig(), a.Mag()}
+}
+
+// Sqrt returns √a. The interval must not have negative numbers
+func (a Interval) Sqrt() Interval {
+	if a.Lo < 0 {
+		chk.Panic("cannot compute the square root of %v\n", a)
+	}
+	return Interval{sqrtDown(a.Lo), sqrtUp(a.Hi)}
+}
+
+// Exp returns exp(a)
+func (a Interval) Exp() Interval {
+	return Interval{math.Max(0, libDown(math.Exp(a.Lo))), libUp(math.Exp(a.Hi))}
+}
+
+// Log returns the natural logarithm log(a). The interval must not have negative numbers
+func (a Interval) Log() Interval {
+	if a.Lo < 0 {
+		chk.Panic("cannot compute the logarithm of %v\n", a)
+	}
+	return Interval{libDown(math.Log(a.Lo)), libUp(math.Log(a.Hi))}
+}
+
+// Atan returns atan(a)
+func (a Interval) Atan() Interval {
+	halfPi := up(math.Pi / 2.0)
+	return Interval{math.Max(-halfPi, libDown(math.Atan(a.Lo))), math.Min(halfPi, libUp(math.Atan(a.Hi)))}
+}
+
+// Sin returns sin(a)
+//
+//	NOTE: [-1, 1] is returned if |a| > 1e8 because the argument reduction loses accuracy
+func (a Interval) Sin() Interval {
+	return a.periodic(math.Sin, math.Pi/2.0)
+}
+
+// Cos returns cos(a). See Sin
+func (a Interval) Cos() Interval {
+	return a.periodic(math.Cos, 0)
+}
+
+// periodic computes the range of sin or cos with maxima at xmax + 2kπ and minima at xmax + π + 2kπ
+func (a Interval) periodic(f func(float64) float64, xmax float64) Interval {
+	full := Interval{-1, 1}
+	if a.Mag() > 1e8 || a.Width() >= 2.0*math.Pi {
+		return full
+	}
+	flo, fhi := f(a.Lo), f(a.Hi)
+	res := Interval{libDown(math.Min(flo, fhi)), libUp(math.Max(flo, fhi))}
+	if containsPeriodic(a, xmax) {
+		res.Hi = 1
+	}
+	if containsPeriodic(a, xmax+math.Pi) {
+		res.Lo = -1
+	}
+	return Interval{math.Max(-1, res.Lo), math.Min(1, res.Hi)}
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// containsPeriodic returns whether a contains (approximately) one of the points c + 2kπ. Points
+// slightly outside a may be included; this only widens the result of periodic
+func containsPeriodic(a Interval, c float64) bool {
+	tol := 1e-15 * (1.0 + a.Mag())
+	k := math.Ceil((a.Lo - tol - c) / (2.0 * math.Pi))
+	return c+2.0*k*math.Pi <= a.Hi+tol
+}
+
+// tiny is the magnitude below which the error-free transformations may fail due to underflow
+const tiny = 1e-290
+
+// down returns the floating-point number before x
+func down(x float64) float64 {
+	return math.Nextafter(x, math.Inf(-1))
+}
+
+// up returns the floating-point number after x
+func up(x float64) float64 {
+	return math.Nextafter(x, math.Inf(+1))
+}
+
+// libDown moves a result of the math package 2 ulps downwards
+func libDown(x float64) float64 {
+	return down(down(x))
+}
+
+// libUp moves a result of the math package 2 ulps upwards
+func libUp(x float64) float64 {
+	return up(up(x))
+}
+
+// roundDown returns the rounded result r if it is a lower bound of the exact result r + e;
+// otherwise, returns the number before r. overflow indicates that r = ±∞ but the exact result is finite
+func roundDown(r, e float64, overflow, inexact bool) float64 {
+	if overflow {
+		if r > 0 {
+			return math.MaxFloat64
+		}
+		return r
+	}
+	if e < 0 || inexact {
+		return down(r)
+	}
+	return r
+}
+
+// roundUp returns the rounded result r if it is an upper bound of the exact result r + e;
+// otherwise, returns the number after r. See roundDown
+func roundUp(r, e float64, overflow, inexact bool) float64 {
+	if overflow {
+		if r < 0 {
+			return -math.MaxFloat64
+		}
+		return r
+	}
+	if e > 0 || inexact {
+		return up(r)
+	}
+	return r
+}
+
+// twoSum returns s = fl(a + b) and the error e = (a + b) - s (Knuth's TwoSum)
+func twoSum(a, b float64) (s, e float64, overflow bool) {
+	s = a + b
+	if math.IsInf(s, 0) {
+		return s, 0, !math.IsInf(a, 0) && !math.IsInf(b, 0)
+	}
+	bb := s - a
+	e = (a - (s - bb)) + (b - bb)
+	return
+}
+
+// addDown returns a lower bound of a + b
+func addDown(a, b float64) float64 {
+	s, e, overflow := twoSum(a, b)
+	return roundDown(s, e, overflow, false)
+}
+
+// addUp returns an upper bound of a + b
+func addUp(a, b float64) float64 {
+	s, e, overflow := twoSum(a, b)
+	return roundUp(s, e, overflow, false)
+}
+
+// subDown returns a lower bound of a - b
+func subDown(a, b float64) float64 {
+	return addDown(a, -b)
+}
+
+// subUp returns an upper bound of a - b
+func subUp(a, b float64) float64 {
+	return addUp(a, -b)
+}
+
+// twoProd returns p = fl(a ⋅ b) and the error e = a⋅b - p; the product 0 ⋅ ∞ is taken as 0
+func twoProd(a, b float64) (p, e float64, overflow, inexact bool) {
+	if a == 0 || b == 0 {
+		return 0, 0, false, false
+	}
+	p = a * b
+	if math.IsInf(p, 0) {
+		return p, 0, !math.IsInf(a, 0) && !math.IsInf(b, 0), false
+	}
+	e = math.FMA(a, b, -p)
+	return p, e, false, math.Abs(p) < tiny
+}
+
+// mulDown returns a lower bound of a ⋅ b
+func mulDown(a, b float64) float64 {
+	return roundDown(twoProd(a, b))
+}
+
+// mulUp returns an upper bound of a ⋅ b
+func mulUp(a, b float64) float64 {
+	return roundUp(twoProd(a, b))
+}
+
+// twoDiv returns q = fl(a / b) and a number e with the sign of the error a/b - q; the divisor
+// must not be zero; 0/∞ is taken as 0 and ∞/∞ is not allowed
+func twoDiv(a, b float64) (q, e float64, overflow, inexact bool) {
+	if math.IsInf(a, 0) && math.IsInf(b, 0) {
+		chk.Panic("cannot divide %g by %g\n", a, b)
+	}
+	q = a / b
+	if math.IsInf(q, 0) {
+		return q, 0, !math.IsInf(a, 0), false
+	}
+	if q == 0 {
+		return q, 0, false, a != 0 && !math.IsInf(b, 0) // underflow if a ≠ 0 and b is finite
+	}
+	r := math.FMA(-q, b, a) // a - q⋅b (exact)
+	return q, r * math.Copysign(1, b), false, math.Abs(q) < tiny || math.Abs(a) < tiny
+}
+
+// divDown returns a lower bound of a / b
+func divDown(a, b float64) float64 {
+	return roundDown(twoDiv(a, b))
+}
+
+// divUp returns an upper bound of a / b
+func divUp(a, b float64) float64 {
+	return roundUp(twoDiv(a, b))
+}
+
+// sqrtDown returns a lower bound of √a
+func sqrtDown(a float64) float64 {
+	s := math.Sqrt(a)
+	if math.IsInf(s, 0) || s == 0 {
+		return s
+	}
+	return roundDown(s, math.FMA(-s, s, a), false, a < tiny)
+}
+
+// sqrtUp returns an upper bound of √a
+func sqrtUp(a float64) float64 {
+	s := math.Sqrt(a)
+	if math.IsInf(s, 0) || s == 0 {
+		return s
+	}
+	return roundUp(s, math.FMA(-s, s, a), false, a < tiny)
+}
+
+// powDown returns a lower bound of xⁿ for x ≥ 0
+func powDown(x float64, n int) float64 {
+	res := 1.0
+	for i := 0; i < n; i++ {
+		res = mulDown(res, x)
+	}
+	return res
+}
+
+// powUp returns an upper bound of xⁿ for x ≥ 0
+func powUp(x float64, n int) float64 {
+	res := 1.0
+	for i := 0; i < n; i++ {
+		res = mulUp(res, x)
+	}
+	return res
+}
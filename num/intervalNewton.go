@@ -0,0 +1,272 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// IntervalNewton encloses all roots of a scalar function in a box (interval) by the interval
+// Newton method with extended division and bisection [1,2]
+//
+//	The Newton operator N(X) = m - f(m) / F'(X), with m = mid(X) and F' an interval extension of
+//	df/dx, contains all roots of f in X. Thus X is replaced by X ∩ N(X) (which may be empty or
+//	have two pieces if 0 ∈ F'(X)) and the box is bisected if the iterations do not make progress.
+//	If N(X) is in the interior of X, then X contains exactly one root.
+//
+//	NOTE: the functions must be evaluated with interval arithmetic, e.g. f(x) = x² - 2 as
+//	      func(x Interval) Interval { return x.Sqr().AddS(-2) }; otherwise roots may be lost
+//
+//	References:
+//	  [1] Moore RE, Kearfott RB, Cloud MJ (2009) Introduction to Interval Analysis. SIAM. 223p
+//	  [2] Hansen E, Walster GW (2004) Global Optimization Using Interval Analysis. Second Edition.
+//	      Marcel Dekker. 489p
+type IntervalNewton struct {
+
+	// configuration
+	MaxIt   int     // max number of processed boxes
+	Tol     float64 // boxes narrower than Tol are accepted as enclosures
+	Verbose bool    // show messages
+
+	// results
+	Unique []bool // Unique[i] indicates that the i-th enclosure has exactly one root (proven)
+
+	// statistics
+	NumFeval int // number of calls to ffcn
+	NumJeval int // number of calls to dfdx
+	NumIter  int // number of processed boxes
+
+	// internal
+	ffcn func(x Interval) Interval // interval extension of f(x)
+	dfdx func(x Interval) Interval // interval extension of df/dx
+}
+
+// NewIntervalNewton returns a new IntervalNewton structure
+//
+//	ffcn -- interval extension of f(x)
+//	dfdx -- interval extension of df/dx
+func NewIntervalNewton(ffcn, dfdx func(x Interval) Interval) (o *IntervalNewton) {
+	o = new(IntervalNewton)
+	o.MaxIt = 10000
+	o.Tol = 1e-10
+	o.ffcn = ffcn
+	o.dfdx = dfdx
+	return
+}
+
+// Solve returns sorted enclosures of all roots of f(x) in box. The enclosures with a proven unique
+// root are indicated in o.Unique. Enclosures without such proof (e.g. of multiple roots) may
+// also contain no root at all
+func (o *IntervalNewton) Solve(box Interval) (roots []Interval) {
+
+	// stack of boxes to be processed
+	type item struct {
+		X      Interval
+		unique bool
+	}
+	stack := []item{{box, false}}
+	var found []item
+	o.NumFeval, o.NumJeval, o.NumIter = 0, 0, 0
+
+	// message
+	if o.Verbose {
+		io.Pf("%6s%25s%25s%8s\n", "it", "lo", "hi", "unique")
+	}
+
+	// process boxes
+	for len(stack) > 0 {
+		if o.NumIter >= o.MaxIt {
+			chk.Panic("interval Newton did not finish after processing %d boxes\n", o.NumIter)
+		}
+		o.NumIter++
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		X := it.X
+		if o.Verbose {
+			io.Pf("%6d%25.17g%25.17g%8v\n", o.NumIter, X.Lo, X.Hi, it.unique)
+		}
+
+		// discard box without roots
+		o.NumFeval++
+		if !o.ffcn(X).Contains(0) {
+			continue
+		}
+
+		// accept narrow box
+		m := X.Mid()
+		if X.Width() <= o.Tol || m <= X.Lo || m >= X.Hi {
+			found = append(found, it)
+			continue
+		}
+
+		// Newton step
+		fm := o.ffcn(IntervalPoint(m))
+		DX := o.dfdx(X)
+		o.NumFeval++
+		o.NumJeval++
+		q1, q2, nq := fm.DivExt(DX)
+		var boxes []Interval
+		for k, q := range []Interval{q1, q2}[:nq] {
+			N := IntervalPoint(m).Sub(q)
+			if k == 0 && nq == 1 && N.Interior(X) {
+				it.unique = true
+			}
+			if Y, ok := X.Intersect(N); ok {
+				boxes = append(boxes, Y)
+			}
+		}
+
+		// bisect if the box has not been reduced enough (unless it has a unique root already)
+		if len(boxes) == 1 && boxes[0].Width() > 0.75*X.Width() {
+			if it.unique {
+				found = append(found, item{boxes[0], true})
+				continue
+			}
+			left, right := boxes[0].Bisect()
+			boxes = []Interval{left, right}
+		}
+		for _, Y := range boxes {
+			stack = append(stack, item{Y, it.unique && len(boxes) == 1})
+		}
+	}
+
+	// sort and merge overlapping enclosures (e.g. a root at a bisection point)
+	sort.Slice(found, func(i, j int) bool { return found[i].X.Lo < found[j].X.Lo })
+	o.Unique = make([]bool, 0, len(found))
+	for _, it := range found {
+		n := len(roots)
+		if n > 0 && it.X.Lo <= roots[n-1].Hi {
+			roots[n-1] = roots[n-1].Hull(it.X)
+			o.Unique[n-1] = false
+			continue
+		}
+		roots = append(roots, it.X)
+		o.Unique = append(o.Unique, it.unique)
+	}
+
+	// try to prove the uniqueness of the remaining enclosures
+	for i, X := range roots {
+		if !o.Unique[i] {
+			roots[i], o.Unique[i] = o.verify(X)
+		}
+	}
+	return
+}
+
+// verify tries to prove that a slightly inflated X has a unique root (ϵ-inflation). This is needed
+// if the root is at the boundary of X; e.g. when the root coincides with a bisection point
+func (o *IntervalNewton) verify(X Interval) (res Interval, unique bool) {
+	δ := 0.1*X.Width() + MACHEPS*X.Mag() + math.SmallestNonzeroFloat64
+	Xi := Interval{subDown(X.Lo, δ), addUp(X.Hi, δ)}
+	m := Xi.Mid()
+	fm := o.ffcn(IntervalPoint(m))
+	DX := o.dfdx(Xi)
+	o.NumFeval++
+	o.NumJeval++
+	if DX.Contains(0) {
+		return X, false
+	}
+	N := IntervalPoint(m).Sub(fm.Div(DX))
+	if !N.Interior(Xi) {
+		return X, false
+	}
+	res, _ = Xi.Intersect(N)
+	return res, true
+}
+
+// Krawczyk applies the Krawczyk operator [1] to enclose a root of a system of equations f(x) = 0
+// in the box X and to prove its existence and uniqueness
+//
+//	K(X) = m - Y⋅f(m) + (I - Y⋅J(X))⋅(X - m)
+//
+//	where m = mid(X), J(X) is an interval extension of the Jacobian matrix and Y ≈ J(m)⁻¹ is the
+//	inverse of the midpoint Jacobian. Every root of f in X is also in K(X); thus X is iteratively
+//	replaced by X ∩ K(X). If K(X) is in the interior of X, then X contains exactly one root.
+//
+//	INPUT:
+//	  ffcn  -- interval extension of f(x): fx = f(x)
+//	  jfcn  -- interval extension of the Jacobian: J[i][j] = dfᵢ/dxⱼ(x)
+//	  X     -- initial box
+//	  maxIt -- max number of iterations; the iterations stop earlier if the box is not reduced
+//
+//	OUTPUT:
+//	  res    -- the reduced box; nil if X has no root
+//	  unique -- indicates that res contains exactly one root (proven)
+func Krawczyk(ffcn func(fx, x []Interval), jfcn func(J [][]Interval, x []Interval), X []Interval, maxIt int) (res []Interval, unique bool) {
+
+	// allocate
+	n := len(X)
+	res = make([]Interval, n)
+	copy(res, X)
+	m := make([]Interval, n)
+	fm := make([]Interval, n)
+	K := make([]Interval, n)
+	J := make([][]Interval, n)
+	for i := 0; i < n; i++ {
+		J[i] = make([]Interval, n)
+	}
+	Jm := la.NewMatrix(n, n)
+	Y := la.NewMatrix(n, n)
+
+	// iterations
+	for it := 0; it < maxIt; it++ {
+
+		// midpoint, function and Jacobian
+		width := 0.0
+		for i := 0; i < n; i++ {
+			m[i] = IntervalPoint(res[i].Mid())
+			width = math.Max(width, res[i].Width())
+		}
+		ffcn(fm, m)
+		jfcn(J, res)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				Jm.Set(i, j, J[i][j].Mid())
+			}
+		}
+		la.MatInv(Y, Jm, false)
+
+		// K = m - Y⋅f(m) + (I - Y⋅J)⋅(X - m)
+		inside := true
+		for i := 0; i < n; i++ {
+			K[i] = m[i]
+			for j := 0; j < n; j++ {
+				K[i] = K[i].Sub(fm[j].MulS(Y.Get(i, j)))
+				var c Interval // c = δᵢⱼ - Σₖ Yᵢₖ⋅Jₖⱼ
+				if i == j {
+					c = IntervalPoint(1)
+				}
+				for k := 0; k < n; k++ {
+					c = c.Sub(J[k][j].MulS(Y.Get(i, k)))
+				}
+				K[i] = K[i].Add(c.Mul(res[j].Sub(m[j])))
+			}
+			if !K[i].Interior(res[i]) {
+				inside = false
+			}
+		}
+		unique = unique || inside
+
+		// X ← X ∩ K
+		newWidth := 0.0
+		for i := 0; i < n; i++ {
+			var ok bool
+			res[i], ok = res[i].Intersect(K[i])
+			if !ok {
+				return nil, false
+			}
+			newWidth = math.Max(newWidth, res[i].Width())
+		}
+		if newWidth >= width {
+			break
+		}
+	}
+	return
+}
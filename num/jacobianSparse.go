@@ -0,0 +1,153 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// SparseJacobian computes sparse Jacobian matrices by finite differences with one function
+// evaluation per group (color) of columns [1]. The columns of a group are structurally orthogonal,
+// i.e. no two of them have a non-zero in the same row; thus they can be perturbed simultaneously.
+// The groups are found by the greedy (sequential) method of Curtis, Powell and Reid [1,2]. For
+// instance, a tridiagonal matrix requires 3 function evaluations instead of n.
+//
+//	NOTE: (1) the sparsity pattern must include all entries that may be non-zero; otherwise the
+//	          derivatives of the missing entries are added to other entries of the same group
+//	      (2) Calc uses internal workspace; thus the same object must not be used concurrently
+//
+//	References:
+//	  [1] Curtis AR, Powell MJD, Reid JK (1974) On the estimation of sparse Jacobian matrices.
+//	      IMA Journal of Applied Mathematics, 13(1):117-119
+//	  [2] Coleman TF, Moré JJ (1983) Estimation of sparse Jacobian matrices and graph coloring
+//	      problems. SIAM Journal on Numerical Analysis, 20(1):187-209
+type SparseJacobian struct {
+	Ncolors int   // number of colors (groups) = number of function evaluations per Jacobian
+	Colors  []int // color of each column
+
+	// internal
+	m, n   int     // number of rows (equations) and columns (variables)
+	nnz    int     // number of non-zeros in the pattern
+	rows   [][]int // row indices of the non-zeros in each column (sorted, unique)
+	groups [][]int // columns of each color
+
+	// workspace
+	xsafe []float64 // unperturbed x [n]
+	delta []float64 // perturbations [n]
+}
+
+// NewSparseJacobian returns a new SparseJacobian with the sparsity pattern given by a Triplet.
+// The values in the Triplet are ignored; repeated entries are allowed
+func NewSparseJacobian(pattern *la.Triplet) (o *SparseJacobian) {
+	m, n := pattern.Size()
+	I, J := pattern.Indices()
+	return newSparseJacobian(m, n, I, J)
+}
+
+// NewSparseJacobianPairs returns a new SparseJacobian with the sparsity pattern given by a list of
+// (row, column) index pairs; e.g. pairs = [][]int{{0,0}, {0,1}, {1,1}}
+func NewSparseJacobianPairs(m, n int, pairs [][]int) (o *SparseJacobian) {
+	I, J := make([]int, len(pairs)), make([]int, len(pairs))
+	for k, p := range pairs {
+		if len(p) != 2 {
+			chk.Panic("each pair must have two indices (row, column). pair %d = %v is invalid", k, p)
+		}
+		I[k], J[k] = p[0], p[1]
+	}
+	return newSparseJacobian(m, n, I, J)
+}
+
+// Nnz returns the number of non-zeros in the sparsity pattern
+func (o *SparseJacobian) Nnz() int {
+	return o.nnz
+}
+
+// Calc computes the Jacobian matrix J = df/dx at x by forward differences
+//
+//	INPUT:
+//	    ffcn : f(x) function
+//	    x    : station where dfdx has to be calculated [restored on exit]
+//	    fx   : f @ x
+//	    w    : workspace with size == m == len(fx)
+//	RETURNS:
+//	    J : dfdx @ x [must be pre-allocated with at least Nnz() entries or be empty]
+func (o *SparseJacobian) Calc(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64) {
+	if len(x) != o.n || len(fx) != o.m {
+		chk.Panic("the lengths of x and fx must be equal to %d and %d. %d and %d are invalid", o.n, o.m, len(x), len(fx))
+	}
+	if J.Max() == 0 {
+		J.Init(o.m, o.n, o.nnz)
+	}
+	J.Start()
+	for _, group := range o.groups {
+		for _, col := range group {
+			o.xsafe[col] = x[col]
+			o.delta[col] = math.Sqrt(MACHEPS * utl.Max(1e-5, math.Abs(x[col])))
+			x[col] += o.delta[col]
+		}
+		ffcn(w, x) // w := f(x + Σ δx[col] for col in group)
+		for _, col := range group {
+			x[col] = o.xsafe[col]
+			for _, row := range o.rows[col] {
+				J.Put(row, col, (w[row]-fx[row])/o.delta[col])
+			}
+		}
+	}
+}
+
+// newSparseJacobian builds the pattern and colors the columns
+func newSparseJacobian(m, n int, I, J []int) (o *SparseJacobian) {
+
+	// pattern: rows of each column and columns of each row
+	o = new(SparseJacobian)
+	o.m, o.n = m, n
+	o.xsafe = make([]float64, n)
+	o.delta = make([]float64, n)
+	o.rows = make([][]int, n)
+	for k := range I {
+		if I[k] < 0 || I[k] >= m || J[k] < 0 || J[k] >= n {
+			chk.Panic("index pair (%d,%d) is outside the %d×%d matrix", I[k], J[k], m, n)
+		}
+		o.rows[J[k]] = append(o.rows[J[k]], I[k])
+	}
+	cols := make([][]int, m)
+	for j := 0; j < n; j++ {
+		o.rows[j] = utl.IntUnique(o.rows[j])
+		for _, i := range o.rows[j] {
+			cols[i] = append(cols[i], j)
+		}
+		o.nnz += len(o.rows[j])
+	}
+
+	// greedy coloring in the natural order: column j receives the smallest color that is not
+	// used by any previous column sharing a row with j
+	o.Colors = make([]int, n)
+	mark := make([]int, n+1) // mark[c] == j+1 ⇒ color c is forbidden for column j
+	for j := 0; j < n; j++ {
+		for _, i := range o.rows[j] {
+			for _, k := range cols[i] {
+				if k < j {
+					mark[o.Colors[k]] = j + 1
+				}
+			}
+		}
+		c := 0
+		for mark[c] == j+1 {
+			c++
+		}
+		o.Colors[j] = c
+		if c == o.Ncolors {
+			o.Ncolors++
+			o.groups = append(o.groups, nil)
+		}
+		o.groups[c] = append(o.groups[c], j)
+	}
+	return
+}
@@ -0,0 +1,830 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// LeastSquares solves nonlinear least-squares problems
+//
+//	find x that minimises χ²(x) = Σᵢ wᵢ⋅rᵢ(x)²   subject to   lower ≤ x ≤ upper
+//
+//	where r(x) are m residuals (e.g. rᵢ = yᵢ - model(tᵢ;x)), x are n parameters and wᵢ are
+//	weights (e.g. wᵢ = 1/σᵢ² where σᵢ are the standard deviations of the data). Methods:
+//
+//	"lm"  -- Levenberg-Marquardt with Marquardt's scaling and Nielsen's update of the damping
+//	         parameter [1,2]; bounds are not considered
+//	"trf" -- trust-region reflective method for bound-constrained problems [3,4]: the iterates
+//	         remain strictly feasible by means of the Coleman-Li scaling and reflections on the
+//	         bounds
+//
+//	After Solve, the covariance matrix of the parameters is estimated by Cov = (Jᵀ⋅W⋅J)⁻¹. If no
+//	weights are given, Cov is multiplied by χ²/(m-n); i.e. the variance of the data is estimated
+//	from the residuals (as in LinFitSigma).
+//
+//	References:
+//	  [1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	      Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//	  [2] Madsen K, Nielsen HB, Tingleff O (2004) Methods for Non-Linear Least Squares Problems.
+//	      Technical University of Denmark. 60p
+//	  [3] Coleman TF, Li Y (1996) An interior trust region approach for nonlinear minimization
+//	      subject to bounds. SIAM Journal on Optimization, 6(2):418-445
+//	  [4] Branch MA, Coleman TF, Li Y (1999) A subspace, interior, and conjugate gradient method
+//	      for large-scale bound-constrained minimization problems. SIAM Journal on Scientific
+//	      Computing, 21(1):1-23
+type LeastSquares struct {
+
+	// configuration
+	Method  string  // "lm" (Levenberg-Marquardt) or "trf" (trust-region reflective)
+	MaxIt   int     // maximum number of iterations
+	Ftol    float64 // tolerance on the relative reduction of χ²
+	Xtol    float64 // tolerance on the relative size of the step
+	Gtol    float64 // tolerance on the gradient (cosine of the angle between r and columns of J; with "trf", also on the scaled gradient max|vⱼ⋅gⱼ|)
+	Verbose bool    // show messages
+
+	// results
+	X         la.Vector  // parameters [n]
+	R         la.Vector  // (unweighted) residuals at X [m]
+	Chi2      float64    // χ² = Σ wᵢ⋅rᵢ²
+	Dof       int        // number of degrees of freedom = m - n
+	Cov       *la.Matrix // covariance matrix of parameters [n][n]
+	StdErr    la.Vector  // standard errors of parameters = √diag(Cov) [n]
+	Corr      *la.Matrix // correlation matrix of parameters [n][n]
+	Converged bool       // convergence has been achieved
+	Reason    string     // reason for stopping
+	Niter     int        // number of iterations
+	Nfeval    int        // number of residual evaluations
+	Njeval    int        // number of Jacobian evaluations (numerical or not)
+
+	// internal
+	m, n   int        // number of residuals and parameters
+	ffcn   fun.Vv     // residuals r(x)
+	jfcn   fun.Mv     // Jacobian dr/dx [may be nil ⇒ numerical]
+	sqw    la.Vector  // √w [may be nil]
+	lower  la.Vector  // lower bounds [may be nil]
+	upper  la.Vector  // upper bounds [may be nil]
+	r      la.Vector  // weighted residuals
+	J      *la.Matrix // weighted Jacobian
+	A      *la.Matrix // Jᵀ⋅J
+	g      la.Vector  // Jᵀ⋅r
+	rtrial la.Vector  // weighted residuals at trial point
+	xtrial la.Vector  // trial point
+	work   la.Vector  // workspace for numerical Jacobian
+}
+
+// NewLeastSquares returns a new nonlinear least-squares solver
+//
+//	m        -- number of residuals
+//	n        -- number of parameters (n ≤ m)
+//	residual -- function computing the residuals r(x)
+//
+//	Defaults: Method = "lm", MaxIt = 200 and Ftol = Xtol = Gtol = 1e-10. The Jacobian is computed
+//	by forward differences unless SetJacobian is called.
+func NewLeastSquares(m, n int, residual fun.Vv) (o *LeastSquares) {
+	if n < 1 || m < n {
+		chk.Panic("number of residuals (m=%d) must be greater than or equal to the number of parameters (n=%d ≥ 1)\n", m, n)
+	}
+	o = new(LeastSquares)
+	o.Method = "lm"
+	o.MaxIt = 200
+	o.Ftol = 1e-10
+	o.Xtol = 1e-10
+	o.Gtol = 1e-10
+	o.m, o.n = m, n
+	o.ffcn = residual
+	o.r = la.NewVector(m)
+	o.rtrial = la.NewVector(m)
+	o.work = la.NewVector(m)
+	o.J = la.NewMatrix(m, n)
+	o.A = la.NewMatrix(n, n)
+	o.g = la.NewVector(n)
+	o.xtrial = la.NewVector(n)
+	return
+}
+
+// SetJacobian sets the function computing the Jacobian J = dr/dx [m][n]
+func (o *LeastSquares) SetJacobian(jacobian fun.Mv) {
+	o.jfcn = jacobian
+}
+
+// SetWeights sets the weights wᵢ ≥ 0 of the residuals [m]; e.g. wᵢ = 1/σᵢ²
+func (o *LeastSquares) SetWeights(w []float64) {
+	if len(w) != o.m {
+		chk.Panic("the number of weights must be equal to m=%d. len(w)=%d is invalid\n", o.m, len(w))
+	}
+	o.sqw = la.NewVector(o.m)
+	for i, v := range w {
+		if v < 0 {
+			chk.Panic("weights must be non-negative. w[%d]=%g is invalid\n", i, v)
+		}
+		o.sqw[i] = math.Sqrt(v)
+	}
+}
+
+// SetBounds sets the lower and upper bounds of the parameters and selects the "trf" method
+//
+//	lower, upper -- bounds [n]; use math.Inf(-1) or math.Inf(+1) for unbounded parameters
+func (o *LeastSquares) SetBounds(lower, upper []float64) {
+	if len(lower) != o.n || len(upper) != o.n {
+		chk.Panic("bounds must have length equal to n=%d. len(lower)=%d and len(upper)=%d are invalid\n", o.n, len(lower), len(upper))
+	}
+	for i := 0; i < o.n; i++ {
+		if lower[i] >= upper[i] {
+			chk.Panic("lower bounds must be smaller than upper bounds. lower[%d]=%g and upper[%d]=%g are invalid\n", i, lower[i], i, upper[i])
+		}
+	}
+	o.lower = la.NewVectorSlice(lower).GetCopy()
+	o.upper = la.NewVectorSlice(upper).GetCopy()
+	o.Method = "trf"
+}
+
+// Solve solves the least-squares problem starting at x0 and computes the statistics
+func (o *LeastSquares) Solve(x0 []float64) {
+
+	// check
+	if len(x0) != o.n {
+		chk.Panic("initial guess must have length equal to n=%d. len(x0)=%d is invalid\n", o.n, len(x0))
+	}
+	o.X = la.NewVectorSlice(x0).GetCopy()
+	o.Converged, o.Reason = false, ""
+	o.Niter, o.Nfeval, o.Njeval = 0, 0, 0
+
+	// solve
+	switch o.Method {
+	case "lm":
+		o.solveLM()
+	case "trf":
+		if o.lower == nil {
+			o.lower = la.NewVector(o.n)
+			o.upper = la.NewVector(o.n)
+			o.lower.Fill(math.Inf(-1))
+			o.upper.Fill(math.Inf(1))
+		}
+		o.solveTRF()
+	default:
+		chk.Panic("method %q is not available. Options are \"lm\" and \"trf\"\n", o.Method)
+	}
+
+	// statistics
+	o.statistics()
+}
+
+// Report returns a summary of the results
+func (o *LeastSquares) Report() string {
+	var b bytes.Buffer
+	io.Ff(&b, "method     = %s\n", o.Method)
+	io.Ff(&b, "converged  = %v (%s)\n", o.Converged, o.Reason)
+	io.Ff(&b, "iterations = %d\n", o.Niter)
+	io.Ff(&b, "nfeval     = %d\n", o.Nfeval)
+	io.Ff(&b, "njeval     = %d\n", o.Njeval)
+	io.Ff(&b, "χ²         = %g\n", o.Chi2)
+	io.Ff(&b, "dof        = %d\n", o.Dof)
+	if o.Dof > 0 {
+		io.Ff(&b, "χ²/dof     = %g\n", o.Chi2/float64(o.Dof))
+	}
+	for j := 0; j < o.n; j++ {
+		io.Ff(&b, "x[%d]       = %23.15e ± %.6e\n", j, o.X[j], o.StdErr[j])
+	}
+	return b.String()
+}
+
+// solveLM implements the Levenberg-Marquardt method
+func (o *LeastSquares) solveLM() {
+
+	// initial values
+	χ2 := o.residuals(o.r, o.X)
+	o.update()
+	μ := 0.0
+	for j := 0; j < o.n; j++ {
+		μ = math.Max(μ, o.A.Get(j, j))
+	}
+	μ *= 1e-3
+	ν := 2.0
+	o.msgLM(0, χ2, μ, 0, true)
+
+	// iterations
+	h := la.NewVector(o.n)
+	d := la.NewVector(o.n)
+	for o.Niter = 1; o.Niter <= o.MaxIt; o.Niter++ {
+
+		// check gradient
+		if o.checkGradient(χ2) {
+			return
+		}
+
+		// step: (A + μ⋅diag(A))⋅h = -g
+		for j := 0; j < o.n; j++ {
+			d[j] = μ * math.Max(o.A.Get(j, j), MACHEPS)
+		}
+		if !lsqCholSolve(h, o.A, d, o.g, -1) {
+			μ *= ν
+			ν *= 2
+			continue
+		}
+		if o.checkStep(h) {
+			return
+		}
+
+		// trial point
+		for j := 0; j < o.n; j++ {
+			o.xtrial[j] = o.X[j] + h[j]
+		}
+		χ2new := o.residuals(o.rtrial, o.xtrial)
+		actual := χ2 - χ2new
+		pred := -lsqModel(o.A, d, o.g, h)
+		ρ := -1.0
+		if pred > 0 && !math.IsNaN(χ2new) {
+			ρ = actual / pred
+		}
+		o.msgLM(o.Niter, χ2new, μ, ρ, false)
+
+		// accept or reject
+		if ρ > 0 {
+			copy(o.X, o.xtrial)
+			copy(o.r, o.rtrial)
+			χ2old := χ2
+			χ2 = χ2new
+			o.update()
+			μ *= math.Max(1.0/3.0, 1.0-math.Pow(2.0*ρ-1.0, 3))
+			ν = 2
+			if o.checkReduction(actual, pred, χ2old) {
+				return
+			}
+		} else {
+			μ *= ν
+			ν *= 2
+		}
+	}
+	o.Niter = o.MaxIt
+	o.Reason = io.Sf("maximum number of iterations (%d) reached", o.MaxIt)
+}
+
+// solveTRF implements the trust-region reflective method
+func (o *LeastSquares) solveTRF() {
+
+	// initial point must be strictly feasible
+	for j := 0; j < o.n; j++ {
+		o.X[j] = lsqStrictlyFeasible(o.X[j], o.lower[j], o.upper[j])
+	}
+
+	// initial values
+	χ2 := o.residuals(o.r, o.X)
+	o.update()
+	v := la.NewVector(o.n)
+	dv := la.NewVector(o.n)
+	d := la.NewVector(o.n)
+	Δ := 0.0
+	o.scaling(v, dv, o.g)
+	for j := 0; j < o.n; j++ {
+		Δ += o.X[j] * o.X[j] / v[j]
+	}
+	Δ = math.Sqrt(Δ)
+	if Δ == 0 {
+		Δ = 1
+	}
+	o.msgLM(0, χ2, Δ, 0, true)
+
+	// iterations
+	Bh := la.NewMatrix(o.n, o.n)
+	diagH := la.NewVector(o.n)
+	gh := la.NewVector(o.n)
+	sh := la.NewVector(o.n)
+	s := la.NewVector(o.n)
+	for o.Niter = 1; o.Niter <= o.MaxIt; o.Niter++ {
+
+		// Coleman-Li scaling
+		o.scaling(v, dv, o.g)
+		gnorm := 0.0
+		for j := 0; j < o.n; j++ {
+			gnorm = math.Max(gnorm, math.Abs(v[j]*o.g[j]))
+		}
+		if o.checkGradient(χ2) {
+			return
+		}
+		if gnorm <= o.Gtol {
+			o.Converged, o.Reason = true, "gtol: the scaled gradient max|vⱼ⋅gⱼ| is small"
+			return
+		}
+
+		// scaled quadratic model: B̂ = D⋅Jᵀ⋅J⋅D + diag(g⋅dv); ĝ = D⋅g
+		for j := 0; j < o.n; j++ {
+			d[j] = math.Sqrt(v[j])
+			diagH[j] = o.g[j] * dv[j]
+			gh[j] = d[j] * o.g[j]
+		}
+		for i := 0; i < o.n; i++ {
+			for j := 0; j < o.n; j++ {
+				Bh.Set(i, j, d[i]*o.A.Get(i, j)*d[j])
+			}
+		}
+		θ := math.Max(0.995, 1.0-gnorm)
+
+		// find an acceptable step
+		for {
+			lsqTrustRegion(sh, Bh, diagH, gh, Δ)
+			pred := o.selectStep(s, sh, d, Bh, diagH, gh, Δ, θ)
+			shNorm := 0.0
+			for j := 0; j < o.n; j++ {
+				o.xtrial[j] = lsqStrictlyFeasible(o.X[j]+s[j], o.lower[j], o.upper[j])
+				shNorm += sh[j] * sh[j]
+			}
+			shNorm = math.Sqrt(shNorm)
+			χ2new := o.residuals(o.rtrial, o.xtrial)
+			actual := χ2 - χ2new
+			ρ := -1.0
+			if pred > 0 && !math.IsNaN(χ2new) {
+				ρ = actual / pred
+			}
+			o.msgLM(o.Niter, χ2new, Δ, ρ, false)
+
+			// update trust-region radius
+			if ρ < 0.25 {
+				Δ = 0.25 * shNorm
+			} else if ρ > 0.75 && shNorm > 0.95*Δ {
+				Δ *= 2.0
+			}
+
+			// accept
+			if ρ > 0 {
+				copy(o.X, o.xtrial)
+				copy(o.r, o.rtrial)
+				χ2old := χ2
+				χ2 = χ2new
+				o.update()
+				if o.checkReduction(actual, pred, χ2old) || o.checkStep(s) {
+					return
+				}
+				break
+			}
+
+			// reject
+			if o.checkStep(s) {
+				return
+			}
+			o.Niter++
+			if o.Niter > o.MaxIt {
+				break
+			}
+		}
+	}
+	o.Niter = o.MaxIt
+	o.Reason = io.Sf("maximum number of iterations (%d) reached", o.MaxIt)
+}
+
+// selectStep selects the step s (unscaled) among the truncated, reflected and (scaled) steepest
+// descent steps such that x+s remains strictly feasible; sh is the scaled step on input and the
+// selected scaled step on output. Returns the predicted reduction of χ²
+func (o *LeastSquares) selectStep(s, sh, d la.Vector, Bh *la.Matrix, diagH, gh la.Vector, Δ, θ float64) (pred float64) {
+
+	// unconstrained step is feasible
+	for j := 0; j < o.n; j++ {
+		s[j] = d[j] * sh[j]
+	}
+	α, hits := o.stepToBound(o.X, s)
+	if α >= 1 {
+		return -lsqModel(Bh, diagH, gh, sh)
+	}
+
+	// truncated step
+	best := la.NewVector(o.n)
+	for j := 0; j < o.n; j++ {
+		best[j] = θ * α * sh[j]
+	}
+	bestValue := lsqModel(Bh, diagH, gh, best)
+
+	// reflected step: from the point on the bound, change the sign of the hit components
+	ph := la.NewVector(o.n)
+	rh := la.NewVector(o.n)
+	xb := la.NewVector(o.n)
+	r := la.NewVector(o.n)
+	for j := 0; j < o.n; j++ {
+		ph[j] = α * sh[j]
+		rh[j] = sh[j]
+		if hits[j] {
+			rh[j] = -sh[j]
+		}
+		r[j] = d[j] * rh[j]
+		xb[j] = o.X[j] + d[j]*ph[j]
+	}
+	toTR := lsqToSphere(ph, rh, Δ)
+	toBound, _ := o.stepToBound(xb, r)
+	stride := math.Min(toBound, toTR)
+	if stride > 0 {
+		lo := (1.0 - θ) * α / stride
+		hi := θ * stride
+		t, value := lsqMinimiseLine(Bh, diagH, gh, ph, rh, lo, hi)
+		if value < bestValue {
+			for j := 0; j < o.n; j++ {
+				best[j] = ph[j] + t*rh[j]
+			}
+			bestValue = value
+		}
+	}
+
+	// anti-gradient step
+	ag := la.NewVector(o.n)
+	agNorm := 0.0
+	for j := 0; j < o.n; j++ {
+		rh[j] = -gh[j]
+		ag[j] = d[j] * rh[j]
+		agNorm += rh[j] * rh[j]
+		ph[j] = 0
+	}
+	if agNorm > 0 {
+		toTR = Δ / math.Sqrt(agNorm)
+		toBound, _ = o.stepToBound(o.X, ag)
+		stride = θ * math.Min(toBound, toTR)
+		t, value := lsqMinimiseLine(Bh, diagH, gh, ph, rh, 0, stride)
+		if value < bestValue {
+			for j := 0; j < o.n; j++ {
+				best[j] = t * rh[j]
+			}
+			bestValue = value
+		}
+	}
+
+	// selected step
+	for j := 0; j < o.n; j++ {
+		sh[j] = best[j]
+		s[j] = d[j] * sh[j]
+	}
+	return -bestValue
+}
+
+// stepToBound returns the largest α such that x + α⋅s is within bounds and the components hitting
+// the bounds
+func (o *LeastSquares) stepToBound(x, s la.Vector) (α float64, hits []bool) {
+	α = math.Inf(1)
+	steps := make([]float64, o.n)
+	for j := 0; j < o.n; j++ {
+		steps[j] = math.Inf(1)
+		if s[j] > 0 {
+			steps[j] = (o.upper[j] - x[j]) / s[j]
+		} else if s[j] < 0 {
+			steps[j] = (o.lower[j] - x[j]) / s[j]
+		}
+		α = math.Min(α, steps[j])
+	}
+	hits = make([]bool, o.n)
+	for j := 0; j < o.n; j++ {
+		hits[j] = steps[j] == α
+	}
+	return
+}
+
+// scaling computes the Coleman-Li scaling vector v and its derivative dv
+func (o *LeastSquares) scaling(v, dv, g la.Vector) {
+	for j := 0; j < o.n; j++ {
+		v[j], dv[j] = 1, 0
+		if g[j] < 0 && !math.IsInf(o.upper[j], 0) {
+			v[j], dv[j] = o.upper[j]-o.X[j], -1
+		} else if g[j] > 0 && !math.IsInf(o.lower[j], 0) {
+			v[j], dv[j] = o.X[j]-o.lower[j], 1
+		}
+	}
+}
+
+// residuals computes the weighted residuals at x and returns χ²
+func (o *LeastSquares) residuals(r, x la.Vector) (χ2 float64) {
+	o.ffcn(r, x)
+	o.Nfeval++
+	for i := 0; i < o.m; i++ {
+		if o.sqw != nil {
+			r[i] *= o.sqw[i]
+		}
+		χ2 += r[i] * r[i]
+	}
+	return
+}
+
+// update computes the weighted Jacobian, A = Jᵀ⋅J and g = Jᵀ⋅r at X
+func (o *LeastSquares) update() {
+	o.Njeval++
+	if o.jfcn != nil {
+		o.jfcn(o.J, o.X)
+		if o.sqw != nil {
+			for i := 0; i < o.m; i++ {
+				for j := 0; j < o.n; j++ {
+					o.J.Set(i, j, o.sqw[i]*o.J.Get(i, j))
+				}
+			}
+		}
+	} else {
+		for j := 0; j < o.n; j++ {
+			xsafe := o.X[j]
+			δ := math.Sqrt(MACHEPS) * math.Max(1.0, math.Abs(xsafe))
+			if o.upper != nil && xsafe+δ > o.upper[j] {
+				δ = -δ
+			}
+			o.X[j] = xsafe + δ
+			o.residuals(o.work, o.X)
+			o.Nfeval--
+			for i := 0; i < o.m; i++ {
+				o.J.Set(i, j, (o.work[i]-o.r[i])/δ)
+			}
+			o.X[j] = xsafe
+		}
+	}
+	for i := 0; i < o.n; i++ {
+		o.g[i] = 0
+		for k := 0; k < o.m; k++ {
+			o.g[i] += o.J.Get(k, i) * o.r[k]
+		}
+		for j := 0; j <= i; j++ {
+			sum := 0.0
+			for k := 0; k < o.m; k++ {
+				sum += o.J.Get(k, i) * o.J.Get(k, j)
+			}
+			o.A.Set(i, j, sum)
+			o.A.Set(j, i, sum)
+		}
+	}
+}
+
+// checkGradient checks whether the residuals are orthogonal to the columns of J
+func (o *LeastSquares) checkGradient(χ2 float64) (stop bool) {
+	if χ2 == 0 {
+		o.Converged, o.Reason = true, "zero residuals"
+		return true
+	}
+	cos := 0.0
+	for j := 0; j < o.n; j++ {
+		if o.A.Get(j, j) > 0 {
+			cos = math.Max(cos, math.Abs(o.g[j])/math.Sqrt(o.A.Get(j, j)*χ2))
+		}
+	}
+	if cos <= o.Gtol {
+		o.Converged, o.Reason = true, "gtol: the residuals are orthogonal to the columns of the Jacobian"
+		return true
+	}
+	return false
+}
+
+// checkStep checks whether the step h is small compared with X
+func (o *LeastSquares) checkStep(h la.Vector) (stop bool) {
+	if h.Norm() <= o.Xtol*(o.X.Norm()+o.Xtol) {
+		o.Converged, o.Reason = true, "xtol: the relative change of the parameters is small"
+		return true
+	}
+	return false
+}
+
+// checkReduction checks whether the actual and predicted relative reductions of χ² are small
+func (o *LeastSquares) checkReduction(actual, pred, χ2old float64) (stop bool) {
+	if math.Abs(actual) <= o.Ftol*χ2old && pred <= o.Ftol*χ2old {
+		o.Converged, o.Reason = true, "ftol: the relative reduction of χ² is small"
+		return true
+	}
+	return false
+}
+
+// statistics computes χ², covariance, standard errors and correlations
+func (o *LeastSquares) statistics() {
+	o.R = la.NewVector(o.m)
+	o.ffcn(o.R, o.X)
+	o.Nfeval++
+	o.Chi2 = 0
+	for i := 0; i < o.m; i++ {
+		ri := o.R[i]
+		if o.sqw != nil {
+			ri *= o.sqw[i]
+		}
+		o.Chi2 += ri * ri
+	}
+	o.Dof = o.m - o.n
+	o.Cov = la.NewMatrix(o.n, o.n)
+	o.StdErr = la.NewVector(o.n)
+	o.Corr = la.NewMatrix(o.n, o.n)
+	scale := 1.0
+	if o.sqw == nil && o.Dof > 0 {
+		scale = o.Chi2 / float64(o.Dof)
+	}
+	e := la.NewVector(o.n)
+	c := la.NewVector(o.n)
+	zero := la.NewVector(o.n)
+	for j := 0; j < o.n; j++ {
+		e.Fill(0)
+		e[j] = 1
+		if !lsqCholSolve(c, o.A, zero, e, 1) {
+			o.Cov.Fill(math.NaN())
+			o.StdErr.Fill(math.NaN())
+			o.Corr.Fill(math.NaN())
+			o.Reason += "; the covariance is not available because Jᵀ⋅J is singular"
+			return
+		}
+		for i := 0; i < o.n; i++ {
+			o.Cov.Set(i, j, scale*c[i])
+		}
+	}
+	for j := 0; j < o.n; j++ {
+		o.StdErr[j] = math.Sqrt(o.Cov.Get(j, j))
+	}
+	for i := 0; i < o.n; i++ {
+		for j := 0; j < o.n; j++ {
+			o.Corr.Set(i, j, o.Cov.Get(i, j)/(o.StdErr[i]*o.StdErr[j]))
+		}
+	}
+}
+
+// msgLM prints information during iterations
+func (o *LeastSquares) msgLM(it int, χ2, μ, ρ float64, first bool) {
+	if !o.Verbose {
+		return
+	}
+	if first {
+		name := "μ"
+		if o.Method == "trf" {
+			name = "Δ"
+		}
+		io.Pf("\n%4s%23s%23s%23s\n", "it", "χ²", name, "ρ")
+	}
+	io.Pf("%4d%23.15e%23.15e%23.15e\n", it, χ2, μ, ρ)
+}
+
+// lsqCholSolve solves (A + diag(d))⋅x = α⋅b using the Cholesky factorisation. Returns false if the
+// matrix is not positive-definite
+func lsqCholSolve(x la.Vector, A *la.Matrix, d, b la.Vector, α float64) (ok bool) {
+	n := len(x)
+	L := la.NewMatrix(n, n)
+	for j := 0; j < n; j++ {
+		for i := j; i < n; i++ {
+			sum := A.Get(i, j)
+			if i == j {
+				sum += d[j]
+			}
+			for k := 0; k < j; k++ {
+				sum -= L.Get(i, k) * L.Get(j, k)
+			}
+			if i == j {
+				if sum <= 0 || math.IsNaN(sum) {
+					return false
+				}
+				L.Set(j, j, math.Sqrt(sum))
+			} else {
+				L.Set(i, j, sum/L.Get(j, j))
+			}
+		}
+	}
+	for i := 0; i < n; i++ { // L⋅y = α⋅b
+		sum := α * b[i]
+		for k := 0; k < i; k++ {
+			sum -= L.Get(i, k) * x[k]
+		}
+		x[i] = sum / L.Get(i, i)
+	}
+	for i := n - 1; i >= 0; i-- { // Lᵀ⋅x = y
+		sum := x[i]
+		for k := i + 1; k < n; k++ {
+			sum -= L.Get(k, i) * x[k]
+		}
+		x[i] = sum / L.Get(i, i)
+	}
+	return true
+}
+
+// lsqModel computes the quadratic model 2⋅gᵀ⋅h + hᵀ⋅(A + diag(d))⋅h; i.e. the predicted change of χ²
+// for the linearised residuals
+func lsqModel(A *la.Matrix, d, g, h la.Vector) (res float64) {
+	n := len(h)
+	for i := 0; i < n; i++ {
+		res += 2*g[i]*h[i] + d[i]*h[i]*h[i]
+		for j := 0; j < n; j++ {
+			res += h[i] * A.Get(i, j) * h[j]
+		}
+	}
+	return
+}
+
+// lsqTrustRegion solves the trust-region subproblem: minimise the quadratic model of B + diag(d)
+// and g with ‖s‖ ≤ Δ. The Lagrange multiplier λ is computed by Newton's method such that
+// (B + diag(d) + λ⋅I)⋅s = -g and ‖s‖ ≈ Δ. See Chapter 4 of [5]
+//
+//	[5] Nocedal J, Wright SJ (2006) Numerical Optimization. Second Edition. Springer. 664p
+func lsqTrustRegion(s la.Vector, B *la.Matrix, d, g la.Vector, Δ float64) {
+	n := len(s)
+	dd := la.NewVector(n)
+	setλ := func(λ float64) bool {
+		for j := 0; j < n; j++ {
+			dd[j] = d[j] + λ
+		}
+		return lsqCholSolve(s, B, dd, g, -1)
+	}
+
+	// unconstrained minimum
+	if setλ(0) && s.Norm() <= Δ {
+		return
+	}
+
+	// bounds for λ
+	gnorm := g.Norm()
+	bnorm, λlo := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			sum += math.Abs(B.Get(i, j))
+		}
+		bnorm = math.Max(bnorm, sum+math.Abs(d[i]))
+		λlo = math.Max(λlo, -(B.Get(i, i) + d[i]))
+	}
+	λhi := gnorm/Δ + bnorm
+	λ := math.Max(λlo, gnorm/Δ)
+
+	// Newton's iterations on 1/Δ - 1/‖s(λ)‖
+	q := la.NewVector(n)
+	for it := 0; it < 50; it++ {
+		if !setλ(λ) {
+			λlo = λ
+			λ = math.Max(math.Sqrt(λlo*λhi), λlo+0.01*(λhi-λlo))
+			continue
+		}
+		snorm := s.Norm()
+		if math.Abs(snorm-Δ) <= 0.1*Δ {
+			return
+		}
+		if snorm < Δ {
+			λhi = λ
+		} else {
+			λlo = λ
+		}
+		lsqCholSolve(q, B, dd, s, 1) // q = (B + diag(dd))⁻¹⋅s ⇒ sᵀ⋅q = ‖L⁻¹⋅s‖²
+		sq := 0.0
+		for j := 0; j < n; j++ {
+			sq += s[j] * q[j]
+		}
+		λ += (snorm * snorm / sq) * (snorm - Δ) / Δ
+		if λ <= λlo || λ >= λhi {
+			λ = math.Max(math.Sqrt(λlo*λhi), λlo+0.01*(λhi-λlo))
+		}
+	}
+	setλ(λhi) // safe choice
+	if snorm := s.Norm(); snorm > Δ {
+		s.Apply(Δ/snorm, s)
+	}
+}
+
+// lsqToSphere returns the largest t ≥ 0 such that ‖p + t⋅r‖ ≤ Δ (with ‖p‖ ≤ Δ)
+func lsqToSphere(p, r la.Vector, Δ float64) (t float64) {
+	var a, b, c float64
+	for j := range p {
+		a += r[j] * r[j]
+		b += p[j] * r[j]
+		c += p[j] * p[j]
+	}
+	c -= Δ * Δ
+	if a == 0 {
+		return math.Inf(1)
+	}
+	disc := math.Sqrt(math.Max(b*b-a*c, 0))
+	return (-b + disc) / a
+}
+
+// lsqMinimiseLine minimises the quadratic model along p + t⋅r for t in [lo,hi] and returns t and
+// the model value
+func lsqMinimiseLine(B *la.Matrix, d, g, p, r la.Vector, lo, hi float64) (t, value float64) {
+	n := len(p)
+	tmp := la.NewVector(n)
+	model := func(t float64) float64 {
+		for j := 0; j < n; j++ {
+			tmp[j] = p[j] + t*r[j]
+		}
+		return lsqModel(B, d, g, tmp)
+	}
+	t, value = lo, model(lo)
+	if v := model(hi); v < value {
+		t, value = hi, v
+	}
+	a := lsqModel(B, d, la.NewVector(n), r) // quadratic coefficient
+	if a > 0 {
+		b := model(1) - model(0) - a // linear coefficient
+		tc := -b / (2 * a)
+		if tc > lo && tc < hi {
+			if v := model(tc); v < value {
+				t, value = tc, v
+			}
+		}
+	}
+	return
+}
+
+// lsqStrictlyFeasible shifts x into the interior of [lower,upper]
+func lsqStrictlyFeasible(x, lower, upper float64) float64 {
+	rstep := 1e-10
+	if x <= lower {
+		x = lower + rstep*math.Max(1, math.Abs(lower))
+	} else if x >= upper {
+		x = upper - rstep*math.Max(1, math.Abs(upper))
+	}
+	if x <= lower || x >= upper {
+		x = (lower + upper) / 2
+	}
+	return x
+}
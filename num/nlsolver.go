@@ -44,16 +44,31 @@ type NlSolver struct {
 	lsReady  bool            // linear solver is ready
 
 	// workspace for numerical Jacobian (sparse)
-	workspaceNumJac la.Vector // workspace
+	workspaceNumJac la.Vector       // workspace
+	sparseJac       *SparseJacobian // sparsity pattern and column coloring [may be nil]
 
 	// data for dense solver (matrix inversion)
 	matrixJ    *la.Matrix // dense Jacobian matrix
 	matrixJinv *la.Matrix // inverse of Jacobian matrix
 
+	// data for trust-region and/or Broyden's methods
+	doglegDense bool // use dense J (dense Jacobian function or Broyden's updates); otherwise sparse
+
+	// data for Jacobian-free Newton-Krylov (JFNK) method
+	precond   fun.Vv    // preconditioner: z = M⁻¹⋅r computed by precond(z, r) [may be nil]
+	jfnkXp    la.Vector // perturbed x for the Jacobian-vector products
+	jfnkFp    la.Vector // f(x) at the perturbed x
+	jfnkEta   float64   // forcing term η (Eisenstat-Walker)
+	jfnkFnorm float64   // ‖f(x)‖ at the previous iteration
+
 	// stats
-	Niter  int // number of iterations from the last call to Solve
-	Nfeval int // number of calls to Ffcn (function evaluations)
-	Njeval int // number of calls to Jfcn (Jacobian evaluations)
+	Niter   int // number of iterations from the last call to Solve
+	Nfeval  int // number of calls to Ffcn (function evaluations)
+	Njeval  int // number of calls to Jfcn (Jacobian evaluations)
+	Nkrylov int // number of Krylov (GMRES) iterations (JFNK only)
+
+	// convergence history
+	Report NlSolverReport // history of iterations from the last call to Solve
 }
 
 // NewNlSolver creates a new NlSolver
@@ -103,9 +118,29 @@ func (o *NlSolver) SetJacobianFunction(Jsparse fun.Tv, Jdense fun.Mv) {
 	o.config.hasJacobianFunction = true
 }
 
+// SetSparseJacobian sets the sparsity pattern (and column coloring) used to compute the numerical
+// Jacobian with one evaluation of F per color instead of one per column. See SparseJacobian.
+// This is useful for large systems with banded or very sparse Jacobians (e.g. FDM residuals).
+// The pattern is used by all numerical Jacobians of Solve (including the dogleg method) and CheckJ
+func (o *NlSolver) SetSparseJacobian(pattern *SparseJacobian) {
+	if pattern.m != o.neq || pattern.n != o.neq {
+		chk.Panic("the pattern must be %d×%d. %d×%d is invalid", o.neq, o.neq, pattern.m, pattern.n)
+	}
+	o.sparseJac = pattern
+	if o.config.hasJacobianFunction {
+		return
+	}
+	o.tripletJ.Init(o.neq, o.neq, pattern.nnz)
+	if o.lsReady { // the structure of J has changed
+		o.linsol.Free()
+		o.linsol = la.NewSparseSolver("umfpack")
+		o.lsReady = false
+	}
+}
+
 // Free frees memory
 func (o *NlSolver) Free() {
-	if !o.config.useDenseSolver {
+	if !o.config.useDenseSolver && !o.config.useJfnk {
 		o.linsol.Free()
 	}
 }
@@ -126,22 +161,39 @@ func (o *NlSolver) Solve(x []float64) {
 
 	// evaluate function @ x
 	o.functionF(o.fx, x) // fx := f(x)
-	o.Nfeval, o.Njeval = 1, 0
+	o.Nfeval, o.Njeval, o.Nkrylov = 1, 0, 0
+	o.Report.reset()
+	o.Report.push(o.fx.Largest(1.0), 0, 0, "", o.Nfeval)
+	defer func() {
+		if err := recover(); err != nil {
+			if o.Report.Reason == "" {
+				o.Report.finish(false, io.Sf("%v", err)) // e.g. singular Jacobian
+			}
+			panic(err)
+		}
+	}()
 
 	// show message
 	if o.config.Verbose {
 		o.msg("", 0, 0, 0, true, false)
 	}
 
+	// trust-region and/or Broyden's methods
+	if o.config.TrustRegion || o.config.Broyden != "" {
+		o.solveDogleg(x)
+		return
+	}
+
 	// iterations
 	var Ldx, LdxPrev, Θ float64 // RMS norm of delta x, convergence rate
-	var fxMax float64
+	var fxMax, relres float64
 	var nfv int
 	for o.Niter = 0; o.Niter < o.config.MaxIterations; o.Niter++ {
 
 		// check convergence on f(x)
 		fxMax = o.fx.Largest(1.0) // den = 1.0
 		if fxMax < o.config.ftol {
+			o.Report.finish(true, "fxMax(ini)")
 			if o.config.Verbose {
 				o.msg("fxMax(ini)", o.Niter, Ldx, fxMax, false, true)
 			}
@@ -159,22 +211,27 @@ func (o *NlSolver) Solve(x []float64) {
 		}
 
 		// evaluate Jacobian @ x
-		if o.Niter == 0 || !o.config.ConstantJacobian {
+		if (o.Niter == 0 || !o.config.ConstantJacobian) && !o.config.useJfnk {
 			if o.config.useDenseSolver {
 				o.functionJdense(o.matrixJ, x)
 			} else {
 				if o.config.hasJacobianFunction {
 					o.functionJsparse(&o.tripletJ, x)
 				} else {
-					Jacobian(&o.tripletJ, o.functionF, x, o.fx, o.workspaceNumJac)
-					o.Nfeval += o.neq
+					o.Nfeval += o.numericalJacobian(x)
 				}
 			}
 			o.Njeval++
 		}
 
-		// dense solution
-		if o.config.useDenseSolver {
+		// Jacobian-free solution
+		if o.config.useJfnk {
+
+			// solve linear system (compute mdx) with GMRES
+			relres = o.jfnkSolve(x)
+
+			// dense solution
+		} else if o.config.useDenseSolver {
 
 			// invert matrix
 			la.MatInv(o.matrixJinv, o.matrixJ, false)
@@ -226,9 +283,22 @@ func (o *NlSolver) Solve(x []float64) {
 		o.functionF(o.fx, x)
 		o.Nfeval++
 
-		// check convergence on f(x) => avoid line-search if converged already
+		// record iteration
 		fxMax = o.fx.Largest(1.0) // den = 1.0
+		if o.config.useJfnk {
+			step := "jfnk"
+			if relres > o.jfnkEta {
+				step = "jfnk-maxit"
+			}
+			o.Report.push(fxMax, Ldx, 0, step, o.Nfeval)
+			o.Report.setKrylov(o.jfnkEta, relres)
+		} else {
+			o.Report.push(fxMax, Ldx, 0, "newton", o.Nfeval)
+		}
+
+		// check convergence on f(x) => avoid line-search if converged already
 		if fxMax < o.config.ftol {
+			o.Report.finish(true, "fxMax")
 			if o.config.Verbose {
 				o.msg("fxMax", o.Niter, Ldx, fxMax, false, true)
 			}
@@ -237,6 +307,7 @@ func (o *NlSolver) Solve(x []float64) {
 
 		// check convergence on Ldx
 		if Ldx < o.config.fnewt {
+			o.Report.finish(true, "Ldx")
 			if o.config.Verbose {
 				o.msg("Ldx", o.Niter, Ldx, fxMax, false, true)
 			}
@@ -245,7 +316,11 @@ func (o *NlSolver) Solve(x []float64) {
 
 		// call line-search => update x and fx
 		if o.config.LineSearch {
-			nfv = LineSearch(x, o.fx, o.functionF, o.mdx, o.x0, o.dphidx, o.phi, o.config.LineSearchMaxIt, true)
+			if o.config.useJfnk {
+				nfv = o.jfnkLineSearch(x)
+			} else {
+				nfv = LineSearch(x, o.fx, o.functionF, o.mdx, o.x0, o.dphidx, o.phi, o.config.LineSearchMaxIt, true)
+			}
 			o.Nfeval += nfv
 			Ldx = 0.0
 			for i := 0; i < o.neq; i++ {
@@ -253,7 +328,9 @@ func (o *NlSolver) Solve(x []float64) {
 			}
 			Ldx = math.Sqrt(Ldx / float64(o.neq))
 			fxMax = o.fx.Largest(1.0) // den = 1.0
+			o.Report.amend(fxMax, Ldx, "line-search", o.Nfeval)
 			if Ldx < o.config.fnewt {
+				o.Report.finish(true, "Ldx(linsrch)")
 				if o.config.Verbose {
 					o.msg("Ldx(linsrch)", o.Niter, Ldx, fxMax, false, true)
 				}
@@ -265,6 +342,7 @@ func (o *NlSolver) Solve(x []float64) {
 		if o.Niter > 0 && o.config.EnforceConvRate {
 			Θ = Ldx / LdxPrev
 			if Θ > 0.99 {
+				o.Report.finish(false, io.Sf("diverging with Θ = %g", Θ))
 				chk.Panic("solver is diverging with Θ = %g (Ldx=%g, LdxPrev=%g)", Θ, Ldx, LdxPrev)
 			}
 		}
@@ -278,12 +356,15 @@ func (o *NlSolver) Solve(x []float64) {
 
 	// check convergence
 	if o.Niter == o.config.MaxIterations {
+		o.Report.finish(false, io.Sf("maximum number of iterations (%d) reached", o.Niter))
 		chk.Panic("cannot converge after %d iterations", o.Niter)
 	}
 }
 
 // CheckJ check Jacobian matrix
 //  Ouptut: cnd -- condition number (with Frobenius norm)
+//  NOTE: the Jacobian used by Solve is compared with the dense numerical Jacobian; thus, without
+//        Jacobian function, the sparsity pattern (see SetSparseJacobian) is checked
 func (o *NlSolver) CheckJ(x []float64, tol float64, verbose bool) (cnd float64) {
 
 	// Jacobian matrix
@@ -295,8 +376,11 @@ func (o *NlSolver) CheckJ(x []float64, tol float64, verbose bool) (cnd float64)
 		if o.config.hasJacobianFunction {
 			o.functionJsparse(&o.tripletJ, x)
 		} else {
-			work := la.NewVector(o.neq)
-			Jacobian(&o.tripletJ, o.functionF, x, o.fx, work)
+			if len(o.workspaceNumJac) != o.neq {
+				o.workspaceNumJac = la.NewVector(o.neq)
+			}
+			o.functionF(o.fx, x)
+			o.numericalJacobian(x)
 		}
 		Jmat = o.tripletJ.ToDense()
 	}
@@ -326,6 +410,17 @@ func (o *NlSolver) CheckJ(x []float64, tol float64, verbose bool) (cnd float64)
 	return
 }
 
+// numericalJacobian computes the numerical Jacobian into tripletJ, using the column coloring if
+// available. Returns the number of function evaluations
+func (o *NlSolver) numericalJacobian(x []float64) (nfeval int) {
+	if o.sparseJac != nil {
+		o.sparseJac.Calc(&o.tripletJ, o.functionF, x, o.fx, o.workspaceNumJac)
+		return o.sparseJac.Ncolors
+	}
+	Jacobian(&o.tripletJ, o.functionF, x, o.fx, o.workspaceNumJac)
+	return o.neq
+}
+
 // msg prints information on residuals
 func (o *NlSolver) msg(typ string, it int, Ldx, fxMax float64, first, last bool) {
 	if first {
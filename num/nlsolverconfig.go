@@ -21,15 +21,26 @@ type NlSolverConfig struct {
 	MaxIterations    int  // Newton's method maximum iterations
 	EnforceConvRate  bool // check and enforce convergence rate
 
+	// globalisation and quasi-Newton methods (dense Jacobian)
+	TrustRegion bool    // use Powell's dogleg trust-region method
+	TrustRadius float64 // initial trust-region radius; 0 ⇒ max(1, ‖x‖)
+	Broyden     string  // "" (Newton), "good" or "bad": Broyden's rank-one updates of the Jacobian
+
 	// function to be called during each output
 	OutCallback func(x []float64) // output callback function
 
 	// configurations for linear solver
 	LinSolConfig *la.SparseConfig // configurations for sparse linear solver
 
+	// configurations for Jacobian-free Newton-Krylov (JFNK) method
+	GmresRestart int     // GMRES: dimension of the Krylov subspace before restarting
+	GmresMaxIt   int     // GMRES: maximum number of iterations per Newton iteration
+	EtaMax       float64 // maximum forcing term η (Eisenstat-Walker)
+
 	// internal
 	useDenseSolver      bool // use dense solver instead of Umfpack (sparse)
 	hasJacobianFunction bool // false => use numerical Jacobian (with sparse solver)
+	useJfnk             bool // use Jacobian-free Newton-Krylov method
 
 	// tolerances
 	atol  float64 // absolute tolerance
@@ -48,6 +59,12 @@ type NlSolverConfig struct {
 //   Atol        = 1e-8
 //   Rtol        = 1e-8
 //   Ftol        = 1e-9
+//   TrustRegion  = false
+//   TrustRadius  = 0
+//   Broyden      = ""
+//   GmresRestart = 30
+//   GmresMaxIt   = 300
+//   EtaMax       = 0.9
 func NewNlSolverConfig() (o *NlSolverConfig) {
 
 	// input
@@ -59,12 +76,23 @@ func NewNlSolverConfig() (o *NlSolverConfig) {
 	o.MaxIterations = 20
 	o.EnforceConvRate = false
 
+	// globalisation and quasi-Newton methods
+	o.TrustRegion = false
+	o.TrustRadius = 0
+	o.Broyden = ""
+
 	// configurations for linear solver
 	o.LinSolConfig = la.NewSparseConfig()
 
+	// configurations for JFNK
+	o.GmresRestart = 30
+	o.GmresMaxIt = 300
+	o.EtaMax = 0.9
+
 	// internal
 	o.useDenseSolver = false
 	o.hasJacobianFunction = false
+	o.useJfnk = false
 
 	// tolerances
 	o.SetTolerances(1e-8, 1e-8, 1e-9)
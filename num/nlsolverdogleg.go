@@ -0,0 +1,373 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// solveDogleg solves f(x) = 0 with a Jacobian matrix J, which is either computed at every
+// iteration or approximated by Broyden's rank-one updates [4]. The step is globalised by Powell's
+// dogleg trust-region method [2,3] (TrustRegion), by the line search (LineSearch), or not at all.
+//
+//	Without Broyden's updates, the linear systems are solved with the factorisation of J; i.e.
+//	with the sparse solver if J is given by the sparse Jacobian function or computed numerically
+//	and with the dense LU factorisation if J is given by the dense Jacobian function.
+//
+//	With Broyden's updates, the dense matrices J and J⁻¹ are updated by rank-one formulae; thus
+//	the cost of each iteration is O(n²) but the memory is also O(n²) and J⁻¹ is computed whenever
+//	the Jacobian is recomputed. Therefore, Broyden's updates are meant for small (dense) systems.
+//	The initial Jacobian is computed by the (dense or sparse) Jacobian function or numerically
+//	and is recomputed only when the update fails; e.g. when the trust-region step is rejected or
+//	the denominator of the update is nearly zero.
+//
+//	References:
+//	  [2] Powell MJD (1970) A hybrid method for nonlinear equations. In: Rabinowitz P (editor)
+//	      Numerical Methods for Nonlinear Algebraic Equations. Gordon and Breach. pp 87-114
+//	  [3] Nocedal J, Wright SJ (2006) Numerical Optimization. Second Edition. Springer. 664p
+//	  [4] Broyden CG (1965) A class of methods for solving nonlinear simultaneous equations.
+//	      Mathematics of Computation, 19(92):577-593
+func (o *NlSolver) solveDogleg(x la.Vector) {
+
+	// check
+	if o.config.useJfnk {
+		chk.Panic("TrustRegion and Broyden options cannot be used with the JFNK method\n")
+	}
+	switch o.config.Broyden {
+	case "", "good", "bad":
+	default:
+		chk.Panic("Broyden = %q is invalid. Options are \"\", \"good\" and \"bad\"\n", o.config.Broyden)
+	}
+
+	// workspace
+	n := o.neq
+	o.doglegDense = o.config.Broyden != "" || o.functionJdense != nil
+	if o.doglegDense && o.matrixJ == nil {
+		o.matrixJ = la.NewMatrix(n, n)
+	}
+	if o.config.Broyden != "" && o.matrixJinv == nil {
+		o.matrixJinv = la.NewMatrix(n, n)
+	}
+	xt := la.NewVector(n)   // trial x
+	ft := la.NewVector(n)   // f(xt)
+	fold := la.NewVector(n) // f at the previous x
+	p := la.NewVector(n)    // step
+	g := la.NewVector(n)    // gradient of φ = ½⋅‖f‖²; i.e. g = Jᵀ⋅f
+	Jv := la.NewVector(n)   // J⋅v (workspace)
+	s := la.NewVector(n)    // δx
+	y := la.NewVector(n)    // δf
+
+	// initial Jacobian and trust-region radius
+	o.doglegJacobian(x)
+	fresh := true
+	newStep := true // the Newton step must be computed
+	Δ := 0.0
+	if o.config.TrustRegion {
+		Δ = o.config.TrustRadius
+		if Δ <= 0 {
+			Δ = math.Max(1.0, x.Norm())
+		}
+	}
+
+	// iterations
+	var Ldx, LdxPrev, Θ float64
+	for o.Niter = 0; o.Niter < o.config.MaxIterations; o.Niter++ {
+
+		// check convergence on f(x)
+		fxMax := o.fx.Largest(1.0)
+		if fxMax < o.config.ftol {
+			o.Report.finish(true, "fxMax(ini)")
+			if o.config.Verbose {
+				o.msg("fxMax(ini)", o.Niter, Ldx, fxMax, false, true)
+			}
+			break
+		}
+
+		// show message
+		if o.config.Verbose {
+			o.msg("", o.Niter, Ldx, fxMax, false, false)
+		}
+
+		// output
+		if o.config.OutCallback != nil {
+			o.config.OutCallback(x)
+		}
+
+		// Newton step (-mdx) and gradient of φ
+		if newStep {
+			o.doglegSolve()        // mdx = inv(J) * fx
+			o.doglegMulTr(g, o.fx) // g = tra(J) * fx
+		}
+		newStep = true
+		φ := 0.5 * la.VecDot(o.fx, o.fx)
+		copy(fold, o.fx)
+		copy(o.x0, x)
+
+		// trust-region step
+		step := "newton"
+		if o.config.TrustRegion {
+			step = o.dogleg(p, g, Jv, Δ)
+			for i := 0; i < n; i++ {
+				xt[i] = x[i] + p[i]
+			}
+			o.functionF(ft, xt)
+			o.Nfeval++
+
+			// ratio between actual and predicted reductions
+			o.doglegMul(Jv, p)
+			pred := -la.VecDot(o.fx, Jv) - 0.5*la.VecDot(Jv, Jv) // φ - ½⋅‖f + J⋅p‖²
+			ρ := -1.0
+			φt := 0.5 * la.VecDot(ft, ft)
+			if pred > 0 && !math.IsNaN(φt) {
+				ρ = (φ - φt) / pred
+			}
+
+			// update radius
+			pnorm := p.Norm()
+			if ρ < 0.25 {
+				Δ = 0.25 * pnorm
+			} else if ρ > 0.75 && pnorm >= 0.99*Δ {
+				Δ *= 2.0
+			}
+
+			// reject step
+			if ρ <= 1e-4 {
+				o.Report.push(fxMax, 0, Δ, "rejected", o.Nfeval)
+				if Δ <= MACHEPS*(1.0+x.Norm()) {
+					o.Report.finish(false, "the trust-region radius is too small")
+					chk.Panic("the trust-region radius is too small (Δ = %g)\n", Δ)
+				}
+				if !fresh {
+					o.doglegJacobian(x) // Broyden's approximation may be poor
+					fresh = true
+				} else {
+					newStep = false // x and J are unchanged
+				}
+				continue
+			}
+			copy(x, xt)
+			copy(o.fx, ft)
+
+			// line search or full step
+		} else {
+			for i := 0; i < n; i++ {
+				x[i] -= o.mdx[i]
+			}
+			o.functionF(o.fx, x)
+			o.Nfeval++
+			if o.config.LineSearch {
+				copy(o.dphidx, g)
+				o.Nfeval += LineSearch(x, o.fx, o.functionF, o.mdx, o.x0, o.dphidx, φ, o.config.LineSearchMaxIt, true)
+				step = "line-search"
+			}
+		}
+
+		// record iteration
+		Ldx = 0.0
+		for i := 0; i < n; i++ {
+			s[i] = x[i] - o.x0[i]
+			y[i] = o.fx[i] - fold[i]
+			Ldx += (s[i] / o.scal[i]) * (s[i] / o.scal[i])
+		}
+		Ldx = math.Sqrt(Ldx / float64(n))
+		fxMax = o.fx.Largest(1.0)
+		o.Report.push(fxMax, Ldx, Δ, step, o.Nfeval)
+
+		// check convergence
+		if fxMax < o.config.ftol {
+			o.Report.finish(true, "fxMax")
+			if o.config.Verbose {
+				o.msg("fxMax", o.Niter, Ldx, fxMax, false, true)
+			}
+			break
+		}
+		if Ldx < o.config.fnewt {
+			o.Report.finish(true, "Ldx")
+			if o.config.Verbose {
+				o.msg("Ldx", o.Niter, Ldx, fxMax, false, true)
+			}
+			break
+		}
+
+		// check convergence rate
+		if o.Niter > 0 && o.config.EnforceConvRate {
+			Θ = Ldx / LdxPrev
+			if Θ > 0.99 {
+				o.Report.finish(false, io.Sf("diverging with Θ = %g", Θ))
+				chk.Panic("solver is diverging with Θ = %g (Ldx=%g, LdxPrev=%g)", Θ, Ldx, LdxPrev)
+			}
+		}
+		LdxPrev = Ldx
+
+		// update Jacobian
+		if o.config.Broyden == "" {
+			if !o.config.ConstantJacobian {
+				o.doglegJacobian(x)
+			}
+		} else {
+			fresh = false
+			if !o.broydenUpdate(s, y) {
+				o.doglegJacobian(x)
+				fresh = true
+			}
+		}
+	}
+
+	// output
+	if o.config.OutCallback != nil {
+		o.config.OutCallback(x)
+	}
+
+	// check convergence
+	if o.Niter == o.config.MaxIterations {
+		o.Report.finish(false, io.Sf("maximum number of iterations (%d) reached", o.Niter))
+		chk.Panic("cannot converge after %d iterations", o.Niter)
+	}
+}
+
+// dogleg computes the dogleg step p within the trust region of radius Δ. The Newton step is -mdx,
+// g = Jᵀ⋅f is the gradient of φ = ½⋅‖f‖² and Jg is workspace. Returns the kind of step
+func (o *NlSolver) dogleg(p, g, Jg la.Vector, Δ float64) (step string) {
+
+	// Newton step within the trust region
+	pNnorm := o.mdx.Norm()
+	if pNnorm <= Δ {
+		p.Apply(-1, o.mdx)
+		return "newton"
+	}
+
+	// Cauchy point: minimiser of φ along -g
+	o.doglegMul(Jg, g)
+	gnorm, Jgnorm := g.Norm(), Jg.Norm()
+	if Jgnorm == 0 {
+		p.Apply(-Δ/pNnorm, o.mdx)
+		return "newton"
+	}
+	α := gnorm * gnorm / (Jgnorm * Jgnorm)
+	if α*gnorm >= Δ {
+		p.Apply(-Δ/gnorm, g)
+		return "cauchy"
+	}
+
+	// dogleg: p = pC + τ⋅(pN - pC) with ‖p‖ = Δ
+	pC := la.NewVector(len(p))
+	d := la.NewVector(len(p))
+	for i := range p {
+		pC[i] = -α * g[i]
+		d[i] = -o.mdx[i] - pC[i]
+	}
+	τ := lsqToSphere(pC, d, Δ)
+	for i := range p {
+		p[i] = pC[i] + τ*d[i]
+	}
+	return "dogleg"
+}
+
+// doglegJacobian computes the Jacobian matrix at x. The sparse matrix is factorised; the dense
+// matrix is inverted if Broyden's updates are used
+func (o *NlSolver) doglegJacobian(x la.Vector) {
+	switch {
+	case o.functionJdense != nil:
+		o.functionJdense(o.matrixJ, x)
+	case o.functionJsparse != nil:
+		o.functionJsparse(&o.tripletJ, x)
+	default:
+		o.Nfeval += o.numericalJacobian(x)
+	}
+	o.Njeval++
+	if !o.doglegDense {
+		if !o.lsReady {
+			o.linsol.Init(&o.tripletJ, o.config.LinSolConfig)
+			o.lsReady = true
+		}
+		o.linsol.Fact()
+		return
+	}
+	if o.functionJdense == nil {
+		copy(o.matrixJ.Data, o.tripletJ.ToMatrix(nil).ToDense().Data)
+	}
+	if o.config.Broyden != "" {
+		la.MatInv(o.matrixJinv, o.matrixJ, false)
+	}
+}
+
+// doglegSolve solves J⋅mdx = f(x)
+func (o *NlSolver) doglegSolve() {
+	switch {
+	case !o.doglegDense:
+		o.linsol.Solve(o.mdx, o.fx)
+	case o.config.Broyden != "":
+		la.MatVecMul(o.mdx, 1, o.matrixJinv, o.fx)
+	default:
+		la.DenSolve(o.mdx, o.matrixJ, o.fx, true)
+	}
+}
+
+// doglegMul computes Jv = J⋅v
+func (o *NlSolver) doglegMul(Jv, v la.Vector) {
+	if o.doglegDense {
+		la.MatVecMul(Jv, 1, o.matrixJ, v)
+		return
+	}
+	la.SpTriMatVecMul(Jv, &o.tripletJ, v)
+}
+
+// doglegMulTr computes Jᵀv = Jᵀ⋅v
+func (o *NlSolver) doglegMulTr(JTv, v la.Vector) {
+	if o.doglegDense {
+		la.MatTrVecMul(JTv, 1, o.matrixJ, v)
+		return
+	}
+	la.SpTriMatTrVecMul(JTv, &o.tripletJ, v)
+}
+
+// broydenUpdate updates J and J⁻¹ by Broyden's rank-one formulae [4] with s = δx and y = δf.
+// The other matrix is updated by the Sherman-Morrison formula. Returns false if the update is not
+// possible because the denominator is (nearly) zero
+//
+//	"good": J   ← J   + (y - J⋅s)⋅sᵀ / (sᵀ⋅s)
+//	"bad":  J⁻¹ ← J⁻¹ + (s - J⁻¹⋅y)⋅yᵀ / (yᵀ⋅y)
+func (o *NlSolver) broydenUpdate(s, y la.Vector) (ok bool) {
+	n := o.neq
+	J, H := o.matrixJ, o.matrixJinv
+	Js, Hy := la.NewVector(n), la.NewVector(n)
+	la.MatVecMul(Js, 1, J, s)
+	la.MatVecMul(Hy, 1, H, y)
+	tol := math.Sqrt(MACHEPS)
+	if o.config.Broyden == "good" {
+		ss := la.VecDot(s, s)
+		sHy := la.VecDot(s, Hy)
+		if ss == 0 || math.Abs(sHy) <= tol*s.Norm()*Hy.Norm() {
+			return false
+		}
+		sH := la.NewVector(n) // sᵀ⋅H
+		la.MatTrVecMul(sH, 1, H, s)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				J.Add(i, j, (y[i]-Js[i])*s[j]/ss)
+				H.Add(i, j, (s[i]-Hy[i])*sH[j]/sHy)
+			}
+		}
+		return true
+	}
+	yy := la.VecDot(y, y)
+	yJs := la.VecDot(y, Js)
+	if yy == 0 || math.Abs(yJs) <= tol*y.Norm()*Js.Norm() {
+		return false
+	}
+	yJ := la.NewVector(n) // yᵀ⋅J
+	la.MatTrVecMul(yJ, 1, J, y)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			H.Add(i, j, (s[i]-Hy[i])*y[j]/yy)
+			J.Add(i, j, (y[i]-Js[i])*yJ[j]/yJs)
+		}
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+)
+
+// SetJFNK selects the Jacobian-free Newton-Krylov (JFNK) method [1,2]. The Jacobian matrix is
+// never assembled: the Jacobian-vector products are approximated by finite differences of f(x)
+//
+//	J⋅v ≈ (f(x + ε⋅v) - f(x)) / ε
+//
+//	and the linear systems are solved (inexactly) by the restarted GMRES method. The tolerance of
+//	GMRES is given by the Eisenstat-Walker forcing terms [3]; i.e. the linear systems are solved
+//	loosely far from the solution and more accurately near the solution.
+//
+//	precond -- [optional] right preconditioner computing z = M⁻¹⋅r by precond(z, r), where M ≈ J;
+//	           e.g. an incomplete factorisation or the solution with the diagonal of J. May be nil
+//
+//	NOTE: if LineSearch is set, a derivative-free backtracking on ‖f(x)‖ is used
+//
+//	The forcing term η and the relative residual achieved by GMRES are recorded in Report. If
+//	GMRES reaches GmresMaxIt before the residual is reduced by η, the (inexact) step is still
+//	taken and is recorded as "jfnk-maxit". If the residual is not reduced at all, Solve panics
+//
+//	References:
+//	  [1] Knoll DA, Keyes DE (2004) Jacobian-free Newton-Krylov methods: a survey of approaches and
+//	      applications. Journal of Computational Physics, 193(2):357-397
+//	  [2] Kelley CT (2003) Solving Nonlinear Equations with Newton's Method. SIAM. 104p
+//	  [3] Eisenstat SC, Walker HF (1996) Choosing the forcing terms in an inexact Newton method.
+//	      SIAM Journal on Scientific Computing, 17(1):16-32
+func (o *NlSolver) SetJFNK(precond fun.Vv) {
+	o.config.useJfnk = true
+	o.config.useDenseSolver = false
+	o.precond = precond
+	o.jfnkXp = la.NewVector(o.neq)
+	o.jfnkFp = la.NewVector(o.neq)
+}
+
+// jfnkSolve solves J⋅mdx = f(x) with GMRES and the Eisenstat-Walker forcing term
+func (o *NlSolver) jfnkSolve(x la.Vector) (relres float64) {
+
+	// forcing term (choice 2 of [3] with the safeguards of [2])
+	γ := 0.9
+	fnorm := o.fx.Norm()
+	if o.Niter == 0 {
+		o.jfnkEta = o.config.EtaMax
+	} else {
+		ratio := fnorm / o.jfnkFnorm
+		η := γ * ratio * ratio
+		if γ*o.jfnkEta*o.jfnkEta > 0.1 {
+			η = math.Max(η, γ*o.jfnkEta*o.jfnkEta)
+		}
+		o.jfnkEta = math.Min(η, o.config.EtaMax)
+	}
+	o.jfnkEta = math.Max(o.jfnkEta, 0.5*o.config.ftol/fnorm)
+	o.jfnkFnorm = fnorm
+
+	// Jacobian-vector product
+	xnorm := x.Norm()
+	Jv := func(jv, v la.Vector) {
+		vnorm := v.Norm()
+		if vnorm == 0 {
+			jv.Fill(0)
+			return
+		}
+		ε := math.Sqrt(MACHEPS) * (1.0 + xnorm) / vnorm
+		for i := 0; i < o.neq; i++ {
+			o.jfnkXp[i] = x[i] + ε*v[i]
+		}
+		o.functionF(o.jfnkFp, o.jfnkXp)
+		o.Nfeval++
+		for i := 0; i < o.neq; i++ {
+			jv[i] = (o.jfnkFp[i] - o.fx[i]) / ε
+		}
+	}
+
+	// solve linear system
+	nit, relres := gmres(o.mdx, o.fx, Jv, o.precond, o.jfnkEta, o.config.GmresRestart, o.config.GmresMaxIt)
+	o.Nkrylov += nit
+	if relres >= 1 {
+		chk.Panic("GMRES failed to reduce the residual after %d iterations (relres = %g)\n", nit, relres)
+	}
+	return
+}
+
+// jfnkLineSearch performs a backtracking along -mdx such that ‖f(x)‖ decreases sufficiently
+// (Armijo rule). On input, x = x0 - mdx and fx = f(x)
+func (o *NlSolver) jfnkLineSearch(x []float64) (nFeval int) {
+	α := 1e-4 // Armijo coefficient
+	λ := 1.0
+	for it := 0; it < o.config.LineSearchMaxIt; it++ {
+		if o.fx.Norm() <= (1.0-α*λ)*o.jfnkFnorm {
+			return
+		}
+		λ *= 0.5
+		for i := 0; i < o.neq; i++ {
+			x[i] = o.x0[i] - λ*o.mdx[i]
+		}
+		o.functionF(o.fx, x)
+		nFeval++
+	}
+	return
+}
+
+// gmres solves A⋅x = b by the restarted GMRES(m) method with right preconditioning; i.e. it solves
+// A⋅M⁻¹⋅u = b and computes x = M⁻¹⋅u. The initial guess is x = 0. See Algorithm 6.11 of [4]
+//
+//	Input:
+//	  A       -- computes the product Av = A⋅v by A(Av, v)
+//	  precond -- computes z = M⁻¹⋅r by precond(z, r) [may be nil]
+//	  tol     -- relative tolerance: ‖b - A⋅x‖ ≤ tol⋅‖b‖
+//	  restart -- dimension of the Krylov subspace before restarting
+//	  maxIt   -- maximum number of iterations (total)
+//
+//	Output:
+//	  x      -- solution
+//	  nit    -- number of iterations
+//	  relres -- relative residual ‖b - A⋅x‖/‖b‖ (estimate from the Arnoldi process)
+//
+//	Reference:
+//	  [4] Saad Y (2003) Iterative Methods for Sparse Linear Systems. Second Edition. SIAM. 528p
+func gmres(x, b la.Vector, A, precond fun.Vv, tol float64, restart, maxIt int) (nit int, relres float64) {
+
+	// check
+	n := len(b)
+	x.Fill(0)
+	bnorm := b.Norm()
+	if bnorm == 0 {
+		return
+	}
+	if restart > n {
+		restart = n
+	}
+
+	// workspace
+	V := make([]la.Vector, restart+1)
+	for i := range V {
+		V[i] = la.NewVector(n)
+	}
+	H := la.NewMatrix(restart+1, restart)
+	cs, sn := la.NewVector(restart), la.NewVector(restart)
+	g, y := la.NewVector(restart+1), la.NewVector(restart)
+	r, w, z := la.NewVector(n), la.NewVector(n), la.NewVector(n)
+	applyPrecond := func(z, v la.Vector) {
+		if precond == nil {
+			copy(z, v)
+		} else {
+			precond(z, v)
+		}
+	}
+
+	// cycles
+	copy(r, b)
+	relres = 1
+	for nit < maxIt {
+
+		// start Arnoldi process
+		β := r.Norm()
+		relres = β / bnorm
+		if relres <= tol {
+			return
+		}
+		V[0].Apply(1.0/β, r)
+		g.Fill(0)
+		g[0] = β
+
+		// iterations
+		k := 0
+		for k < restart && nit < maxIt {
+
+			// w = A⋅M⁻¹⋅vₖ
+			applyPrecond(z, V[k])
+			A(w, z)
+			nit++
+
+			// modified Gram-Schmidt
+			for i := 0; i <= k; i++ {
+				hik := la.VecDot(w, V[i])
+				H.Set(i, k, hik)
+				la.VecAdd(w, -hik, V[i], 1, w)
+			}
+			hnext := w.Norm()
+			H.Set(k+1, k, hnext)
+			if hnext > 0 {
+				V[k+1].Apply(1.0/hnext, w)
+			}
+
+			// apply previous Givens rotations to the new column
+			for i := 0; i < k; i++ {
+				t := cs[i]*H.Get(i, k) + sn[i]*H.Get(i+1, k)
+				H.Set(i+1, k, -sn[i]*H.Get(i, k)+cs[i]*H.Get(i+1, k))
+				H.Set(i, k, t)
+			}
+
+			// new Givens rotation
+			den := math.Hypot(H.Get(k, k), hnext)
+			if den == 0 {
+				cs[k], sn[k] = 1, 0
+			} else {
+				cs[k], sn[k] = H.Get(k, k)/den, hnext/den
+			}
+			H.Set(k, k, den)
+			H.Set(k+1, k, 0)
+			g[k+1] = -sn[k] * g[k]
+			g[k] *= cs[k]
+			k++
+
+			// check convergence
+			relres = math.Abs(g[k]) / bnorm
+			if relres <= tol || hnext == 0 {
+				break
+			}
+		}
+
+		// solve H⋅y = g (upper triangular)
+		for i := k - 1; i >= 0; i-- {
+			sum := g[i]
+			for j := i + 1; j < k; j++ {
+				sum -= H.Get(i, j) * y[j]
+			}
+			if H.Get(i, i) != 0 {
+				y[i] = sum / H.Get(i, i)
+			} else {
+				y[i] = 0
+			}
+		}
+
+		// update x = x + M⁻¹⋅(V⋅y)
+		w.Fill(0)
+		for i := 0; i < k; i++ {
+			la.VecAdd(w, y[i], V[i], 1, w)
+		}
+		applyPrecond(z, w)
+		la.VecAdd(x, 1, z, 1, x)
+		if relres <= tol {
+			return
+		}
+
+		// residual for the restart: r = b - A⋅x
+		A(w, x)
+		la.VecAdd(r, 1, b, -1, w)
+	}
+	return
+}
@@ -0,0 +1,89 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"bytes"
+
+	"github.com/cpmech/gosl/io"
+)
+
+// NlSolverReport holds the convergence history of the last call to NlSolver.Solve (or FixedPoint.Solve)
+//
+//	NOTE: the report is complete even if Solve panics (e.g. because the maximum number of
+//	      iterations has been reached); thus it can be inspected after recovering from the panic
+type NlSolverReport struct {
+	Converged bool      // convergence has been achieved
+	Reason    string    // reason for stopping; e.g. "fxMax", "Ldx" or the cause of failure
+	FxMax     []float64 // max(|f(x)|) at each iteration; index 0 corresponds to the trial x
+	Ldx       []float64 // RMS of the scaled δx at each iteration
+	Delta     []float64 // trust-region radius at each iteration (zero if not used)
+	Step      []string  // kind of step: "newton", "jfnk", "jfnk-maxit", "line-search", "cauchy", "dogleg", "rejected" or FixedPoint.Method
+	Nfeval    []int     // cumulative number of function evaluations at each iteration
+	Eta       []float64 // forcing term η of the linear solution at each iteration (JFNK only; zero otherwise)
+	Relres    []float64 // relative residual of the linear solution by GMRES at each iteration (JFNK only; zero otherwise)
+}
+
+// String returns a table with the history of iterations
+func (o *NlSolverReport) String() string {
+	var b bytes.Buffer
+	krylov := false
+	for _, η := range o.Eta {
+		krylov = krylov || η > 0
+	}
+	io.Ff(&b, "%4s%23s%23s%23s%13s%8s", "it", "Ldx", "fxMax", "Δ", "step", "nfeval")
+	if krylov {
+		io.Ff(&b, "%11s%11s", "η", "relres")
+	}
+	io.Ff(&b, "\n")
+	for i := range o.FxMax {
+		io.Ff(&b, "%4d%23.15e%23.15e%23.15e%13s%8d", i, o.Ldx[i], o.FxMax[i], o.Delta[i], o.Step[i], o.Nfeval[i])
+		if krylov {
+			io.Ff(&b, "%11.3e%11.3e", o.Eta[i], o.Relres[i])
+		}
+		io.Ff(&b, "\n")
+	}
+	if o.Converged {
+		io.Ff(&b, "converged with %s\n", o.Reason)
+	} else {
+		io.Ff(&b, "failed: %s\n", o.Reason)
+	}
+	return b.String()
+}
+
+// reset clears the history
+func (o *NlSolverReport) reset() {
+	o.Converged, o.Reason = false, ""
+	o.FxMax, o.Ldx, o.Delta, o.Step, o.Nfeval = nil, nil, nil, nil, nil
+	o.Eta, o.Relres = nil, nil
+}
+
+// push appends the results of one iteration
+func (o *NlSolverReport) push(fxMax, Ldx, Δ float64, step string, nfeval int) {
+	o.FxMax = append(o.FxMax, fxMax)
+	o.Ldx = append(o.Ldx, Ldx)
+	o.Delta = append(o.Delta, Δ)
+	o.Step = append(o.Step, step)
+	o.Nfeval = append(o.Nfeval, nfeval)
+	o.Eta = append(o.Eta, 0)
+	o.Relres = append(o.Relres, 0)
+}
+
+// setKrylov sets the forcing term and the GMRES relative residual of the last iteration
+func (o *NlSolverReport) setKrylov(η, relres float64) {
+	k := len(o.FxMax) - 1
+	o.Eta[k], o.Relres[k] = η, relres
+}
+
+// amend replaces the results of the last iteration
+func (o *NlSolverReport) amend(fxMax, Ldx float64, step string, nfeval int) {
+	k := len(o.FxMax) - 1
+	o.FxMax[k], o.Ldx[k], o.Step[k], o.Nfeval[k] = fxMax, Ldx, step, nfeval
+}
+
+// finish sets the reason for stopping
+func (o *NlSolverReport) finish(converged bool, reason string) {
+	o.Converged, o.Reason = converged, reason
+}
@@ -0,0 +1,489 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"bytes"
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Polynomial implements a polynomial with real coefficients
+//
+//	p(x) = c₀ + c₁⋅x + c₂⋅x² + ... + cₙ⋅xⁿ
+//
+//	NOTE: the operations (Add, Mul, Div, ...) return new polynomials and do not modify the receiver
+//
+//	References:
+//	  [1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	      Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//	  [2] Aberth O (1973) Iteration methods for finding all zeros of a polynomial simultaneously.
+//	      Mathematics of Computation, 27(122):339-344
+//	  [3] Forsythe GE (1957) Generation and use of orthogonal polynomials for data-fitting with a
+//	      digital computer. Journal of the SIAM, 5(2):74-88
+type Polynomial struct {
+	C []float64 // coefficients in ascending order of powers [n+1]
+}
+
+// NewPolynomial returns a new polynomial with coefficients c₀, c₁, ... (ascending order of powers)
+func NewPolynomial(c ...float64) (o *Polynomial) {
+	o = new(Polynomial)
+	o.C = make([]float64, len(c))
+	copy(o.C, c)
+	if len(o.C) == 0 {
+		o.C = []float64{0}
+	}
+	return
+}
+
+// NewPolynomialFromRoots returns the monic polynomial (x - z₀)⋅(x - z₁)⋯ with the given roots
+//
+//	NOTE: complex roots must appear in conjugate pairs; the imaginary parts of the coefficients
+//	      are discarded
+func NewPolynomialFromRoots(roots ...complex128) (o *Polynomial) {
+	c := []complex128{1}
+	for _, z := range roots {
+		next := make([]complex128, len(c)+1)
+		for i, ci := range c {
+			next[i+1] += ci
+			next[i] -= z * ci
+		}
+		c = next
+	}
+	o = new(Polynomial)
+	o.C = make([]float64, len(c))
+	for i, ci := range c {
+		if math.Abs(imag(ci)) > 1e-10*math.Max(1, cmplx.Abs(ci)) {
+			chk.Panic("complex roots must appear in conjugate pairs. coefficient c[%d]=%v is not real\n", i, ci)
+		}
+		o.C[i] = real(ci)
+	}
+	return
+}
+
+// Degree returns the degree of the polynomial; i.e. the index of the last non-zero coefficient.
+// The degree of the zero polynomial is -1
+func (o *Polynomial) Degree() int {
+	n := len(o.C) - 1
+	for n >= 0 && o.C[n] == 0 {
+		n--
+	}
+	return n
+}
+
+// GetCopy returns a copy of this polynomial
+func (o *Polynomial) GetCopy() *Polynomial {
+	return NewPolynomial(o.C...)
+}
+
+// Eval evaluates p(x) using Horner's method. This function can be used as fun.Ss
+func (o *Polynomial) Eval(x float64) (res float64) {
+	for i := len(o.C) - 1; i >= 0; i-- {
+		res = res*x + o.C[i]
+	}
+	return
+}
+
+// EvalC evaluates p(z) for complex z using Horner's method
+func (o *Polynomial) EvalC(z complex128) (res complex128) {
+	for i := len(o.C) - 1; i >= 0; i-- {
+		res = res*z + complex(o.C[i], 0)
+	}
+	return
+}
+
+// EvalDerivs evaluates p(x) and its derivatives using Horner's method (ddpoly of [1])
+//
+//	Output:
+//	  d -- d[0] = p(x), d[1] = dp/dx, d[2] = d²p/dx², ...; the number of derivatives is len(d)-1
+//	       [pre-allocated]
+func (o *Polynomial) EvalDerivs(d []float64, x float64) {
+	nd := len(d) - 1
+	nc := len(o.C) - 1
+	for j := 0; j <= nd; j++ {
+		d[j] = 0
+	}
+	d[0] = o.C[nc]
+	for i := nc - 1; i >= 0; i-- {
+		for j := utl.Imin(nd, nc-i); j >= 1; j-- {
+			d[j] = d[j]*x + d[j-1]
+		}
+		d[0] = d[0]*x + o.C[i]
+	}
+	cnst := 1.0
+	for j := 2; j <= nd; j++ {
+		cnst *= float64(j)
+		d[j] *= cnst
+	}
+}
+
+// Add returns p(x) + q(x)
+func (o *Polynomial) Add(q *Polynomial) (r *Polynomial) {
+	r = new(Polynomial)
+	r.C = make([]float64, utl.Imax(len(o.C), len(q.C)))
+	for i, c := range o.C {
+		r.C[i] += c
+	}
+	for i, c := range q.C {
+		r.C[i] += c
+	}
+	return
+}
+
+// Sub returns p(x) - q(x)
+func (o *Polynomial) Sub(q *Polynomial) (r *Polynomial) {
+	return o.Add(q.Scale(-1))
+}
+
+// Scale returns α⋅p(x)
+func (o *Polynomial) Scale(α float64) (r *Polynomial) {
+	r = o.GetCopy()
+	for i := range r.C {
+		r.C[i] *= α
+	}
+	return
+}
+
+// Mul returns p(x)⋅q(x)
+func (o *Polynomial) Mul(q *Polynomial) (r *Polynomial) {
+	r = new(Polynomial)
+	r.C = make([]float64, len(o.C)+len(q.C)-1)
+	for i, a := range o.C {
+		for j, b := range q.C {
+			r.C[i+j] += a * b
+		}
+	}
+	return
+}
+
+// Div computes the quotient and remainder of p(x) / q(x) such that p = quo⋅q + rem with
+// deg(rem) < deg(q) (poldiv of [1])
+func (o *Polynomial) Div(q *Polynomial) (quo, rem *Polynomial) {
+	nq := q.Degree()
+	if nq < 0 {
+		chk.Panic("cannot divide by the zero polynomial\n")
+	}
+	np := o.Degree()
+	rem = NewPolynomial(o.C[:utl.Imax(np, 0)+1]...)
+	if np < nq {
+		quo = NewPolynomial(0)
+		return
+	}
+	quo = new(Polynomial)
+	quo.C = make([]float64, np-nq+1)
+	for k := np - nq; k >= 0; k-- {
+		quo.C[k] = rem.C[nq+k] / q.C[nq]
+		for j := nq + k - 1; j >= k; j-- {
+			rem.C[j] -= quo.C[k] * q.C[j-k]
+		}
+		rem.C[nq+k] = 0
+	}
+	rem.C = rem.C[:utl.Imax(nq, 1)]
+	return
+}
+
+// Compose returns p(q(x))
+func (o *Polynomial) Compose(q *Polynomial) (r *Polynomial) {
+	r = NewPolynomial(o.C[len(o.C)-1])
+	for i := len(o.C) - 2; i >= 0; i-- {
+		r = r.Mul(q)
+		r.C[0] += o.C[i]
+	}
+	return
+}
+
+// Deriv returns the derivative dp/dx
+func (o *Polynomial) Deriv() (r *Polynomial) {
+	if len(o.C) < 2 {
+		return NewPolynomial(0)
+	}
+	r = new(Polynomial)
+	r.C = make([]float64, len(o.C)-1)
+	for i := 1; i < len(o.C); i++ {
+		r.C[i-1] = float64(i) * o.C[i]
+	}
+	return
+}
+
+// Integ returns the antiderivative P(x) of p(x) such that P(0) = c
+func (o *Polynomial) Integ(c float64) (r *Polynomial) {
+	r = new(Polynomial)
+	r.C = make([]float64, len(o.C)+1)
+	r.C[0] = c
+	for i, a := range o.C {
+		r.C[i+1] = a / float64(i+1)
+	}
+	return
+}
+
+// Integral returns the integral of p(x) from a to b
+func (o *Polynomial) Integral(a, b float64) float64 {
+	P := o.Integ(0)
+	return P.Eval(b) - P.Eval(a)
+}
+
+// Roots computes all (complex) roots of the polynomial as the eigenvalues of the companion matrix;
+// the roots are then polished by Newton's method on the original polynomial. Zero roots (c₀ = 0)
+// are extracted exactly. The roots are sorted by real and then imaginary parts
+func (o *Polynomial) Roots() (roots []complex128) {
+	c, nzero := o.reduced()
+	n := len(c) - 1
+	if n > 0 {
+		A := la.NewMatrix(n, n)
+		for i := 0; i < n; i++ {
+			A.Set(0, i, -c[n-1-i]/c[n])
+			if i > 0 {
+				A.Set(i, i-1, 1)
+			}
+		}
+		roots = make([]complex128, n)
+		la.EigenVal(roots, A, false)
+		for k := range roots {
+			roots[k] = o.polish(roots[k])
+		}
+	}
+	return polyFinishRoots(roots, nzero)
+}
+
+// RootsAberth computes all (complex) roots of the polynomial using the Aberth-Ehrlich
+// simultaneous iterations [2]; the roots are sorted by real and then imaginary parts
+//
+//	tol   -- tolerance on the relative size of the corrections; e.g. 1e-15
+//	maxIt -- maximum number of iterations; e.g. 100
+func (o *Polynomial) RootsAberth(tol float64, maxIt int) (roots []complex128) {
+	c, nzero := o.reduced()
+	n := len(c) - 1
+	if n > 0 {
+
+		// initial values on a circle with radius given by the Cauchy bound
+		p := NewPolynomial(c...)
+		dp := p.Deriv()
+		radius := 0.0
+		for i := 0; i < n; i++ {
+			radius = math.Max(radius, math.Pow(math.Abs(c[i]/c[n]), 1.0/float64(n-i)))
+		}
+		roots = make([]complex128, n)
+		for k := 0; k < n; k++ {
+			θ := 2.0*math.Pi*float64(k)/float64(n) + 0.4
+			roots[k] = cmplx.Rect(radius, θ)
+		}
+
+		// iterations
+		done := make([]bool, n)
+		for it := 0; it < maxIt; it++ {
+			converged := true
+			for k := 0; k < n; k++ {
+				if done[k] {
+					continue
+				}
+				z := roots[k]
+				pz := p.EvalC(z)
+				if pz == 0 {
+					done[k] = true
+					continue
+				}
+				ratio := pz / dp.EvalC(z)
+				var sum complex128
+				for j := 0; j < n; j++ {
+					if j != k {
+						sum += 1 / (z - roots[j])
+					}
+				}
+				w := ratio / (1 - ratio*sum)
+				roots[k] = z - w
+				if cmplx.Abs(w) <= tol*cmplx.Abs(roots[k]) {
+					done[k] = true
+				} else {
+					converged = false
+				}
+			}
+			if converged {
+				break
+			}
+		}
+	}
+	return polyFinishRoots(roots, nzero)
+}
+
+// String returns a string representation of the polynomial
+func (o *Polynomial) String() string {
+	var b bytes.Buffer
+	for i, c := range o.C {
+		if c == 0 && len(o.C) > 1 {
+			continue
+		}
+		if b.Len() > 0 {
+			if c < 0 {
+				b.WriteString(" - ")
+			} else {
+				b.WriteString(" + ")
+			}
+			c = math.Abs(c)
+		}
+		switch i {
+		case 0:
+			io.Ff(&b, "%g", c)
+		case 1:
+			io.Ff(&b, "%g⋅x", c)
+		default:
+			io.Ff(&b, "%g⋅x^%d", c, i)
+		}
+	}
+	if b.Len() == 0 {
+		return "0"
+	}
+	return b.String()
+}
+
+// PolyFit computes the weighted least-squares polynomial of degree deg fitting the points (x,y);
+// i.e. it minimises Σ wᵢ⋅(yᵢ - p(xᵢ))²
+//
+//	x, y -- data points [ndata]
+//	w    -- weights [ndata]; e.g. wᵢ = 1/σᵢ². May be nil ⇒ unit weights
+//	deg  -- degree of the polynomial (≤ ndata-1)
+//
+//	NOTE: to avoid the ill-conditioning of the normal equations with the monomial basis, the
+//	      abscissae are mapped onto [-1,1] and the fitting is computed with polynomials that are
+//	      orthogonal over the data points (Forsythe's method [3]). The result is then converted to
+//	      the monomial basis.
+func PolyFit(x, y, w []float64, deg int) (p *Polynomial) {
+
+	// check
+	ndata := len(x)
+	if len(y) != ndata || (w != nil && len(w) != ndata) {
+		chk.Panic("x, y and w must have the same length. len(x)=%d, len(y)=%d and len(w)=%d are invalid\n", len(x), len(y), len(w))
+	}
+	if deg < 0 || deg > ndata-1 {
+		chk.Panic("degree must be in [0, ndata-1=%d]. deg=%d is invalid\n", ndata-1, deg)
+	}
+	weight := func(i int) float64 {
+		if w == nil {
+			return 1
+		}
+		return w[i]
+	}
+
+	// map x onto t ∈ [-1,1]
+	xmin, xmax := x[0], x[0]
+	for _, v := range x {
+		xmin, xmax = math.Min(xmin, v), math.Max(xmax, v)
+	}
+	shift, scale := (xmax+xmin)/2, (xmax-xmin)/2
+	if scale == 0 {
+		scale = 1
+	}
+	t := make([]float64, ndata)
+	for i := 0; i < ndata; i++ {
+		t[i] = (x[i] - shift) / scale
+	}
+
+	// orthogonal polynomials: P₋₁ = 0, P₀ = 1, Pₖ₊₁ = (t - αₖ)⋅Pₖ - βₖ⋅Pₖ₋₁
+	prev := make([]float64, ndata) // Pₖ₋₁(tᵢ)
+	curr := make([]float64, ndata) // Pₖ(tᵢ)
+	next := make([]float64, ndata)
+	for i := range curr {
+		curr[i] = 1
+	}
+	Pprev, Pcurr := NewPolynomial(0), NewPolynomial(1)
+	ptn := NewPolynomial(0) // p(t) = Σ bₖ⋅Pₖ(t)
+	tpoly := NewPolynomial(0, 1)
+	normPrev := 1.0
+	for k := 0; k <= deg; k++ {
+
+		// coefficient bₖ = (y,Pₖ)/(Pₖ,Pₖ) and αₖ = (t⋅Pₖ,Pₖ)/(Pₖ,Pₖ)
+		var norm, yp, tp float64
+		for i := 0; i < ndata; i++ {
+			wp := weight(i) * curr[i]
+			norm += wp * curr[i]
+			yp += wp * y[i]
+			tp += wp * t[i] * curr[i]
+		}
+		if norm == 0 {
+			chk.Panic("the fitting of degree %d is singular; e.g. there are not enough distinct points with positive weights\n", deg)
+		}
+		ptn = ptn.Add(Pcurr.Scale(yp / norm))
+		if k == deg {
+			break
+		}
+
+		// next orthogonal polynomial
+		α := tp / norm
+		β := 0.0
+		if k > 0 {
+			β = norm / normPrev
+		}
+		for i := 0; i < ndata; i++ {
+			next[i] = (t[i]-α)*curr[i] - β*prev[i]
+		}
+		Pnext := tpoly.Sub(NewPolynomial(α)).Mul(Pcurr).Sub(Pprev.Scale(β))
+		prev, curr, next = curr, next, prev
+		Pprev, Pcurr = Pcurr, Pnext
+		normPrev = norm
+	}
+
+	// back to x: p(x) = p̂((x - shift)/scale)
+	p = ptn.Compose(NewPolynomial(-shift/scale, 1/scale))
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// reduced returns the coefficients without leading zeros and without the factor x^nzero
+func (o *Polynomial) reduced() (c []float64, nzero int) {
+	n := o.Degree()
+	if n < 0 {
+		chk.Panic("the zero polynomial has infinitely many roots\n")
+	}
+	for nzero < n && o.C[nzero] == 0 {
+		nzero++
+	}
+	c = o.C[nzero : n+1]
+	return
+}
+
+// polish improves a root z by Newton's method on the polynomial; the new value is accepted only if
+// the polynomial decreases
+func (o *Polynomial) polish(z complex128) complex128 {
+	dp := o.Deriv()
+	pz := cmplx.Abs(o.EvalC(z))
+	for it := 0; it < 10 && pz > 0; it++ {
+		d := dp.EvalC(z)
+		if d == 0 {
+			break
+		}
+		znew := z - o.EvalC(z)/d
+		pnew := cmplx.Abs(o.EvalC(znew))
+		if pnew >= pz {
+			break
+		}
+		z, pz = znew, pnew
+	}
+	return z
+}
+
+// polyFinishRoots appends the zero roots, removes tiny imaginary parts and sorts the roots
+func polyFinishRoots(roots []complex128, nzero int) []complex128 {
+	for k := 0; k < nzero; k++ {
+		roots = append(roots, 0)
+	}
+	for k, z := range roots {
+		if math.Abs(imag(z)) <= 100*MACHEPS*math.Max(1, math.Abs(real(z))) {
+			roots[k] = complex(real(z), 0)
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		if real(roots[i]) != real(roots[j]) {
+			return real(roots[i]) < real(roots[j])
+		}
+		return imag(roots[i]) < imag(roots[j])
+	})
+	return roots
+}
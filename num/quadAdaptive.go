@@ -0,0 +1,1161 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// QuadAdaptive implements native (pure Go) automatic integrators based on the adaptive
+// Gauss-Kronrod algorithms of QUADPACK [1]: globally adaptive bisection of the subinterval with
+// the largest error combined with extrapolation by the epsilon algorithm of Wynn. The following
+// cases are handled (names of the corresponding QUADPACK routines in parentheses):
+//
+//	Integrate    -- finite (QAGS), semi-infinite or infinite (QAGI) intervals
+//	IntegratePts -- finite intervals with breakpoints; e.g. discontinuities or singularities (QAGP)
+//	IntegrateOsc -- oscillatory weights cos(ω⋅x) or sin(ω⋅x) over finite intervals using the
+//	                modified Clenshaw-Curtis rule (QAWO) and over infinite intervals (QAWF)
+//
+//	All methods return the integral, an estimate of the absolute error, the number of function
+//	evaluations and the QUADPACK error code ier. ier = 0 means that the requested accuracy
+//	abserr ≤ max(EpsAbs, EpsRel⋅|res|) has been achieved; otherwise (see QuadIerMessage):
+//
+//	  1 -- maximum number of subdivisions (Limit) or cycles (LimLst) reached
+//	  2 -- roundoff error prevents the requested accuracy from being achieved
+//	  3 -- extremely bad integrand behaviour; e.g. non-integrable singularity
+//	  4 -- the algorithm (extrapolation) does not converge
+//	  5 -- the integral is probably divergent, or slowly convergent
+//
+//	NOTE: QuadAdaptive holds only the configuration and is not modified by the methods; thus the
+//	      same object can be used concurrently by several goroutines (provided that f is safe).
+//
+//	Reference:
+//	  [1] Piessens R, de Doncker-Kapenga E, Uberhuber CW, Kahaner DK (1983) QUADPACK: A
+//	      Subroutine Package for Automatic Integration. Springer. 301p
+type QuadAdaptive struct {
+	EpsAbs float64 // absolute tolerance
+	EpsRel float64 // relative tolerance
+	Limit  int     // maximum number of subintervals
+	Key    int     // Gauss-Kronrod pair for finite intervals: 15 ⇒ G7K15; 21 ⇒ G10K21
+	LimLst int     // maximum number of cycles for Fourier integrals over infinite intervals
+}
+
+// NewQuadAdaptive returns a new adaptive integrator with default parameters
+//
+//	epsAbs -- absolute tolerance
+//	epsRel -- relative tolerance
+//
+//	Defaults: Limit = 200, Key = 21 (G10K21) and LimLst = 50
+func NewQuadAdaptive(epsAbs, epsRel float64) (o *QuadAdaptive) {
+	o = new(QuadAdaptive)
+	o.EpsAbs = epsAbs
+	o.EpsRel = epsRel
+	o.Limit = 200
+	o.Key = 21
+	o.LimLst = 50
+	return
+}
+
+// Integrate computes the integral of f(x) from a to b
+//
+//	a and/or b may be infinite (math.Inf); in this case the interval is mapped onto (0,1] by
+//	means of x = a + (1-t)/t (or similar) and the G7K15 rule is used.
+//
+//	Output:
+//	  res    -- the integral
+//	  abserr -- estimate of the absolute error
+//	  neval  -- number of function evaluations
+//	  ier    -- error code; zero if successful
+func (o *QuadAdaptive) Integrate(f fun.Ss, a, b float64) (res, abserr float64, neval, ier int) {
+	o.check()
+	fc := func(x float64) float64 {
+		neval++
+		return f(x)
+	}
+	if a == b {
+		return
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1
+	}
+
+	// finite interval
+	if !math.IsInf(a, 0) && !math.IsInf(b, 0) {
+		rule := o.rule(fc)
+		res, abserr, ier = o.adapt(rule, []float64{a, b}, o.EpsAbs, o.EpsRel, 0)
+		ier = quadIer(ier)
+		res *= sign
+		return
+	}
+
+	// infinite interval
+	g := func(t float64) float64 {
+		s := (1.0 - t) / t
+		switch {
+		case math.IsInf(a, 0) && math.IsInf(b, 0):
+			return (fc(s) + fc(-s)) / (t * t)
+		case math.IsInf(b, 0):
+			return fc(a+s) / (t * t)
+		}
+		return fc(b-s) / (t * t)
+	}
+	rule := func(a, b float64, level int) (r, e, rabs, rasc float64) {
+		return quadGK(g, a, b, quadXgk15, quadWgk15, quadWg7)
+	}
+	res, abserr, ier = o.adapt(rule, []float64{0, 1}, o.EpsAbs, o.EpsRel, 0)
+	ier = quadIer(ier)
+	res *= sign
+	return
+}
+
+// IntegratePts computes the integral of f(x) from a to b with breakpoints
+//
+//	pts -- breakpoints within (a,b) where the integrand has local difficulties such as
+//	       discontinuities, singularities or peaks. f is not evaluated at these points.
+//
+//	Output:
+//	  res    -- the integral
+//	  abserr -- estimate of the absolute error
+//	  neval  -- number of function evaluations
+//	  ier    -- error code; zero if successful
+func (o *QuadAdaptive) IntegratePts(f fun.Ss, a, b float64, pts []float64) (res, abserr float64, neval, ier int) {
+	o.check()
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		chk.Panic("the limits of integration must be finite when breakpoints are given. a=%g, b=%g is invalid\n", a, b)
+	}
+	fc := func(x float64) float64 {
+		neval++
+		return f(x)
+	}
+	if a == b {
+		return
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1
+	}
+	if o.Limit <= len(pts) {
+		chk.Panic("Limit=%d must be greater than the number of breakpoints=%d\n", o.Limit, len(pts))
+	}
+	x := make([]float64, len(pts)+2)
+	x[0] = a
+	x[len(x)-1] = b
+	copy(x[1:], pts)
+	sort.Float64s(x[1 : len(x)-1])
+	for i := 1; i < len(x)-1; i++ {
+		if x[i] <= a || x[i] >= b {
+			chk.Panic("breakpoints must be within (a,b). pts[%d]=%g is invalid\n", i-1, x[i])
+		}
+	}
+	rule := o.rule(fc)
+	res, abserr, ier = o.adapt(rule, x, o.EpsAbs, o.EpsRel, 0)
+	ier = quadIer(ier)
+	res *= sign
+	return
+}
+
+// IntegrateOsc computes the integral of f(x)⋅cos(ω⋅x) or f(x)⋅sin(ω⋅x) from a to b
+//
+//	Finite intervals are handled with the modified Clenshaw-Curtis rule (25 points) on the
+//	subintervals containing many periods of the weight and with G7K15 on the other ones.
+//	If b = +∞ (or a = -∞), the integral is computed over successive intervals covering an odd
+//	number of half-periods and the epsilon algorithm is applied to the series of partial sums.
+//	In this case EpsAbs must be positive and EpsRel is not used.
+//
+//	Input:
+//	  useSin -- use sin(ω⋅x) instead of cos(ω⋅x)
+//	Output:
+//	  res    -- the integral
+//	  abserr -- estimate of the absolute error
+//	  neval  -- number of function evaluations
+//	  ier    -- error code; zero if successful
+func (o *QuadAdaptive) IntegrateOsc(f fun.Ss, a, b, ω float64, useSin bool) (res, abserr float64, neval, ier int) {
+	o.check()
+	fc := func(x float64) float64 {
+		neval++
+		return f(x)
+	}
+	if a == b {
+		return
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1
+	}
+	if useSin && ω < 0 {
+		sign = -sign
+	}
+	ω = math.Abs(ω)
+
+	// finite interval
+	if !math.IsInf(a, 0) && !math.IsInf(b, 0) {
+		res, abserr, ier = o.oscillatory(fc, a, b, ω, useSin, o.EpsAbs, o.EpsRel, nil)
+		ier = quadIer(ier)
+		res *= sign
+		return
+	}
+
+	// infinite interval: reflect x → -x if a = -∞
+	if o.EpsAbs <= 0 {
+		chk.Panic("EpsAbs must be positive for Fourier integrals over infinite intervals\n")
+	}
+	var r, e float64
+	var ierg int
+	g := func(x float64) float64 { return fc(-x) }
+	if useSin {
+		g = func(x float64) float64 { return -fc(-x) }
+	}
+	switch {
+	case math.IsInf(a, 0) && math.IsInf(b, 0):
+		res, abserr, ier = o.fourier(fc, 0, ω, useSin)
+		r, e, ierg = o.fourier(g, 0, ω, useSin)
+	case math.IsInf(b, 0):
+		res, abserr, ier = o.fourier(fc, a, ω, useSin)
+	default:
+		r, e, ierg = o.fourier(g, -b, ω, useSin)
+	}
+	if ier == 0 {
+		ier = ierg
+	}
+	res = sign * (res + r)
+	abserr += e
+	return
+}
+
+// IntegrateExpIx computes the integral of f(x)⋅exp(i⋅m⋅x) from a to b with i = √-1
+//
+//	       b                           b                           b
+//	res = ∫  f(x) ⋅ exp(i⋅m⋅x) dx   = ∫  f(x) ⋅ cos(m⋅x) dx + i ⋅ ∫  f(x) ⋅ sin(m⋅x) dx
+//	      a                           a                           a
+//
+//	See IntegrateOsc. abserr is the sum of the errors of the real and imaginary parts and ier is
+//	the first non-zero error code of the real and imaginary parts
+func (o *QuadAdaptive) IntegrateExpIx(f fun.Ss, a, b, m float64) (res complex128, abserr float64, neval, ier int) {
+	rc, ec, nc, ierc := o.IntegrateOsc(f, a, b, m, false)
+	rs, es, ns, iers := o.IntegrateOsc(f, a, b, m, true)
+	ier = ierc
+	if ier == 0 {
+		ier = iers
+	}
+	return complex(rc, rs), ec + es, nc + ns, ier
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// constants
+const (
+	quadUflow = 2.2250738585072014e-308 // smallest positive normalised number
+	quadOflow = math.MaxFloat64         // largest number
+)
+
+// quadRuleFcn computes the integral over [a,b] and the error estimates. level is the number of
+// bisections leading to [a,b]. resabs approximates the integral of |f| and resasc approximates
+// the integral of |f - mean(f)|
+type quadRuleFcn func(a, b float64, level int) (res, abserr, resabs, resasc float64)
+
+// quadInterval holds the data of a subinterval in the adaptive algorithm
+type quadInterval struct {
+	a, b  float64 // limits
+	r, e  float64 // integral and error estimate
+	level int     // number of bisections
+}
+
+// check checks the configuration
+func (o *QuadAdaptive) check() {
+	if o.EpsAbs <= 0 && o.EpsRel < math.Max(50*MACHEPS, 0.5e-28) {
+		chk.Panic("EpsAbs=%g and EpsRel=%g are invalid: EpsAbs must be positive or EpsRel ≥ %g\n", o.EpsAbs, o.EpsRel, 50*MACHEPS)
+	}
+	if o.Limit < 1 {
+		chk.Panic("Limit=%d must be positive\n", o.Limit)
+	}
+	if o.Key != 15 && o.Key != 21 {
+		chk.Panic("Key=%d is invalid. Options are 15 and 21\n", o.Key)
+	}
+}
+
+// rule returns the Gauss-Kronrod rule for finite intervals selected by Key
+func (o *QuadAdaptive) rule(f fun.Ss) quadRuleFcn {
+	if o.Key == 15 {
+		return func(a, b float64, level int) (r, e, rabs, rasc float64) {
+			return quadGK(f, a, b, quadXgk15, quadWgk15, quadWg7)
+		}
+	}
+	return func(a, b float64, level int) (r, e, rabs, rasc float64) {
+		return quadGK(f, a, b, quadXgk21, quadWgk21, quadWg10)
+	}
+}
+
+// adapt performs the globally adaptive integration with extrapolation (QAGSE, QAGPE and QAWOE)
+//
+//	pts   -- sorted points defining the initial subintervals; including a and b
+//	omega -- |ω| for oscillatory integrands [or zero]. Extrapolation is only started after the
+//	         subintervals are small compared with the period of the weight function
+//
+//	ier   -- zero if successful; otherwise 1: Limit reached; 2: roundoff error; 3: roundoff error
+//	         in the extrapolation table; 4: subinterval too small; 5: extrapolation does not
+//	         converge; 6: divergent integral. See quadIer
+func (o *QuadAdaptive) adapt(rule quadRuleFcn, pts []float64, epsAbs, epsRel, omega float64) (result, abserr float64, ier int) {
+
+	// first approximation
+	nint := len(pts) - 1
+	ints := make([]quadInterval, nint, o.Limit+nint)
+	flagged := make([]bool, nint)
+	ndin := false
+	defabs := 0.0
+	for i := 0; i < nint; i++ {
+		r, e, rabs, rasc := rule(pts[i], pts[i+1], 0)
+		ints[i] = quadInterval{pts[i], pts[i+1], r, e, 0}
+		result += r
+		abserr += e
+		defabs += rabs
+		if e == rasc && e != 0 {
+			flagged[i], ndin = true, true
+		}
+	}
+	errsum := 0.0
+	for i := 0; i < nint; i++ {
+		if flagged[i] {
+			ints[i].e = abserr
+		}
+		errsum += ints[i].e
+	}
+	dres := math.Abs(result)
+	errbnd := math.Max(epsAbs, epsRel*dres)
+	if abserr <= 100*MACHEPS*defabs && abserr > errbnd {
+		ier = 2
+	}
+	if o.Limit <= nint {
+		ier = 1
+	}
+	if ier != 0 || (abserr <= errbnd && !ndin) || abserr == 0 {
+		return
+	}
+
+	// extrapolation from the beginning is only worthwhile if the weight does not oscillate much
+	var table quadEpsTable
+	width := pts[nint] - pts[0]
+	extall := false
+	if 0.5*width*omega <= 2 {
+		extall = true
+		table.append(result)
+	}
+	if 0.25*width*omega <= 2 {
+		extall = true
+	}
+
+	// auxiliary
+	order := make([]int, nint, o.Limit+nint)
+	for i := 0; i < nint; i++ {
+		order[i] = i
+	}
+	quadSortErrors(order, ints)
+	maxerr := order[0]
+	area := result
+	abserr = quadOflow
+	ksgn := -1
+	if dres >= (1-50*MACHEPS)*defabs {
+		ksgn = 1
+	}
+	levmax := 1 // subintervals with level < levmax are "large"
+	var erlarg, ertest, correc float64
+	var extrap, noext bool
+	var ierro, iroff1, iroff2, iroff3, ktmin int
+
+	// loop over subintervals
+	for last := nint + 1; last <= o.Limit; last++ {
+
+		// bisect the subinterval with the largest error estimate
+		iv := ints[maxerr]
+		level := iv.level + 1
+		a1, b1 := iv.a, 0.5*(iv.a+iv.b)
+		a2, b2 := b1, iv.b
+		erlast := iv.e
+		area1, error1, _, defab1 := rule(a1, b1, level)
+		area2, error2, _, defab2 := rule(a2, b2, level)
+
+		// improve previous approximations to integral and error and test for accuracy
+		area12 := area1 + area2
+		erro12 := error1 + error2
+		errsum += erro12 - erlast
+		area += area12 - iv.r
+		if defab1 != error1 && defab2 != error2 {
+			if math.Abs(iv.r-area12) <= 1e-5*math.Abs(area12) && erro12 >= 0.99*erlast {
+				if extrap {
+					iroff2++
+				} else {
+					iroff1++
+				}
+			}
+			if last > 10 && erro12 > erlast {
+				iroff3++
+			}
+		}
+		ints[maxerr] = quadInterval{a1, b1, area1, error1, level}
+		ints = append(ints, quadInterval{a2, b2, area2, error2, level})
+		order = append(order, last-1)
+		quadSortErrors(order, ints)
+		errbnd = math.Max(epsAbs, epsRel*math.Abs(area))
+
+		// test for roundoff error and eventually set error flag
+		if iroff1+iroff2 >= 10 || iroff3 >= 20 {
+			ier = 2
+		}
+		if iroff2 >= 5 {
+			ierro = 3
+		}
+		if last == o.Limit {
+			ier = 1
+		}
+		if math.Max(math.Abs(a1), math.Abs(b2)) <= (1+100*MACHEPS)*(math.Abs(a2)+1000*quadUflow) {
+			ier = 4
+		}
+
+		// check convergence
+		if errsum <= errbnd {
+			return quadSumResults(ints), errsum, 0
+		}
+		if ier != 0 {
+			break
+		}
+
+		// first bisection
+		if last == nint+1 && extall {
+			levmax++
+			table.append(area)
+			ertest = errbnd
+			erlarg = errsum
+			maxerr = order[0]
+			continue
+		}
+		if noext {
+			maxerr = order[0]
+			continue
+		}
+
+		// error over the large subintervals
+		if extall {
+			erlarg -= erlast
+			if level < levmax {
+				erlarg += erro12
+			}
+		}
+
+		// test whether the subinterval to be bisected next is the smallest one
+		if !extrap {
+			maxerr = order[0]
+			if ints[maxerr].level < levmax {
+				continue
+			}
+			if !extall {
+				levmax++
+				w := ints[maxerr].b - ints[maxerr].a
+				if 0.25*w*omega > 2 {
+					continue
+				}
+				extall = true
+				ertest = errbnd
+				erlarg = errsum
+				continue
+			}
+			extrap = true
+		}
+
+		// the large subintervals are bisected before extrapolating
+		if ierro != 3 && erlarg > ertest {
+			jupbnd := last
+			if last > 2+o.Limit/2 {
+				jupbnd = o.Limit + 3 - last
+			}
+			found := false
+			for k := 0; k < jupbnd && k < len(order); k++ {
+				if ints[order[k]].level < levmax {
+					maxerr, found = order[k], true
+					break
+				}
+			}
+			if found {
+				continue
+			}
+		}
+
+		// perform extrapolation
+		table.append(area)
+		if table.n > 2 {
+			reseps, abseps := table.extrapolate()
+			ktmin++
+			if ktmin > 5 && abserr < 1e-3*errsum {
+				ier = 5
+			}
+			if abseps < abserr {
+				ktmin = 0
+				abserr = abseps
+				result = reseps
+				correc = erlarg
+				ertest = math.Max(epsAbs, epsRel*math.Abs(reseps))
+				if abserr <= ertest {
+					break
+				}
+			}
+			if table.n == 1 {
+				noext = true
+			}
+			if ier == 5 {
+				break
+			}
+		}
+
+		// prepare bisection of the smallest subinterval
+		maxerr = order[0]
+		extrap = false
+		levmax++
+		erlarg = errsum
+	}
+
+	// set final result and error estimate
+	if abserr == quadOflow || table.nres == 0 {
+		return quadSumResults(ints), errsum, ier
+	}
+	if ier+ierro != 0 {
+		if ierro == 3 {
+			abserr += correc
+		}
+		if ier == 0 {
+			ier = 3
+		}
+		if result != 0 && area != 0 {
+			if abserr/math.Abs(result) > errsum/math.Abs(area) {
+				return quadSumResults(ints), errsum, ier
+			}
+		} else if abserr > errsum {
+			return quadSumResults(ints), errsum, ier
+		} else if area == 0 {
+			return
+		}
+	}
+	if ksgn == -1 && math.Max(math.Abs(result), math.Abs(area)) <= defabs*0.01 {
+		return
+	}
+	if 0.01 > result/area || result/area > 100 || errsum > math.Abs(area) {
+		ier = 6 // divergent or slowly convergent integral
+	}
+	return
+}
+
+// oscillatory computes the integral of f(x)⋅cos(ω⋅x) or f(x)⋅sin(ω⋅x) over the finite interval
+// [a,b] with a < b and ω ≥ 0 (QAWOE)
+//
+//	moms -- cache of Chebyshev moments for each bisection level [may be nil]. Can be shared by
+//	        calls with intervals of the same length
+func (o *QuadAdaptive) oscillatory(f fun.Ss, a, b, ω float64, useSin bool, epsAbs, epsRel float64, moms *[][]float64) (res, abserr float64, ier int) {
+	if moms == nil {
+		moms = new([][]float64)
+	}
+	w := func(x float64) float64 { return f(x) * math.Cos(ω*x) }
+	if useSin {
+		w = func(x float64) float64 { return f(x) * math.Sin(ω*x) }
+	}
+	rule := func(a, b float64, level int) (r, e, rabs, rasc float64) {
+		hlgth := 0.5 * (b - a)
+		if ω*hlgth <= 2 {
+			return quadGK(w, a, b, quadXgk15, quadWgk15, quadWg7)
+		}
+		for len(*moms) <= level {
+			*moms = append(*moms, nil)
+		}
+		if (*moms)[level] == nil {
+			(*moms)[level] = quadChebMoments(ω * hlgth)
+		}
+		return quadClenshawCurtis(f, a, b, ω, useSin, (*moms)[level])
+	}
+	return o.adapt(rule, []float64{a, b}, epsAbs, epsRel, ω)
+}
+
+// fourier computes the integral of f(x)⋅cos(ω⋅x) or f(x)⋅sin(ω⋅x) from a to +∞ with ω ≥ 0 (QAWFE)
+//
+//	ier -- error code (see QuadAdaptive): 1: LimLst reached; 3: bad integrand behaviour within the
+//	       cycles; 4: extrapolation does not converge
+func (o *QuadAdaptive) fourier(f fun.Ss, a, ω float64, useSin bool) (result, abserr float64, ier int) {
+
+	// non-oscillatory integrand
+	epsAbs := o.EpsAbs
+	if ω == 0 {
+		if useSin {
+			return
+		}
+		cpy := *o
+		cpy.EpsRel = 0
+		result, abserr, _, ier = cpy.Integrate(f, a, math.Inf(1))
+		return
+	}
+
+	// the intervals cover an odd number of half-periods
+	cycle := float64(2*int(ω)+1) * math.Pi / ω
+	p, p1 := 0.9, 0.1
+	eps := epsAbs
+	if epsAbs > quadUflow/p1 {
+		eps = epsAbs * p1
+	}
+	ep := eps
+	fact := 1.0
+	c1, c2 := a, a+cycle
+	var table quadEpsTable
+	var moms [][]float64
+	var psum, errsum, correc, drl float64
+	var ktmin int
+
+	// loop over cycles
+	for lst := 1; lst <= o.LimLst; lst++ {
+
+		// integrate over current subinterval
+		rs, er, ierl := o.oscillatory(f, c1, c2, ω, useSin, eps*fact, 0, &moms)
+		fact *= p
+		errsum += er
+		drl = 50 * math.Abs(rs)
+		psum += rs
+
+		// test on accuracy with partial sum
+		if errsum+drl <= epsAbs && lst >= 6 {
+			return psum, errsum + drl, 0
+		}
+		correc = math.Max(correc, er)
+		if ierl != 0 {
+			eps = math.Max(ep, correc*p1)
+			ier = 7
+		}
+		if ier == 7 && errsum+drl <= correc*10 && lst > 5 {
+			return psum, errsum + drl, 3
+		}
+		table.append(psum)
+
+		// perform extrapolation
+		if lst > 2 {
+			if lst == o.LimLst {
+				ier = 1
+			}
+			reseps, abseps := table.extrapolate()
+			ktmin++
+			if ktmin >= 15 && abserr <= 1e-3*(errsum+drl) {
+				ier = 4
+			}
+			if abseps <= abserr || lst == 3 {
+				abserr = abseps
+				result = reseps
+				ktmin = 0
+				if abserr+10*correc <= epsAbs || (abserr <= epsAbs && 10*correc >= epsAbs) {
+					break
+				}
+			}
+			if ier != 0 && ier != 7 {
+				break
+			}
+		}
+		c1, c2 = c2, c2+cycle
+	}
+
+	// set final result and error estimate
+	abserr += 10 * correc
+	if ier == 0 {
+		return
+	}
+	code := ier
+	if ier == 7 {
+		code = 3 // bad integrand behaviour within the cycles
+	}
+	if result != 0 && psum != 0 {
+		if abserr/math.Abs(result) > (errsum+drl)/math.Abs(psum) {
+			return psum, errsum + drl, code
+		}
+	} else if abserr > errsum {
+		return psum, errsum + drl, code
+	} else if psum == 0 {
+		return result, abserr, code
+	}
+	if ier != 7 {
+		abserr += drl
+	}
+	return result, abserr, code
+}
+
+// quadIer converts the error code of adapt to the error code of the QuadAdaptive methods
+func quadIer(ier int) int {
+	if ier > 2 {
+		return ier - 1
+	}
+	return ier
+}
+
+// QuadIerMessage returns the message corresponding to the error code ier of the QuadAdaptive
+// methods (the same messages as in the qpck package)
+func QuadIerMessage(ier int) string {
+	switch ier {
+	case 0:
+		return "success"
+	case 1:
+		return "error # 1: maximum number of subdivisions reached"
+	case 2:
+		return "error # 2: the occurrence of roundoff error is detected"
+	case 3:
+		return "error # 3: extremely bad integrand behaviour"
+	case 4:
+		return "error # 4: the algorithm does not converge"
+	case 5:
+		return "error # 5: the integral is probably divergent, or slowly convergent"
+	}
+	return "unknown error"
+}
+
+// quadSumResults returns the sum of the integrals over all subintervals
+func quadSumResults(ints []quadInterval) (res float64) {
+	for _, iv := range ints {
+		res += iv.r
+	}
+	return
+}
+
+// quadSortErrors sorts the indices of subintervals in decreasing order of errors
+func quadSortErrors(order []int, ints []quadInterval) {
+	sort.SliceStable(order, func(i, j int) bool { return ints[order[i]].e > ints[order[j]].e })
+}
+
+// rules ///////////////////////////////////////////////////////////////////////////////////////////
+
+// quadGK computes the integral over [a,b] using a Gauss-Kronrod pair (QK15, QK21)
+//
+//	xgk -- Kronrod abscissae in decreasing order, the last one being zero. The odd ones (0-based)
+//	       are the Gauss abscissae
+//	wgk -- Kronrod weights
+//	wg  -- Gauss weights
+func quadGK(f fun.Ss, a, b float64, xgk, wgk, wg []float64) (res, abserr, resabs, resasc float64) {
+	var fv1, fv2 [11]float64
+	n := len(xgk)
+	centr := 0.5 * (a + b)
+	hlgth := 0.5 * (b - a)
+	dhlgth := math.Abs(hlgth)
+	fc := f(centr)
+	resg := 0.0
+	if n%2 == 0 {
+		resg = fc * wg[n/2-1]
+	}
+	resk := fc * wgk[n-1]
+	resabs = math.Abs(resk)
+	for j := 0; j < (n-1)/2; j++ {
+		jtw := 2*j + 1
+		absc := hlgth * xgk[jtw]
+		fval1, fval2 := f(centr-absc), f(centr+absc)
+		fv1[jtw], fv2[jtw] = fval1, fval2
+		resg += wg[j] * (fval1 + fval2)
+		resk += wgk[jtw] * (fval1 + fval2)
+		resabs += wgk[jtw] * (math.Abs(fval1) + math.Abs(fval2))
+	}
+	for j := 0; j < n/2; j++ {
+		jtwm1 := 2 * j
+		absc := hlgth * xgk[jtwm1]
+		fval1, fval2 := f(centr-absc), f(centr+absc)
+		fv1[jtwm1], fv2[jtwm1] = fval1, fval2
+		resk += wgk[jtwm1] * (fval1 + fval2)
+		resabs += wgk[jtwm1] * (math.Abs(fval1) + math.Abs(fval2))
+	}
+	reskh := resk * 0.5
+	resasc = wgk[n-1] * math.Abs(fc-reskh)
+	for j := 0; j < n-1; j++ {
+		resasc += wgk[j] * (math.Abs(fv1[j]-reskh) + math.Abs(fv2[j]-reskh))
+	}
+	res = resk * hlgth
+	resabs *= dhlgth
+	resasc *= dhlgth
+	abserr = math.Abs((resk - resg) * hlgth)
+	if resasc != 0 && abserr != 0 {
+		abserr = resasc * math.Min(1, math.Pow(200*abserr/resasc, 1.5))
+	}
+	if resabs > quadUflow/(50*MACHEPS) {
+		abserr = math.Max(50*MACHEPS*resabs, abserr)
+	}
+	return
+}
+
+// quadClenshawCurtis computes the integral of f(x)⋅cos(ω⋅x) or f(x)⋅sin(ω⋅x) over [a,b] using the
+// modified Clenshaw-Curtis method (QC25F). The error is estimated by comparing the results of
+// the 13-point and 25-point formulae
+//
+//	mom -- [25] modified Chebyshev moments computed with quadChebMoments(ω⋅(b-a)/2)
+func quadClenshawCurtis(f fun.Ss, a, b, ω float64, useSin bool, mom []float64) (res, abserr, resabs, resasc float64) {
+
+	// values at the Chebyshev points x[j] = cos(j⋅π/24); the end values are halved
+	centr := 0.5 * (a + b)
+	hlgth := 0.5 * (b - a)
+	var fval [25]float64
+	fval[0] = 0.5 * f(centr+hlgth)
+	fval[12] = f(centr)
+	fval[24] = 0.5 * f(centr-hlgth)
+	for i := 1; i < 12; i++ {
+		fval[i] = f(centr + hlgth*quadCos24[i])
+		fval[24-i] = f(centr - hlgth*quadCos24[i])
+	}
+
+	// Chebyshev series coefficients of degrees 12 and 24
+	var cheb12 [13]float64
+	var cheb24 [25]float64
+	for k := 0; k < 25; k++ {
+		for j := 0; j < 25; j++ {
+			cheb24[k] += fval[j] * quadCos24[(k*j)%48]
+		}
+		cheb24[k] /= 12
+	}
+	for k := 0; k < 13; k++ {
+		for j := 0; j < 13; j++ {
+			cheb12[k] += fval[2*j] * quadCos24[(2*k*j)%48]
+		}
+		cheb12[k] /= 6
+	}
+	cheb12[0] *= 0.5
+	cheb12[12] *= 0.5
+	cheb24[0] *= 0.5
+	cheb24[24] *= 0.5
+
+	// integrals of the series times cos(ω⋅h⋅t) [even] and sin(ω⋅h⋅t) [odd]
+	var resc12, ress12, resc24, ress24 float64
+	for k := 0; k < 13; k += 2 {
+		resc12 += cheb12[k] * mom[k]
+	}
+	for k := 1; k < 13; k += 2 {
+		ress12 += cheb12[k] * mom[k]
+	}
+	for k := 0; k < 25; k += 2 {
+		resc24 += cheb24[k] * mom[k]
+	}
+	for k := 1; k < 25; k += 2 {
+		ress24 += cheb24[k] * mom[k]
+	}
+	for k := 0; k < 25; k++ {
+		resabs += math.Abs(cheb24[k])
+	}
+	resabs *= math.Abs(hlgth)
+	resasc = quadOflow
+	estc := math.Abs(resc24 - resc12)
+	ests := math.Abs(ress24 - ress12)
+
+	// cos(ω⋅(c+h⋅t)) = cos(ω⋅c)⋅cos(ω⋅h⋅t) - sin(ω⋅c)⋅sin(ω⋅h⋅t) and similarly for sin
+	conc := hlgth * math.Cos(centr*ω)
+	cons := hlgth * math.Sin(centr*ω)
+	if useSin {
+		res = conc*ress24 + cons*resc24
+		abserr = math.Abs(conc*ests) + math.Abs(cons*estc)
+		return
+	}
+	res = conc*resc24 - cons*ress24
+	abserr = math.Abs(conc*estc) + math.Abs(cons*ests)
+	return
+}
+
+// quadChebMoments computes the modified Chebyshev moments (see QC25F in [1])
+//
+//	          1                                    1
+//	mom[k] = ∫ T_k(t) cos(p⋅t) dt  (k even)     = ∫ T_k(t) sin(p⋅t) dt  (k odd)
+//	        -1                                   -1
+//
+//	The moments are computed by forward recursion if |p| > 24; otherwise they are computed by
+//	solving a tridiagonal system (Oliver's method) because the recursion is unstable
+func quadChebMoments(p float64) (mom []float64) {
+	mom = make([]float64, 25)
+	noequ := 25
+	par2 := p * p
+	par22 := par2 + 2
+	sinpar, cospar := math.Sin(p), math.Cos(p)
+	var v [28]float64
+	var d, d1, d2 [25]float64
+
+	// moments of even degree (cosine)
+	v[0] = 2 * sinpar / p
+	v[1] = (8*cospar + (par2+par2-8)*sinpar/p) / par2
+	v[2] = (32*(par2-12)*cospar + (2*((par2-80)*par2+192)*sinpar)/p) / (par2 * par2)
+	ac := 8 * cospar
+	as := 24 * p * sinpar
+	if math.Abs(p) > 24 {
+		an := 4.0
+		for i := 3; i < 13; i++ {
+			an2 := an * an
+			v[i] = ((an2-4)*(2*(par22-an2-an2)*v[i-1]-ac) + as - par2*(an+1)*(an+2)*v[i-2]) / (par2 * (an - 1) * (an - 2))
+			an += 2
+		}
+	} else {
+		an := 6.0
+		for k := 0; k < noequ-1; k++ {
+			an2 := an * an
+			d[k] = -2 * (an2 - 4) * (par22 - an2 - an2)
+			d2[k] = (an - 1) * (an - 2) * par2
+			d1[k+1] = (an + 3) * (an + 4) * par2
+			v[k+3] = as - (an2-4)*ac
+			an += 2
+		}
+		an2 := an * an
+		d[noequ-1] = -2 * (an2 - 4) * (par22 - an2 - an2)
+		v[noequ+2] = as - (an2-4)*ac
+		v[3] -= 56 * par2 * v[2]
+		ass := p * sinpar
+		asap := (((((210*par2-1)*cospar-(105*par2-63)*ass)/an2-(1-15*par2)*cospar+15*ass)/an2-cospar+3*ass)/an2 - cospar) / an2
+		v[noequ+2] -= 2 * asap * par2 * (an - 1) * (an - 2)
+		quadTridiag(d1[1:], d[:], d2[:noequ-1], v[3:3+noequ])
+	}
+	for j := 0; j < 13; j++ {
+		mom[2*j] = v[j]
+	}
+
+	// moments of odd degree (sine)
+	v[0] = 2 * (sinpar - p*cospar) / par2
+	v[1] = (18-48/par2)*sinpar/par2 + (-2+48/par2)*cospar/p
+	ac = -24 * p * cospar
+	as = -8 * sinpar
+	if math.Abs(p) > 24 {
+		an := 3.0
+		for i := 2; i < 12; i++ {
+			an2 := an * an
+			v[i] = ((an2-4)*(2*(par22-an2-an2)*v[i-1]+as) + ac - par2*(an+1)*(an+2)*v[i-2]) / (par2 * (an - 1) * (an - 2))
+			an += 2
+		}
+	} else {
+		an := 5.0
+		for k := 0; k < noequ-1; k++ {
+			an2 := an * an
+			d[k] = -2 * (an2 - 4) * (par22 - an2 - an2)
+			d2[k] = (an - 1) * (an - 2) * par2
+			d1[k+1] = (an + 3) * (an + 4) * par2
+			v[k+2] = ac + (an2-4)*as
+			an += 2
+		}
+		an2 := an * an
+		d[noequ-1] = -2 * (an2 - 4) * (par22 - an2 - an2)
+		v[noequ+1] = ac + (an2-4)*as
+		v[2] -= 42 * par2 * v[1]
+		ass := p * cospar
+		asap := (((((105*par2-63)*ass+(210*par2-1)*sinpar)/an2+(15*par2-1)*sinpar-15*ass)/an2-3*ass-sinpar)/an2 - sinpar) / an2
+		v[noequ+1] -= 2 * asap * par2 * (an - 1) * (an - 2)
+		quadTridiag(d1[1:], d[:], d2[:noequ-1], v[2:2+noequ])
+	}
+	for j := 0; j < 12; j++ {
+		mom[2*j+1] = v[j]
+	}
+	return
+}
+
+// quadTridiag solves the tridiagonal system with sub-diagonal dl, diagonal d and super-diagonal du
+// using Gaussian elimination with partial pivoting (as LAPACK's dgtsv). The solution is returned
+// in b. dl, d and du are modified
+func quadTridiag(dl, d, du, b []float64) {
+	n := len(d)
+	for i := 0; i < n-1; i++ {
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			if d[i] == 0 {
+				chk.Panic("tridiagonal system is singular\n")
+			}
+			fact := dl[i] / d[i]
+			d[i+1] -= fact * du[i]
+			b[i+1] -= fact * b[i]
+			dl[i] = 0
+		} else {
+			fact := d[i] / dl[i]
+			d[i] = dl[i]
+			temp := d[i+1]
+			d[i+1] = du[i] - fact*temp
+			if i < n-2 {
+				dl[i] = du[i+1]
+				du[i+1] = -fact * dl[i]
+			}
+			du[i] = temp
+			temp = b[i]
+			b[i] = b[i+1]
+			b[i+1] = temp - fact*b[i+1]
+		}
+	}
+	if d[n-1] == 0 {
+		chk.Panic("tridiagonal system is singular\n")
+	}
+	b[n-1] /= d[n-1]
+	b[n-2] = (b[n-2] - du[n-2]*b[n-1]) / d[n-2]
+	for i := n - 3; i >= 0; i-- {
+		b[i] = (b[i] - du[i]*b[i+1] - dl[i]*b[i+2]) / d[i]
+	}
+}
+
+// extrapolation ///////////////////////////////////////////////////////////////////////////////////
+
+// quadEpsTable holds the table of the epsilon algorithm (QELG)
+type quadEpsTable struct {
+	n      int         // number of elements in the current diagonal of the table
+	tab    [52]float64 // the table; the last element is the latest approximation
+	res3la [3]float64  // last three results of the extrapolation
+	nres   int         // number of calls to extrapolate
+}
+
+// append appends a new element to the table
+func (o *quadEpsTable) append(y float64) {
+	if o.n < 50 {
+		o.tab[o.n] = y
+		o.n++
+	}
+}
+
+// extrapolate determines the limit of the sequence in the table by means of the epsilon algorithm
+// of Wynn and estimates the error by comparing the last three results
+func (o *quadEpsTable) extrapolate() (result, abserr float64) {
+	e := o.tab[:]
+	n := o.n - 1 // index of the latest element
+	current := e[n]
+	result, abserr = current, quadOflow
+	if n < 2 {
+		return
+	}
+	newelm := n / 2
+	nfinal := n
+	e[n+2] = e[n]
+	e[n] = quadOflow
+	for i := 0; i < newelm; i++ {
+		res := e[n-2*i+2]
+		e0, e1, e2 := e[n-2*i-2], e[n-2*i-1], res
+		e1abs := math.Abs(e1)
+		delta2, delta3 := e2-e1, e1-e0
+		err2, err3 := math.Abs(delta2), math.Abs(delta3)
+		tol2 := math.Max(math.Abs(e2), e1abs) * MACHEPS
+		tol3 := math.Max(e1abs, math.Abs(e0)) * MACHEPS
+		if err2 <= tol2 && err3 <= tol3 {
+			// e0, e1 and e2 are equal to within machine accuracy; convergence is assumed
+			o.n = nfinal + 1
+			e[n] = current
+			return res, math.Max(err2+err3, 5*MACHEPS*math.Abs(res))
+		}
+		e3 := e[n-2*i]
+		e[n-2*i] = e1
+		delta1 := e1 - e3
+		err1 := math.Abs(delta1)
+		tol1 := math.Max(e1abs, math.Abs(e3)) * MACHEPS
+		if err1 <= tol1 || err2 <= tol2 || err3 <= tol3 {
+			nfinal = 2 * i
+			break
+		}
+		ss := 1/delta1 + 1/delta2 - 1/delta3
+		if math.Abs(ss*e1) <= 1e-4 {
+			nfinal = 2 * i
+			break
+		}
+		res = e1 + 1/ss
+		e[n-2*i] = res
+		if err := err2 + math.Abs(res-e2) + err3; err <= abserr {
+			abserr = err
+			result = res
+		}
+	}
+
+	// shift the table
+	if nfinal == 49 {
+		nfinal = 48
+	}
+	ib := 0
+	if n%2 == 1 {
+		ib = 1
+	}
+	for i := 0; i <= newelm; i++ {
+		e[ib+2*i] = e[ib+2*i+2]
+	}
+	if n != nfinal {
+		for i := 0; i <= nfinal; i++ {
+			e[i] = e[n-nfinal+i]
+		}
+	}
+	o.n = nfinal + 1
+
+	// error estimate
+	if o.nres < 3 {
+		o.res3la[o.nres] = result
+		abserr = quadOflow
+	} else {
+		abserr = math.Abs(result-o.res3la[2]) + math.Abs(result-o.res3la[1]) + math.Abs(result-o.res3la[0])
+		o.res3la[0], o.res3la[1], o.res3la[2] = o.res3la[1], o.res3la[2], result
+	}
+	o.nres++
+	abserr = math.Max(abserr, 5*MACHEPS*math.Abs(result))
+	return
+}
+
+// constants ///////////////////////////////////////////////////////////////////////////////////////
+
+// quadCos24 holds cos(k⋅π/24) for k = 0...47
+var quadCos24 = func() (c []float64) {
+	c = make([]float64, 48)
+	for k := 0; k < 48; k++ {
+		c[k] = math.Cos(float64(k) * math.Pi / 24)
+	}
+	return
+}()
+
+// G7K15 rule
+var (
+	quadXgk15 = []float64{
+		0.991455371120812639206854697526329,
+		0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926,
+		0.741531185599394439863864773280788,
+		0.586087235467691130294144838258730,
+		0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245,
+		0.000000000000000000000000000000000,
+	}
+	quadWgk15 = []float64{
+		0.022935322010529224963732008058970,
+		0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518,
+		0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550,
+		0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649,
+		0.209482141084727828012999174891714,
+	}
+	quadWg7 = []float64{
+		0.129484966168869693270611432679082,
+		0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975,
+		0.417959183673469387755102040816327,
+	}
+)
+
+// G10K21 rule
+var (
+	quadXgk21 = []float64{
+		0.995657163025808080735527280689003,
+		0.973906528517171720077964012084452,
+		0.930157491355708226001207180059508,
+		0.865063366688984510732096688423493,
+		0.780817726586416897063717578345042,
+		0.679409568299024406234327365114874,
+		0.562757134668604683339000099272694,
+		0.433395394129247190799265943165784,
+		0.294392862701460198131126603103866,
+		0.148874338981631210884826001129720,
+		0.000000000000000000000000000000000,
+	}
+	quadWgk21 = []float64{
+		0.011694638867371874278064396062192,
+		0.032558162307964727478818972459390,
+		0.054755896574351996031381300244580,
+		0.075039674810919952767043140916190,
+		0.093125454583697605535065465083366,
+		0.109387158802297641899210590325805,
+		0.123491976262065851077958109831074,
+		0.134709217311473325928054001771707,
+		0.142775938577060080797094273138717,
+		0.147739104901338491374841515972068,
+		0.149445554002916905664936468389821,
+	}
+	quadWg10 = []float64{
+		0.066671344308688137593568809893332,
+		0.149451349150580593145776339657697,
+		0.219086362515982043995534934228163,
+		0.269266719309996355091226921569469,
+		0.295524224714752870173892994651338,
+	}
+)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/utl"
 )
 
@@ -179,3 +180,168 @@ func GaussJacobiXW(alf, bet float64, n int) (x, w []float64) {
 	utl.Qsort2(x, w)
 	return
 }
+
+// ClenshawCurtisXW computes positions (xi) and weights (wi) to perform Clenshaw-Curtis
+// integrations over [-1,1]. The positions are the n extrema of the Chebyshev polynomial of
+// degree n-1 (including ±1), sorted in ascending order; for n=1, x=0 and w=2. The rules with
+// n = 1, 3, 5, 9, 17, ... 2ᵏ+1 points are nested. See [1].
+//
+//	Input:
+//	  n -- number of points for quadrature formula
+//	Reference:
+//	[1] Trefethen LN (2000) Spectral Methods in MATLAB. SIAM. 165p
+func ClenshawCurtisXW(n int) (x, w []float64) {
+	if n < 1 {
+		chk.Panic("number of points must be positive. n=%d is invalid\n", n)
+	}
+	x = make([]float64, n)
+	w = make([]float64, n)
+	if n == 1 {
+		w[0] = 2
+		return
+	}
+	N := n - 1
+	for j := 0; j < n; j++ {
+		x[j] = math.Sin(math.Pi * float64(2*j-N) / float64(2*N)) // symmetric version of -cos(jπ/N)
+	}
+	if N%2 == 0 {
+		w[0] = 1.0 / float64(N*N-1)
+	} else {
+		w[0] = 1.0 / float64(N*N)
+	}
+	w[N] = w[0]
+	for j := 1; j < N; j++ {
+		θ := math.Pi * float64(j) / float64(N)
+		v := 1.0
+		for k := 1; k <= (N-1)/2; k++ {
+			v -= 2.0 * math.Cos(2.0*float64(k)*θ) / float64(4*k*k-1)
+		}
+		if N%2 == 0 {
+			v -= math.Cos(float64(N)*θ) / float64(N*N-1)
+		}
+		w[j] = 2.0 * v / float64(N)
+	}
+	return
+}
+
+// GaussPattersonXW computes positions (xi) and weights (wi) to perform Gauss-Patterson
+// integrations over [-1,1]. The rule of level l has n = 2ˡ-1 points and is obtained by the
+// optimal addition of 2ˡ⁻¹ points to the rule of level l-1; i.e. the rules are nested. Level 1 is
+// the midpoint rule, level 2 is the 3-point Gauss-Legendre rule and level 3 is the 7-point
+// Kronrod extension. The degree of precision is 3⋅2ˡ⁻¹-1 for l ≥ 2. The positions are sorted in
+// ascending order. The new points are the roots of the polynomial computed by expansion in
+// Legendre polynomials according to [1].
+//
+//	Input:
+//	  level -- level of the rule: 1 ≤ level ≤ 6 (1, 3, 7, 15, 31 and 63 points)
+//	Reference:
+//	[1] Patterson TNL (1968) The optimum addition of points to quadrature formulae. Mathematics
+//	    of Computation, 22(104):847-856
+func GaussPattersonXW(level int) (x, w []float64) {
+	if level < 1 || level > 6 {
+		chk.Panic("level must be in [1,6]. level=%d is invalid\n", level)
+	}
+	x = []float64{0}
+	for l := 2; l <= level; l++ {
+		x = pattersonExtend(x)
+	}
+	w = interpolatoryWeights(x)
+	return
+}
+
+// pattersonExtend returns the nodes of the Patterson extension (in ascending order) of the
+// quadrature rule with nodes ξ (in ascending order)
+func pattersonExtend(ξ []float64) (x []float64) {
+
+	// Gauss-Legendre rule to compute the inner products exactly
+	n := len(ξ)
+	m := n + 1 // number of new points
+	xg, wg := GaussLegendreXW(-1, 1, n+m+1)
+
+	// extension polynomial E(x) = P_m(x) + Σ c_j P_j(x) such that ∫ p(x) E(x) P_k(x) dx = 0 for
+	// k < m, where p(x) = Π (x - ξ_i)
+	A := la.NewMatrix(m, m)
+	b := la.NewVector(m)
+	P := make([]float64, m+1)
+	for g := 0; g < len(xg); g++ {
+		p := 1.0
+		for i := 0; i < n; i++ {
+			p *= xg[g] - ξ[i]
+		}
+		legendreValues(P, xg[g])
+		for k := 0; k < m; k++ {
+			for j := 0; j < m; j++ {
+				A.Add(k, j, wg[g]*p*P[j]*P[k])
+			}
+			b[k] -= wg[g] * p * P[m] * P[k]
+		}
+	}
+	c := la.NewVector(m)
+	la.DenSolve(c, A, b, false)
+	E := func(z float64) (res float64) {
+		legendreValues(P, z)
+		res = P[m]
+		for j := 0; j < m; j++ {
+			res += c[j] * P[j]
+		}
+		return
+	}
+
+	// the roots of E interlace with the existing nodes: find them by bisection
+	x = make([]float64, 0, n+m)
+	lo := -1.0
+	for i := 0; i <= n; i++ {
+		hi := 1.0
+		if i < n {
+			hi = ξ[i]
+		}
+		a, b := lo, hi
+		fa := E(a)
+		for it := 0; it < 200 && b-a > MACHEPS*math.Max(1, math.Abs(a)); it++ {
+			mid := (a + b) / 2.0
+			fm := E(mid)
+			if (fm < 0) == (fa < 0) {
+				a, fa = mid, fm
+			} else {
+				b = mid
+			}
+		}
+		x = append(x, (a+b)/2.0)
+		if i < n {
+			x = append(x, ξ[i])
+		}
+		lo = hi
+	}
+	return
+}
+
+// interpolatoryWeights computes the weights of the interpolatory quadrature rule over [-1,1]
+// with the given nodes by solving the moment equations Σ wᵢ P_k(xᵢ) = ∫ P_k(x) dx
+func interpolatoryWeights(x []float64) (w []float64) {
+	n := len(x)
+	A := la.NewMatrix(n, n)
+	b := la.NewVector(n)
+	P := make([]float64, n)
+	for i := 0; i < n; i++ {
+		legendreValues(P, x[i])
+		for k := 0; k < n; k++ {
+			A.Set(k, i, P[k])
+		}
+	}
+	b[0] = 2
+	w = make([]float64, n)
+	la.DenSolve(w, A, b, false)
+	return
+}
+
+// legendreValues computes the Legendre polynomials P_0(x) ... P_{len(P)-1}(x)
+func legendreValues(P []float64, x float64) {
+	P[0] = 1
+	if len(P) > 1 {
+		P[1] = x
+	}
+	for k := 2; k < len(P); k++ {
+		K := float64(k)
+		P[k] = ((2*K-1)*x*P[k-1] - (K-1)*P[k-2]) / K
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// QuadMonteCarlo approximates the integral of f(x) over the hyper-rectangle defined by a and b
+// using plain Monte Carlo integration with n uniformly distributed random points. See Chapter 7.7
+// of [1]. The standard error decreases with 1/√n independently of the dimension.
+//
+//	a -- lower limits [ndim]
+//	b -- upper limits [ndim]
+//	n -- number of points (≥ 2)
+//
+//	Output:
+//	  res    -- the integral
+//	  stderr -- the estimated standard deviation of res
+//
+//	NOTE: the random numbers are generated by rnd.Float64; thus rnd.Init may be used to set the seed
+//
+//	Reference:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func QuadMonteCarlo(a, b []float64, n int, f fun.Sv) (res, stderr float64) {
+	vol := mcCheck(a, b, n, 2)
+	x := la.NewVector(len(a))
+	var m mcStat
+	for k := 0; k < n; k++ {
+		for i := 0; i < len(a); i++ {
+			x[i] = rnd.Float64(a[i], b[i])
+		}
+		m.add(f(x))
+	}
+	res = vol * m.mean
+	stderr = math.Abs(vol) * math.Sqrt(m.variance()/float64(n))
+	return
+}
+
+// QuadMonteCarloStrat approximates the integral of f(x) over the hyper-rectangle defined by a and
+// b using stratified Monte Carlo integration. The region is divided into ndivⁿᵈⁱᵐ equal cells
+// and n random points are used in each cell; the total number of function evaluations is thus
+// n⋅ndivⁿᵈⁱᵐ. Stratification reduces the variance of the estimate compared with plain Monte Carlo
+// with the same number of points. See Chapter 7.8 of [1].
+//
+//	a    -- lower limits [ndim]
+//	b    -- upper limits [ndim]
+//	ndiv -- number of divisions along each direction (≥ 1)
+//	n    -- number of points in each cell (≥ 2)
+//
+//	Output:
+//	  res    -- the integral
+//	  stderr -- the estimated standard deviation of res
+//
+//	Reference:
+//	[1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	    Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+func QuadMonteCarloStrat(a, b []float64, ndiv, n int, f fun.Sv) (res, stderr float64) {
+	vol := mcCheck(a, b, n, 2)
+	if ndiv < 1 {
+		chk.Panic("number of divisions must be positive. ndiv=%d is invalid\n", ndiv)
+	}
+	ndim := len(a)
+	ncells := math.Pow(float64(ndiv), float64(ndim))
+	vcell := vol / ncells
+	x := la.NewVector(ndim)
+	idx := make([]int, ndim) // multi-index of cell
+	var variance float64
+	for {
+		var m mcStat
+		for k := 0; k < n; k++ {
+			for i := 0; i < ndim; i++ {
+				δ := (b[i] - a[i]) / float64(ndiv)
+				x[i] = a[i] + δ*(float64(idx[i])+rnd.Float64(0, 1))
+			}
+			m.add(f(x))
+		}
+		res += vcell * m.mean
+		variance += vcell * vcell * m.variance() / float64(n)
+		i := 0
+		for ; i < ndim; i++ {
+			idx[i]++
+			if idx[i] < ndiv {
+				break
+			}
+			idx[i] = 0
+		}
+		if i == ndim {
+			break
+		}
+	}
+	stderr = math.Sqrt(variance)
+	return
+}
+
+// QuadQuasiMonteCarlo approximates the integral of f(x) over the hyper-rectangle defined by a
+// and b using randomised quasi-Monte Carlo integration. The low-discrepancy sequence given by
+// rnd.HaltonPoints is shifted nrep times by random vectors modulo 1 (Cranley-Patterson rotation)
+// such that each replicate is an unbiased estimate and the standard error can be computed from
+// the spread of the replicates [1,2]. The total number of function evaluations is n⋅nrep. For
+// smooth integrands, the error decreases nearly as 1/n instead of 1/√n as in Monte Carlo.
+//
+//	a    -- lower limits [ndim]
+//	b    -- upper limits [ndim]
+//	n    -- number of points in the Halton sequence (≥ 1)
+//	nrep -- number of random shifts (≥ 2)
+//
+//	Output:
+//	  res    -- the integral
+//	  stderr -- the estimated standard deviation of res
+//
+//	References:
+//	[1] Cranley R, Patterson TNL (1976) Randomization of number theoretic methods for multiple
+//	    integration. SIAM Journal on Numerical Analysis, 13(6):904-914
+//	[2] L'Ecuyer P, Lemieux C (2002) Recent advances in randomized quasi-Monte Carlo methods. In:
+//	    Modeling Uncertainty. Springer. pp 419-474
+func QuadQuasiMonteCarlo(a, b []float64, n, nrep int, f fun.Sv) (res, stderr float64) {
+	vol := mcCheck(a, b, n, 1)
+	if nrep < 2 {
+		chk.Panic("number of replicates must be at least 2. nrep=%d is invalid\n", nrep)
+	}
+	ndim := len(a)
+	u := rnd.HaltonPoints(ndim, n)
+	shift := make([]float64, ndim)
+	x := la.NewVector(ndim)
+	var reps mcStat
+	for r := 0; r < nrep; r++ {
+		rnd.Float64s(shift, 0, 1)
+		var m mcStat
+		for k := 0; k < n; k++ {
+			for i := 0; i < ndim; i++ {
+				t := u[i][k] + shift[i]
+				if t >= 1 {
+					t--
+				}
+				x[i] = a[i] + (b[i]-a[i])*t
+			}
+			m.add(f(x))
+		}
+		reps.add(vol * m.mean)
+	}
+	res = reps.mean
+	stderr = math.Sqrt(reps.variance() / float64(nrep))
+	return
+}
+
+// mcCheck checks the input of Monte Carlo integrators and returns the signed volume of the region
+func mcCheck(a, b []float64, n, nmin int) (vol float64) {
+	if len(a) < 1 || len(b) != len(a) {
+		chk.Panic("limits must have the same (non-zero) length. len(a)=%d, len(b)=%d is invalid\n", len(a), len(b))
+	}
+	if n < nmin {
+		chk.Panic("number of points must be at least %d. n=%d is invalid\n", nmin, n)
+	}
+	vol = 1.0
+	for i := 0; i < len(a); i++ {
+		vol *= b[i] - a[i]
+	}
+	return
+}
+
+// mcStat computes the mean and variance of samples by means of Welford's algorithm
+type mcStat struct {
+	n    int     // number of samples
+	mean float64 // mean value
+	m2   float64 // sum of squares of differences from the mean
+}
+
+// add adds a new sample
+func (o *mcStat) add(y float64) {
+	o.n++
+	δ := y - o.mean
+	o.mean += δ / float64(o.n)
+	o.m2 += δ * (y - o.mean)
+}
+
+// variance returns the (unbiased) sample variance
+func (o *mcStat) variance() float64 {
+	if o.n < 2 {
+		return 0
+	}
+	return o.m2 / float64(o.n-1)
+}
@@ -0,0 +1,272 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// QuadTanhSinh implements the double-exponential (tanh-sinh) quadrature of Takahasi and Mori [1].
+// The integrand is transformed by x = φ(t) such that the transformed integrand decays double
+// exponentially as |t| → ∞; thus the trapezoidal rule in t converges very quickly even if f(x)
+// has algebraic or logarithmic singularities at the endpoints. The step size is halved at each
+// level (reusing all previous function evaluations) until two successive estimates agree [2].
+// The following transformations are used:
+//
+//	finite interval    [a,b]   -- tanh-sinh: x = c + h⋅tanh(π/2⋅sinh(t)); c=(a+b)/2, h=(b-a)/2
+//	semi-infinite      [a,∞)   -- exp-sinh:  x = a + exp(π/2⋅sinh(t))
+//	semi-infinite      (-∞,b]  -- exp-sinh:  x = b - exp(π/2⋅sinh(t))
+//	infinite           (-∞,∞)  -- sinh-sinh: x = sinh(π/2⋅sinh(t))
+//
+//	The integrand is never evaluated at the endpoints. Nonetheless, near an endpoint b ≠ 0, the
+//	abscissae are limited by the machine precision (e.g. b - x ≥ ϵ⋅|b|). Therefore, singularities
+//	are best placed at a=0 or b=0 by a change of variables; e.g. 1/√(1-x) on [0,1] → 1/√x.
+//
+//	The error estimate is the difference between the last two levels; since the convergence is
+//	nearly quadratic, the actual error is usually much smaller. However, the parts of [a,b] next
+//	to a finite endpoint that cannot be reached by the abscissae are not accounted for by this
+//	difference. Thus the integral over these parts (tails) is estimated by assuming that
+//	|f| ≈ C⋅d⁻ᵅ, where d is the distance to the endpoint, and is added to the error estimate.
+//	The exponent α is fitted to the two abscissae nearest to the endpoint and limited to 0.9.
+//
+//	NOTE: QuadTanhSinh holds only the configuration and is not modified by Integrate; thus the
+//	      same object can be used concurrently by several goroutines (provided that f is safe).
+//
+//	References:
+//	  [1] Takahasi H, Mori M (1974) Double exponential formulas for numerical integration.
+//	      Publications of the Research Institute for Mathematical Sciences, 9(3):721-741
+//	  [2] Bailey DH, Jeyabalan K, Li XS (2005) A comparison of three high-precision quadrature
+//	      schemes. Experimental Mathematics, 14(3):317-329
+type QuadTanhSinh struct {
+	EpsAbs   float64 // absolute tolerance
+	EpsRel   float64 // relative tolerance
+	MaxLevel int     // maximum number of levels (halving of the step size)
+}
+
+// NewQuadTanhSinh returns a new double-exponential integrator with default parameters
+//
+//	epsAbs -- absolute tolerance
+//	epsRel -- relative tolerance
+//
+//	Defaults: MaxLevel = 12
+func NewQuadTanhSinh(epsAbs, epsRel float64) (o *QuadTanhSinh) {
+	o = new(QuadTanhSinh)
+	o.EpsAbs = epsAbs
+	o.EpsRel = epsRel
+	o.MaxLevel = 12
+	return
+}
+
+// Integrate computes the integral of f(x) from a to b
+//
+//	a and/or b may be infinite (math.Inf)
+//
+//	Output:
+//	  res       -- the integral
+//	  abserr    -- estimate of the absolute error, including the truncated tails
+//	  neval     -- number of function evaluations
+//	  converged -- abserr ≤ max(EpsAbs, EpsRel⋅|res|) has been achieved within MaxLevel levels
+func (o *QuadTanhSinh) Integrate(f fun.Ss, a, b float64) (res, abserr float64, neval int, converged bool) {
+
+	// check
+	if o.EpsAbs <= 0 && o.EpsRel <= 0 {
+		chk.Panic("EpsAbs=%g and EpsRel=%g are invalid: at least one tolerance must be positive\n", o.EpsAbs, o.EpsRel)
+	}
+	if o.MaxLevel < 1 {
+		chk.Panic("MaxLevel=%d must be positive\n", o.MaxLevel)
+	}
+	if math.IsNaN(a) || math.IsNaN(b) {
+		chk.Panic("limits of integration must not be NaN\n")
+	}
+	if a == b {
+		converged = true
+		return
+	}
+	sign := 1.0
+	if a > b {
+		a, b, sign = b, a, -1
+	}
+
+	// transformation and endpoints corresponding to t → -∞ and t → +∞
+	var φ tsMap
+	var ends [2]float64
+	switch {
+	case math.IsInf(a, -1) && math.IsInf(b, 1):
+		φ = tsSinhSinh
+		ends = [2]float64{a, b}
+	case math.IsInf(b, 1):
+		φ = func(t float64) (x, w float64) {
+			e, w := tsExpSinh(t)
+			return a + e, w
+		}
+		ends = [2]float64{a, b}
+	case math.IsInf(a, -1):
+		φ = func(t float64) (x, w float64) {
+			e, w := tsExpSinh(t)
+			return b - e, w
+		}
+		ends = [2]float64{b, a}
+	default:
+		φ = func(t float64) (x, w float64) { return tsTanhSinh(t, a, b) }
+		ends = [2]float64{a, b}
+	}
+
+	// the two abscissae nearest to each endpoint and whether points have been dropped
+	var tails [2]tsTail
+	for k := 0; k < 2; k++ {
+		tails[k].d1, tails[k].d2 = math.Inf(1), math.Inf(1)
+	}
+
+	// term of the trapezoidal sum; ok is false if the point cannot be used
+	term := func(t float64) (val float64, ok bool) {
+		k := 0
+		if t > 0 {
+			k = 1
+		}
+		x, w := φ(t)
+		if x <= a || x >= b || math.IsInf(x, 0) || math.IsInf(w, 0) {
+			tails[k].dropped = true
+			return 0, false
+		}
+		neval++
+		fx := f(x)
+		val = w * fx
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			tails[k].dropped = true
+			return 0, false
+		}
+		if t != 0 {
+			tails[k].update(math.Abs(x-ends[k]), math.Abs(fx))
+		}
+		return val, true
+	}
+
+	// level 0: step size equal to 1; find the truncation points tmax on each side
+	sum, ok := term(0)
+	if !ok {
+		chk.Panic("integrand is not finite at the centre of the transformation\n")
+	}
+	tmax := []float64{0, 0} // left and right
+	for k, s := range []float64{-1, 1} {
+		for j := 1; ; j++ {
+			tmax[k] = float64(j) // finer levels may use points in (j-1,j) even if j is not usable
+			val, ok := term(s * float64(j))
+			if !ok {
+				break
+			}
+			sum += val
+			if j >= 3 && math.Abs(val) <= tsNegligible*math.Abs(sum) {
+				break
+			}
+		}
+	}
+	res = sum
+
+	// refinement: halve the step size and add the new points (odd multiples of h)
+	h := 1.0
+	for level := 1; level <= o.MaxLevel; level++ {
+		h /= 2
+		var add float64 // sum of new terms (added separately to reduce roundoff errors)
+		for k, s := range []float64{-1, 1} {
+			for t := h; t <= tmax[k]; t += 2 * h {
+				if val, ok := term(s * t); ok {
+					add += val
+				}
+			}
+		}
+		sum += add
+		old := res
+		res = h * sum
+		abserr = math.Abs(res - old)
+		for k := 0; k < 2; k++ {
+			if tails[k].dropped && !math.IsInf(ends[k], 0) {
+				abserr += tails[k].integral()
+			}
+		}
+		if level > 2 && abserr <= math.Max(o.EpsAbs, o.EpsRel*math.Abs(res)) {
+			converged = true
+			break
+		}
+	}
+	res *= sign
+	return
+}
+
+// tsTail holds the two abscissae nearest to an endpoint in order to estimate the integral over
+// the part of the interval between the nearest abscissa and the endpoint
+type tsTail struct {
+	d1, f1  float64 // distance to the endpoint and |f| of the nearest abscissa
+	d2, f2  float64 // distance to the endpoint and |f| of the second nearest abscissa
+	dropped bool    // some points near the endpoint could not be used
+}
+
+// update updates the nearest abscissae with a new point with distance d and |f(x)| = fx
+func (o *tsTail) update(d, fx float64) {
+	switch {
+	case d < o.d1:
+		o.d2, o.f2 = o.d1, o.f1
+		o.d1, o.f1 = d, fx
+	case d < o.d2 && d > o.d1:
+		o.d2, o.f2 = d, fx
+	}
+}
+
+// integral estimates the integral of |f| from the endpoint to the nearest abscissa with
+// |f| ≈ C⋅d⁻ᵅ, where α ϵ [0, 0.9] is fitted to the two nearest abscissae
+func (o *tsTail) integral() float64 {
+	if math.IsInf(o.d1, 0) {
+		return 0
+	}
+	α := 0.0
+	if !math.IsInf(o.d2, 0) && o.f1 > 0 && o.f2 > 0 {
+		α = math.Log(o.f1/o.f2) / math.Log(o.d2/o.d1)
+		α = math.Max(0, math.Min(0.9, α))
+	}
+	return o.f1 * o.d1 / (1 - α)
+}
+
+// tsNegligible defines the relative size of terms that can be neglected when truncating the
+// trapezoidal sum; because of the double exponential decay, the next terms are much smaller
+const tsNegligible = 1e-20
+
+// tsMap defines the transformation x = φ(t) and returns the weight w = dφ/dt
+type tsMap func(t float64) (x, w float64)
+
+// tsTanhSinh computes the tanh-sinh transformation for the finite interval [a,b]. The distance
+// from the nearest endpoint is computed directly to avoid cancellation errors
+func tsTanhSinh(t, a, b float64) (x, w float64) {
+	h := (b - a) / 2.0
+	u := math.Pi / 2.0 * math.Sinh(math.Abs(t))
+	ch := math.Cosh(u)
+	δ := h / (math.Exp(u) * ch) // h⋅(1 - tanh(u))
+	w = h * math.Pi / 2.0 * math.Cosh(t) / (ch * ch)
+	switch {
+	case t > 0:
+		x = b - δ
+	case t < 0:
+		x = a + δ
+	default:
+		x = a + h
+	}
+	return
+}
+
+// tsExpSinh computes the exp-sinh transformation e = exp(π/2⋅sinh(t)) for semi-infinite intervals
+func tsExpSinh(t float64) (e, w float64) {
+	e = math.Exp(math.Pi / 2.0 * math.Sinh(t))
+	w = math.Pi / 2.0 * math.Cosh(t) * e
+	return
+}
+
+// tsSinhSinh computes the sinh-sinh transformation for the infinite interval (-∞,∞)
+func tsSinhSinh(t float64) (x, w float64) {
+	u := math.Pi / 2.0 * math.Sinh(t)
+	x = math.Sinh(u)
+	w = math.Pi / 2.0 * math.Cosh(t) * math.Cosh(u)
+	return
+}
@@ -0,0 +1,309 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+// RootFinder implements bracketed root finders for scalar functions: Newton's and Halley's methods
+// safeguarded by bisection [1] and the derivative-free ITP (Interpolate, Truncate and Project)
+// method [2]. The root must be bracketed by [xa, xb] and the bracket is kept (and reduced) during
+// the iterations; thus, convergence is guaranteed for continuous functions.
+//
+//	References:
+//	  [1] Press WH, Teukolsky SA, Vetterling WT, Flannery BP (2007) Numerical Recipes: The Art of
+//	      Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//	  [2] Oliveira IFD, Takahashi RHC (2020) An enhancement of the bisection method average
+//	      performance preserving minmax optimality. ACM Transactions on Mathematical Software,
+//	      47(1):5:1-5:24
+type RootFinder struct {
+
+	// configuration
+	MaxIt   int     // max iterations
+	Tol     float64 // tolerance on x
+	Verbose bool    // show messages
+
+	// configuration: ITP method
+	ItpK1 float64 // truncation factor κ₁ > 0; use 0 for κ₁ = 0.2/(xb-xa)
+	ItpK2 float64 // truncation exponent κ₂ ∈ [1, 1+φ) [default = 2]
+	ItpN0 int     // slack of the projection n₀ ≥ 0 [default = 1]
+
+	// statistics
+	NumFeval int // number of calls to ffcn (function evaluations)
+	NumJeval int // number of calls to dfdx and d2fdx2 (derivative evaluations)
+	NumIter  int // number of iterations from the last call
+
+	// internal
+	ffcn   fun.Ss // y = f(x) function
+	dfdx   fun.Ss // df/dx [optional / may be nil]
+	d2fdx2 fun.Ss // d²f/dx² [optional / may be nil]
+}
+
+// NewRootFinder returns a new RootFinder structure
+//
+//	ffcn   -- function f(x)
+//	dfdx   -- derivative df(x)/dx [optional / may be nil; required by Newton and Halley]
+//	d2fdx2 -- second derivative d²f(x)/dx² [optional / may be nil; required by Halley]
+func NewRootFinder(ffcn, dfdx, d2fdx2 fun.Ss) (o *RootFinder) {
+	o = new(RootFinder)
+	o.MaxIt = 100
+	o.Tol = 1e-10
+	o.ItpK2 = 2
+	o.ItpN0 = 1
+	o.ffcn = ffcn
+	o.dfdx = dfdx
+	o.d2fdx2 = d2fdx2
+	return
+}
+
+// Newton solves f(x) = 0 for x in [xa, xb] with f(xa)⋅f(xb) ≤ 0 by Newton's method combined with
+// bisection (rtsafe of [1]). A bisection step is taken whenever the Newton step would leave the
+// current bracket or would not reduce the step size by at least a half
+func (o *RootFinder) Newton(xa, xb float64) (res float64) {
+	if o.dfdx == nil {
+		chk.Panic("Newton's method requires the derivative dfdx\n")
+	}
+	return o.safeguarded(xa, xb, false)
+}
+
+// Halley solves f(x) = 0 for x in [xa, xb] with f(xa)⋅f(xb) ≤ 0 by Halley's method (cubic
+// convergence) combined with bisection. See Newton
+//
+//	x ← x - 2⋅f⋅f' / (2⋅f'² - f⋅f'')
+func (o *RootFinder) Halley(xa, xb float64) (res float64) {
+	if o.dfdx == nil || o.d2fdx2 == nil {
+		chk.Panic("Halley's method requires the derivatives dfdx and d2fdx2\n")
+	}
+	return o.safeguarded(xa, xb, true)
+}
+
+// ITP solves f(x) = 0 for x in [xa, xb] with f(xa)⋅f(xb) ≤ 0 by the ITP method [2]
+//
+//	The regula falsi estimate is truncated towards the midpoint and projected onto a neighbourhood
+//	of the midpoint such that the number of iterations never exceeds the one of the bisection
+//	method by more than n₀. For smooth functions, the convergence order is (1+√5)/2 (as the secant
+//	method). Derivatives are not needed.
+func (o *RootFinder) ITP(xa, xb float64) (res float64) {
+
+	// initialization
+	a, b, ya, yb, done := o.init(xa, xb)
+	if done {
+		return a
+	}
+	κ1, κ2 := o.ItpK1, o.ItpK2
+	if κ1 <= 0 {
+		κ1 = 0.2 / (b - a)
+	}
+	ϵ := o.Tol / 2.0
+	nhalf := math.Ceil(math.Log2((b - a) / (2.0 * ϵ)))
+	nmax := nhalf + float64(o.ItpN0)
+
+	// message
+	if o.Verbose {
+		io.Pf("%4s%23s%23s%23s\n", "it", "x", "f(x)", "b-a")
+		io.Pf("%69.1e\n", o.Tol)
+	}
+
+	// iterations
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// converged?
+		if b-a <= 2.0*ϵ {
+			return (a + b) / 2.0
+		}
+
+		// interpolation (regula falsi)
+		xh := (a + b) / 2.0
+		r := ϵ*math.Pow(2, nmax-float64(o.NumIter)) - (b-a)/2.0
+		δ := κ1 * math.Pow(b-a, κ2)
+		xf := (yb*a - ya*b) / (yb - ya)
+
+		// truncation
+		σ := 1.0
+		if xh < xf {
+			σ = -1.0
+		}
+		xt := xh
+		if δ <= math.Abs(xh-xf) {
+			xt = xf + σ*δ
+		}
+
+		// projection
+		x := xh - σ*r
+		if math.Abs(xt-xh) <= r {
+			x = xt
+		}
+
+		// update the bracket
+		y := o.ffcn(x)
+		o.NumFeval++
+		if o.Verbose {
+			io.Pf("%4d%23.15e%23.15e%23.15e\n", o.NumIter, x, y, b-a)
+		}
+		switch {
+		case y == 0:
+			return x
+		case y*ya > 0:
+			a, ya = x, y
+		default:
+			b, yb = x, y
+		}
+	}
+
+	// did not converge
+	chk.Panic("fail to converge after %d iterations", o.NumIter)
+	return
+}
+
+// All finds all roots of f(x) in [xa, xb] by bracketing the sign changes with Bracket.Roots and
+// refining each bracket with Halley's method if d2fdx2 and dfdx are available, Newton's method if
+// only dfdx is available, or the ITP method otherwise. The statistics include the scanning
+//
+//	n -- number of subintervals for the initial scanning (e.g. 100); see Bracket.Roots
+func (o *RootFinder) All(xa, xb float64, n int) (roots []float64) {
+	bracket := NewBracket(o.ffcn)
+	list := bracket.Roots(xa, xb, n)
+	nfeval, njeval, nit := bracket.NumFeval, 0, 0
+	for _, ab := range list {
+		var x float64
+		switch {
+		case ab[0] == ab[1]:
+			x = ab[0]
+		case o.dfdx != nil && o.d2fdx2 != nil:
+			x = o.Halley(ab[0], ab[1])
+		case o.dfdx != nil:
+			x = o.Newton(ab[0], ab[1])
+		default:
+			x = o.ITP(ab[0], ab[1])
+		}
+		if ab[0] != ab[1] {
+			nfeval += o.NumFeval
+			njeval += o.NumJeval
+			nit += o.NumIter
+		}
+		roots = append(roots, x)
+	}
+	o.NumFeval, o.NumJeval, o.NumIter = nfeval, njeval, nit
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// init checks the bracket and returns it sorted (a < b) with the function values. If the root is
+// at one of the bounds, returns done = true and the root in a
+func (o *RootFinder) init(xa, xb float64) (a, b, ya, yb float64, done bool) {
+	o.NumFeval, o.NumJeval, o.NumIter = 0, 0, 0
+	a, b = xa, xb
+	if b < a {
+		a, b = b, a
+	}
+	ya, yb = o.ffcn(a), o.ffcn(b)
+	o.NumFeval = 2
+	if ya == 0 {
+		return a, b, ya, yb, true
+	}
+	if yb == 0 {
+		return b, b, yb, yb, true
+	}
+	if ya*yb > 0 {
+		chk.Panic("root must be bracketed: xa=%g, xb=%g, fa=%g, fb=%g => fa * fb > 0", xa, xb, ya, yb)
+	}
+	return
+}
+
+// safeguarded implements Newton's or Halley's method combined with bisection
+func (o *RootFinder) safeguarded(xa, xb float64, halley bool) (res float64) {
+
+	// initialization: f(xl) < 0 < f(xh)
+	xl, xh, yl, _, done := o.init(xa, xb)
+	if done {
+		return xl
+	}
+	if yl > 0 {
+		xl, xh = xh, xl
+	}
+	x := (xl + xh) / 2.0
+	dxold := math.Abs(xh - xl)
+	dx := dxold
+	y, dy, d2y := o.eval(x, halley)
+
+	// message
+	if o.Verbose {
+		io.Pf("%4s%23s%23s%23s\n", "it", "x", "f(x)", "err")
+		io.Pf("%69.1e\n", o.Tol)
+	}
+
+	// iterations
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// message
+		if o.Verbose {
+			io.Pf("%4d%23.15e%23.15e%23.15e\n", o.NumIter, x, y, math.Abs(dx))
+		}
+
+		// Newton's or Halley's step
+		step, ok := 0.0, dy != 0
+		if ok {
+			step = y / dy
+			if halley {
+				den := 2.0*dy*dy - y*d2y
+				ok = den != 0
+				if ok {
+					step = 2.0 * y * dy / den
+				}
+			}
+		}
+		xnew := x - step
+
+		// bisection if the step leaves the bracket or is not fast enough
+		if !ok || (xnew-xl)*(xnew-xh) >= 0 || math.Abs(2.0*step) > math.Abs(dxold) {
+			dxold = dx
+			dx = (xh - xl) / 2.0
+			x = xl + dx
+		} else {
+			dxold = dx
+			dx = step
+			x = xnew
+		}
+
+		// converged?
+		if math.Abs(dx) <= 2.0*MACHEPS*math.Abs(x)+o.Tol/2.0 {
+			return x
+		}
+
+		// update the bracket
+		y, dy, d2y = o.eval(x, halley)
+		if y == 0 {
+			return x
+		}
+		if y < 0 {
+			xl = x
+		} else {
+			xh = x
+		}
+	}
+
+	// did not converge
+	chk.Panic("fail to converge after %d iterations", o.NumIter)
+	return
+}
+
+// eval computes f(x), df/dx and d²f/dx² [if halley]
+func (o *RootFinder) eval(x float64, halley bool) (y, dy, d2y float64) {
+	y = o.ffcn(x)
+	dy = o.dfdx(x)
+	o.NumFeval++
+	o.NumJeval++
+	if halley {
+		d2y = o.d2fdx2(x)
+		o.NumJeval++
+	}
+	return
+}
@@ -0,0 +1,491 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+)
+
+// SparseGrid implements Smolyak sparse grids for quadrature and interpolation of functions f(x)
+// with x ϵ Rⁿᵈⁱᵐ. The sparse grid operator is the sum of tensor products of 1D difference
+// operators Δₗ = Uₗ - Uₗ₋₁ (U₀ = 0) over a downward closed set of multi-indices k = (k₀,k₁,...)
+// with kᵢ ≥ 1 denoting the level of the 1D rule along each direction [1]:
+//
+//	A(f) = Σ_k (Δ_k₀ ⊗ Δ_k₁ ⊗ ... ⊗ Δ_kₙ₋₁)(f)
+//
+//	The isotropic (classical Smolyak) grid uses all k such that Σ(kᵢ-1) ≤ level-1. The
+//	dimension-adaptive algorithm [2] selects the multi-indices with largest contributions and is
+//	therefore well suited to functions in which only some directions are important.
+//
+//	Quadrature and interpolation use the same grid: the interpolant is built hierarchically from
+//	the Lagrange interpolants on the nodes of the 1D rules.
+//
+//	1D rules:
+//	  "cc" -- Clenshaw-Curtis over [lo,hi]: 1, 3, 5, 9, 17, ... 2ˡ⁻¹+1 points (nested); maximum level = 12
+//	  "gp" -- Gauss-Patterson over [lo,hi]: 1, 3, 7, 15, 31, 63 points (nested); maximum level = 6
+//	  "gh" -- Gauss-Hermite with the standard normal density φ(x)=exp(-x²/2)/√(2π) as weight
+//	          function: 1, 3, 5, 7, ... 2l-1 points; maximum level = 25. Thus the integral is the
+//	          expectation E[f(X)] with X having independent standard normal components. lo and
+//	          hi must be nil
+//
+//	NOTE: the Gauss-Hermite rules are NOT nested (only the central node is shared by all
+//	      levels); thus, with "gh", the grid contains the points of every level used by the
+//	      multi-indices and needs more function evaluations than a grid built from a nested
+//	      Hermite family (e.g. Genz-Keister), which is not available here
+//
+//	References:
+//	  [1] Gerstner T, Griebel M (1998) Numerical integration using sparse grids. Numerical
+//	      Algorithms, 18:209-232
+//	  [2] Gerstner T, Griebel M (2003) Dimension-adaptive tensor-product quadrature. Computing,
+//	      71:65-87
+type SparseGrid struct {
+	Ndim  int         // number of dimensions
+	Rule  string      // 1D rule: "cc", "gp" or "gh"
+	X     [][]float64 // points [npts][ndim]
+	W     []float64   // quadrature weights [npts]
+	F     []float64   // function values at points [npts]; computed by Compute or Adaptive
+	Index [][]int     // multi-indices of the grid [nidx][ndim] (levels ≥ 1)
+
+	// internal
+	maxLevel int              // maximum level of 1D rule
+	c, h     []float64        // centre and half-length of each direction (scaling of reference rules)
+	nodes    []float64        // unique 1D nodes of all levels (reference coordinates)
+	levels   []*sparseLevel1d // 1D rules; levels[l-1] corresponds to level l
+	points   map[string]int   // maps the 1D node ids of a point to its position in X
+	set      map[string]int   // maps a multi-index to its position in Index
+	support  [][]int          // positions in X of the tensor points of each Δ_k
+	delta    []float64        // contribution of each Δ_k to the integral (adaptive algorithm)
+	active   []bool           // active multi-indices (adaptive algorithm)
+}
+
+// sparseLevel1d holds a 1D rule and its difference with the rule of the previous level
+type sparseLevel1d struct {
+	ids  []int     // node ids
+	x, w []float64 // nodes and weights (reference coordinates)
+	λ    []float64 // barycentric weights
+	dIds []int     // ids of the support of the difference rule: union of nodes of this and previous levels
+	dW   []float64 // weights of the difference rule
+	pos  []int     // position of dIds in this level [or -1]
+	prev []int     // position of dIds in the previous level [or -1]
+}
+
+// NewSparseGrid returns a new sparse grid (without points)
+//
+//	ndim   -- number of dimensions
+//	rule   -- 1D rule: "cc", "gp" or "gh"
+//	lo, hi -- lower and upper limits [ndim] for "cc" and "gp"; nil for "gh"
+func NewSparseGrid(ndim int, rule string, lo, hi []float64) (o *SparseGrid) {
+	if ndim < 1 {
+		chk.Panic("number of dimensions must be positive. ndim=%d is invalid\n", ndim)
+	}
+	o = new(SparseGrid)
+	o.Ndim = ndim
+	o.Rule = rule
+	o.c = make([]float64, ndim)
+	o.h = make([]float64, ndim)
+	switch rule {
+	case "cc", "gp":
+		if len(lo) != ndim || len(hi) != ndim {
+			chk.Panic("limits must have length equal to ndim=%d. len(lo)=%d and len(hi)=%d are invalid\n", ndim, len(lo), len(hi))
+		}
+		for i := 0; i < ndim; i++ {
+			o.c[i] = (lo[i] + hi[i]) / 2.0
+			o.h[i] = (hi[i] - lo[i]) / 2.0
+		}
+		o.maxLevel = 12
+		if rule == "gp" {
+			o.maxLevel = 6
+		}
+	case "gh":
+		if lo != nil || hi != nil {
+			chk.Panic("limits must be nil with the Gauss-Hermite rule\n")
+		}
+		for i := 0; i < ndim; i++ {
+			o.h[i] = 1
+		}
+		o.maxLevel = 25
+	default:
+		chk.Panic("rule %q is not available. Options are \"cc\", \"gp\" and \"gh\"\n", rule)
+	}
+	o.reset()
+	return
+}
+
+// Isotropic builds the classical Smolyak grid; i.e. with all multi-indices k satisfying
+// Σ(kᵢ-1) ≤ level-1. Compute must be called next in order to use Integral or Interp
+func (o *SparseGrid) Isotropic(level int) {
+	if level < 1 || level > o.maxLevel {
+		chk.Panic("level must be in [1,%d]. level=%d is invalid\n", o.maxLevel, level)
+	}
+	o.reset()
+	k := make([]int, o.Ndim)
+	for sum := 0; sum < level; sum++ { // increasing sum ⇒ downward closed set
+		o.isotropic(k, 0, sum)
+	}
+}
+
+// Compute evaluates f at all points of the grid
+func (o *SparseGrid) Compute(f fun.Sv) {
+	x := la.NewVector(o.Ndim)
+	o.F = make([]float64, len(o.X))
+	for p := 0; p < len(o.X); p++ {
+		copy(x, o.X[p])
+		o.F[p] = f(x)
+	}
+}
+
+// Adaptive builds the grid by means of the dimension-adaptive algorithm of [2] and evaluates f.
+// Starting with k = (1,1,...,1), the multi-index with the largest contribution |Δ_k(f)| is
+// refined by adding its forward neighbours k+eᵢ that keep the set downward closed, until the sum
+// of contributions of the not-refined (active) multi-indices is ≤ max(epsAbs, epsRel⋅|res|) or
+// the number of points reaches maxPts
+//
+//	Output:
+//	  res    -- the integral
+//	  abserr -- estimate of the absolute error
+//	  converged -- abserr ≤ max(epsAbs, epsRel⋅|res|) has been achieved with at most maxPts points
+func (o *SparseGrid) Adaptive(f fun.Sv, epsAbs, epsRel float64, maxPts int) (res, abserr float64, converged bool) {
+	if epsAbs <= 0 && epsRel <= 0 {
+		chk.Panic("epsAbs=%g and epsRel=%g are invalid: at least one tolerance must be positive\n", epsAbs, epsRel)
+	}
+	o.reset()
+	k := make([]int, o.Ndim)
+	for i := 0; i < o.Ndim; i++ {
+		k[i] = 1
+	}
+	o.addAndEval(k, f)
+	for {
+
+		// result and error estimate
+		res, abserr = 0, 0
+		best := -1
+		for j, d := range o.delta {
+			res += d
+			if o.active[j] {
+				abserr += math.Abs(d)
+				if best < 0 || math.Abs(d) > math.Abs(o.delta[best]) {
+					best = j
+				}
+			}
+		}
+		converged = abserr <= math.Max(epsAbs, epsRel*math.Abs(res))
+		if best < 0 || converged || len(o.X) >= maxPts {
+			return
+		}
+
+		// refine
+		o.active[best] = false
+		for i := 0; i < o.Ndim; i++ {
+			copy(k, o.Index[best])
+			k[i]++
+			if k[i] > o.maxLevel {
+				continue
+			}
+			if _, found := o.set[sparseKey(k)]; found {
+				continue
+			}
+			admissible := true
+			for j := 0; j < o.Ndim; j++ {
+				if k[j] > 1 {
+					k[j]--
+					pos, found := o.set[sparseKey(k)]
+					k[j]++
+					if !found || o.active[pos] {
+						admissible = false
+						break
+					}
+				}
+			}
+			if admissible {
+				o.addAndEval(k, f)
+			}
+		}
+	}
+}
+
+// Integral returns the integral computed with the quadrature weights. Compute or Adaptive must be
+// called first
+func (o *SparseGrid) Integral() (res float64) {
+	o.checkF()
+	for p := 0; p < len(o.X); p++ {
+		res += o.W[p] * o.F[p]
+	}
+	return
+}
+
+// Interp computes the sparse grid interpolant at x. Compute or Adaptive must be called first
+func (o *SparseGrid) Interp(x la.Vector) (res float64) {
+
+	// 1D basis functions of the difference operators at x
+	o.checkF()
+	basis := make([][][]float64, o.Ndim) // [ndim][level][len(dIds)]
+	for i := 0; i < o.Ndim; i++ {
+		basis[i] = make([][]float64, len(o.levels))
+		ξ := (x[i] - o.c[i]) / o.h[i]
+		for l, lv := range o.levels {
+			cur := lagrangeValues(lv.x, lv.λ, ξ)
+			var old []float64
+			if l > 0 {
+				old = lagrangeValues(o.levels[l-1].x, o.levels[l-1].λ, ξ)
+			}
+			b := make([]float64, len(lv.dIds))
+			for j := range lv.dIds {
+				if lv.pos[j] >= 0 {
+					b[j] += cur[lv.pos[j]]
+				}
+				if lv.prev[j] >= 0 {
+					b[j] -= old[lv.prev[j]]
+				}
+			}
+			basis[i][l] = b
+		}
+	}
+
+	// sum contributions of multi-indices
+	idx := make([]int, o.Ndim)
+	for j, k := range o.Index {
+		for i := range idx {
+			idx[i] = 0
+		}
+		for _, p := range o.support[j] {
+			b := 1.0
+			for i := 0; i < o.Ndim; i++ {
+				b *= basis[i][k[i]-1][idx[i]]
+			}
+			res += b * o.F[p]
+			sparseNext(idx, k, o.levels)
+		}
+	}
+	return
+}
+
+// reset clears the grid
+func (o *SparseGrid) reset() {
+	o.X, o.W, o.F, o.Index = nil, nil, nil, nil
+	o.points = make(map[string]int)
+	o.set = make(map[string]int)
+	o.support, o.delta, o.active = nil, nil, nil
+}
+
+// checkF checks whether f has been computed at all points
+func (o *SparseGrid) checkF() {
+	if len(o.X) == 0 || len(o.F) != len(o.X) {
+		chk.Panic("function values are not available. Compute or Adaptive must be called first\n")
+	}
+}
+
+// isotropic recursively generates the multi-indices with Σ(kᵢ-1) = sum
+func (o *SparseGrid) isotropic(k []int, dim, sum int) {
+	if dim == o.Ndim-1 {
+		k[dim] = sum + 1
+		o.add(k)
+		return
+	}
+	for s := 0; s <= sum; s++ {
+		k[dim] = s + 1
+		o.isotropic(k, dim+1, sum-s)
+	}
+}
+
+// add adds a multi-index, the new points and updates the quadrature weights
+func (o *SparseGrid) add(k []int) {
+	lvs := make([]*sparseLevel1d, o.Ndim)
+	for i := 0; i < o.Ndim; i++ {
+		lvs[i] = o.level(k[i])
+	}
+	idx := make([]int, o.Ndim)
+	key := make([]byte, 2*o.Ndim)
+	var support []int
+	for {
+		w := 1.0
+		for i := 0; i < o.Ndim; i++ {
+			id := lvs[i].dIds[idx[i]]
+			key[2*i], key[2*i+1] = byte(id>>8), byte(id)
+			w *= o.h[i] * lvs[i].dW[idx[i]]
+		}
+		p, found := o.points[string(key)]
+		if !found {
+			p = len(o.X)
+			o.points[string(key)] = p
+			x := make([]float64, o.Ndim)
+			for i := 0; i < o.Ndim; i++ {
+				x[i] = o.c[i] + o.h[i]*o.nodes[lvs[i].dIds[idx[i]]]
+			}
+			o.X = append(o.X, x)
+			o.W = append(o.W, 0)
+		}
+		o.W[p] += w
+		support = append(support, p)
+		if sparseNext(idx, k, o.levels) {
+			break
+		}
+	}
+	o.set[sparseKey(k)] = len(o.Index)
+	o.Index = append(o.Index, append([]int{}, k...))
+	o.support = append(o.support, support)
+}
+
+// addAndEval adds a multi-index, evaluates f at the new points and computes the contribution Δ_k
+func (o *SparseGrid) addAndEval(k []int, f fun.Sv) {
+	o.add(k)
+	x := la.NewVector(o.Ndim)
+	for p := len(o.F); p < len(o.X); p++ {
+		copy(x, o.X[p])
+		o.F = append(o.F, f(x))
+	}
+	j := len(o.Index) - 1
+	idx := make([]int, o.Ndim)
+	d := 0.0
+	for _, p := range o.support[j] {
+		w := 1.0
+		for i := 0; i < o.Ndim; i++ {
+			w *= o.h[i] * o.levels[k[i]-1].dW[idx[i]]
+		}
+		d += w * o.F[p]
+		sparseNext(idx, k, o.levels)
+	}
+	o.delta = append(o.delta, d)
+	o.active = append(o.active, true)
+}
+
+// level returns the 1D rule of level l, computing it (and the previous levels) if necessary
+func (o *SparseGrid) level(l int) *sparseLevel1d {
+	for len(o.levels) < l {
+		lv := new(sparseLevel1d)
+		L := len(o.levels) + 1
+		switch o.Rule {
+		case "cc":
+			n := 1
+			if L > 1 {
+				n = (1 << uint(L-1)) + 1
+			}
+			lv.x, lv.w = ClenshawCurtisXW(n)
+		case "gp":
+			lv.x, lv.w = GaussPattersonXW(L)
+		case "gh":
+			lv.x, lv.w = fun.NewGeneralOrthoPoly("H", 2*L-1, 0, 0).GaussXW()
+			lv.x[L-1] = 0 // exact central node
+			for j := range lv.x {
+				lv.x[j] *= math.Sqrt2
+				lv.w[j] /= math.SqrtPi
+			}
+		}
+
+		// node ids
+		lv.ids = make([]int, len(lv.x))
+		for j, ξ := range lv.x {
+			lv.ids[j] = -1
+			for id, ν := range o.nodes {
+				if math.Abs(ξ-ν) < 1e-13*math.Max(1, math.Abs(ξ)) {
+					lv.ids[j] = id
+					lv.x[j] = ν // exactly the same node
+					break
+				}
+			}
+			if lv.ids[j] < 0 {
+				lv.ids[j] = len(o.nodes)
+				o.nodes = append(o.nodes, ξ)
+			}
+		}
+		if len(o.nodes) > 1<<16 {
+			chk.Panic("too many 1D nodes\n")
+		}
+
+		// barycentric weights
+		lv.λ = make([]float64, len(lv.x))
+		scale := 1.0
+		if len(lv.x) > 1 {
+			scale = 4.0 / (lv.x[len(lv.x)-1] - lv.x[0]) // 1/capacity of the interval
+		}
+		for j := range lv.x {
+			lv.λ[j] = 1
+			for m := range lv.x {
+				if m != j {
+					lv.λ[j] /= scale * (lv.x[j] - lv.x[m])
+				}
+			}
+		}
+
+		// difference rule
+		var old *sparseLevel1d
+		if L > 1 {
+			old = o.levels[L-2]
+		}
+		for j, id := range lv.ids {
+			lv.dIds = append(lv.dIds, id)
+			lv.dW = append(lv.dW, lv.w[j])
+			lv.pos = append(lv.pos, j)
+			lv.prev = append(lv.prev, -1)
+		}
+		if old != nil {
+			for j, id := range old.ids {
+				found := false
+				for m, did := range lv.dIds {
+					if did == id {
+						lv.dW[m] -= old.w[j]
+						lv.prev[m] = j
+						found = true
+						break
+					}
+				}
+				if !found {
+					lv.dIds = append(lv.dIds, id)
+					lv.dW = append(lv.dW, -old.w[j])
+					lv.pos = append(lv.pos, -1)
+					lv.prev = append(lv.prev, j)
+				}
+			}
+		}
+		o.levels = append(o.levels, lv)
+	}
+	return o.levels[l-1]
+}
+
+// sparseKey returns a key corresponding to multi-index k
+func sparseKey(k []int) string {
+	key := make([]byte, len(k))
+	for i, v := range k {
+		key[i] = byte(v)
+	}
+	return string(key)
+}
+
+// sparseNext increments the tensor index idx over the supports of the difference rules of k and
+// returns true if all indices have been visited
+func sparseNext(idx, k []int, levels []*sparseLevel1d) (done bool) {
+	for i := 0; i < len(idx); i++ {
+		idx[i]++
+		if idx[i] < len(levels[k[i]-1].dIds) {
+			return false
+		}
+		idx[i] = 0
+	}
+	return true
+}
+
+// lagrangeValues computes the Lagrange basis polynomials at ξ using the barycentric formula
+func lagrangeValues(x, λ []float64, ξ float64) (ℓ []float64) {
+	ℓ = make([]float64, len(x))
+	sum := 0.0
+	for j := range x {
+		if ξ == x[j] {
+			for m := range ℓ {
+				ℓ[m] = 0
+			}
+			ℓ[j] = 1
+			return
+		}
+		ℓ[j] = λ[j] / (ξ - x[j])
+		sum += ℓ[j]
+	}
+	for j := range ℓ {
+		ℓ[j] /= sum
+	}
+	return
+}
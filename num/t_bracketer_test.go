@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
 )
 
 func checkBracket(tst *testing.T, a, b, c, fa, fb, fc float64) {
@@ -99,3 +100,51 @@ func TestBracket02(tst *testing.T) {
 	a, b, c, fa, fb, fc = bracket.Min(-1, -2)
 	checkBracket(tst, a, b, c, fa, fb, fc)
 }
+
+func TestBracket03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Bracket03. bracketing roots")
+
+	// expanding from a single guess
+	ffcn := func(x float64) float64 { return math.Exp(x) - 100 } // root at ln(100) ≈ 4.6
+	bracket := NewBracket(ffcn)
+	bracket.Verbose = chk.Verbose
+	for _, x0 := range []float64{-5, 0, 4.6, 20} {
+		a, b, fa, fb := bracket.Root(x0, 0)
+		io.Pforan("x0 = %5g: [%g, %g] nfeval = %d\n", x0, a, b, bracket.NumFeval)
+		if a >= b || fa*fb > 0 || a > math.Log(100) || b < math.Log(100) {
+			tst.Errorf("[%g, %g] does not bracket the root\n", a, b)
+		}
+	}
+
+	// failure: no real root
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				tst.Errorf("x² + 1 = 0 should not be bracketed\n")
+			}
+		}()
+		NewBracket(func(x float64) float64 { return x*x + 1 }).Root(0, 1)
+	}()
+
+	// scanning: two nearby roots within the same subinterval and an exact root at x = 0
+	ffcn = func(x float64) float64 { return x * (x - 0.3) * (x - 0.301) }
+	bracket = NewBracket(ffcn)
+	brackets := bracket.Roots(-1, 1, 4)
+	io.Pforan("brackets = %v (nfeval = %d)\n", brackets, bracket.NumFeval)
+	chk.Int(tst, "number of brackets", len(brackets), 3)
+	chk.Array(tst, "exact root", 1e-17, brackets[0], []float64{0, 0})
+	lo, hi := []float64{0, 0.3}, []float64{0.301, 0.5}
+	for i, xref := range []float64{0.3, 0.301} {
+		ab := brackets[i+1]
+		if ab[0] < lo[i] || ab[0] >= xref || ab[1] <= xref || ab[1] > hi[i] {
+			tst.Errorf("%v does not bracket only the root x = %g\n", ab, xref)
+		}
+	}
+
+	// without subdivisions, the nearby roots are missed
+	bracket.MaxLevel = 0
+	brackets = bracket.Roots(-1, 1, 4)
+	chk.Int(tst, "number of brackets (MaxLevel = 0)", len(brackets), 1)
+}
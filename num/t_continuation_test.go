@@ -0,0 +1,234 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestContinuation01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Continuation01. folds of λ = x³ - 3x")
+
+	// F(x, λ) = x³ - 3x - λ with folds at (x, λ) = (-1, 2) and (1, -2)
+	ffcn := func(fx, x la.Vector, λ float64) {
+		fx[0] = x[0]*x[0]*x[0] - 3*x[0] - λ
+	}
+	dfdx := func(dfdx *la.Matrix, x la.Vector, λ float64) {
+		dfdx.Set(0, 0, 3*x[0]*x[0]-3)
+	}
+	dfdλ := func(dfdλ, x la.Vector, λ float64) {
+		dfdλ[0] = -1
+	}
+
+	// pseudo-arclength with analytical and numerical Jacobians
+	for _, numerical := range []bool{false, true} {
+		io.Pf("\nnumerical Jacobian = %v\n", numerical)
+		cont := NewContinuation(1, ffcn)
+		cont.Verbose = chk.Verbose
+		cont.Locate = true
+		cont.LamMin, cont.LamMax = -3, 3
+		if !numerical {
+			cont.SetJacobian(dfdx, dfdλ)
+		}
+		cont.Run(la.NewVectorSlice([]float64{-1.9}), -2)
+		io.Pforan("%v", cont.Table())
+		chk.Float64(tst, "x(start)", 1e-10, cont.Points[0].X[0], -2)
+		for _, p := range cont.Points {
+			chk.Float64(tst, "F", 1e-10, p.X[0]*p.X[0]*p.X[0]-3*p.X[0]-p.Lambda, 0)
+		}
+		if len(cont.Special) != 2 {
+			tst.Errorf("there should be 2 folds\n")
+			return
+		}
+		tolx := 1e-7
+		if numerical {
+			tolx = 1e-5
+		}
+		for i, xref := range []float64{-1, 1} {
+			sp := cont.Special[i]
+			chk.String(tst, sp.Kind, "fold")
+			chk.Float64(tst, "x(fold)", tolx, sp.X[0], xref)
+			chk.Float64(tst, "λ(fold)", 1e-10, sp.Lambda, -2*xref)
+		}
+		last := cont.Points[len(cont.Points)-1]
+		if last.Lambda <= 3 {
+			tst.Errorf("the continuation should stop with λ > 3\n")
+		}
+		chk.Bool(tst, "increasing s", cont.Points[len(cont.Points)-1].S > cont.Points[1].S, true)
+	}
+
+	// natural-parameter continuation stops at the first fold
+	cont := NewContinuation(1, ffcn)
+	cont.Verbose = chk.Verbose
+	cont.Method = "natural"
+	cont.DsMin = 1e-4
+	cont.LamMax = 3
+	cont.SetJacobian(dfdx, dfdλ)
+	cont.Run(la.NewVectorSlice([]float64{-1.9}), -2)
+	io.Pforan("\nnatural:\n%v", cont.Table())
+	last := cont.Points[len(cont.Points)-1]
+	if last.Lambda > 2 || last.Lambda < 1.99 || last.X[0] > -1 {
+		tst.Errorf("natural-parameter continuation should stop just before the fold at λ = 2\n")
+	}
+	chk.Int(tst, "number of special points", len(cont.Special), 0)
+}
+
+func TestContinuation02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Continuation02. pitchfork bifurcation and branch switching")
+
+	// F(x, λ) = x⋅(λ - x²): trivial branch x = 0 and secondary branch λ = x² meeting at (0, 0)
+	ffcn := func(fx, x la.Vector, λ float64) {
+		fx[0] = x[0] * (λ - x[0]*x[0])
+	}
+	dfdx := func(dfdx *la.Matrix, x la.Vector, λ float64) {
+		dfdx.Set(0, 0, λ-3*x[0]*x[0])
+	}
+	dfdλ := func(dfdλ, x la.Vector, λ float64) {
+		dfdλ[0] = x[0]
+	}
+
+	// trivial branch
+	for _, method := range []string{"arclength", "natural"} {
+		cont := NewContinuation(1, ffcn)
+		cont.Verbose = chk.Verbose
+		cont.Method = method
+		cont.Locate = true
+		cont.Ds = 0.15
+		cont.DsMax = 0.15
+		cont.LamMax = 1
+		cont.SetJacobian(dfdx, dfdλ)
+		cont.Run(la.NewVector(1), -1)
+		io.Pforan("\n%s:\n%v", method, cont.Table())
+		if len(cont.Special) != 1 {
+			tst.Errorf("there should be 1 branch point\n")
+			return
+		}
+		bp := cont.Special[0]
+		chk.String(tst, bp.Kind, "branch")
+		chk.Float64(tst, "λ(branch)", 1e-12, bp.Lambda, 0)
+		chk.Float64(tst, "x(branch)", 1e-15, bp.X[0], 0)
+
+		// secondary branch in both directions
+		for _, ds := range []float64{0.1, -0.1} {
+			cont.Ds = ds
+			cont.SwitchBranch(bp)
+			io.Pforan("\nsecondary branch (ds = %g):\n%v", ds, cont.Table())
+			chk.Int(tst, "number of special points", len(cont.Special), 0)
+			for i, p := range cont.Points {
+				chk.Float64(tst, "λ = x²", 1e-10, p.Lambda, p.X[0]*p.X[0])
+				if i > 0 && p.X[0]*ds <= 0 {
+					tst.Errorf("the secondary branch should be traced in the direction of ds\n")
+				}
+			}
+			last := cont.Points[len(cont.Points)-1]
+			if last.Lambda <= 1 {
+				tst.Errorf("the continuation should stop with λ > 1\n")
+			}
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	cont := NewContinuation(1, ffcn)
+	cont.SwitchBranch(&ContPoint{Kind: "fold"})
+}
+
+func TestContinuation03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Continuation03. fold of the Bratu problem and callback")
+
+	// -u'' = λ⋅exp(u) on (0,1) with u(0) = u(1) = 0; finite differences with n interior points
+	n := 20
+	h := 1.0 / float64(n+1)
+	ffcn := func(fx, u la.Vector, λ float64) {
+		for i := 0; i < n; i++ {
+			ul, ur := 0.0, 0.0
+			if i > 0 {
+				ul = u[i-1]
+			}
+			if i < n-1 {
+				ur = u[i+1]
+			}
+			fx[i] = (-ul+2*u[i]-ur)/(h*h) - λ*math.Exp(u[i])
+		}
+	}
+	dfdx := func(dfdx *la.Matrix, u la.Vector, λ float64) {
+		dfdx.Fill(0)
+		for i := 0; i < n; i++ {
+			dfdx.Set(i, i, 2/(h*h)-λ*math.Exp(u[i]))
+			if i > 0 {
+				dfdx.Set(i, i-1, -1/(h*h))
+			}
+			if i < n-1 {
+				dfdx.Set(i, i+1, -1/(h*h))
+			}
+		}
+	}
+	dfdλ := func(dfdλ, u la.Vector, λ float64) {
+		for i := 0; i < n; i++ {
+			dfdλ[i] = -math.Exp(u[i])
+		}
+	}
+
+	// trace the lower branch, pass the fold and stop with the callback when u(½) > 4
+	var nstream int
+	cont := NewContinuation(n, ffcn)
+	cont.Verbose = chk.Verbose
+	cont.Locate = true
+	cont.Ds = 0.5
+	cont.DsMax = 0.5
+	cont.SetJacobian(dfdx, dfdλ)
+	cont.Callback = func(p *ContPoint) (stop bool) {
+		nstream++
+		return p.X[n/2] > 4
+	}
+	cont.Run(la.NewVector(n), 0)
+	io.Pforan("%v", cont.Table())
+	chk.Int(tst, "number of streamed points", nstream, len(cont.Points))
+	chk.String(tst, cont.Reason, "stopped by callback")
+	if len(cont.Special) != 1 {
+		tst.Errorf("there should be 1 fold\n")
+		return
+	}
+
+	// the fold of the continuous problem is at λ = 3.513830719; the error of the discretisation is O(h²)
+	fold := cont.Special[0]
+	chk.String(tst, fold.Kind, "fold")
+	io.Pforan("λ(fold) = %.10f\n", fold.Lambda)
+	chk.Float64(tst, "λ(fold)", 0.02, fold.Lambda, 3.513830719)
+	chk.Float64(tst, "dλ/ds(fold)", 1e-8, fold.Tangent[n], 0)
+
+	// at the fold, Fx is singular; its null vector is the tangent
+	J := la.NewMatrix(n, n)
+	dfdx(J, fold.X, fold.Lambda)
+	Jt := la.NewVector(n)
+	la.MatVecMul(Jt, 1, J, fold.Tangent[:n])
+	chk.Float64(tst, "‖Fx⋅t‖/‖t‖", 1e-6, Jt.Norm()/fold.Tangent[:n].Norm(), 0)
+
+	// compare with the solution computed with NlSolver at the same λ
+	for _, p := range cont.Points {
+		if p.Tangent[n] < 0 {
+			break // upper branch: NlSolver starting from zero would converge to the lower branch
+		}
+		if p.Kind != "regular" || p.Lambda < 1 {
+			continue
+		}
+		sol := NewNlSolver(n, func(fx, u la.Vector) { ffcn(fx, u, p.Lambda) })
+		sol.SetJacobianFunction(nil, func(J *la.Matrix, u la.Vector) { dfdx(J, u, p.Lambda) })
+		u := la.NewVector(n)
+		sol.Solve(u)
+		chk.Array(tst, io.Sf("u(λ=%.3f)", p.Lambda), 1e-8, u, p.X)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestCubature01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Cubature01. Genz-Malik rule")
+
+	// degree 5 polynomial is integrated exactly by the first region
+	f := func(x la.Vector) float64 {
+		return 1 + x[0]*x[1] + math.Pow(x[0], 4)*x[1] + x[0]*x[0]*math.Pow(x[1], 3) + math.Pow(x[1], 5)
+	}
+	ref := 3.0*2.0 + 4.5*2.0 + 48.6*2.0 + 9.0*4.0 + 3.0*(64.0/6.0) // over [0,3]×[0,2]
+	o := NewCubature(0, 1e-12)
+	res, abserr, neval, converged := o.Integrate(f, []float64{0, 0}, []float64{3, 2})
+	io.Pforan("res = %v  abserr = %v  neval = %v  converged = %v\n", res, abserr, neval, converged)
+	chk.Float64(tst, "poly", 1e-12, res, ref)
+	chk.Int(tst, "neval", neval, 17)
+	if !converged {
+		tst.Errorf("the integration should have converged\n")
+	}
+
+	// reversed limits
+	res, _, _, _ = o.Integrate(f, []float64{3, 0}, []float64{0, 2})
+	chk.Float64(tst, "reversed", 1e-12, res, -ref)
+	res, _, neval, converged = o.Integrate(f, []float64{3, 0}, []float64{3, 2})
+	chk.Float64(tst, "zero volume", 1e-15, res, 0)
+	chk.Int(tst, "neval(zero volume)", neval, 0)
+	if !converged {
+		tst.Errorf("the integration over a zero volume should have converged\n")
+	}
+
+	// Gaussian over 3D box
+	g := func(x la.Vector) float64 { return math.Exp(-x[0]*x[0] - x[1]*x[1] - x[2]*x[2]) }
+	ref = math.Pow(math.Sqrt(math.Pi)*math.Erf(1), 3)
+	o = NewCubature(0, 1e-7)
+	res, abserr, neval, _ = o.Integrate(g, []float64{-1, -1, -1}, []float64{1, 1, 1})
+	io.Pforan("res = %v  abserr = %v  neval = %v\n", res, abserr, neval)
+	chk.Float64(tst, "gaussian", 1e-9, res, ref)
+	if abserr > 1e-7*ref || neval >= o.MaxEval {
+		tst.Errorf("the error estimate is too large: %g\n", abserr)
+	}
+
+	// one-dimensional
+	o.EpsRel = 1e-10
+	h := func(x la.Vector) float64 { return math.Sqrt(x[0]) }
+	res, abserr, neval, _ = o.Integrate(h, []float64{0}, []float64{1})
+	io.Pforan("res = %v  abserr = %v  neval = %v\n", res, abserr, neval)
+	chk.Float64(tst, "√x", 1e-10, res, 2.0/3.0)
+	if neval%30 != 15 {
+		tst.Errorf("the number of evaluations is incorrect: %d\n", neval)
+	}
+}
+
+func TestCubature02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Cubature02. Genz test functions in 3 to 5 dimensions")
+
+	for _, ndim := range []int{3, 4, 5} {
+
+		// oscillatory: cos(2πu + Σ cᵢxᵢ)
+		c := []float64{0.9, 0.6, 1.2, 0.3, 0.8}[:ndim]
+		u := 0.3
+		osc := func(x la.Vector) float64 {
+			s := 2 * math.Pi * u
+			for i := 0; i < ndim; i++ {
+				s += c[i] * x[i]
+			}
+			return math.Cos(s)
+		}
+		z := cmplx.Exp(complex(0, 2*math.Pi*u))
+		for i := 0; i < ndim; i++ {
+			z *= (cmplx.Exp(complex(0, c[i])) - 1) / complex(0, c[i])
+		}
+		a, b := make([]float64, ndim), make([]float64, ndim)
+		for i := 0; i < ndim; i++ {
+			b[i] = 1
+		}
+		o := NewCubature(0, 1e-8)
+		res, abserr, neval, _ := o.Integrate(osc, a, b)
+		io.Pforan("ndim=%d: oscillatory: res = %v  abserr = %.2e  neval = %v\n", ndim, res, abserr, neval)
+		chk.Float64(tst, "oscillatory", 1e-8, res, real(z))
+
+		// product peak: Π 1/(cᵢ⁻² + (xᵢ-wᵢ)²)
+		w := []float64{0.2, 0.5, 0.7, 0.4, 0.6}[:ndim]
+		peak := func(x la.Vector) float64 {
+			p := 1.0
+			for i := 0; i < ndim; i++ {
+				p /= 1.0/(c[i]*c[i]) + (x[i]-w[i])*(x[i]-w[i])
+			}
+			return p
+		}
+		ref := 1.0
+		for i := 0; i < ndim; i++ {
+			ref *= c[i] * (math.Atan(c[i]*(1-w[i])) + math.Atan(c[i]*w[i]))
+		}
+		o = NewCubature(0, 1e-6)
+		res, abserr, neval, converged := o.Integrate(peak, a, b)
+		io.Pforan("ndim=%d: product peak: res = %v  abserr = %.2e  neval = %v\n", ndim, res, abserr, neval)
+		if !converged {
+			tst.Errorf("the integration should have converged\n")
+		}
+		chk.Float64(tst, "product peak", 1e-6*ref, res, ref)
+		if math.Abs(res-ref) > abserr {
+			tst.Errorf("the error estimate is smaller than the actual error\n")
+		}
+
+		// maximum number of evaluations is respected
+		o.EpsRel = 1e-15
+		o.MaxEval = 5000
+		_, abserr, neval, converged = o.Integrate(peak, a, b)
+		io.Pforan("ndim=%d: MaxEval=%d: abserr = %.2e  neval = %v  converged = %v\n", ndim, o.MaxEval, abserr, neval, converged)
+		if neval > o.MaxEval {
+			tst.Errorf("the number of evaluations exceeds MaxEval: %d\n", neval)
+		}
+		if converged {
+			tst.Errorf("the integration should not have converged with MaxEval=%d\n", o.MaxEval)
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	o := NewCubature(0, 0)
+	o.Integrate(func(x la.Vector) float64 { return 1 }, []float64{0, 0}, []float64{1, 1})
+}
+
+func TestCubature03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Cubature03. tensor-product Gauss-Legendre rule")
+
+	// exact for degree 2n-1 in each variable
+	f := func(x la.Vector) float64 { return math.Pow(x[0], 5) * x[1] * x[1] * math.Pow(x[2], 3) }
+	ref := (64.0 / 6.0) * (28.0 / 3.0) * (-1.0 / 4.0)
+	res := QuadGaussLegendreNd([]float64{0, -1, -1}, []float64{2, 3, 0}, 3, f)
+	io.Pforan("res = %v\n", res)
+	chk.Float64(tst, "poly", 1e-13, res, ref)
+
+	// smooth function in 4D
+	neval := 0
+	g := func(x la.Vector) float64 {
+		neval++
+		return math.Exp(x[0] + 0.5*x[1] - x[2] + 2*x[3])
+	}
+	ref = (math.E - 1) * 2 * (math.Exp(0.5) - 1) * (1 - math.Exp(-1)) * (math.Exp(2) - 1) / 2
+	res = QuadGaussLegendreNd([]float64{0, 0, 0, 0}, []float64{1, 1, 1, 1}, 8, g)
+	io.Pforan("res = %v  neval = %v\n", res, neval)
+	chk.Float64(tst, "exp", 1e-13, res, ref)
+	chk.Int(tst, "neval", neval, 8*8*8*8)
+}
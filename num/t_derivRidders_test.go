@@ -0,0 +1,165 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestDerivRidders01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("DerivRidders01. 1st and 2nd derivatives with error estimates")
+
+	names := []string{"x²", "exp(x)", "exp(-x²)", "1/x", "x⋅√x", "sin(1/x)"}
+	fcns := []fun.Ss{
+		func(x float64) float64 { return x * x },
+		func(x float64) float64 { return math.Exp(x) },
+		func(x float64) float64 { return math.Exp(-x * x) },
+		func(x float64) float64 { return 1.0 / x },
+		func(x float64) float64 { return x * math.Sqrt(x) },
+		func(x float64) float64 { return math.Sin(1.0 / x) },
+	}
+	danas := []fun.Ss{
+		func(x float64) float64 { return 2 * x },
+		func(x float64) float64 { return math.Exp(x) },
+		func(x float64) float64 { return -2 * x * math.Exp(-x*x) },
+		func(x float64) float64 { return -1.0 / (x * x) },
+		func(x float64) float64 { return 1.5 * math.Sqrt(x) },
+		func(x float64) float64 { return -math.Cos(1.0/x) / (x * x) },
+	}
+	ddanas := []fun.Ss{
+		func(x float64) float64 { return 2 },
+		func(x float64) float64 { return math.Exp(x) },
+		func(x float64) float64 { return (4*x*x - 2) * math.Exp(-x*x) },
+		func(x float64) float64 { return 2.0 / (x * x * x) },
+		func(x float64) float64 { return 0.75 / math.Sqrt(x) },
+		func(x float64) float64 { return (2*x*math.Cos(1.0/x) - math.Sin(1.0/x)) / (x * x * x * x) },
+	}
+	xvals := []float64{0.5, 1, 2, 10}
+	for k, f := range fcns {
+		io.Pf("\n%s\n", names[k])
+		for _, x := range xvals {
+			h := 0.0
+			if k == 5 {
+				h = 0.1 * x * x // sin(1/x) oscillates quickly for small x
+			}
+			d, err := DerivRidders(x, h, f)
+			dd, err2 := SecondDerivRidders(x, h, f)
+			ana, ana2 := danas[k](x), ddanas[k](x)
+			io.Pf("x = %4.1f  df/dx: err = %.2e (est %.2e)  d²f/dx²: err = %.2e (est %.2e)\n",
+				x, math.Abs(d-ana), err, math.Abs(dd-ana2), err2)
+			chk.Float64(tst, io.Sf("df/dx @ %g", x), 1e-9*(1+math.Abs(ana)), d, ana)
+			chk.Float64(tst, io.Sf("d²f/dx² @ %g", x), 1e-6*(1+math.Abs(ana2)), dd, ana2)
+			if math.Abs(d-ana) > 10*err+1e-14*(1+math.Abs(ana)) {
+				tst.Errorf("error estimate of df/dx is unreliable: %g > 10⋅%g\n", math.Abs(d-ana), err)
+			}
+		}
+	}
+}
+
+func TestDerivRidders02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("DerivRidders02. complex-step derivatives")
+
+	// function from [3]
+	f := func(z complex128) complex128 {
+		s, c := cmplx.Sin(z), cmplx.Cos(z)
+		return cmplx.Exp(z) / cmplx.Sqrt(s*s*s+c*c*c)
+	}
+	dana := func(x float64) float64 {
+		s, c := math.Sin(x), math.Cos(x)
+		den := s*s*s + c*c*c
+		return math.Exp(x) / math.Sqrt(den) * (1 - (3*s*s*c-3*c*c*s)/(2*den))
+	}
+	for _, x := range []float64{0.5, 1, 1.5} {
+		d := DerivComplexStep(x, f)
+		ana := dana(x)
+		dr, err := DerivRidders(x, 0, func(x float64) float64 { return real(f(complex(x, 0))) })
+		io.Pf("x = %g: complex-step err = %.2e  Ridders err = %.2e (est %.2e)\n", x, math.Abs(d-ana), math.Abs(dr-ana), err)
+		chk.Float64(tst, "df/dx (complex-step)", 1e-14*math.Abs(ana), d, ana)
+	}
+
+	// gradient of Rosenbrock's function
+	x := la.NewVectorSlice([]float64{1.2, 0.8, -0.5, 2.0})
+	gana := la.NewVector(len(x))
+	rosenbrockGrad(gana, x)
+	g := la.NewVector(len(x))
+	GradientComplexStep(g, x, func(z []complex128) (res complex128) {
+		for i := 0; i < len(z)-1; i++ {
+			a, b := z[i+1]-z[i]*z[i], 1-z[i]
+			res += 100*a*a + b*b
+		}
+		return
+	})
+	chk.Array(tst, "gradient (complex-step)", 1e-13, g, gana)
+}
+
+func TestDerivRidders03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("DerivRidders03. gradient and Hessian of fun.Sv")
+
+	// Rosenbrock's function
+	n := 4
+	x := la.NewVectorSlice([]float64{1.2, 0.8, -0.5, 2.0})
+	gana := la.NewVector(n)
+	rosenbrockGrad(gana, x)
+	Hana := la.NewMatrix(n, n)
+	for i := 0; i < n-1; i++ {
+		Hana.Add(i, i, 1200*x[i]*x[i]-400*x[i+1]+2)
+		Hana.Add(i, i+1, -400*x[i])
+		Hana.Add(i+1, i, -400*x[i])
+		Hana.Add(i+1, i+1, 200)
+	}
+
+	// gradient
+	g := la.NewVector(n)
+	errg := GradientRidders(g, x, 0, rosenbrock)
+	io.Pforan("gradient: max error estimate = %.2e\n", errg)
+	chk.Array(tst, "gradient", 1e-9, g, gana)
+
+	// Hessian
+	H := la.NewMatrix(n, n)
+	errH := HessianRidders(H, x, 0, rosenbrock)
+	io.Pforan("Hessian: max error estimate = %.2e\n", errH)
+	chk.Deep2(tst, "Hessian", 1e-7, H.GetDeep2(), Hana.GetDeep2())
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			chk.Float64(tst, "symmetry", 1e-15, H.Get(i, j), H.Get(j, i))
+		}
+	}
+
+	// with the initial step given
+	GradientRidders(g, x, 0.5, rosenbrock)
+	chk.Array(tst, "gradient (h=0.5)", 1e-9, g, gana)
+}
+
+// rosenbrock computes Rosenbrock's function f(x) = Σ 100⋅(xᵢ₊₁ - xᵢ²)² + (1 - xᵢ)²
+func rosenbrock(x la.Vector) (res float64) {
+	for i := 0; i < len(x)-1; i++ {
+		a, b := x[i+1]-x[i]*x[i], 1-x[i]
+		res += 100*a*a + b*b
+	}
+	return
+}
+
+// rosenbrockGrad computes the gradient of Rosenbrock's function
+func rosenbrockGrad(g, x la.Vector) {
+	g.Fill(0)
+	for i := 0; i < len(x)-1; i++ {
+		a := x[i+1] - x[i]*x[i]
+		g[i] += -400*x[i]*a - 2*(1-x[i])
+		g[i+1] += 200 * a
+	}
+}
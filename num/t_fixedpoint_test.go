@@ -0,0 +1,187 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// linearFixedPoint returns G(x) = A⋅x + b where A = 0.9⋅tridiag(½, 0, ½) (spectral radius ≈ 0.9)
+// and the solution of (I - A)⋅x = b
+func linearFixedPoint(n int) (G func(gx, x la.Vector), xref la.Vector) {
+	A := la.NewMatrix(n, n)
+	b := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			A.Set(i, i-1, 0.45)
+		}
+		if i < n-1 {
+			A.Set(i, i+1, 0.45)
+		}
+		b[i] = math.Sin(float64(i))
+	}
+	G = func(gx, x la.Vector) {
+		la.MatVecMul(gx, 1, A, x)
+		la.VecAdd(gx, 1, b, 1, gx)
+	}
+	M := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			M.Set(i, j, -A.Get(i, j))
+		}
+		M.Add(i, i, 1)
+	}
+	xref = la.NewVector(n)
+	la.DenSolve(xref, M, b, false)
+	return
+}
+
+func TestFixedPoint01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FixedPoint01. linear problem")
+
+	n := 50
+	G, xref := linearFixedPoint(n)
+	config := NewNlSolverConfig()
+	config.Verbose = chk.Verbose
+	config.MaxIterations = 1000
+	niter := make(map[string]int)
+	for _, method := range []string{"picard", "aitken", "anderson"} {
+		sol := NewFixedPoint(n, G, config)
+		sol.Method = method
+		x := la.NewVector(n)
+		sol.Solve(x)
+		io.Pforan("%-8s: Niter = %3d  Nfeval = %3d\n", method, sol.Niter, sol.Nfeval)
+		chk.Array(tst, "x", 1e-8, x, xref)
+		chk.Bool(tst, "converged", sol.Report.Converged, true)
+		chk.Int(tst, "len(history)", len(sol.Report.FxMax), sol.Niter+2)
+		chk.String(tst, sol.Report.Step[1], method)
+		niter[method] = sol.Niter
+	}
+	if niter["anderson"] > niter["picard"]/2 {
+		tst.Errorf("Anderson acceleration should reduce the number of iterations significantly\n")
+	}
+	if niter["aitken"] >= niter["picard"] {
+		tst.Errorf("Aitken's relaxation should reduce the number of iterations\n")
+	}
+
+	// Anderson with depth 0 is equivalent to Picard's iterations
+	for _, β := range []float64{1, 0.7} {
+		xpic, xand := la.NewVector(n), la.NewVector(n)
+		pic := NewFixedPoint(n, G, config)
+		pic.Method = "picard"
+		pic.Beta = β
+		pic.Solve(xpic)
+		and := NewFixedPoint(n, G, config)
+		and.Depth = 0
+		and.Beta = β
+		and.Solve(xand)
+		chk.Int(tst, "Niter", and.Niter, pic.Niter)
+		chk.Array(tst, "x", 1e-15, xand, xpic)
+	}
+}
+
+func TestFixedPoint02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FixedPoint02. nonlinear problem: Bratu with Picard's linearisation")
+
+	// -u'' = λ⋅exp(u) on (0,1) with u(0) = u(1) = 0; u = L⁻¹⋅(λ⋅exp(u)) where L is the discrete -d²/dx²
+	n := 100
+	λ := 3.0
+	h := 1.0 / float64(n+1)
+	c := make([]float64, n)
+	G := func(gx, u la.Vector) {
+		a, b := -1/(h*h), 2/(h*h) // Thomas algorithm
+		c[0] = a / b
+		gx[0] = λ * math.Exp(u[0]) / b
+		for i := 1; i < n; i++ {
+			m := b - a*c[i-1]
+			c[i] = a / m
+			gx[i] = (λ*math.Exp(u[i]) - a*gx[i-1]) / m
+		}
+		for i := n - 2; i >= 0; i-- {
+			gx[i] -= c[i] * gx[i+1]
+		}
+	}
+
+	// solve
+	config := NewNlSolverConfig()
+	config.Verbose = chk.Verbose
+	config.MaxIterations = 500
+	config.SetTolerances(1e-10, 1e-10, 1e-12)
+	var sols []la.Vector
+	var niter []int
+	for _, method := range []string{"picard", "aitken", "anderson"} {
+		sol := NewFixedPoint(n, G, config)
+		sol.Method = method
+		sol.Depth = 3
+		u := la.NewVector(n)
+		sol.Solve(u)
+		io.Pforan("%-8s: Niter = %3d  Nfeval = %3d  ω = %g\n", method, sol.Niter, sol.Nfeval, sol.Omega)
+		sols = append(sols, u)
+		niter = append(niter, sol.Niter)
+
+		// residual of the original equations
+		fmax := 0.0
+		for i := 0; i < n; i++ {
+			ul, ur := 0.0, 0.0
+			if i > 0 {
+				ul = u[i-1]
+			}
+			if i < n-1 {
+				ur = u[i+1]
+			}
+			fmax = math.Max(fmax, math.Abs((-ul+2*u[i]-ur)/(h*h)-λ*math.Exp(u[i])))
+		}
+		chk.Float64(tst, "max|f|", 1e-7, fmax, 0)
+	}
+	chk.Array(tst, "aitken", 1e-9, sols[1], sols[0])
+	chk.Array(tst, "anderson", 1e-9, sols[2], sols[0])
+	if niter[2] >= niter[0]/2 {
+		tst.Errorf("Anderson acceleration should reduce the number of iterations\n")
+	}
+}
+
+func TestFixedPoint03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FixedPoint03. failure and report")
+
+	n := 50
+	G, _ := linearFixedPoint(n)
+	config := NewNlSolverConfig()
+	config.MaxIterations = 10
+	sol := NewFixedPoint(n, G, config)
+	sol.Method = "picard"
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				tst.Errorf("Picard's iterations should not converge in 10 iterations\n")
+			}
+		}()
+		sol.Solve(la.NewVector(n))
+	}()
+	io.Pforan("%v", &sol.Report)
+	chk.Bool(tst, "converged", sol.Report.Converged, false)
+	chk.String(tst, sol.Report.Reason, "maximum number of iterations (10) reached")
+	chk.Int(tst, "len(history)", len(sol.Report.FxMax), 11)
+	for i := 1; i < len(sol.Report.FxMax); i++ {
+		if sol.Report.FxMax[i] >= sol.Report.FxMax[i-1] {
+			tst.Errorf("the residual should decrease\n")
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol.Method = "newton"
+	sol.Solve(la.NewVector(n))
+}
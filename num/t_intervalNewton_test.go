@@ -0,0 +1,137 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// checkRootsEnclosed checks that each root is in an enclosure with proven uniqueness and that each
+// enclosure contains one root
+func checkRootsEnclosed(tst *testing.T, roots []float64, encl []Interval, unique []bool) {
+	if len(roots) != len(encl) {
+		tst.Errorf("the number of enclosures (%d) should be equal to the number of roots (%d)\n", len(encl), len(roots))
+		return
+	}
+	for i, x := range roots {
+		if !encl[i].Contains(x) {
+			tst.Errorf("root %d = %.17g is not in %v\n", i, x, encl[i])
+		}
+		if !unique[i] {
+			tst.Errorf("enclosure %d = %v should have a unique root\n", i, encl[i])
+		}
+	}
+}
+
+func TestIntervalNewton01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("IntervalNewton01. all roots of scalar functions")
+
+	// f(x) = sin(x) - 0.1⋅x with 7 roots in [-10, 10]
+	f := func(x float64) float64 { return math.Sin(x) - 0.1*x }
+	F := func(x Interval) Interval { return x.Sin().Sub(x.MulS(0.1)) }
+	dF := func(x Interval) Interval { return x.Cos().AddS(-0.1) }
+	o := NewIntervalNewton(F, dF)
+	o.Verbose = chk.Verbose
+	encl := o.Solve(NewInterval(-10, 10))
+	io.Pforan("nit = %d  nfeval = %d  njeval = %d\n", o.NumIter, o.NumFeval, o.NumJeval)
+
+	// roots by Brent's method with brackets from scanning
+	var roots []float64
+	brent := NewBrent(f, nil)
+	brent.Tol = 0 // full precision
+	for _, ab := range NewBracket(f).Roots(-10, 10, 40) {
+		x := ab[0]
+		if ab[0] != ab[1] {
+			x = brent.Root(ab[0], ab[1])
+		}
+		roots = append(roots, x)
+	}
+	for i, X := range encl {
+		io.Pf("%v  width = %.2e  unique = %v\n", X, X.Width(), o.Unique[i])
+	}
+	io.Pforan("Brent: %v\n", roots)
+	chk.Int(tst, "number of roots", len(encl), 7)
+	checkRootsEnclosed(tst, roots, encl, o.Unique)
+
+	// polynomial with nearby roots: (x - 1)⋅(x - 1.001)⋅(x - 2)
+	p := func(x float64) float64 { return (x - 1) * (x - 1.001) * (x - 2) }
+	P := func(x Interval) Interval { return x.AddS(-1).Mul(x.AddS(-1.001)).Mul(x.AddS(-2)) }
+	dP := func(x Interval) Interval {
+		a, b, c := x.AddS(-1), x.AddS(-1.001), x.AddS(-2)
+		return b.Mul(c).Add(a.Mul(c)).Add(a.Mul(b))
+	}
+	o = NewIntervalNewton(P, dP)
+	encl = o.Solve(NewInterval(-5, 5))
+	io.Pforan("\n(x-1)(x-1.001)(x-2): %v\n", encl)
+	brent = NewBrent(p, nil)
+	brent.Tol = 0
+	roots = []float64{brent.Root(0.5, 1.0005), brent.Root(1.0005, 1.5), brent.Root(1.5, 2.5)}
+	checkRootsEnclosed(tst, roots, encl, o.Unique)
+
+	// no roots
+	o = NewIntervalNewton(func(x Interval) Interval { return x.Sqr().AddS(1) }, func(x Interval) Interval { return x.MulS(2) })
+	encl = o.Solve(NewInterval(-3, 3))
+	chk.Int(tst, "number of roots of x²+1", len(encl), 0)
+
+	// double root: enclosed but not proven unique
+	o = NewIntervalNewton(func(x Interval) Interval { return x.AddS(-1).Sqr() }, func(x Interval) Interval { return x.AddS(-1).MulS(2) })
+	o.Tol = 1e-6
+	encl = o.Solve(NewInterval(-3, 3))
+	io.Pforan("\n(x-1)²: %v  unique = %v\n", encl, o.Unique)
+	chk.Int(tst, "number of enclosures of (x-1)²", len(encl), 1)
+	if !encl[0].Contains(1) || o.Unique[0] {
+		tst.Errorf("(x-1)²: enclosure %v is incorrect\n", encl[0])
+	}
+}
+
+func TestIntervalNewton02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("IntervalNewton02. Krawczyk test for systems")
+
+	// f₀ = x² + y² - 4 and f₁ = x⋅y - 1 with four roots. Eliminating y: x² + 1/x² = 4
+	ffcn := func(fx, x []Interval) {
+		fx[0] = x[0].Sqr().Add(x[1].Sqr()).AddS(-4)
+		fx[1] = x[0].Mul(x[1]).AddS(-1)
+	}
+	jfcn := func(J [][]Interval, x []Interval) {
+		J[0][0], J[0][1] = x[0].MulS(2), x[1].MulS(2)
+		J[1][0], J[1][1] = x[1], x[0]
+	}
+
+	// roots by Brent's method on the reduced equation
+	g := func(x float64) float64 { return x*x + 1/(x*x) - 4 }
+	brent := NewBrent(g, nil)
+	brent.Tol = 0
+	xs := []float64{brent.Root(-2, -1), brent.Root(-1, -0.1), brent.Root(0.1, 1), brent.Root(1, 2)}
+	io.Pforan("Brent: x = %v\n", xs)
+
+	// boxes around each root
+	for _, x := range xs {
+		y := 1 / x
+		X := []Interval{NewInterval(x-0.1, x+0.1), NewInterval(y-0.1, y+0.1)}
+		res, unique := Krawczyk(ffcn, jfcn, X, 20)
+		io.Pf("x = %v\ny = %v  unique = %v\n", res[0], res[1], unique)
+		if !unique || !res[0].Contains(x) || !res[1].Contains(y) {
+			tst.Errorf("Krawczyk failed to enclose root (%g, %g)\n", x, y)
+		}
+		if res[0].Width() > 1e-12 || res[1].Width() > 1e-12 {
+			tst.Errorf("Krawczyk enclosure is too wide\n")
+		}
+	}
+
+	// box without roots
+	res, unique := Krawczyk(ffcn, jfcn, []Interval{NewInterval(0.5, 0.9), NewInterval(0.1, 0.4)}, 20)
+	io.Pforan("no roots: %v  unique = %v\n", res, unique)
+	if res != nil || unique {
+		tst.Errorf("box without roots should be discarded\n")
+	}
+}
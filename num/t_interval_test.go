@@ -0,0 +1,166 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// checkEnclosure checks that res contains f(x) for samples x in the interval a
+func checkEnclosure(tst *testing.T, msg string, a, res Interval, f func(x float64) float64) {
+	for k := 0; k <= 100; k++ {
+		x := a.Lo + float64(k)*(a.Hi-a.Lo)/100.0
+		if k == 100 {
+			x = a.Hi
+		}
+		if fx := f(x); !res.Contains(fx) {
+			tst.Errorf("%s: f(%g) = %.17g is not in %v\n", msg, x, fx, res)
+			return
+		}
+	}
+}
+
+func TestInterval01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Interval01. outward rounded arithmetic")
+
+	// exact operations are not widened
+	a := NewInterval(1, 2)
+	b := NewInterval(-3, 4)
+	chk.Float64(tst, "mid", 1e-17, a.Mid(), 1.5)
+	chk.Float64(tst, "width", 1e-17, b.Width(), 7)
+	chk.Array(tst, "a+b", 1e-17, []float64{a.Add(b).Lo, a.Add(b).Hi}, []float64{-2, 6})
+	chk.Array(tst, "a-b", 1e-17, []float64{a.Sub(b).Lo, a.Sub(b).Hi}, []float64{-3, 5})
+	chk.Array(tst, "a*b", 1e-17, []float64{a.Mul(b).Lo, a.Mul(b).Hi}, []float64{-6, 8})
+	chk.Array(tst, "b/a", 1e-17, []float64{b.Div(a).Lo, b.Div(a).Hi}, []float64{-3, 4})
+	chk.Array(tst, "b²", 1e-17, []float64{b.Sqr().Lo, b.Sqr().Hi}, []float64{0, 16})
+	chk.Array(tst, "b³", 1e-17, []float64{b.PowN(3).Lo, b.PowN(3).Hi}, []float64{-27, 64})
+	chk.Array(tst, "-b", 1e-17, []float64{b.Neg().Lo, b.Neg().Hi}, []float64{-4, 3})
+	chk.Array(tst, "|b|", 1e-17, []float64{b.Abs().Lo, b.Abs().Hi}, []float64{0, 4})
+	chk.Array(tst, "√[4,9]", 1e-17, []float64{NewInterval(4, 9).Sqrt().Lo, NewInterval(4, 9).Sqrt().Hi}, []float64{2, 3})
+
+	// inexact operations contain the exact result and are one ulp wide
+	third := IntervalPoint(1).Div(IntervalPoint(3))
+	io.Pforan("1/3 = %v\n", third)
+	chk.Float64(tst, "ulps(1/3)", 1e-17, third.Hi, math.Nextafter(third.Lo, 1))
+	if !third.Contains(1.0 / 3.0) {
+		tst.Errorf("1/3 should be in %v\n", third)
+	}
+	tenth := IntervalPoint(0.1).Add(IntervalPoint(0.2))
+	io.Pforan("0.1+0.2 = %v\n", tenth)
+	chk.Float64(tst, "ulps(0.1+0.2)", 1e-17, tenth.Hi, math.Nextafter(tenth.Lo, 1))
+	sqrt2 := IntervalPoint(2).Sqrt()
+	io.Pforan("√2 = %v\n", sqrt2)
+	if sqrt2.Sqr().Contains(2) == false || sqrt2.Lo*sqrt2.Lo > 2 {
+		tst.Errorf("√2 should be enclosed by %v\n", sqrt2)
+	}
+
+	// accumulation: Σ 0.1 (ten times) encloses 1 although the floating-point sum is not 1
+	sum, fsum := IntervalPoint(0), 0.0
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(IntervalPoint(0.1))
+		fsum += 0.1
+	}
+	io.Pforan("Σ 0.1 = %v  (floating-point: %.17g)\n", sum, fsum)
+	if !sum.Contains(fsum) || sum.Width() > 1e-15 {
+		tst.Errorf("Σ 0.1 = %v is incorrect\n", sum)
+	}
+
+	// dependency problem: x - x ≠ 0 for intervals
+	d := a.Sub(a)
+	chk.Array(tst, "a-a", 1e-17, []float64{d.Lo, d.Hi}, []float64{-1, 1})
+
+	// overflow and infinite bounds
+	big := IntervalPoint(math.MaxFloat64).Add(IntervalPoint(math.MaxFloat64))
+	chk.Float64(tst, "big.Lo", 1e-17, big.Lo, math.MaxFloat64)
+	if !math.IsInf(big.Hi, +1) {
+		tst.Errorf("upper bound of big should be +∞\n")
+	}
+	c := NewInterval(0, math.Inf(1)).Mul(NewInterval(0, 1))
+	chk.Float64(tst, "[0,∞)⋅[0,1] lo", 1e-17, c.Lo, 0)
+
+	// extended division
+	q1, q2, n := NewInterval(1, 2).DivExt(NewInterval(-1, 1))
+	io.Pforan("[1,2]/[-1,1] = %v ∪ %v\n", q1, q2)
+	chk.Int(tst, "n", n, 2)
+	chk.Array(tst, "q1,q2", 1e-17, []float64{q1.Hi, q2.Lo}, []float64{-1, 1})
+	_, _, n = NewInterval(1, 2).DivExt(NewInterval(0, 0))
+	chk.Int(tst, "n (empty)", n, 0)
+	q1, _, n = NewInterval(-2, -1).DivExt(NewInterval(0, 4))
+	chk.Int(tst, "n (half-line)", n, 1)
+	chk.Float64(tst, "q1.Hi", 1e-17, q1.Hi, -0.25)
+
+	// set operations
+	r, ok := a.Intersect(b)
+	chk.Array(tst, "a∩b", 1e-17, []float64{r.Lo, r.Hi}, []float64{1, 2})
+	_, ok = a.Intersect(NewInterval(3, 4))
+	if ok {
+		tst.Errorf("intersection should be empty\n")
+	}
+	h := a.Hull(NewInterval(3, 4))
+	chk.Array(tst, "hull", 1e-17, []float64{h.Lo, h.Hi}, []float64{1, 4})
+	if !NewInterval(1.5, 1.6).Interior(a) || a.Interior(a) || !a.Subset(a) {
+		tst.Errorf("Interior or Subset failed\n")
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	a.Div(b)
+}
+
+func TestInterval02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Interval02. elementary functions")
+
+	boxes := []Interval{
+		NewInterval(-3, -2.5), NewInterval(-1, 1), NewInterval(0.1, 0.3),
+		NewInterval(1, 7), NewInterval(4, 4.1), NewInterval(-10, 20),
+	}
+	for _, a := range boxes {
+		checkEnclosure(tst, "sin "+a.String(), a, a.Sin(), math.Sin)
+		checkEnclosure(tst, "cos "+a.String(), a, a.Cos(), math.Cos)
+		checkEnclosure(tst, "atan "+a.String(), a, a.Atan(), math.Atan)
+		checkEnclosure(tst, "exp "+a.String(), a, a.Exp(), math.Exp)
+		checkEnclosure(tst, "x⁵ "+a.String(), a, a.PowN(5), func(x float64) float64 { return x * x * x * x * x })
+		if a.Lo > 0 {
+			checkEnclosure(tst, "log "+a.String(), a, a.Log(), math.Log)
+			checkEnclosure(tst, "sqrt "+a.String(), a, a.Sqrt(), math.Sqrt)
+		}
+	}
+
+	// extrema inside the box are included
+	s := NewInterval(1, 2).Sin()
+	io.Pforan("sin([1,2]) = %v\n", s)
+	chk.Float64(tst, "max sin([1,2])", 1e-17, s.Hi, 1)
+	chk.Float64(tst, "min sin([1,2])", 1e-15, s.Lo, math.Sin(1))
+	c := NewInterval(3, 4).Cos()
+	io.Pforan("cos([3,4]) = %v\n", c)
+	chk.Float64(tst, "min cos([3,4])", 1e-17, c.Lo, -1)
+	chk.Float64(tst, "max cos([3,4])", 1e-15, c.Hi, math.Cos(4))
+	w := NewInterval(0, 7).Sin()
+	chk.Array(tst, "sin([0,7])", 1e-17, []float64{w.Lo, w.Hi}, []float64{-1, 1})
+
+	// the bounds are tight
+	e := NewInterval(0, 1).Exp()
+	io.Pforan("exp([0,1]) = %v\n", e)
+	chk.Float64(tst, "exp([0,1]).Lo", 1e-15, e.Lo, 1)
+	chk.Float64(tst, "exp([0,1]).Hi", 1e-15, e.Hi, math.E)
+
+	// composition: f(x) = x⋅exp(-x) over [0, 2] has range [0, 1/e]
+	x := NewInterval(0, 2)
+	f := x.Mul(x.Neg().Exp())
+	io.Pforan("x⋅exp(-x) over [0,2] = %v\n", f)
+	checkEnclosure(tst, "x⋅exp(-x)", x, f, func(x float64) float64 { return x * math.Exp(-x) })
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewInterval(-1, 1).Sqrt()
+}
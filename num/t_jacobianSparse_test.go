@@ -0,0 +1,203 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// bratuResidual returns the residual of -d²u/dx² = λ⋅exp(u) on (0,1) with u(0) = u(1) = 0 discretised
+// by finite differences with n interior points, and its (tridiagonal) sparsity pattern
+func bratuResidual(n int, λ float64) (ffcn func(fx, u la.Vector), pattern *la.Triplet) {
+	h := 1.0 / float64(n+1)
+	ffcn = func(fx, u la.Vector) {
+		for i := 0; i < n; i++ {
+			ul, ur := 0.0, 0.0
+			if i > 0 {
+				ul = u[i-1]
+			}
+			if i < n-1 {
+				ur = u[i+1]
+			}
+			fx[i] = (-ul+2*u[i]-ur)/(h*h) - λ*math.Exp(u[i])
+		}
+	}
+	pattern = la.NewTriplet(n, n, 3*n)
+	for i := 0; i < n; i++ {
+		for j := i - 1; j <= i+1; j++ {
+			if j >= 0 && j < n {
+				pattern.Put(i, j, 1)
+			}
+		}
+	}
+	return
+}
+
+func TestJacobianSparse01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("JacobianSparse01. column coloring")
+
+	// tridiagonal
+	n := 10
+	_, pattern := bratuResidual(n, 1)
+	sj := NewSparseJacobian(pattern)
+	io.Pforan("tridiagonal: colors = %v\n", sj.Colors)
+	chk.Int(tst, "Ncolors", sj.Ncolors, 3)
+	chk.Int(tst, "Nnz", sj.Nnz(), 3*n-2)
+	for j := 0; j < n; j++ {
+		chk.Int(tst, io.Sf("color[%d]", j), sj.Colors[j], j%3)
+	}
+
+	// the same with index pairs (repeated and in any order)
+	var pairs [][]int
+	for i := n - 1; i >= 0; i-- {
+		for j := i - 1; j <= i+1; j++ {
+			if j >= 0 && j < n {
+				pairs = append(pairs, []int{i, j}, []int{i, j})
+			}
+		}
+	}
+	sjp := NewSparseJacobianPairs(n, n, pairs)
+	chk.Ints(tst, "colors (pairs)", sjp.Colors, sj.Colors)
+	chk.Int(tst, "Nnz (pairs)", sjp.Nnz(), sj.Nnz())
+
+	// arrow matrix: the first row and column are full
+	//   x x x x x
+	//   x x . . .
+	//   x . x . .
+	//   x . . x .
+	//   x . . . x
+	pairs = [][]int{}
+	for i := 0; i < 5; i++ {
+		pairs = append(pairs, []int{0, i}, []int{i, 0}, []int{i, i})
+	}
+	sja := NewSparseJacobianPairs(5, 5, pairs)
+	io.Pforan("arrow: colors = %v\n", sja.Colors)
+	chk.Ints(tst, "colors (arrow)", sja.Colors, []int{0, 1, 2, 3, 4})
+
+	// diagonal and rectangular patterns
+	sjd := NewSparseJacobianPairs(3, 4, [][]int{{0, 0}, {1, 1}, {2, 2}, {2, 3}})
+	chk.Ints(tst, "colors (rectangular)", sjd.Colors, []int{0, 0, 0, 1})
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewSparseJacobianPairs(3, 3, [][]int{{0, 0}, {3, 1}})
+}
+
+func TestJacobianSparse02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("JacobianSparse02. sparse versus dense numerical Jacobian")
+
+	// Bratu residual
+	n := 30
+	ffcn, pattern := bratuResidual(n, 2)
+	var nfeval int
+	fcn := func(fx, u la.Vector) {
+		nfeval++
+		ffcn(fx, u)
+	}
+	x := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		x[i] = 1 + 0.5*math.Sin(float64(i))
+	}
+	xcopy := x.GetCopy()
+	fx := la.NewVector(n)
+	w := la.NewVector(n)
+	ffcn(fx, x)
+
+	// dense
+	var Jden la.Triplet
+	Jacobian(&Jden, fcn, x, fx, w)
+	chk.Int(tst, "nfeval (dense)", nfeval, n)
+
+	// sparse: each row depends on a single column of each group; thus the results are identical
+	nfeval = 0
+	var Jsp la.Triplet
+	sj := NewSparseJacobian(pattern)
+	sj.Calc(&Jsp, fcn, x, fx, w)
+	chk.Int(tst, "nfeval (sparse)", nfeval, 3)
+	chk.Int(tst, "Len(J)", Jsp.Len(), sj.Nnz())
+	chk.Array(tst, "x (restored)", 1e-17, x, xcopy)
+	chk.Deep2(tst, "J", 1e-17, Jsp.ToDense().GetDeep2(), Jden.ToDense().GetDeep2())
+
+	// compare with analytical Jacobian (forward differences; the entries are of order 1/h² ≈ 1000)
+	h := 1.0 / float64(n+1)
+	Jana := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		Jana.Set(i, i, 2/(h*h)-2*math.Exp(x[i]))
+		if i > 0 {
+			Jana.Set(i, i-1, -1/(h*h))
+		}
+		if i < n-1 {
+			Jana.Set(i, i+1, -1/(h*h))
+		}
+	}
+	chk.Deep2(tst, "J (analytical)", 1e-3, Jsp.ToDense().GetDeep2(), Jana.GetDeep2())
+}
+
+func TestJacobianSparse03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("JacobianSparse03. NlSolver with sparse numerical Jacobian")
+
+	// Bratu residual
+	n := 50
+	ffcn, pattern := bratuResidual(n, 2)
+
+	// dense numerical Jacobian
+	sol := NewNlSolver(n, ffcn)
+	defer sol.Free()
+	sol.config.Verbose = chk.Verbose
+	uden := la.NewVector(n)
+	sol.Solve(uden)
+	nfevalDense := sol.Nfeval
+
+	// sparse numerical Jacobian
+	sol.SetSparseJacobian(NewSparseJacobian(pattern))
+	usp := la.NewVector(n)
+	sol.Solve(usp)
+	io.Pforan("Nfeval: dense = %d, sparse = %d\n", nfevalDense, sol.Nfeval)
+	chk.Array(tst, "u", 1e-15, usp, uden)
+	chk.Int(tst, "Nfeval", sol.Nfeval, nfevalDense-sol.Njeval*(n-3))
+
+	// trust region (dogleg)
+	dog := NewNlSolver(n, ffcn)
+	dog.config.TrustRegion = true
+	dog.SetSparseJacobian(NewSparseJacobian(pattern))
+	udog := la.NewVector(n)
+	dog.Solve(udog)
+	chk.Array(tst, "u (dogleg)", 1e-9, udog, uden)
+
+	// CheckJ uses the pattern: the complete pattern yields the dense numerical Jacobian
+	sol.CheckJ(usp, 1e-10, false)
+
+	// CheckJ detects incomplete patterns
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				tst.Errorf("CheckJ should detect the incomplete pattern\n")
+			}
+		}()
+		diag := NewNlSolver(n, ffcn)
+		defer diag.Free()
+		pairs := make([][]int, n)
+		for i := 0; i < n; i++ {
+			pairs[i] = []int{i, i}
+		}
+		diag.SetSparseJacobian(NewSparseJacobianPairs(n, n, pairs))
+		diag.CheckJ(usp, 1e-5, false)
+	}()
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol.SetSparseJacobian(NewSparseJacobianPairs(n, n+1, [][]int{{0, 0}}))
+}
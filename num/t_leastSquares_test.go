@@ -0,0 +1,208 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestLeastSquares01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LeastSquares01. straight line: comparison with LinFitSigma")
+
+	// data
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	y := []float64{2.1, 3.9, 6.2, 7.8, 10.1, 12.2, 13.8, 16.1, 18.0, 20.2}
+	a, b, σa, σb, χ2 := LinFitSigma(x, y)
+
+	// y = x₀ + x₁⋅t
+	m := len(x)
+	residual := func(r, p la.Vector) {
+		for i := 0; i < m; i++ {
+			r[i] = y[i] - p[0] - p[1]*x[i]
+		}
+	}
+	jacobian := func(J *la.Matrix, p la.Vector) {
+		for i := 0; i < m; i++ {
+			J.Set(i, 0, -1)
+			J.Set(i, 1, -x[i])
+		}
+	}
+
+	// analytical and numerical Jacobians
+	for _, numerical := range []bool{false, true} {
+		o := NewLeastSquares(m, 2, residual)
+		if !numerical {
+			o.SetJacobian(jacobian)
+		}
+		o.Solve([]float64{0, 0})
+		io.Pforan("numerical = %v\n%s", numerical, o.Report())
+		chk.Bool(tst, "converged", o.Converged, true)
+		chk.Int(tst, "dof", o.Dof, m-2)
+		chk.Float64(tst, "a", 1e-8, o.X[0], a)
+		chk.Float64(tst, "b", 1e-8, o.X[1], b)
+		chk.Float64(tst, "χ²", 1e-12, o.Chi2, χ2)
+		chk.Float64(tst, "σa", 1e-7, o.StdErr[0], σa)
+		chk.Float64(tst, "σb", 1e-7, o.StdErr[1], σb)
+		chk.Float64(tst, "corr(a,a)", 1e-14, o.Corr.Get(0, 0), 1)
+		chk.Float64(tst, "corr(a,b)", 1e-14, o.Corr.Get(0, 1), o.Corr.Get(1, 0))
+		if o.Corr.Get(0, 1) >= 0 {
+			tst.Errorf("the intercept and slope should be negatively correlated\n")
+		}
+	}
+}
+
+func TestLeastSquares02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LeastSquares02. exponential decay with weights")
+
+	// data generated by y = 5⋅exp(-0.3⋅t) + 1 with deterministic perturbations
+	m := 30
+	t := make([]float64, m)
+	y := make([]float64, m)
+	σ := make([]float64, m)
+	w := make([]float64, m)
+	for i := 0; i < m; i++ {
+		t[i] = float64(i) * 0.5
+		σ[i] = 0.01 + 0.002*float64(i)
+		w[i] = 1.0 / (σ[i] * σ[i])
+		y[i] = 5*math.Exp(-0.3*t[i]) + 1 + σ[i]*math.Sin(float64(3*i))
+	}
+	residual := func(r, p la.Vector) {
+		for i := 0; i < m; i++ {
+			r[i] = y[i] - p[0]*math.Exp(-p[1]*t[i]) - p[2]
+		}
+	}
+	jacobian := func(J *la.Matrix, p la.Vector) {
+		for i := 0; i < m; i++ {
+			e := math.Exp(-p[1] * t[i])
+			J.Set(i, 0, -e)
+			J.Set(i, 1, p[0]*t[i]*e)
+			J.Set(i, 2, -1)
+		}
+	}
+
+	// solve with both methods
+	var sols []*LeastSquares
+	for _, method := range []string{"lm", "trf"} {
+		o := NewLeastSquares(m, 3, residual)
+		o.Method = method
+		o.SetJacobian(jacobian)
+		o.SetWeights(w)
+		o.Solve([]float64{1, 1, 0})
+		io.Pforan("%s", o.Report())
+		chk.Bool(tst, "converged", o.Converged, true)
+		chk.Float64(tst, "A", 0.05, o.X[0], 5)
+		chk.Float64(tst, "k", 0.01, o.X[1], 0.3)
+		chk.Float64(tst, "c", 0.05, o.X[2], 1)
+		for j := 0; j < 3; j++ {
+			if math.Abs(o.X[j]-[]float64{5, 0.3, 1}[j]) > 4*o.StdErr[j] {
+				tst.Errorf("parameter %d is too far from the exact value: %g ± %g\n", j, o.X[j], o.StdErr[j])
+			}
+		}
+		sols = append(sols, o)
+	}
+
+	// both methods give the same results
+	chk.Array(tst, "x(lm) = x(trf)", 1e-7, sols[0].X, sols[1].X)
+	chk.Float64(tst, "χ²(lm) = χ²(trf)", 1e-9, sols[0].Chi2, sols[1].Chi2)
+	chk.Deep2(tst, "cov(lm) = cov(trf)", 1e-9, sols[0].Cov.GetDeep2(), sols[1].Cov.GetDeep2())
+
+	// covariance is the inverse of Jᵀ⋅W⋅J (no scaling with weights)
+	o := sols[0]
+	J := la.NewMatrix(m, 3)
+	jacobian(J, o.X)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			sum := 0.0 // (Jᵀ⋅W⋅J⋅Cov)ᵢⱼ
+			for k := 0; k < 3; k++ {
+				a := 0.0
+				for l := 0; l < m; l++ {
+					a += J.Get(l, i) * w[l] * J.Get(l, k)
+				}
+				sum += a * o.Cov.Get(k, j)
+			}
+			if i == j {
+				chk.Float64(tst, "I", 1e-9, sum, 1)
+			} else {
+				chk.Float64(tst, "0", 1e-9, sum, 0)
+			}
+		}
+	}
+}
+
+func TestLeastSquares03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LeastSquares03. Rosenbrock and bounds")
+
+	// Rosenbrock: r₀ = 10⋅(x₁ - x₀²), r₁ = 1 - x₀
+	residual := func(r, x la.Vector) {
+		r[0] = 10 * (x[1] - x[0]*x[0])
+		r[1] = 1 - x[0]
+	}
+	jacobian := func(J *la.Matrix, x la.Vector) {
+		J.Set(0, 0, -20*x[0])
+		J.Set(0, 1, 10)
+		J.Set(1, 0, -1)
+		J.Set(1, 1, 0)
+	}
+	for _, method := range []string{"lm", "trf"} {
+		o := NewLeastSquares(2, 2, residual)
+		o.Method = method
+		o.SetJacobian(jacobian)
+		o.Solve([]float64{-1.2, 1})
+		io.Pforan("%s: x = %v  niter = %d  reason = %s\n", method, o.X, o.Niter, o.Reason)
+		chk.Bool(tst, "converged", o.Converged, true)
+		chk.Array(tst, "x", 1e-8, o.X, []float64{1, 1})
+		chk.Float64(tst, "χ²", 1e-15, o.Chi2, 0)
+	}
+
+	// with bounds: the solution is on the bound x₁ ≤ 0.5
+	o := NewLeastSquares(2, 2, residual)
+	o.SetJacobian(jacobian)
+	o.SetBounds([]float64{math.Inf(-1), -1.5}, []float64{math.Inf(1), 0.5})
+	o.Solve([]float64{2, 0})
+	io.Pforan("%s", o.Report())
+	chk.String(tst, o.Method, "trf")
+	chk.Bool(tst, "converged", o.Converged, true)
+	if o.X[1] > 0.5 {
+		tst.Errorf("the solution must satisfy the bounds: x = %v\n", o.X)
+	}
+	chk.Float64(tst, "x₁", 1e-7, o.X[1], 0.5)
+
+	// at the bound x₁ = 0.5, the first-order condition for x₀ gives 200⋅x₀³ - 99⋅x₀ - 1 = 0
+	x0 := o.X[0]
+	chk.Float64(tst, "dχ²/dx₀", 1e-5, 200*x0*x0*x0-99*x0-1, 0)
+
+	// the active bound keeps the cosine criterion away from zero; thus, without ftol and xtol,
+	// the method must stop because the scaled gradient vanishes
+	q := NewLeastSquares(2, 2, residual)
+	q.SetJacobian(jacobian)
+	q.SetBounds([]float64{math.Inf(-1), -1.5}, []float64{math.Inf(1), 0.5})
+	q.Ftol, q.Xtol, q.Gtol = 0, 0, 1e-8
+	q.Solve([]float64{2, 0})
+	io.Pforan("gtol: x = %v  niter = %d  reason = %s\n", q.X, q.Niter, q.Reason)
+	chk.Bool(tst, "converged(gtol)", q.Converged, true)
+	chk.String(tst, q.Reason[:4], "gtol")
+	chk.Array(tst, "x(gtol)", 1e-7, q.X, o.X)
+
+	// numerical Jacobian with bounds
+	p := NewLeastSquares(2, 2, residual)
+	p.SetBounds([]float64{math.Inf(-1), -1.5}, []float64{math.Inf(1), 0.5})
+	p.Solve([]float64{2, 0.5})
+	chk.Array(tst, "x(num)", 1e-6, p.X, o.X)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	o.Solve([]float64{1})
+}
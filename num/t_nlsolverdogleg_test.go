@@ -0,0 +1,244 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// atanProblem returns a problem where Newton's method diverges for |x| > 1.39
+func atanProblem() (ffcn func(fx, x la.Vector), Jfcn func(dfdx *la.Matrix, x la.Vector)) {
+	ffcn = func(fx, x la.Vector) {
+		fx[0] = math.Atan(x[0])
+		fx[1] = math.Atan(x[1]) + 0.5*x[0]
+	}
+	Jfcn = func(dfdx *la.Matrix, x la.Vector) {
+		dfdx.Set(0, 0, 1/(1+x[0]*x[0]))
+		dfdx.Set(0, 1, 0)
+		dfdx.Set(1, 0, 0.5)
+		dfdx.Set(1, 1, 1/(1+x[1]*x[1]))
+	}
+	return
+}
+
+func TestNlSolverDogleg01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NlSolverDogleg01. trust region: poor starting guess")
+
+	// Newton's method fails; the report records the history
+	ffcn, Jfcn := atanProblem()
+	sol := NewNlSolver(2, ffcn)
+	sol.config.Verbose = chk.Verbose
+	sol.config.MaxIterations = 10
+	sol.SetJacobianFunction(nil, Jfcn)
+	x := la.NewVectorSlice([]float64{3, -3})
+	func() {
+		defer func() {
+			if err := recover(); err == nil {
+				tst.Errorf("Newton's method should fail\n")
+			}
+		}()
+		sol.Solve(x)
+	}()
+	io.Pforan("\nNewton:\n%v", &sol.Report)
+	chk.Bool(tst, "converged", sol.Report.Converged, false)
+	if sol.Report.Reason == "" {
+		tst.Errorf("the reason for failing should be recorded\n")
+	}
+	k := len(sol.Report.FxMax) - 1
+	chk.Int(tst, "len(history)", len(sol.Report.Step), k+1)
+	chk.Float64(tst, "fxMax(0)", 1e-15, sol.Report.FxMax[0], math.Atan(3))
+	if sol.Report.FxMax[k] <= sol.Report.FxMax[0] {
+		tst.Errorf("the history should show that Newton's method diverges\n")
+	}
+
+	// dogleg method converges
+	for _, broyden := range []string{"", "good", "bad"} {
+		sol = NewNlSolver(2, ffcn)
+		sol.config.Verbose = chk.Verbose
+		sol.config.TrustRegion = true
+		sol.config.Broyden = broyden
+		sol.config.MaxIterations = 50
+		sol.SetJacobianFunction(nil, Jfcn)
+		x = la.NewVectorSlice([]float64{3, -3})
+		sol.Solve(x)
+		io.Pforan("\ndogleg (broyden=%q):\n%v", broyden, &sol.Report)
+		chk.Bool(tst, "converged", sol.Report.Converged, true)
+		chk.Array(tst, "x", 1e-9, x, nil)
+		chk.Int(tst, "len(history)", len(sol.Report.FxMax), len(sol.Report.Step))
+		steps := map[string]bool{}
+		for _, s := range sol.Report.Step {
+			steps[s] = true
+		}
+		if !steps["newton"] || !(steps["dogleg"] || steps["cauchy"]) {
+			tst.Errorf("the history should contain Newton and dogleg/Cauchy steps: %v\n", sol.Report.Step)
+		}
+		if broyden != "" && sol.Njeval >= sol.Niter {
+			tst.Errorf("Broyden's method should require fewer Jacobian evaluations: Njeval = %d, Niter = %d\n", sol.Njeval, sol.Niter)
+		}
+	}
+}
+
+func TestNlSolverDogleg02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NlSolverDogleg02. Broyden's updates")
+
+	// small problems with analytical or numerical initial Jacobian
+	for index := 0; index < 2; index++ {
+		for _, broyden := range []string{"good", "bad"} {
+			for _, numJacobian := range []bool{false, true} {
+				for _, lineSearch := range []bool{false, true} {
+					name, xTrial, xRef, funcF, _, funcJdense := problem(index)
+					io.Pf("\n%s (broyden=%s, numJ=%v, line=%v)\n", name, broyden, numJacobian, lineSearch)
+					sol := NewNlSolver(len(xTrial), funcF)
+					sol.config.Verbose = chk.Verbose
+					sol.config.Broyden = broyden
+					sol.config.LineSearch = lineSearch
+					sol.config.MaxIterations = 50
+					if !numJacobian {
+						sol.SetJacobianFunction(nil, funcJdense)
+					}
+					x := xTrial.GetCopy()
+					sol.Solve(x)
+					tolx := 1e-8
+					if index == 1 {
+						tolx = 1e-4 // xRef has only 4 digits
+					}
+					checkProblem(tst, sol, x, xRef, funcF, tolx, 1e-9, false)
+					chk.Bool(tst, "converged", sol.Report.Converged, true)
+					io.Pforan("Niter = %d  Njeval = %d  Nfeval = %d\n", sol.Niter, sol.Njeval, sol.Nfeval)
+				}
+			}
+		}
+	}
+
+	// Broyden tridiagonal function
+	n := 20
+	ffcn := func(fx, x la.Vector) {
+		for i := 0; i < n; i++ {
+			xl, xr := 0.0, 0.0
+			if i > 0 {
+				xl = x[i-1]
+			}
+			if i < n-1 {
+				xr = x[i+1]
+			}
+			fx[i] = (3-2*x[i])*x[i] - xl - 2*xr + 1
+		}
+	}
+	Jfcn := func(dfdx *la.Matrix, x la.Vector) {
+		for i := 0; i < n; i++ {
+			dfdx.Set(i, i, 3-4*x[i])
+			if i > 0 {
+				dfdx.Set(i, i-1, -1)
+			}
+			if i < n-1 {
+				dfdx.Set(i, i+1, -2)
+			}
+		}
+	}
+	var sols []la.Vector
+	for _, broyden := range []string{"", "good", "bad"} {
+		sol := NewNlSolver(n, ffcn)
+		sol.config.Verbose = chk.Verbose
+		sol.config.TrustRegion = true
+		sol.config.Broyden = broyden
+		sol.config.MaxIterations = 100
+		sol.SetJacobianFunction(nil, Jfcn)
+		x := la.NewVector(n)
+		x.Fill(-1)
+		sol.Solve(x)
+		fx := la.NewVector(n)
+		ffcn(fx, x)
+		io.Pforan("broyden=%q: Niter = %d  Njeval = %d  max|f| = %.2e\n", broyden, sol.Niter, sol.Njeval, fx.Largest(1))
+		chk.Float64(tst, "max|f|", 1e-9, fx.Largest(1), 0)
+		if broyden != "" && sol.Njeval > 3 {
+			tst.Errorf("too many Jacobian evaluations: %d\n", sol.Njeval)
+		}
+		sols = append(sols, x)
+	}
+	chk.Array(tst, "good", 1e-8, sols[1], sols[0])
+	chk.Array(tst, "bad", 1e-8, sols[2], sols[0])
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol := NewNlSolver(n, ffcn)
+	sol.config.Broyden = "ugly"
+	sol.Solve(la.NewVector(n))
+}
+
+func TestNlSolverDogleg03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NlSolverDogleg03. trust region with sparse Jacobian")
+
+	// Broyden tridiagonal function
+	n := 500
+	ffcn := func(fx, x la.Vector) {
+		for i := 0; i < n; i++ {
+			xl, xr := 0.0, 0.0
+			if i > 0 {
+				xl = x[i-1]
+			}
+			if i < n-1 {
+				xr = x[i+1]
+			}
+			fx[i] = (3-2*x[i])*x[i] - xl - 2*xr + 1
+		}
+	}
+	Jfcn := func(dfdx *la.Triplet, x la.Vector) {
+		dfdx.Start()
+		for i := 0; i < n; i++ {
+			dfdx.Put(i, i, 3-4*x[i])
+			if i > 0 {
+				dfdx.Put(i, i-1, -1)
+			}
+			if i < n-1 {
+				dfdx.Put(i, i+1, -2)
+			}
+		}
+	}
+
+	// analytical and numerical (with sparsity pattern) Jacobians
+	var sols []la.Vector
+	for _, numJacobian := range []bool{false, true} {
+		sol := NewNlSolver(n, ffcn)
+		sol.config.Verbose = chk.Verbose
+		sol.config.TrustRegion = true
+		sol.config.MaxIterations = 100
+		if numJacobian {
+			var pattern la.Triplet
+			pattern.Init(n, n, 3*n)
+			Jfcn(&pattern, la.NewVector(n))
+			sol.SetSparseJacobian(NewSparseJacobian(&pattern))
+		} else {
+			sol.SetJacobianFunction(Jfcn, nil)
+		}
+		x := la.NewVector(n)
+		x.Fill(-1)
+		sol.Solve(x)
+		sol.Free()
+		fx := la.NewVector(n)
+		ffcn(fx, x)
+		io.Pforan("numJ=%v: Niter = %d  Njeval = %d  Nfeval = %d  max|f| = %.2e\n", numJacobian, sol.Niter, sol.Njeval, sol.Nfeval, fx.Largest(1))
+		chk.Float64(tst, "max|f|", 1e-9, fx.Largest(1), 0)
+		chk.Bool(tst, "converged", sol.Report.Converged, true)
+		if sol.matrixJ != nil || sol.matrixJinv != nil {
+			tst.Errorf("dense matrices should not be allocated\n")
+		}
+		if numJacobian && sol.Nfeval > 10*sol.Niter {
+			tst.Errorf("the numerical Jacobian should use the sparsity pattern: Nfeval = %d\n", sol.Nfeval)
+		}
+		sols = append(sols, x)
+	}
+	chk.Array(tst, "numerical", 1e-8, sols[1], sols[0])
+}
@@ -0,0 +1,217 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestNlSolverJFNK01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NlSolverJFNK01. small problems")
+
+	for _, lineSearch := range []bool{false, true} {
+		for index := 0; index < 2; index++ { // problem 2 has several roots
+			name, xTrial, xRef, funcF, _, _ := problem(index)
+			io.Pf("\n%s (line search = %v)\n", name, lineSearch)
+			sol := NewNlSolver(len(xTrial), funcF)
+			sol.config.Verbose = chk.Verbose
+			sol.config.LineSearch = lineSearch
+			sol.SetJFNK(nil)
+			x := xTrial.GetCopy()
+			sol.Solve(x)
+			sol.Free()
+			tolx := 1e-10
+			if index == 1 {
+				tolx = 1e-4 // xRef has only 4 digits
+			}
+			checkProblem(tst, sol, x, xRef, funcF, tolx, 1e-9, false)
+			chk.Int(tst, "Njeval", sol.Njeval, 0)
+			io.Pforan("Niter = %d  Nfeval = %d  Nkrylov = %d\n", sol.Niter, sol.Nfeval, sol.Nkrylov)
+		}
+	}
+}
+
+func TestNlSolverJFNK02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NlSolverJFNK02. Bratu problem with many unknowns")
+
+	// -u'' = λ⋅exp(u) on (0,1) with u(0) = u(1) = 0; finite differences with n interior points
+	n := 300
+	λ := 1.0
+	h := 1.0 / float64(n+1)
+	ffcn := func(fx, u la.Vector) {
+		for i := 0; i < n; i++ {
+			ul, ur := 0.0, 0.0
+			if i > 0 {
+				ul = u[i-1]
+			}
+			if i < n-1 {
+				ur = u[i+1]
+			}
+			fx[i] = (-ul+2*u[i]-ur)/(h*h) - λ*math.Exp(u[i])
+		}
+	}
+
+	// preconditioner: solve with the (tridiagonal) discrete Laplacian by the Thomas algorithm
+	c := make([]float64, n)
+	precond := func(z, r la.Vector) {
+		a, b := -1/(h*h), 2/(h*h) // sub/super diagonal and diagonal
+		c[0] = a / b
+		z[0] = r[0] / b
+		for i := 1; i < n; i++ {
+			m := b - a*c[i-1]
+			c[i] = a / m
+			z[i] = (r[i] - a*z[i-1]) / m
+		}
+		for i := n - 2; i >= 0; i-- {
+			z[i] -= c[i] * z[i+1]
+		}
+	}
+
+	// solve without and with preconditioner
+	var sols []la.Vector
+	var nkrylov []int
+	for _, M := range []func(z, r la.Vector){nil, precond} {
+		sol := NewNlSolver(n, ffcn)
+		sol.config.Verbose = chk.Verbose
+		sol.config.SetTolerances(1e-10, 1e-10, 1e-8)
+		sol.config.GmresRestart = n // the unpreconditioned system is ill-conditioned
+		sol.config.GmresMaxIt = 5000
+		sol.SetJFNK(M)
+		u := la.NewVector(n)
+		sol.Solve(u)
+		fx := la.NewVector(n)
+		ffcn(fx, u)
+		io.Pforan("precond = %v: Niter = %d  Nfeval = %d  Nkrylov = %d  max|f| = %.2e\n", M != nil, sol.Niter, sol.Nfeval, sol.Nkrylov, fx.Largest(1))
+		chk.Float64(tst, "max|f|", 1e-8, fx.Largest(1), 0)
+		sols = append(sols, u)
+		nkrylov = append(nkrylov, sol.Nkrylov)
+	}
+	chk.Array(tst, "same solution", 1e-7, sols[0], sols[1])
+	if nkrylov[1] >= nkrylov[0]/10 {
+		tst.Errorf("the preconditioner should reduce the number of Krylov iterations significantly\n")
+	}
+
+	// analytical solution: u(x) = -2⋅log(cosh((x-½)⋅θ/2)/cosh(θ/4)) with θ = √(2λ)⋅cosh(θ/4)
+	θ := 1.0
+	for it := 0; it < 100; it++ {
+		θ = math.Sqrt(2*λ) * math.Cosh(θ/4)
+	}
+	u := sols[1]
+	for _, i := range []int{0, n / 4, n / 2, 3 * n / 4} {
+		x := float64(i+1) * h
+		ana := -2 * math.Log(math.Cosh((x-0.5)*θ/2)/math.Cosh(θ/4))
+		chk.Float64(tst, io.Sf("u(%.3f)", x), 1e-6, u[i], ana)
+	}
+}
+
+func TestNlSolverJFNK03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NlSolverJFNK03. GMRES residuals in the report")
+
+	// the forcing terms are achieved
+	name, xTrial, _, funcF, _, _ := problem(0)
+	io.Pf("\n%s\n", name)
+	sol := NewNlSolver(len(xTrial), funcF)
+	sol.SetJFNK(nil)
+	x := xTrial.GetCopy()
+	sol.Solve(x)
+	io.Pf("%v", &sol.Report)
+	chk.Bool(tst, "converged", sol.Report.Converged, true)
+	for i := 1; i < len(sol.Report.FxMax); i++ {
+		chk.String(tst, sol.Report.Step[i], "jfnk")
+		if sol.Report.Eta[i] <= 0 || sol.Report.Relres[i] > sol.Report.Eta[i] {
+			tst.Errorf("iteration %d: relres = %g should be ≤ η = %g\n", i, sol.Report.Relres[i], sol.Report.Eta[i])
+		}
+	}
+
+	// GMRES stops before achieving the forcing terms: the inexact steps are recorded
+	io.Pf("\n%s with GmresMaxIt = 1\n", name)
+	sol = NewNlSolver(len(xTrial), funcF)
+	sol.config.GmresMaxIt = 1
+	sol.SetJFNK(nil)
+	x = xTrial.GetCopy()
+	func() {
+		defer func() { recover() }() // the iterations may not converge
+		sol.Solve(x)
+	}()
+	io.Pf("%v", &sol.Report)
+	ninexact := 0
+	for i := 1; i < len(sol.Report.FxMax); i++ {
+		if sol.Report.Relres[i] > sol.Report.Eta[i] {
+			chk.String(tst, sol.Report.Step[i], "jfnk-maxit")
+			ninexact++
+		}
+	}
+	if ninexact == 0 {
+		tst.Errorf("the report should contain inexact steps\n")
+	}
+}
+
+func TestGmres01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Gmres01. restarted GMRES on nonsymmetric system")
+
+	// convection-diffusion matrix
+	n := 200
+	A := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		A.Set(i, i, 4)
+		if i > 0 {
+			A.Set(i, i-1, -1.5)
+		}
+		if i < n-1 {
+			A.Set(i, i+1, -0.5)
+		}
+	}
+	Av := func(av, v la.Vector) { la.MatVecMul(av, 1, A, v) }
+	xref := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		xref[i] = math.Sin(float64(i))
+	}
+	b := la.NewVector(n)
+	Av(b, xref)
+
+	// solve with small restart
+	x := la.NewVector(n)
+	for _, restart := range []int{5, 20, 200} {
+		nit, relres := gmres(x, b, Av, nil, 1e-12, restart, 1000)
+		io.Pforan("restart = %3d: nit = %d  relres = %.2e\n", restart, nit, relres)
+		chk.Array(tst, "x", 1e-10, x, xref)
+		r := la.NewVector(n)
+		Av(r, x)
+		la.VecAdd(r, 1, b, -1, r)
+		if r.Norm() > 1e-11*b.Norm() {
+			tst.Errorf("residual is too large: %g\n", r.Norm()/b.Norm())
+		}
+	}
+
+	// diagonal preconditioner
+	jacobi := func(z, r la.Vector) {
+		for i := 0; i < n; i++ {
+			z[i] = r[i] / A.Get(i, i)
+		}
+	}
+	nit, _ := gmres(x, b, Av, jacobi, 1e-12, 20, 1000)
+	chk.Array(tst, "x (precond)", 1e-10, x, xref)
+	io.Pforan("jacobi: nit = %d\n", nit)
+
+	// zero right-hand side
+	b.Fill(0)
+	nit, relres := gmres(x, b, Av, nil, 1e-12, 20, 1000)
+	chk.Int(tst, "nit", nit, 0)
+	chk.Float64(tst, "relres", 1e-15, relres, 0)
+	chk.Array(tst, "x", 1e-15, x, nil)
+}
@@ -0,0 +1,187 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestPolynomial01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Polynomial01. evaluation and arithmetic")
+
+	// p(x) = 1 - 2x + 3x³
+	p := NewPolynomial(1, -2, 0, 3)
+	io.Pforan("p(x) = %v\n", p)
+	chk.String(tst, p.String(), "1 - 2⋅x + 3⋅x^3")
+	chk.Int(tst, "degree", p.Degree(), 3)
+	chk.Int(tst, "degree(0)", NewPolynomial(0, 0).Degree(), -1)
+	chk.Float64(tst, "p(2)", 1e-15, p.Eval(2), 21)
+	chk.Complex128(tst, "p(i)", 1e-15, p.EvalC(1i), 1-5i)
+
+	// derivatives
+	d := make([]float64, 5)
+	p.EvalDerivs(d, 2)
+	chk.Array(tst, "derivs", 1e-15, d, []float64{21, 34, 36, 18, 0})
+	chk.Array(tst, "Deriv", 1e-15, p.Deriv().C, []float64{-2, 0, 9})
+
+	// integration
+	P := p.Integ(5)
+	chk.Array(tst, "Integ", 1e-15, P.C, []float64{5, 1, -1, 0, 0.75})
+	chk.Float64(tst, "Integral", 1e-15, p.Integral(-1, 2), 11.25)
+
+	// arithmetic
+	q := NewPolynomial(-1, 1) // x - 1
+	chk.Array(tst, "p+q", 1e-15, p.Add(q).C, []float64{0, -1, 0, 3})
+	chk.Array(tst, "p-q", 1e-15, p.Sub(q).C, []float64{2, -3, 0, 3})
+	chk.Array(tst, "p⋅q", 1e-15, p.Mul(q).C, []float64{-1, 3, -2, -3, 3})
+	chk.Array(tst, "2⋅p", 1e-15, p.Scale(2).C, []float64{2, -4, 0, 6})
+
+	// division: p = quo⋅q + rem
+	quo, rem := p.Div(q)
+	chk.Array(tst, "quo", 1e-15, quo.C, []float64{1, 3, 3})
+	chk.Array(tst, "rem", 1e-15, rem.C, []float64{2})
+	chk.Array(tst, "quo⋅q+rem", 1e-15, quo.Mul(q).Add(rem).C, p.C)
+	quo, rem = q.Div(p)
+	chk.Array(tst, "quo (deg q < deg p)", 1e-15, quo.C, []float64{0})
+	chk.Array(tst, "rem (deg q < deg p)", 1e-15, rem.C, q.C)
+	r := NewPolynomial(1, 0, 1) // x² + 1
+	quo, rem = NewPolynomial(2, 1, 3, 0, 1).Div(r)
+	chk.Array(tst, "quo", 1e-15, quo.C, []float64{2, 0, 1})
+	chk.Array(tst, "rem", 1e-15, rem.C, []float64{0, 1})
+
+	// composition: p(q(x))
+	pq := p.Compose(q)
+	chk.Array(tst, "p∘q", 1e-15, pq.C, []float64{0, 7, -9, 3})
+	for _, x := range []float64{-1.5, 0.3, 2.7} {
+		chk.Float64(tst, "p(q(x))", 1e-13, pq.Eval(x), p.Eval(q.Eval(x)))
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	p.Div(NewPolynomial(0))
+}
+
+func TestPolynomial02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Polynomial02. roots")
+
+	// real and complex roots
+	ref := []complex128{-2, 0.5 - 1.5i, 0.5 + 1.5i, 1, 3}
+	p := NewPolynomialFromRoots(ref...)
+	io.Pforan("p(x) = %v\n", p)
+	chk.ArrayC(tst, "roots", 1e-13, p.Roots(), ref)
+	chk.ArrayC(tst, "Aberth", 1e-13, p.RootsAberth(1e-15, 100), ref)
+
+	// zero roots are extracted exactly
+	p = NewPolynomial(0, 0, -1, 0, 1) // x²⋅(x² - 1)
+	chk.ArrayC(tst, "zero roots", 1e-15, p.Roots(), []complex128{-1, 0, 0, 1})
+	chk.ArrayC(tst, "zero roots (Aberth)", 1e-15, p.RootsAberth(1e-15, 100), []complex128{-1, 0, 0, 1})
+
+	// comparison with EqCubicSolveReal: x³ - 3x² - 144x + 432
+	x1, x2, x3, nx := EqCubicSolveReal(-3, -144, 432)
+	chk.Int(tst, "nx", nx, 3)
+	roots := NewPolynomial(432, -144, -3, 1).Roots()
+	xs := []float64{x1, x2, x3}
+	for _, x := range xs {
+		found := false
+		for _, z := range roots {
+			if math.Abs(real(z)-x) < 1e-12 && imag(z) == 0 {
+				found = true
+			}
+		}
+		if !found {
+			tst.Errorf("root %g has not been found in %v\n", x, roots)
+		}
+	}
+
+	// Wilkinson polynomial of degree 10
+	ref = make([]complex128, 10)
+	for i := 0; i < 10; i++ {
+		ref[i] = complex(float64(i+1), 0)
+	}
+	p = NewPolynomialFromRoots(ref...)
+	chk.ArrayC(tst, "Wilkinson", 1e-9, p.Roots(), ref)
+	chk.ArrayC(tst, "Wilkinson (Aberth)", 1e-9, p.RootsAberth(1e-15, 200), ref)
+
+	// double root: accuracy limited to √ϵ
+	p = NewPolynomialFromRoots(2, 2, -1)
+	chk.ArrayC(tst, "double root", 1e-7, p.Roots(), []complex128{-1, 2, 2})
+	chk.ArrayC(tst, "double root (Aberth)", 1e-7, p.RootsAberth(1e-15, 100), []complex128{-1, 2, 2})
+
+	// constant polynomial has no roots
+	chk.Int(tst, "no roots", len(NewPolynomial(3).Roots()), 0)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewPolynomial(0).Roots()
+}
+
+func TestPolynomial03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Polynomial03. least-squares fitting")
+
+	// exact polynomial data is reproduced
+	ref := NewPolynomial(2, -1, 0.5, 0.25)
+	n := 12
+	x, y := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = 100 + float64(i)
+		y[i] = ref.Eval(x[i] - 100)
+	}
+	p := PolyFit(x, y, nil, 3)
+	shifted := ref.Compose(NewPolynomial(-100, 1))
+	io.Pforan("p(x) = %v\n", p)
+	for i := 0; i < n; i++ {
+		chk.Float64(tst, "p(xᵢ)", 1e-9, p.Eval(x[i]), y[i])
+	}
+	chk.Array(tst, "coefficients", 1e-6, p.C, shifted.C)
+
+	// straight line: comparison with LinFit
+	xx := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	yy := []float64{2.1, 3.9, 6.2, 7.8, 10.1, 12.2, 13.8, 16.1, 18.0, 20.2}
+	a, b := LinFit(xx, yy)
+	p = PolyFit(xx, yy, nil, 1)
+	chk.Array(tst, "line", 1e-13, p.C, []float64{a, b})
+
+	// weights: zero weight ignores the outlier
+	yy[4] = 100
+	w := make([]float64, len(xx))
+	for i := range w {
+		w[i] = 1
+	}
+	w[4] = 0
+	p = PolyFit(xx, yy, w, 1)
+	xr := append(append([]float64{}, xx[:4]...), xx[5:]...)
+	yr := append(append([]float64{}, yy[:4]...), yy[5:]...)
+	a, b = LinFit(xr, yr)
+	chk.Array(tst, "weighted", 1e-13, p.C, []float64{a, b})
+
+	// high degree on many points: the orthogonal basis keeps the fitting well conditioned
+	n = 200
+	x, y = make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = -1 + 2*float64(i)/float64(n-1)
+		y[i] = math.Cos(3 * x[i])
+	}
+	p = PolyFit(x, y, nil, 20)
+	maxerr := 0.0
+	for i := 0; i < n; i++ {
+		maxerr = math.Max(maxerr, math.Abs(p.Eval(x[i])-y[i]))
+	}
+	io.Pforan("degree 20: max error = %.2e\n", maxerr)
+	chk.Float64(tst, "max error", 1e-13, maxerr, 0)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	PolyFit(xx, yy, nil, len(xx))
+}
@@ -0,0 +1,297 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+	"sync"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestQuadAdaptive01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadAdaptive01. finite intervals")
+
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ref  float64
+	}{
+		{"√(1+sin³x)", func(x float64) float64 { return math.Sqrt(1.0 + math.Pow(math.Sin(x), 3.0)) }, 0, 1, 1.08268158558},
+		{"x²", func(x float64) float64 { return x * x }, -1, 2, 3},
+		{"log(x)/√x", func(x float64) float64 { return math.Log(x) / math.Sqrt(x) }, 0, 1, -4},
+		{"x^(-0.9)", func(x float64) float64 { return math.Pow(x, -0.9) }, 0, 1, 10},
+		{"1/(1+100x²)", func(x float64) float64 { return 1 / (1 + 100*x*x) }, -1, 1, 0.2 * math.Atan(10)},
+		{"reversed", func(x float64) float64 { return math.Exp(x) }, 1, 0, 1 - math.E},
+	}
+
+	for _, key := range []int{15, 21} {
+		o := NewQuadAdaptive(0, 1e-12)
+		o.Key = key
+		for _, t := range tests {
+			res, abserr, neval, ier := o.Integrate(t.f, t.a, t.b)
+			io.Pforan("G%dK%d: %-12s = %23.15e  abserr = %.2e  neval = %d\n", (key-1)/2, key, t.name, res, abserr, neval)
+			chk.Float64(tst, t.name, 1e-11*math.Max(1, math.Abs(t.ref)), res, t.ref)
+			chk.Int(tst, t.name+": ier", ier, 0)
+			if abserr > 1e-10*math.Max(1, math.Abs(t.ref)) {
+				tst.Errorf("%s: the error estimate is too large: %g\n", t.name, abserr)
+			}
+			if neval < key {
+				tst.Errorf("%s: the number of evaluations is incorrect: %d\n", t.name, neval)
+			}
+		}
+	}
+
+	// error estimate is reliable
+	o := NewQuadAdaptive(0, 1e-4)
+	f := func(x float64) float64 { return math.Cos(30*x) * math.Exp(x) }
+	ref := (math.Exp(1)*(math.Cos(30)+30*math.Sin(30)) - 1) / 901
+	res, abserr, _, _ := o.Integrate(f, 0, 1)
+	io.Pforan("res = %v  abserr = %v  error = %v\n", res, abserr, math.Abs(res-ref))
+	if math.Abs(res-ref) > abserr {
+		tst.Errorf("the error estimate is smaller than the actual error\n")
+	}
+
+	// zero-length interval
+	res, abserr, neval, _ := o.Integrate(f, 1, 1)
+	chk.Float64(tst, "∫ over [1,1]", 1e-17, res, 0)
+	chk.Float64(tst, "abserr", 1e-17, abserr, 0)
+	chk.Int(tst, "neval", neval, 0)
+}
+
+func TestQuadAdaptive02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadAdaptive02. infinite intervals and breakpoints")
+
+	o := NewQuadAdaptive(0, 1e-12)
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ref  float64
+	}{
+		{"exp(-x²) [0,∞)", func(x float64) float64 { return math.Exp(-x * x) }, 0, math.Inf(1), math.Sqrt(math.Pi) / 2},
+		{"1/(1+x²) (-∞,∞)", func(x float64) float64 { return 1 / (1 + x*x) }, math.Inf(-1), math.Inf(1), math.Pi},
+		{"exp(x) (-∞,1]", func(x float64) float64 { return math.Exp(x) }, math.Inf(-1), 1, math.E},
+		{"1/(√x(1+x)) [0,∞)", func(x float64) float64 { return 1 / (math.Sqrt(x) * (1 + x)) }, 0, math.Inf(1), math.Pi},
+		{"exp(x) [∞,0]", func(x float64) float64 { return math.Exp(-x) }, math.Inf(1), 0, -1},
+	}
+	for _, t := range tests {
+		res, abserr, neval, _ := o.Integrate(t.f, t.a, t.b)
+		io.Pforan("%-20s = %23.15e  abserr = %.2e  neval = %d\n", t.name, res, abserr, neval)
+		chk.Float64(tst, t.name, 1e-11, res, t.ref)
+	}
+
+	// singularities at interior points: x³⋅log|(x²-1)(x²-2)|
+	f := func(x float64) float64 { return x * x * x * math.Log(math.Abs((x*x-1)*(x*x-2))) }
+	ref := 61*math.Log(2) + 77*math.Log(7)/4 - 27
+	res, abserr, neval, _ := o.IntegratePts(f, 0, 3, []float64{math.Sqrt2, 1})
+	io.Pforan("\nwith breakpoints    = %23.15e  abserr = %.2e  neval = %d\n", res, abserr, neval)
+	chk.Float64(tst, "x³⋅log|(x²-1)(x²-2)|", 1e-11, res, ref)
+	res, _, _, _ = o.IntegratePts(f, 3, 0, []float64{1, math.Sqrt2})
+	chk.Float64(tst, "reversed", 1e-11, res, -ref)
+
+	// discontinuity
+	g := func(x float64) float64 {
+		if x < 1.0/3.0 {
+			return math.Sin(x)
+		}
+		return 2 + x
+	}
+	gref := 1 - math.Cos(1.0/3.0) + 2*(2-1.0/3.0) + (4-1.0/9.0)/2
+	res, _, nevalPts, _ := o.IntegratePts(g, 0, 2, []float64{1.0 / 3.0})
+	chk.Float64(tst, "discontinuity (with breakpoint)", 1e-13, res, gref)
+	res, _, nevalGen, _ := o.Integrate(g, 0, 2)
+	chk.Float64(tst, "discontinuity (without breakpoint)", 1e-11, res, gref)
+	io.Pforan("neval: with breakpoint = %d, without = %d\n", nevalPts, nevalGen)
+	if nevalPts >= nevalGen {
+		tst.Errorf("breakpoints should reduce the number of evaluations\n")
+	}
+}
+
+func TestQuadAdaptive03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadAdaptive03. oscillatory weights over finite intervals")
+
+	// Chebyshev moments
+	for _, p := range []float64{3, 10, 30} {
+		mom := quadChebMoments(p)
+		o := NewQuadAdaptive(1e-15, 0)
+		for k := 0; k < 25; k++ {
+			T := func(t float64) float64 { return math.Cos(float64(k) * math.Acos(t)) }
+			var ref float64
+			if k%2 == 0 {
+				ref, _, _, _ = o.Integrate(func(t float64) float64 { return T(t) * math.Cos(p*t) }, -1, 1)
+			} else {
+				ref, _, _, _ = o.Integrate(func(t float64) float64 { return T(t) * math.Sin(p*t) }, -1, 1)
+			}
+			chk.Float64(tst, io.Sf("p=%g: mom[%d]", p, k), 1e-13, mom[k], ref)
+		}
+	}
+
+	// same problem as in TestQuadCs01
+	o := NewQuadAdaptive(0, 1e-12)
+	ω := math.Pow(2.0, 3.4)
+	f := func(x float64) float64 { return math.Exp(20.0 * (x - 1)) }
+	res, abserr, neval, _ := o.IntegrateOsc(f, 0, 1, ω, true)
+	ref := (20*math.Sin(ω) - ω*math.Cos(ω) + ω*math.Exp(-20)) / (math.Pow(20, 2) + math.Pow(ω, 2))
+	io.Pforan("res = %v  abserr = %.2e  neval = %d\n", res, abserr, neval)
+	chk.Float64(tst, "exp(20(x-1))⋅sin(ωx)", 1e-15, res, ref)
+
+	// high frequency: x⋅cos(ωx) and x⋅sin(ωx)
+	for _, ω := range []float64{50, 1000, -1000} {
+		g := func(x float64) float64 { return x }
+		rc, _, nc, _ := o.IntegrateOsc(g, 0, 1, ω, false)
+		rs, _, ns, _ := o.IntegrateOsc(g, 0, 1, ω, true)
+		refc := (math.Cos(ω) + ω*math.Sin(ω) - 1) / (ω * ω)
+		refs := (math.Sin(ω) - ω*math.Cos(ω)) / (ω * ω)
+		io.Pforan("ω = %5g: neval = %d, %d\n", ω, nc, ns)
+		chk.Float64(tst, io.Sf("x⋅cos(%gx)", ω), 1e-14, rc, refc)
+		chk.Float64(tst, io.Sf("x⋅sin(%gx)", ω), 1e-14, rs, refs)
+	}
+
+	// singular integrand: log(x)⋅sin(10πx) compared with the general integrator
+	h := func(x float64) float64 { return math.Log(x) }
+	res, _, nOsc, _ := o.IntegrateOsc(h, 0, 1, 10*math.Pi, true)
+	ref, _, nGen, _ := o.Integrate(func(x float64) float64 { return math.Log(x) * math.Sin(10*math.Pi*x) }, 0, 1)
+	io.Pforan("log(x)⋅sin(10πx) = %v  neval: osc = %d, gen = %d\n", res, nOsc, nGen)
+	chk.Float64(tst, "log(x)⋅sin(10πx)", 1e-12, res, ref)
+
+	// same problems as in TestQuadExpIx01 and TestQuadExpIx02
+	π := math.Pi
+	m := 4.0
+	I, _, _, _ := o.IntegrateExpIx(func(x float64) float64 { return x * x }, 0, 2*π, m)
+	ee := cmplx.Exp(complex(0, 2*π*m))
+	π2 := complex(π*π, 0)
+	m2 := complex(m*m, 0)
+	m3 := complex(m*m*m, 0)
+	mπ4 := complex(4*π*m, 0)
+	Iana := (2i+mπ4-4i*π2*m2)*ee/m3 - 2i/m3
+	chk.Complex128(tst, "∫ x²⋅exp(i⋅m⋅x) dx", 1e-12, I, Iana)
+
+	p, q := 2.0, 3.0
+	m = 0.5
+	I, _, _, _ = o.IntegrateExpIx(func(x float64) float64 { return p*math.Cos(x) + q*math.Sin(x) }, 0, 2*π, m)
+	ee = cmplx.Exp(complex(0, 2*π*m))
+	Q := complex(q, 0)
+	d := complex(m*m-1, 0)
+	pmi := complex(0, p*m)
+	Iana = (ee*Q-pmi*ee)/d - (Q-pmi)/d
+	chk.Complex128(tst, "∫ [p⋅cos(x)+q⋅sin(x)]⋅exp(i⋅m⋅x) dx", 1e-14, I, Iana)
+}
+
+func TestQuadAdaptive04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadAdaptive04. Fourier integrals over infinite intervals")
+
+	o := NewQuadAdaptive(1e-12, 0)
+
+	// exp(-x)⋅cos(ωx) and exp(-x)⋅sin(ωx)
+	f := func(x float64) float64 { return math.Exp(-x) }
+	for _, ω := range []float64{0, 0.5, 3, 20} {
+		rc, ec, nc, _ := o.IntegrateOsc(f, 0, math.Inf(1), ω, false)
+		rs, es, ns, _ := o.IntegrateOsc(f, 0, math.Inf(1), ω, true)
+		io.Pforan("ω = %4g: cos: abserr = %.2e  neval = %4d   sin: abserr = %.2e  neval = %4d\n", ω, ec, nc, es, ns)
+		chk.Float64(tst, io.Sf("exp(-x)⋅cos(%gx)", ω), 1e-11, rc, 1/(1+ω*ω))
+		chk.Float64(tst, io.Sf("exp(-x)⋅sin(%gx)", ω), 1e-11, rs, ω/(1+ω*ω))
+	}
+
+	// slowly decaying integrands
+	g := func(x float64) float64 { return 1 / (1 + x*x) }
+	res, abserr, neval, _ := o.IntegrateOsc(g, 0, math.Inf(1), 3, false)
+	io.Pforan("cos(3x)/(1+x²) [0,∞)    = %v  abserr = %.2e  neval = %d\n", res, abserr, neval)
+	chk.Float64(tst, "cos(3x)/(1+x²) [0,∞)", 1e-10, res, math.Pi*math.Exp(-3)/2)
+
+	res, abserr, neval, _ = o.IntegrateOsc(g, math.Inf(-1), math.Inf(1), 3, false)
+	io.Pforan("cos(3x)/(1+x²) (-∞,∞)   = %v  abserr = %.2e  neval = %d\n", res, abserr, neval)
+	chk.Float64(tst, "cos(3x)/(1+x²) (-∞,∞)", 1e-10, res, math.Pi*math.Exp(-3))
+
+	h := func(x float64) float64 { return x / (1 + x*x) }
+	res, abserr, neval, _ = o.IntegrateOsc(h, math.Inf(-1), math.Inf(1), 1, true)
+	io.Pforan("x⋅sin(x)/(1+x²) (-∞,∞)  = %v  abserr = %.2e  neval = %d\n", res, abserr, neval)
+	chk.Float64(tst, "x⋅sin(x)/(1+x²) (-∞,∞)", 1e-10, res, math.Pi/math.E)
+
+	res, _, _, _ = o.IntegrateOsc(h, math.Inf(-1), 0, -1, true)
+	chk.Float64(tst, "x⋅sin(-x)/(1+x²) (-∞,0]", 1e-10, res, -math.Pi/math.E/2)
+}
+
+func TestQuadAdaptive05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadAdaptive05. concurrency and errors")
+
+	// the same integrator is used by several goroutines
+	o := NewQuadAdaptive(0, 1e-12)
+	n := 16
+	res := make([]float64, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := float64(i + 1)
+			switch i % 3 {
+			case 0:
+				res[i], _, _, _ = o.Integrate(func(x float64) float64 { return math.Exp(-c * x) }, 0, math.Inf(1))
+			case 1:
+				res[i], _, _, _ = o.IntegratePts(func(x float64) float64 { return math.Exp(-c * x) }, 0, 100, []float64{1})
+			default:
+				res[i], _, _, _ = o.IntegrateOsc(func(x float64) float64 { return math.Exp(-c * x) }, 0, 50, 30, false)
+				res[i] *= (c*c + 900) / (c * c)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < n; i++ {
+		c := float64(i + 1)
+		chk.Float64(tst, io.Sf("goroutine %d", i), 1e-12, res[i]*c, 1)
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	o.EpsRel = 0
+	o.Integrate(func(x float64) float64 { return x }, 0, 1)
+}
+
+func TestQuadAdaptive06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadAdaptive06. error codes")
+
+	// divergent integral
+	o := NewQuadAdaptive(0, 1e-10)
+	res, abserr, neval, ier := o.Integrate(func(x float64) float64 { return 1 / x }, 0, 1)
+	io.Pforan("1/x on [0,1]: res = %v  abserr = %.2e  neval = %d  ier = %d (%s)\n", res, abserr, neval, ier, QuadIerMessage(ier))
+	if ier == 0 {
+		tst.Errorf("the divergent integral should be reported\n")
+	}
+
+	// maximum number of subintervals reached
+	o.Limit = 3
+	res, abserr, neval, ier = o.Integrate(func(x float64) float64 { return math.Log(x) / math.Sqrt(x) }, 0, 1)
+	io.Pforan("Limit = 3: res = %v  abserr = %.2e  neval = %d  ier = %d (%s)\n", res, abserr, neval, ier, QuadIerMessage(ier))
+	chk.Int(tst, "ier (Limit)", ier, 1)
+	_, _, _, ier = o.IntegratePts(func(x float64) float64 { return math.Log(x) / math.Sqrt(x) }, 0, 1, []float64{0.5})
+	chk.Int(tst, "ier (Limit, pts)", ier, 1)
+
+	// maximum number of cycles reached
+	o = NewQuadAdaptive(1e-12, 0)
+	o.LimLst = 3
+	res, abserr, neval, ier = o.IntegrateOsc(func(x float64) float64 { return 1 / (1 + x*x) }, 0, math.Inf(1), 3, false)
+	io.Pforan("LimLst = 3: res = %v  abserr = %.2e  neval = %d  ier = %d (%s)\n", res, abserr, neval, ier, QuadIerMessage(ier))
+	chk.Int(tst, "ier (LimLst)", ier, 1)
+	_, _, _, ier = o.IntegrateExpIx(func(x float64) float64 { return 1 / (1 + x*x) }, 0, math.Inf(1), 3)
+	chk.Int(tst, "ier (ExpIx)", ier, 1)
+	chk.String(tst, QuadIerMessage(1), "error # 1: maximum number of subdivisions reached")
+}
@@ -45,3 +45,78 @@ func Test_gaussLegXW01(tst *testing.T) {
 	chk.Array(tst, "xJ", 1e-15, xJ, xRef)
 	chk.Array(tst, "wJ", 1e-14, wJ, wRef)
 }
+
+func Test_clenCurtXW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("clenCurtXW01. Clenshaw-Curtis x-w data.")
+
+	x, w := ClenshawCurtisXW(1)
+	chk.Array(tst, "x(n=1)", 1e-15, x, []float64{0})
+	chk.Array(tst, "w(n=1)", 1e-15, w, []float64{2})
+
+	x, w = ClenshawCurtisXW(3)
+	chk.Array(tst, "x(n=3)", 1e-15, x, []float64{-1, 0, 1})
+	chk.Array(tst, "w(n=3)", 1e-15, w, []float64{1.0 / 3.0, 4.0 / 3.0, 1.0 / 3.0})
+
+	x, w = ClenshawCurtisXW(5)
+	chk.Array(tst, "x(n=5)", 1e-15, x, []float64{-1, -math.Sqrt2 / 2, 0, math.Sqrt2 / 2, 1})
+	chk.Array(tst, "w(n=5)", 1e-15, w, []float64{1.0 / 15.0, 8.0 / 15.0, 12.0 / 15.0, 8.0 / 15.0, 1.0 / 15.0})
+
+	// exact for polynomials of degree n-1
+	for _, n := range []int{4, 9, 17} {
+		x, w = ClenshawCurtisXW(n)
+		for k := 0; k < n; k++ {
+			res := 0.0
+			for i := 0; i < n; i++ {
+				res += w[i] * math.Pow(x[i], float64(k))
+			}
+			ref := 0.0
+			if k%2 == 0 {
+				ref = 2.0 / float64(k+1)
+			}
+			chk.Float64(tst, io.Sf("n=%d: ∫x^%d", n, k), 1e-14, res, ref)
+		}
+	}
+}
+
+func Test_gaussPattXW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("gaussPattXW01. Gauss-Patterson x-w data.")
+
+	x, w := GaussPattersonXW(2)
+	chk.Array(tst, "x(l=2)", 1e-15, x, []float64{-math.Sqrt(0.6), 0, math.Sqrt(0.6)})
+	chk.Array(tst, "w(l=2)", 1e-15, w, []float64{5.0 / 9.0, 8.0 / 9.0, 5.0 / 9.0})
+
+	// Kronrod extension of the 3-point Gauss rule
+	x, w = GaussPattersonXW(3)
+	io.Pforan("x = %v\n", x)
+	io.Pforan("w = %v\n", w)
+	chk.Array(tst, "x(l=3)", 1e-15, x[4:], []float64{0.434243749346802558, 0.774596669241483377, 0.960491268708020283})
+	chk.Array(tst, "w(l=3)", 1e-15, w[3:], []float64{0.450916538658474142, 0.401397414775962222, 0.268488089868333440, 0.104656226026467265})
+
+	// nested, positive weights and exact for degree 3⋅2ˡ⁻¹-1
+	for level := 2; level <= 6; level++ {
+		xprev, _ := GaussPattersonXW(level - 1)
+		x, w = GaussPattersonXW(level)
+		n := len(x)
+		chk.Int(tst, "n", n, (1<<uint(level))-1)
+		for i := 0; i < len(xprev); i++ {
+			chk.Float64(tst, "nested", 1e-15, x[2*i+1], xprev[i])
+		}
+		for i := 0; i < n; i++ {
+			if w[i] <= 0 {
+				tst.Errorf("level=%d: weights must be positive\n", level)
+			}
+		}
+		deg := 3*(1<<uint(level-1)) - 1
+		for k := 0; k <= deg; k += 2 {
+			res := 0.0
+			for i := 0; i < n; i++ {
+				res += w[i] * math.Pow(x[i], float64(k))
+			}
+			chk.Float64(tst, io.Sf("l=%d: ∫x^%d", level, k), 1e-14, res, 2.0/float64(k+1))
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+func TestQuadMonteCarlo01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadMonteCarlo01. plain, stratified and quasi-Monte Carlo")
+
+	// smooth function over a 4D box
+	f := func(x la.Vector) float64 { return math.Exp(x[0] + 0.5*x[1] - x[2] + 2*x[3]) }
+	a := []float64{0, 0, 0, 0}
+	b := []float64{1, 1, 1, 1}
+	ref := (math.E - 1) * 2 * (math.Exp(0.5) - 1) * (1 - math.Exp(-1)) * (math.Exp(2) - 1) / 2
+
+	// plain
+	rnd.Init(1234)
+	res, errMC := QuadMonteCarlo(a, b, 20000, f)
+	io.Pforan("plain:      res = %v  stderr = %.2e  error = %.2e\n", res, errMC, math.Abs(res-ref))
+	if math.Abs(res-ref) > 4*errMC {
+		tst.Errorf("plain Monte Carlo: the error is too large\n")
+	}
+
+	// stratified: same number of points
+	res, errST := QuadMonteCarloStrat(a, b, 5, 32, f)
+	io.Pforan("stratified: res = %v  stderr = %.2e  error = %.2e\n", res, errST, math.Abs(res-ref))
+	if math.Abs(res-ref) > 4*errST {
+		tst.Errorf("stratified Monte Carlo: the error is too large\n")
+	}
+	if errST > errMC {
+		tst.Errorf("stratification should reduce the standard error\n")
+	}
+
+	// quasi-Monte Carlo: same number of points
+	res, errQMC := QuadQuasiMonteCarlo(a, b, 2000, 10, f)
+	io.Pforan("quasi:      res = %v  stderr = %.2e  error = %.2e\n", res, errQMC, math.Abs(res-ref))
+	if math.Abs(res-ref) > 4*errQMC {
+		tst.Errorf("quasi-Monte Carlo: the error is too large\n")
+	}
+	if errQMC > errMC/5 {
+		tst.Errorf("quasi-Monte Carlo should be much more accurate than plain Monte Carlo\n")
+	}
+
+	// reversed limits give the opposite sign
+	g := func(x la.Vector) float64 { return x[0] * x[1] }
+	res, _ = QuadMonteCarlo([]float64{2, 0}, []float64{0, 1}, 1000, g)
+	chk.Float64(tst, "reversed (plain)", 0.2, res, -1)
+	res, _ = QuadMonteCarloStrat([]float64{2, 0}, []float64{0, 1}, 4, 10, g)
+	chk.Float64(tst, "reversed (stratified)", 0.1, res, -1)
+	res, _ = QuadQuasiMonteCarlo([]float64{2, 0}, []float64{0, 1}, 500, 4, g)
+	chk.Float64(tst, "reversed (quasi)", 0.01, res, -1)
+
+	// constant function has no variance
+	res, stderr := QuadMonteCarlo([]float64{-1, 0, 2}, []float64{1, 3, 3}, 10, func(x la.Vector) float64 { return 2 })
+	chk.Float64(tst, "constant", 1e-14, res, 12)
+	chk.Float64(tst, "stderr", 1e-14, stderr, 0)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	QuadMonteCarlo(a, b, 1, f)
+}
+
+func TestQuadMonteCarlo02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadMonteCarlo02. expectation of function of random variables")
+
+	// E[max(X1+X2+X3-1.5, 0)] with Xi uniform in [0,1]: the integrand has a kink
+	f := func(x la.Vector) float64 { return math.Max(x[0]+x[1]+x[2]-1.5, 0) }
+	a := []float64{0, 0, 0}
+	b := []float64{1, 1, 1}
+	ref := 13.0 / 64.0 // from the Irwin-Hall distribution of X1+X2+X3
+
+	rnd.Init(4321)
+	res, stderr := QuadQuasiMonteCarlo(a, b, 4096, 8, f)
+	io.Pforan("quasi: res = %v  stderr = %.2e  error = %.2e\n", res, stderr, math.Abs(res-ref))
+	if math.Abs(res-ref) > 4*stderr || stderr > 1e-3 {
+		tst.Errorf("quasi-Monte Carlo: the error is too large\n")
+	}
+
+	// the kink reduces the efficiency of adaptive cubature
+	o := NewCubature(1e-6, 0)
+	res, abserr, neval, _ := o.Integrate(f, a, b)
+	io.Pforan("cubature: res = %v  abserr = %.2e  neval = %d  error = %.2e\n", res, abserr, neval, math.Abs(res-ref))
+	chk.Float64(tst, "cubature", 1e-5, res, ref)
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestQuadTanhSinh01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadTanhSinh01. finite intervals with endpoint singularities")
+
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ref  float64
+		tol  float64
+	}{
+		{"exp(x)", func(x float64) float64 { return math.Exp(x) }, 0, 1, math.E - 1, 1e-14},
+		{"√x", func(x float64) float64 { return math.Sqrt(x) }, 0, 1, 2.0 / 3.0, 1e-14},
+		{"log(x)", func(x float64) float64 { return math.Log(x) }, 0, 1, -1, 1e-14},
+		{"log(x)/√x", func(x float64) float64 { return math.Log(x) / math.Sqrt(x) }, 0, 1, -4, 1e-13},
+		{"x^(-0.9)", func(x float64) float64 { return math.Pow(x, -0.9) }, 0, 1, 10, 1e-12},
+		{"log(x)⋅log(1-x)", func(x float64) float64 { return math.Log(x) * math.Log1p(-x) }, 0, 1, 2 - math.Pi*math.Pi/6, 1e-13},
+		{"x^(-1/2)⋅(2-x)", func(x float64) float64 { return (2 - x) / math.Sqrt(x) }, 0, 4, 8.0 / 3.0, 1e-13},
+		{"reversed", func(x float64) float64 { return 1 / math.Sqrt(x) }, 1, 0, -2, 1e-14},
+	}
+
+	o := NewQuadTanhSinh(0, 1e-12)
+	for _, t := range tests {
+		res, abserr, neval, converged := o.Integrate(t.f, t.a, t.b)
+		io.Pforan("%-16s = %23.15e  abserr = %.2e  neval = %d\n", t.name, res, abserr, neval)
+		chk.Float64(tst, t.name, t.tol, res, t.ref)
+		if !converged {
+			tst.Errorf("%s: the integration should have converged\n", t.name)
+		}
+		if neval > 500 {
+			tst.Errorf("%s: too many function evaluations: %d\n", t.name, neval)
+		}
+	}
+
+	// singularity at b ≠ 0: the accuracy is limited by 1-x ≥ ϵ
+	o2 := NewQuadTanhSinh(0, 1e-8)
+	res, abserr, neval, _ := o2.Integrate(func(x float64) float64 { return 1 / math.Sqrt(1-x*x) }, -1, 1)
+	io.Pforan("%-16s = %23.15e  abserr = %.2e  neval = %d\n", "1/√(1-x²)", res, abserr, neval)
+	chk.Float64(tst, "1/√(1-x²)", 1e-7, res, math.Pi)
+	if neval > 500 {
+		tst.Errorf("1/√(1-x²): too many function evaluations: %d\n", neval)
+	}
+	if math.Abs(res-math.Pi) > abserr {
+		tst.Errorf("1/√(1-x²): abserr = %.2e underestimates the error\n", abserr)
+	}
+
+	// truncated tails: the error is dominated by the parts that cannot be reached by the abscissae
+	for _, t := range []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ref  float64
+	}{
+		{"1 on [1e8,1e8+1]", func(x float64) float64 { return 1 }, 1e8, 1e8 + 1, 1},
+		{"1/√(1-x)", func(x float64) float64 { return 1 / math.Sqrt(1-x) }, 0, 1, 2},
+	} {
+		res, abserr, neval, converged := o.Integrate(t.f, t.a, t.b)
+		err := math.Abs(res - t.ref)
+		io.Pforan("%-16s = %23.15e  abserr = %.2e  error = %.2e  neval = %d  converged = %v\n", t.name, res, abserr, err, neval, converged)
+		if err > abserr {
+			tst.Errorf("%s: abserr = %.2e underestimates the error = %.2e\n", t.name, abserr, err)
+		}
+		if abserr > 100*err {
+			tst.Errorf("%s: abserr = %.2e overestimates the error = %.2e\n", t.name, abserr, err)
+		}
+		if converged {
+			tst.Errorf("%s: the requested accuracy cannot be achieved\n", t.name)
+		}
+	}
+
+	// zero-length interval
+	res, abserr, neval, converged := o.Integrate(math.Sqrt, 1, 1)
+	chk.Float64(tst, "zero-length", 1e-15, res, 0)
+	chk.Float64(tst, "zero-length: abserr", 1e-15, abserr, 0)
+	chk.Int(tst, "zero-length: neval", neval, 0)
+	if !converged {
+		tst.Errorf("zero-length: converged should be true\n")
+	}
+
+	// comparison with elementary Simpson rule
+	var simp ElementarySimpson
+	nsimp := 0
+	simp.Init(func(x float64) float64 {
+		nsimp++
+		if x == 0 {
+			return 0
+		}
+		return math.Sqrt(x) * math.Log(x)
+	}, 0, 1, 1e-6)
+	ref := -4.0 / 9.0
+	resSimp := simp.Integrate()
+	res, _, neval, _ = o.Integrate(func(x float64) float64 { return math.Sqrt(x) * math.Log(x) }, 0, 1)
+	io.Pforan("Simpson: res = %v  neval = %d  error = %.2e\n", resSimp, nsimp, math.Abs(resSimp-ref))
+	io.Pforan("TanhSinh: res = %v  neval = %d  error = %.2e\n", res, neval, math.Abs(res-ref))
+	chk.Float64(tst, "√x⋅log(x)", 1e-15, res, ref)
+	if neval >= nsimp {
+		tst.Errorf("tanh-sinh should require fewer function evaluations than Simpson's rule\n")
+	}
+}
+
+func TestQuadTanhSinh02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("QuadTanhSinh02. semi-infinite and infinite intervals")
+
+	inf := math.Inf(1)
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ref  float64
+		tol  float64
+	}{
+		{"exp(-x)", func(x float64) float64 { return math.Exp(-x) }, 0, inf, 1, 1e-14},
+		{"exp(-x)/√x", func(x float64) float64 { return math.Exp(-x) / math.Sqrt(x) }, 0, inf, math.Sqrt(math.Pi), 1e-13},
+		{"1/(1+x²)", func(x float64) float64 { return 1 / (1 + x*x) }, 0, inf, math.Pi / 2, 1e-13},
+		{"log(x)/(1+x²)", func(x float64) float64 { return math.Log(x) / (1 + x*x) }, 0, inf, 0, 1e-13},
+		{"1/x²", func(x float64) float64 { return 1 / (x * x) }, 2, inf, 0.5, 1e-14},
+		{"exp(x)", func(x float64) float64 { return math.Exp(x) }, -inf, 1, math.E, 1e-13},
+		{"exp(-x²)", func(x float64) float64 { return math.Exp(-x * x) }, -inf, inf, math.Sqrt(math.Pi), 1e-14},
+		{"1/(1+x²) (-∞,∞)", func(x float64) float64 { return 1 / (1 + x*x) }, -inf, inf, math.Pi, 1e-13},
+		{"reversed", func(x float64) float64 { return math.Exp(-x) }, inf, 0, -1, 1e-14},
+	}
+
+	o := NewQuadTanhSinh(1e-14, 1e-12)
+	for _, t := range tests {
+		res, abserr, neval, converged := o.Integrate(t.f, t.a, t.b)
+		io.Pforan("%-16s = %23.15e  abserr = %.2e  neval = %d\n", t.name, res, abserr, neval)
+		chk.Float64(tst, t.name, t.tol, res, t.ref)
+		if !converged {
+			tst.Errorf("%s: the integration should have converged\n", t.name)
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	o.EpsAbs, o.EpsRel = 0, 0
+	o.Integrate(math.Exp, 0, 1)
+}
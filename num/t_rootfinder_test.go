@@ -0,0 +1,110 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestRootFinder01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RootFinder01. Newton, Halley and ITP with brackets")
+
+	// functions: plain Newton's method diverges (atan), cycles (x³ - 2x + 2 from x = 0) or has a
+	// vanishing derivative in the bracket (cos(x) - x³ near x = 0)
+	names := []string{"atan(x)", "x³-2x+2", "cos(x)-x³", "exp(x)-100"}
+	ffcns := []fun.Ss{
+		func(x float64) float64 { return math.Atan(x) },
+		func(x float64) float64 { return x*x*x - 2*x + 2 },
+		func(x float64) float64 { return math.Cos(x) - x*x*x },
+		func(x float64) float64 { return math.Exp(x) - 100 },
+	}
+	dfdxs := []fun.Ss{
+		func(x float64) float64 { return 1 / (1 + x*x) },
+		func(x float64) float64 { return 3*x*x - 2 },
+		func(x float64) float64 { return -math.Sin(x) - 3*x*x },
+		func(x float64) float64 { return math.Exp(x) },
+	}
+	d2fdx2s := []fun.Ss{
+		func(x float64) float64 { return -2 * x / ((1 + x*x) * (1 + x*x)) },
+		func(x float64) float64 { return 6 * x },
+		func(x float64) float64 { return -math.Cos(x) - 6*x },
+		func(x float64) float64 { return math.Exp(x) },
+	}
+	brackets := [][]float64{{-10, 20}, {-3, 0}, {-1, 2}, {0, 10}}
+	roots := []float64{0, -1.769292354238631, 0.865474033101614, math.Log(100)}
+	total := make(map[string]int)
+	for k, f := range ffcns {
+		io.Pf("\n%s\n", names[k])
+		xa, xb := brackets[k][0], brackets[k][1]
+		nbis := int(math.Ceil(math.Log2((xb - xa) / 1e-10)))
+		o := NewRootFinder(f, dfdxs[k], d2fdx2s[k])
+		o.Verbose = chk.Verbose
+		for _, method := range []string{"newton", "halley", "itp"} {
+			var x float64
+			switch method {
+			case "newton":
+				x = o.Newton(xa, xb)
+			case "halley":
+				x = o.Halley(xa, xb)
+			case "itp":
+				x = o.ITP(xa, xb)
+			}
+			io.Pforan("%-6s: x = %.15f  nit = %2d  nfeval = %2d  njeval = %2d\n", method, x, o.NumIter, o.NumFeval, o.NumJeval)
+			chk.Float64(tst, method, 1e-10, x, roots[k])
+			if method == "itp" && o.NumIter > nbis+o.ItpN0 {
+				tst.Errorf("ITP: the number of iterations (%d) should not exceed the one of bisection (%d) + n₀\n", o.NumIter, nbis)
+			}
+			total[method] += o.NumIter
+		}
+
+		// the bracket may be given in any order
+		chk.Float64(tst, "ITP(xb, xa)", 1e-10, o.ITP(xb, xa), roots[k])
+	}
+	io.Pforan("\ntotal number of iterations = %v\n", total)
+
+	// root at the bounds
+	o := NewRootFinder(func(x float64) float64 { return x*x - 4 }, func(x float64) float64 { return 2 * x }, nil)
+	chk.Float64(tst, "root at xb", 1e-17, o.Newton(-1, 2), 2)
+	chk.Float64(tst, "root at xa", 1e-17, o.ITP(2, 5), 2)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	o.Halley(-1, 3)
+}
+
+func TestRootFinder02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RootFinder02. all roots in an interval")
+
+	// roots of sin(x) in [-10, 10] (one exactly at x = 0)
+	f := func(x float64) float64 { return math.Sin(x) }
+	for _, withDeriv := range []bool{false, true} {
+		o := NewRootFinder(f, nil, nil)
+		if withDeriv {
+			o = NewRootFinder(f, math.Cos, func(x float64) float64 { return -math.Sin(x) })
+		}
+		roots := o.All(-10, 10, 20)
+		io.Pforan("roots = %v\nnfeval = %d  njeval = %d\n", roots, o.NumFeval, o.NumJeval)
+		chk.Int(tst, "number of roots", len(roots), 7)
+		for i, x := range roots {
+			chk.Float64(tst, io.Sf("root %d", i), 1e-10, x, float64(i-3)*math.Pi)
+		}
+	}
+
+	// Wilkinson-like polynomial with nearby roots: (x - 1)⋅(x - 1.001)⋅(x - 2)⋅(x - 3)
+	p := func(x float64) float64 { return (x - 1) * (x - 1.001) * (x - 2) * (x - 3) }
+	o := NewRootFinder(p, nil, nil)
+	roots := o.All(0, 4, 8)
+	io.Pforan("roots = %v\n", roots)
+	chk.Array(tst, "roots", 1e-10, roots, []float64{1, 1.001, 2, 3})
+}
@@ -0,0 +1,207 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestSparseGrid01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("SparseGrid01. isotropic Clenshaw-Curtis grid in 2D")
+
+	// number of points
+	o := NewSparseGrid(2, "cc", []float64{0, 0}, []float64{1, 2})
+	for level, npts := range []int{1, 5, 13, 29, 65} {
+		o.Isotropic(level + 1)
+		chk.Int(tst, io.Sf("npts(level=%d)", level+1), len(o.X), npts)
+	}
+
+	// sum of weights is the area; polynomial is integrated exactly
+	o.Isotropic(4)
+	chk.Float64(tst, "ΣW", 1e-14, la.Vector(o.W).Accum(), 2)
+	f := func(x la.Vector) float64 { return x[0]*x[0]*x[1] + math.Pow(x[1], 3) + 1 }
+	o.Compute(f)
+	ref := (1.0/3.0)*2.0 + 4.0 + 2.0
+	io.Pforan("integral = %v\n", o.Integral())
+	chk.Float64(tst, "integral", 1e-14, o.Integral(), ref)
+
+	// interpolation reproduces the function at the points and the polynomial everywhere
+	for p, x := range o.X {
+		chk.Float64(tst, "F", 1e-14, o.Interp(x), o.F[p])
+	}
+	for _, x := range [][]float64{{0.1, 0.3}, {0.77, 1.9}, {0.5, 1.1}} {
+		chk.Float64(tst, io.Sf("I%v", x), 1e-14, o.Interp(x), f(x))
+	}
+
+	// smooth function
+	g := func(x la.Vector) float64 { return math.Exp(-x[0]) * math.Sin(x[1]) }
+	o.Isotropic(8)
+	o.Compute(g)
+	ref = (1 - math.Exp(-1)) * (1 - math.Cos(2))
+	io.Pforan("npts = %d  integral = %v  error = %.2e\n", len(o.X), o.Integral(), math.Abs(o.Integral()-ref))
+	chk.Float64(tst, "integral", 1e-13, o.Integral(), ref)
+	for _, x := range [][]float64{{0.1, 0.3}, {0.77, 1.9}, {0.5, 1.1}} {
+		chk.Float64(tst, io.Sf("I%v", x), 1e-9, o.Interp(x), g(x))
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewSparseGrid(2, "cc", []float64{0, 0}, []float64{1, 2}).Integral()
+}
+
+func TestSparseGrid02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("SparseGrid02. Gauss-Patterson and Gauss-Hermite in high dimensions")
+
+	// Gauss-Patterson in 8D: much fewer points than the tensor rule
+	ndim := 8
+	lo, hi := make([]float64, ndim), make([]float64, ndim)
+	c := make([]float64, ndim)
+	ref := 1.0
+	for i := 0; i < ndim; i++ {
+		hi[i] = 1
+		c[i] = 1.0 / float64(i+2)
+		ref *= (math.Exp(c[i]) - 1) / c[i]
+	}
+	f := func(x la.Vector) (res float64) {
+		for i := 0; i < ndim; i++ {
+			res += c[i] * x[i]
+		}
+		return math.Exp(res)
+	}
+	o := NewSparseGrid(ndim, "gp", lo, hi)
+	o.Isotropic(4)
+	o.Compute(f)
+	io.Pforan("gp: npts = %d (tensor: %d)  integral = %v  error = %.2e\n", len(o.X), int(math.Pow(7, float64(ndim))), o.Integral(), math.Abs(o.Integral()-ref))
+	chk.Float64(tst, "gp", 1e-8, o.Integral(), ref)
+	if len(o.X) > 2000 {
+		tst.Errorf("too many points: %d\n", len(o.X))
+	}
+
+	// Gauss-Hermite in 10D: E[exp(Σ cᵢXᵢ)] = exp(Σ cᵢ²/2)
+	ndim = 10
+	c = make([]float64, ndim)
+	sum := 0.0
+	for i := 0; i < ndim; i++ {
+		c[i] = 0.3 / float64(i+1)
+		sum += c[i] * c[i] / 2.0
+	}
+	ref = math.Exp(sum)
+	o = NewSparseGrid(ndim, "gh", nil, nil)
+	o.Isotropic(4)
+	chk.Float64(tst, "ΣW", 1e-12, la.Vector(o.W).Accum(), 1)
+	o.Compute(f)
+	io.Pforan("gh: npts = %d  integral = %v  error = %.2e\n", len(o.X), o.Integral(), math.Abs(o.Integral()-ref))
+	chk.Float64(tst, "gh", 1e-7, o.Integral(), ref)
+
+	// mean and variance of X₀⋅X₁ + X₂²
+	g := func(x la.Vector) float64 { return x[0]*x[1] + x[2]*x[2] }
+	g2 := func(x la.Vector) float64 { return math.Pow(g(x), 2) }
+	o = NewSparseGrid(3, "gh", nil, nil)
+	o.Isotropic(4)
+	o.Compute(g)
+	mean := o.Integral()
+	o.Compute(g2)
+	variance := o.Integral() - mean*mean
+	chk.Float64(tst, "mean", 1e-14, mean, 1)
+	chk.Float64(tst, "variance", 1e-13, variance, 3)
+}
+
+func TestSparseGrid03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("SparseGrid03. dimension-adaptive refinement")
+
+	// function depending strongly on x₀ and weakly on the other variables
+	ndim := 6
+	lo, hi := make([]float64, ndim), make([]float64, ndim)
+	ref := 1.0
+	a := make([]float64, ndim)
+	for i := 0; i < ndim; i++ {
+		hi[i] = 1
+		a[i] = math.Pow(0.1, float64(i))
+		ref *= (math.Exp(a[i]*5) - 1) / (a[i] * 5)
+	}
+	neval := 0
+	f := func(x la.Vector) (res float64) {
+		neval++
+		for i := 0; i < ndim; i++ {
+			res += 5 * a[i] * x[i]
+		}
+		return math.Exp(res)
+	}
+
+	// adaptive
+	o := NewSparseGrid(ndim, "cc", lo, hi)
+	res, abserr, converged := o.Adaptive(f, 0, 1e-10, 10000)
+	io.Pforan("adaptive:  npts = %d  neval = %d  res = %v  abserr = %.2e  error = %.2e\n", len(o.X), neval, res, abserr, math.Abs(res-ref))
+	if !converged {
+		tst.Errorf("the adaptive algorithm should have converged\n")
+	}
+	chk.Float64(tst, "adaptive", 1e-10*ref, res, ref)
+	chk.Float64(tst, "integral", 1e-10*ref, o.Integral(), res)
+	chk.Int(tst, "neval", neval, len(o.X))
+	maxLevel := make([]int, ndim)
+	for _, k := range o.Index {
+		for i := 0; i < ndim; i++ {
+			maxLevel[i] = utl.Imax(maxLevel[i], k[i])
+		}
+	}
+	io.Pforan("max levels = %v\n", maxLevel)
+	if maxLevel[0] <= maxLevel[ndim-1] {
+		tst.Errorf("the first direction should be refined more\n")
+	}
+
+	// isotropic grid with similar accuracy needs many more points
+	iso := NewSparseGrid(ndim, "cc", lo, hi)
+	level := 1
+	for ; level <= 12; level++ {
+		iso.Isotropic(level)
+		iso.Compute(f)
+		if math.Abs(iso.Integral()-ref) < 1e-10*ref {
+			break
+		}
+	}
+	io.Pforan("isotropic: npts = %d  level = %d\n", len(iso.X), level)
+	if len(iso.X) < 2*len(o.X) {
+		tst.Errorf("the adaptive grid should require fewer points\n")
+	}
+
+	// interpolation with the adaptive grid. NOTE: the refinement targets the integral; thus the
+	// interpolation error is not controlled by abserr. It is dominated by the weak directions, which
+	// are refined up to level 3 only (5 points), and amounts to about 2e-5⋅f(x) here. For
+	// comparison, the isotropic grid with 10 times more points yields about 4e-6⋅f(x)
+	for _, x := range [][]float64{{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}, {0.9, 0.1, 0.5, 0.5, 0.2, 0.7}} {
+		io.Pforan("interpolation error: adaptive = %.2e  isotropic = %.2e\n", math.Abs(o.Interp(x)-f(x)), math.Abs(iso.Interp(x)-f(x)))
+		chk.Float64(tst, io.Sf("I%v", x), 1e-4*f(x), o.Interp(x), f(x))
+	}
+
+	// non-nested rule
+	o = NewSparseGrid(3, "gh", nil, nil)
+	res, abserr, converged = o.Adaptive(func(x la.Vector) float64 { return math.Cos(x[0]) * math.Exp(0.1*x[1]+0.01*x[2]) }, 1e-12, 0, 5000)
+	ref = math.Exp(-0.5) * math.Exp(0.005+0.00005)
+	io.Pforan("gh adaptive: npts = %d  res = %v  abserr = %.2e  error = %.2e\n", len(o.X), res, abserr, math.Abs(res-ref))
+	chk.Float64(tst, "gh adaptive", 1e-11, res, ref)
+	if !converged {
+		tst.Errorf("the adaptive algorithm should have converged with the Gauss-Hermite rule\n")
+	}
+
+	// maximum number of points is reached before the tolerance
+	o = NewSparseGrid(ndim, "cc", lo, hi)
+	res, abserr, converged = o.Adaptive(f, 0, 1e-10, 200)
+	io.Pforan("maxPts=200: npts = %d  res = %v  abserr = %.2e  converged = %v\n", len(o.X), res, abserr, converged)
+	if converged || abserr <= 1e-10*math.Abs(res) {
+		tst.Errorf("the adaptive algorithm should not have converged with maxPts=200\n")
+	}
+}
@@ -52,6 +52,9 @@ func (o *BwEuler) Init(ndim int, conf *Config, work *rkwork, stat *Stat, fcn Fun
 	if M != nil {
 		chk.Panic("Backward-Euler solver cannot handle M matrix yet\n")
 	}
+	if len(conf.events) > 0 {
+		chk.Panic("events require dense output, which is not available for %q\n", conf.method)
+	}
 	o.ndim = ndim
 	o.conf = conf
 	o.work = work
@@ -145,9 +148,14 @@ func (o *BwEuler) Step(x0 float64, y0 la.Vector) {
 
 			// numerical Jacobian
 			if o.jac == nil { // numerical
-				num.Jacobian(o.dfdy, func(fy, yy la.Vector) {
+				ffcn := func(fy, yy la.Vector) {
 					o.fcn(fy, h, x0, yy)
-				}, y0, o.work.f[0], o.dr) // dr works here as workspace variable
+				}
+				if o.conf.SparseJac != nil { // dr works here as workspace variable
+					o.conf.SparseJac.Calc(o.dfdy, ffcn, y0, o.work.f[0], o.dr)
+				} else {
+					num.Jacobian(o.dfdy, ffcn, y0, o.work.f[0], o.dr)
+				}
 
 				// analytical Jacobian
 			} else {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
 	"github.com/cpmech/gosl/utl"
 )
 
@@ -46,6 +47,12 @@ type Config struct {
 	// configurations for linear solver
 	LinSolConfig *la.SparseConfig // configurations for sparse linear solver
 
+	// numerical Jacobian
+	SparseJac *num.SparseJacobian // sparsity pattern of df/dy with column coloring [may be nil ⇒ dense]
+
+	// events
+	EventTol float64 // tolerance on x to locate events [default = 1e-10]
+
 	// output
 	stepF     StepOutF  // function to process step output (of accepted steps) [may be nil]
 	denseF    DenseOutF // function to process dense output [may be nil]
@@ -53,6 +60,7 @@ type Config struct {
 	stepOut   bool      // perform output of (variable) steps
 	denseOut  bool      // perform dense output is active
 	denseNstp int       // number of dense steps
+	events    []*event  // event functions
 
 	// internal data
 	method    string  // the ODE method
@@ -116,6 +124,9 @@ func NewConfig(method string, lsKind string) (o *Config) {
 	o.StiffNyes = 15
 	o.StiffNnot = 6
 
+	// events
+	o.EventTol = 1e-10
+
 	// configurations for linear solver
 	o.LinSolConfig = la.NewSparseConfig()
 
@@ -202,3 +213,19 @@ func (o *Config) SetDenseOut(save bool, dxOut, xf float64, out DenseOutF) {
 		o.denseDx = dxOut
 	}
 }
+
+// AddEvent adds an event function g(x, y) whose zero crossings are located between accepted steps
+// by means of the dense output of the method (e.g. dopri5, dopri8 and radau5). The events are
+// recorded in the output structure (Output.EventK, EventX, ...)
+//  g         -- event function
+//  direction -- 0: all crossings; +1: only when g increases; -1: only when g decreases
+//  terminal  -- stop the integration at the first crossing
+//  idx       -- returns the index of this event function (as in Output.EventK)
+//  NOTE: (1) events must be added before calling NewSolver
+//        (2) crossings are detected by a change of sign of g between accepted steps; thus, two
+//            crossings within the same step are missed (use smaller tolerances if needed)
+func (o *Config) AddEvent(g EventF, direction int, terminal bool) (idx int) {
+	idx = len(o.events)
+	o.events = append(o.events, &event{g, direction, terminal})
+	return
+}
@@ -69,3 +69,15 @@ type DenseOutF func(istep int, h, x float64, y la.Vector, xout float64, yout la.
 
 // YanaF defines a function to be used when computing analytical solutions
 type YanaF func(res []float64, x float64)
+
+// EventF defines an event (root) function g(x, y) whose zero crossings are located during the
+// integration; e.g. g = y[0] detects when y[0] crosses zero
+//
+//   INPUT:
+//     x -- scalar variable
+//     y -- vector variable
+//
+//   OUTPUT:
+//     g -- value of the event function
+//
+type EventF func(x float64, y la.Vector) (g float64)
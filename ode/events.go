@@ -0,0 +1,122 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"sort"
+
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+// event holds the definition of an event function
+type event struct {
+	g         EventF // event function
+	direction int    // 0: all crossings; +1: increasing g only; -1: decreasing g only
+	terminal  bool   // stop the integration at the first crossing
+}
+
+// eventLocator detects and locates the zero crossings of event functions between accepted steps
+type eventLocator struct {
+	events []*event   // event functions
+	tol    float64    // tolerance on x
+	gPrev  []float64  // g values at the previous accepted step
+	gNew   []float64  // g values at the current accepted step
+	yd     la.Vector  // y values from dense output
+	brent  *num.Brent // root finder
+
+	// from RK method
+	dout func(yout la.Vector, h, x float64, y la.Vector, xout float64) // function to calculate dense values of y
+
+	// auxiliary
+	h, x float64   // current stepsize and x (at the end of the step)
+	y    la.Vector // current y (at the end of the step)
+	k    int       // index of the event being located
+}
+
+// newEventLocator returns a new structure or nil if there are no events
+func newEventLocator(ndim int, conf *Config, dout func(yout la.Vector, h, x float64, y la.Vector, xout float64)) (o *eventLocator) {
+	if len(conf.events) == 0 {
+		return
+	}
+	o = new(eventLocator)
+	o.events = conf.events
+	o.tol = conf.EventTol
+	o.gPrev = make([]float64, len(o.events))
+	o.gNew = make([]float64, len(o.events))
+	o.yd = la.NewVector(ndim)
+	o.dout = dout
+	o.brent = num.NewBrent(func(s float64) float64 {
+		switch s {
+		case o.x - o.h:
+			return o.gPrev[o.k]
+		case o.x:
+			return o.gNew[o.k]
+		}
+		o.dout(o.yd, o.h, o.x, o.y, s)
+		return o.events[o.k].g(s, o.yd)
+	}, nil)
+	o.brent.Tol = o.tol
+	return
+}
+
+// init computes the event functions at the initial state
+func (o *eventLocator) init(x float64, y la.Vector) {
+	for k, e := range o.events {
+		o.gPrev[k] = e.g(x, y)
+	}
+}
+
+// locate locates the crossings within the step just accepted (from x-h to x), records them in
+// out, and returns stop = true if a terminal event occurred. In this case, x and y at the event
+// are returned in xe and y
+func (o *eventLocator) locate(out *Output, h, x float64, y la.Vector) (xe float64, stop bool) {
+
+	// crossings: a change of sign of g, or g becoming zero
+	type crossing struct {
+		k, dir int
+		x      float64
+	}
+	var list []crossing
+	o.h, o.x, o.y = h, x, y
+	for k, e := range o.events {
+		o.gNew[k] = e.g(x, y)
+		g0, g1 := o.gPrev[k], o.gNew[k]
+		if g0 == 0 || g0*g1 > 0 {
+			continue
+		}
+		dir := 1
+		if g0 > 0 {
+			dir = -1
+		}
+		if e.direction*dir < 0 {
+			continue
+		}
+		c := crossing{k, dir, x}
+		if g1 != 0 {
+			o.k = k
+			c.x = o.brent.Root(x-h, x)
+		}
+		list = append(list, c)
+	}
+	copy(o.gPrev, o.gNew)
+
+	// record events in order of occurrence up to the first terminal event
+	sort.SliceStable(list, func(i, j int) bool { return list[i].x < list[j].x })
+	for _, c := range list {
+		o.yd.Apply(1, y)
+		if c.x != x {
+			o.dout(o.yd, h, x, y, c.x)
+		}
+		if out != nil {
+			out.saveEvent(c.k, c.dir, c.x, o.yd)
+		}
+		if o.events[c.k].terminal {
+			y.Apply(1, o.yd)
+			return c.x, true
+		}
+	}
+	return
+}
@@ -36,6 +36,9 @@ func (o *FwEuler) Init(ndim int, conf *Config, work *rkwork, stat *Stat, fcn Fun
 	if M != nil {
 		chk.Panic("Forward-Euler solver cannot handle M matrix yet\n")
 	}
+	if len(conf.events) > 0 {
+		chk.Panic("events require dense output, which is not available for %q\n", conf.method)
+	}
 	o.ndim = ndim
 	o.conf = conf
 	o.work = work
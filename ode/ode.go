@@ -43,6 +43,9 @@ type Solver struct {
 	FixedOnly bool     // method can only be used with fixed steps
 	Implicit  bool     // method is implicit
 	work      *rkwork  // Runge-Kutta workspace
+
+	// events
+	evt *eventLocator // locates the zero crossings of event functions [may be nil]
 }
 
 // NewSolver returns a new ODE structure with default values and allocated slices
@@ -88,6 +91,9 @@ func NewSolver(ndim int, conf *Config, fcn Func, jac JacF, M *la.Triplet) (o *So
 	if o.Out != nil {
 		o.Out.dout = o.rkm.DenseOut
 	}
+
+	// events
+	o.evt = newEventLocator(ndim, o.conf, o.rkm.DenseOut)
 	return
 }
 
@@ -125,21 +131,28 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 	o.Stat.Reset()
 	o.Stat.Hopt = o.work.h
 	if o.Out != nil {
+		o.Out.resetEvents()
 		stop := o.Out.execute(0, false, o.work.rs, o.work.h, x, y)
 		if stop {
 			return
 		}
 	}
 
+	// events
+	if o.evt != nil {
+		o.evt.init(x, y)
+	}
+
 	// set control flags
 	o.work.first = true
 
 	// first scaling variable
 	la.VecScaleAbs(o.work.scal, o.conf.atol, o.conf.rtol, y) // scal = atol + rtol * abs(y)
 
-	// make sure that final x is equal to xf in the end
+	// make sure that final x is equal to xf in the end (unless a terminal event occurred)
+	var stopped bool
 	defer func() {
-		if math.Abs(x-xf) > 1e-10 {
+		if !stopped && math.Abs(x-xf) > 1e-10 {
 			io.Pf("warning: |x - xf| = %v > 1e-8\n", math.Abs(x-xf))
 		}
 	}()
@@ -161,6 +174,11 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 			o.work.first = false
 			x = float64(n+1) * o.work.h
 			o.rkm.Accept(y, x)
+			if o.evt != nil {
+				if stopped = o.stopAtEvent(istep, &x, y); stopped {
+					return
+				}
+			}
 			if o.Out != nil {
 				stop := o.Out.execute(istep, false, o.work.rs, o.work.h, x, y)
 				if stop {
@@ -263,6 +281,13 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 			dxnew = o.rkm.Accept(y, x)
 			x += o.work.h
 
+			// events
+			if o.evt != nil {
+				if stopped = o.stopAtEvent(o.Stat.Naccepted, &x, y); stopped {
+					return
+				}
+			}
+
 			// output
 			if o.Out != nil {
 				stop := o.Out.execute(o.Stat.Naccepted, last, o.work.rs, o.work.h, x, y)
@@ -353,3 +378,18 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 		chk.Panic("substepping did not converge after %d steps\n", o.conf.NmaxSS)
 	}
 }
+
+// stopAtEvent locates the events within the step just accepted and returns stop = true if a
+// terminal event occurred; in this case, x and y are set to the values at the event
+func (o *Solver) stopAtEvent(istep int, x *float64, y la.Vector) (stop bool) {
+	xe, stop := o.evt.locate(o.Out, o.work.h, *x, y)
+	if !stop {
+		return
+	}
+	*x = xe
+	if o.Out != nil {
+		o.Out.EventStop = true
+		o.Out.execute(istep, true, o.work.rs, o.work.h, xe, y)
+	}
+	return
+}
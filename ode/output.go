@@ -33,6 +33,13 @@ type Output struct {
 	xout      float64     // current x of dense output
 	yout      la.Vector   // current y of dense output (used if denseF != nil only)
 
+	// events
+	EventK    []int       // index of event function (as returned by Config.AddEvent) [nevents]
+	EventDir  []int       // direction of crossing: +1 if g increases; -1 if g decreases [nevents]
+	EventX    []float64   // X values at events [nevents]
+	EventY    []la.Vector // Y values at events [nevents][ndim]
+	EventStop bool        // a terminal event has stopped the integration
+
 	// from RK method
 	dout func(yout la.Vector, h, x float64, y la.Vector, xout float64) // function to calculate dense values of y
 }
@@ -140,6 +147,23 @@ func (o *Output) execute(istep int, last bool, ρs, h, x float64, y []float64) (
 	return
 }
 
+// resetEvents clears the event log (at the beginning of Solve)
+func (o *Output) resetEvents() {
+	o.EventK = o.EventK[:0]
+	o.EventDir = o.EventDir[:0]
+	o.EventX = o.EventX[:0]
+	o.EventY = o.EventY[:0]
+	o.EventStop = false
+}
+
+// saveEvent records an event
+func (o *Output) saveEvent(k, dir int, x float64, y la.Vector) {
+	o.EventK = append(o.EventK, k)
+	o.EventDir = append(o.EventDir, dir)
+	o.EventX = append(o.EventX, x)
+	o.EventY = append(o.EventY, y.GetCopy())
+}
+
 // step output ////////////////////////////////////////////////////////////////////////////////////
 
 // GetStepRs returns all ρs (stiffness ratio) values
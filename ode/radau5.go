@@ -233,9 +233,14 @@ func (o *Radau5) Step(x0 float64, y0 la.Vector) {
 
 			// numerical Jacobian
 			if o.jac == nil { // numerical
-				num.Jacobian(o.dfdy, func(fy, yy la.Vector) {
+				ffcn := func(fy, yy la.Vector) {
 					o.fcn(fy, h, x0, yy)
-				}, y0, o.work.f0, o.w[0]) // w works here as workspace variable
+				}
+				if o.conf.SparseJac != nil { // w works here as workspace variable
+					o.conf.SparseJac.Calc(o.dfdy, ffcn, y0, o.work.f0, o.w[0])
+				} else {
+					num.Jacobian(o.dfdy, ffcn, y0, o.work.f0, o.w[0])
+				}
 
 				// analytical Jacobian
 			} else {
@@ -5,9 +5,14 @@
 package ode
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+	"github.com/cpmech/gosl/utl"
 )
 
 func TestBwEuler01a(tst *testing.T) {
@@ -77,3 +82,65 @@ func TestBwEuler01b(tst *testing.T) {
 	// check results
 	chk.Float64(tst, "yFin", 1e-4, p.Y[0], p.CalcYana(0, p.Xf))
 }
+
+func TestBwEuler02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BwEuler02. Backward-Euler (sparse numerical Jacobian)")
+
+	// reaction-diffusion: dyᵢ/dx = (yᵢ₋₁ - 2yᵢ + yᵢ₊₁)/Δ² + yᵢ² with y₀ = yₙ₊₁ = 0
+	ndim := 20
+	Δ := 1.0 / float64(ndim+1)
+	var ncalls int
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		ncalls++
+		for i := 0; i < ndim; i++ {
+			yl, yr := 0.0, 0.0
+			if i > 0 {
+				yl = y[i-1]
+			}
+			if i < ndim-1 {
+				yr = y[i+1]
+			}
+			f[i] = (yl-2*y[i]+yr)/(Δ*Δ) + y[i]*y[i]
+		}
+	}
+	pattern := la.NewTriplet(ndim, ndim, 3*ndim)
+	for i := 0; i < ndim; i++ {
+		for j := utl.Imax(0, i-1); j <= utl.Imin(ndim-1, i+1); j++ {
+			pattern.Put(i, j, 1)
+		}
+	}
+
+	// solve with dense and sparse numerical Jacobians
+	var res []la.Vector
+	var stats []*Stat
+	var calls []int
+	for _, sparse := range []bool{false, true} {
+		conf := NewConfig("bweuler", "")
+		conf.SetFixedH(0.01, 0.2)
+		if sparse {
+			conf.SparseJac = num.NewSparseJacobian(pattern)
+			chk.Int(tst, "Ncolors", conf.SparseJac.Ncolors, 3)
+		}
+		sol := NewSolver(ndim, conf, fcn, nil, nil)
+		y := la.NewVector(ndim)
+		for i := 0; i < ndim; i++ {
+			y[i] = math.Sin(math.Pi * float64(i+1) * Δ)
+		}
+		ncalls = 0
+		sol.Solve(y, 0.0, 0.2)
+		sol.Free()
+		io.Pforan("sparse = %v: Nfeval = %d  Njeval = %d  calls to fcn = %d\n", sparse, sol.Stat.Nfeval, sol.Stat.Njeval, ncalls)
+		res = append(res, y)
+		stats = append(stats, sol.Stat)
+		calls = append(calls, ncalls)
+	}
+
+	// check
+	chk.Array(tst, "y", 1e-15, res[1], res[0])
+	chk.Int(tst, "Nfeval", stats[1].Nfeval, stats[0].Nfeval)
+	chk.Int(tst, "Njeval", stats[1].Njeval, stats[0].Njeval)
+	chk.Int(tst, "calls to fcn (dense)", calls[0], stats[0].Nfeval+ndim*stats[0].Njeval)
+	chk.Int(tst, "calls to fcn (sparse)", calls[1], stats[1].Nfeval+3*stats[1].Njeval)
+}
@@ -0,0 +1,136 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestEvents01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Events01. falling body: terminal event")
+
+	// falling body: y = [height, velocity]
+	grav, h0 := 9.81, 10.0
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		f[0] = y[1]
+		f[1] = -grav
+	}
+	jac := func(dfdy *la.Triplet, h, x float64, y la.Vector) {
+		if dfdy.Max() == 0 {
+			dfdy.Init(2, 2, 1)
+		}
+		dfdy.Start()
+		dfdy.Put(0, 1, 1)
+	}
+	xe := math.Sqrt(2 * h0 / grav)
+
+	for _, method := range []string{"dopri5", "dopri8", "radau5"} {
+		io.Pf("\n%s\n", method)
+
+		// configuration: stop when the height becomes zero
+		conf := NewConfig(method, "")
+		conf.SetStepOut(true, nil)
+		conf.SetTol(1e-8)
+		ground := conf.AddEvent(func(x float64, y la.Vector) float64 { return y[0] }, -1, true)
+
+		// solve
+		sol := NewSolver(2, conf, fcn, jac, nil)
+		y := la.Vector{h0, 0}
+		sol.Solve(y, 0, 5)
+		sol.Free()
+
+		// check
+		io.Pforan("x = %v  y = %v  nevents = %d\n", sol.Out.EventX, y, len(sol.Out.EventX))
+		if !sol.Out.EventStop {
+			tst.Errorf("%s: integration should have been stopped by the event\n", method)
+			continue
+		}
+		chk.Ints(tst, "EventK", sol.Out.EventK, []int{ground})
+		chk.Ints(tst, "EventDir", sol.Out.EventDir, []int{-1})
+		chk.Float64(tst, "xe", 1e-9, sol.Out.EventX[0], xe)
+		chk.Array(tst, "ye", 1e-9, sol.Out.EventY[0], []float64{0, -grav * xe})
+		chk.Array(tst, "y", 1e-17, y, sol.Out.EventY[0])
+		xs := sol.Out.GetStepX()
+		chk.Float64(tst, "last step x", 1e-17, xs[len(xs)-1], sol.Out.EventX[0])
+	}
+}
+
+func TestEvents02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Events02. harmonic oscillator: direction filter")
+
+	// y = [cos(x), -sin(x)]
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		f[0] = y[1]
+		f[1] = -y[0]
+	}
+	jac := func(dfdy *la.Triplet, h, x float64, y la.Vector) {
+		if dfdy.Max() == 0 {
+			dfdy.Init(2, 2, 2)
+		}
+		dfdy.Start()
+		dfdy.Put(0, 1, 1)
+		dfdy.Put(1, 0, -1)
+	}
+
+	// events in [0, 10]: cos(x) = 0 (all directions) and sin(x) = 0 with increasing -sin(x)
+	π := math.Pi
+	kCorrect := []int{0, 1, 0, 0, 1}
+	dirCorrect := []int{-1, +1, +1, -1, +1}
+	xCorrect := []float64{π / 2, π, 3 * π / 2, 5 * π / 2, 3 * π}
+
+	for _, method := range []string{"dopri5", "radau5"} {
+		io.Pf("\n%s\n", method)
+
+		// configuration
+		conf := NewConfig(method, "")
+		conf.SetTol(1e-9)
+		conf.AddEvent(func(x float64, y la.Vector) float64 { return y[0] }, 0, false)
+		conf.AddEvent(func(x float64, y la.Vector) float64 { return y[1] }, +1, false)
+
+		// solve twice: the event log must be restarted by Solve
+		sol := NewSolver(2, conf, fcn, jac, nil)
+		y := la.Vector{1, 0}
+		sol.Solve(y, 0, 10)
+		y = la.Vector{1, 0}
+		sol.Solve(y, 0, 10)
+		sol.Free()
+
+		// check
+		io.Pforan("k = %v\nx = %v\n", sol.Out.EventK, sol.Out.EventX)
+		if sol.Out.EventStop {
+			tst.Errorf("%s: non-terminal events should not stop the integration\n", method)
+		}
+		chk.Ints(tst, "EventK", sol.Out.EventK, kCorrect)
+		chk.Ints(tst, "EventDir", sol.Out.EventDir, dirCorrect)
+		chk.Array(tst, "EventX", 1e-7, sol.Out.EventX, xCorrect)
+		for i, x := range sol.Out.EventX {
+			chk.Array(tst, io.Sf("EventY[%d]", i), 1e-7, sol.Out.EventY[i], []float64{math.Cos(x), -math.Sin(x)})
+		}
+		chk.Array(tst, "y(xf)", 1e-7, y, []float64{math.Cos(10), -math.Sin(10)})
+	}
+
+	// errors: methods without dense output
+	for _, method := range []string{"fweuler", "bweuler", "moeuler"} {
+		func() {
+			defer func() {
+				if err := recover(); err == nil {
+					tst.Errorf("%s: NewSolver should reject events\n", method)
+				}
+			}()
+			conf := NewConfig(method, "")
+			conf.AddEvent(func(x float64, y la.Vector) float64 { return y[0] }, 0, false)
+			NewSolver(2, conf, fcn, jac, nil)
+		}()
+	}
+}